@@ -0,0 +1,32 @@
+// Command tangy calls the methods of Tangy from the command line, configured like the integration tests,
+// from configs/config.yaml and environment variables such as DATABASE_HOST.
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/content-services/tang/internal/config"
+	"github.com/content-services/tang/internal/tangycli"
+	"github.com/content-services/tang/pkg/tangy"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+func main() {
+	log.Logger = zerolog.New(zerolog.ConsoleWriter{Out: os.Stderr}).Level(zerolog.WarnLevel).With().Timestamp().Logger()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	cli := tangycli.CLI{
+		Tangy: func() (tangy.Tangy, error) {
+			return tangy.New(config.Get().Database.TangyDatabase(), tangy.Logger{Enabled: false})
+		},
+		Stdout: os.Stdout,
+		Stderr: os.Stderr,
+	}
+	code := cli.Run(ctx, os.Args[1:])
+	stop()
+	os.Exit(code)
+}
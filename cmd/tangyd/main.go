@@ -0,0 +1,66 @@
+// Command tangyd serves the queries of Tangy as a REST/JSON API, configured like the integration tests,
+// from configs/config.yaml and environment variables such as TANGYD_ADDRESS.
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/content-services/tang/internal/config"
+	"github.com/content-services/tang/internal/tangyd"
+	"github.com/content-services/tang/pkg/tangy"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+func main() {
+	if err := run(); err != nil {
+		log.Fatal().Err(err).Msg("tangyd stopped")
+	}
+}
+
+func run() error {
+	c := config.Get()
+	level, err := zerolog.ParseLevel(c.Tangyd.LogLevel)
+	if err != nil {
+		return err
+	}
+	logger := log.Logger.Level(level)
+
+	database := c.Database.TangyDatabase()
+	database.SQLComments = c.Tangyd.SQLComments
+	t, err := tangy.New(database, tangy.Logger{Enabled: false})
+	if err != nil {
+		return err
+	}
+	defer t.Close()
+	t = tangy.Wrap(t, tangy.LoggingInterceptor(logger))
+
+	server := &http.Server{
+		Addr:              c.Tangyd.Address,
+		Handler:           tangyd.New(t),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	shutdown := make(chan error, 1)
+	go func() {
+		<-ctx.Done()
+		logger.Info().Msg("Stopping tangyd")
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), c.Tangyd.ShutdownTimeout)
+		defer cancel()
+		shutdown <- server.Shutdown(shutdownCtx)
+	}()
+
+	logger.Info().Str("address", c.Tangyd.Address).Msg("Starting tangyd")
+	if err := server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return <-shutdown
+}
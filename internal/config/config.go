@@ -2,7 +2,9 @@ package config
 
 import (
 	"strings"
+	"time"
 
+	"github.com/content-services/tang/pkg/tangy"
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/viper"
 )
@@ -10,6 +12,7 @@ import (
 type Config struct {
 	Server   Server
 	Database Database
+	Tangyd   Tangyd
 }
 
 func Get() Config {
@@ -46,6 +49,7 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("database.user", "")
 	v.SetDefault("database.password", "")
 	v.SetDefault("database.name", "")
+	v.SetDefault("database.pgbouncer_port", "")
 
 	v.SetDefault("server.url", "")
 	v.SetDefault("server.content_url", "")
@@ -54,6 +58,11 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("server.storage_type", "")
 	v.SetDefault("server.download_policy", "")
 	v.SetDefault("server.content_path_prefix", "/api/pulp-content/")
+
+	v.SetDefault("tangyd.address", ":8000")
+	v.SetDefault("tangyd.shutdown_timeout", "30s")
+	v.SetDefault("tangyd.log_level", "info")
+	v.SetDefault("tangyd.sql_comments", false)
 }
 
 // Server configuration options for connecting to a pulp server
@@ -76,4 +85,31 @@ type Database struct {
 	Password   string `mapstructure:"password"`
 	CACertPath string `mapstructure:"ca_cert_path"`
 	PoolLimit  int    `mapstructure:"pool_limit"`
+	// PgBouncerPort is the port of a PgBouncer in transaction pooling mode in front of the database
+	PgBouncerPort int `mapstructure:"pgbouncer_port"`
+}
+
+// TangyDatabase returns the tangy.Database connecting to the database
+func (d Database) TangyDatabase() tangy.Database {
+	return tangy.Database{
+		Name:       d.Name,
+		Host:       d.Host,
+		Port:       d.Port,
+		User:       d.User,
+		Password:   d.Password,
+		CACertPath: d.CACertPath,
+		PoolLimit:  d.PoolLimit,
+	}
+}
+
+// Tangyd configuration options for the tangyd HTTP server
+type Tangyd struct {
+	// Address is the address the server listens on, such as ":8000"
+	Address string `mapstructure:"address"`
+	// ShutdownTimeout is the time requests are given to complete when the server is stopped
+	ShutdownTimeout time.Duration `mapstructure:"shutdown_timeout"`
+	// LogLevel is the zerolog level of the logs of the server, debug logging every Tangy call
+	LogLevel string `mapstructure:"log_level"`
+	// SQLComments sets tangy.Database.SQLComments, commenting statements with the X-Request-Id of their request
+	SQLComments bool `mapstructure:"sql_comments"`
 }
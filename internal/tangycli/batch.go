@@ -0,0 +1,134 @@
+package tangycli
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/content-services/tang/pkg/tangy"
+)
+
+// batchOutput is the JSON output of a call of a batch
+type batchOutput struct {
+	Call   string `json:"call"`
+	Result any    `json:"result,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// batchCommand returns the command running calls of the commands that may be batched with
+// tangy.Tangy.RpmRepositoryVersionBatch, each argument being a command without rpm and -href, such as
+// "packages list -limit 10". The results of every call are written, and the command fails with the error
+// of the first call that failed.
+func batchCommand(commands []command) command {
+	batchable := make(map[string]command)
+	for _, cmd := range commands {
+		if cmd.batch != nil {
+			batchable[strings.TrimPrefix(cmd.path, "rpm ")] = cmd
+		}
+	}
+	return command{
+		path:    "rpm batch",
+		summary: "Run several RPM list and search commands on the same repository versions at once",
+		methods: []string{"RpmRepositoryVersionBatch"},
+		hrefs:   hrefsVersions,
+		args:    `"<command> [flags]"...`,
+		define: func(fs *flag.FlagSet, h *hrefFlags) run {
+			return func(ctx context.Context, t tangy.Tangy, w io.Writer, f format) error {
+				if f == formatCSV {
+					return usageErrorf("the output of a batch cannot be csv")
+				}
+				calls := fs.Args()
+				if len(calls) == 0 {
+					return usageErrorf("a batch needs at least one command")
+				}
+
+				batch := &tangy.Batch{}
+				results := make([]func() (any, error), len(calls))
+				for i, c := range calls {
+					add, err := parseBatchCall(batchable, c)
+					if err != nil {
+						return err
+					}
+					results[i] = add(batch)
+				}
+				if err := t.RpmRepositoryVersionBatch(ctx, h.hrefs, batch); err != nil {
+					return err
+				}
+
+				var firstErr error
+				outputs := make([]batchOutput, len(calls))
+				for i, result := range results {
+					outputs[i].Call = calls[i]
+					value, err := result()
+					if err != nil {
+						if firstErr == nil {
+							firstErr = err
+						}
+						outputs[i].Error = err.Error()
+						continue
+					}
+					outputs[i].Result = value
+				}
+				if err := writeBatch(w, f, outputs); err != nil {
+					return err
+				}
+				return firstErr
+			}
+		},
+	}
+}
+
+// parseBatchCall parses a call of a batch, returning the function adding it to a batch
+func parseBatchCall(batchable map[string]command, call string) (func(b *tangy.Batch) func() (any, error), error) {
+	args := strings.Fields(call)
+	for path, cmd := range batchable {
+		words := strings.Fields(path)
+		if len(args) < len(words) || strings.Join(args[:len(words)], " ") != path {
+			continue
+		}
+		fs := flag.NewFlagSet(path, flag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+		add := cmd.batch(fs)
+		if err := fs.Parse(args[len(words):]); err != nil {
+			return nil, usageErrorf("%s: %v", call, err)
+		}
+		if fs.NArg() > 0 {
+			return nil, usageErrorf("%s: unexpected arguments %q", call, strings.Join(fs.Args(), " "))
+		}
+		return add, nil
+	}
+	return nil, usageErrorf("%q cannot be batched, the commands of a batch are %s", call, strings.Join(slices.Sorted(maps.Keys(batchable)), ", "))
+}
+
+// writeBatch writes the results of the calls of a batch, as a JSON array, or as a table for each call
+func writeBatch(w io.Writer, f format, outputs []batchOutput) error {
+	if f == formatJSON {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(outputs)
+	}
+	for i, output := range outputs {
+		separator := ""
+		if i > 0 {
+			separator = "\n"
+		}
+		if _, err := fmt.Fprintf(w, "%s==> %s <==\n", separator, output.Call); err != nil {
+			return err
+		}
+		if output.Error != "" {
+			if _, err := fmt.Fprintf(w, "error: %s\n", output.Error); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := writeResult(w, f, output.Result); err != nil {
+			return err
+		}
+	}
+	return nil
+}
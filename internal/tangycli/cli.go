@@ -0,0 +1,175 @@
+// Package tangycli implements the tangy command, calling the methods of a Tangy from the command line and writing
+// their results as tables, JSON or CSV.
+package tangycli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/content-services/tang/pkg/tangy"
+)
+
+// Exit codes of the tangy command
+const (
+	ExitOK = 0
+	// ExitError is the exit code of errors without a more specific code, such as database errors
+	ExitError = 1
+	// ExitUsage is the exit code of unknown commands, and of invalid flags and arguments
+	ExitUsage = 2
+	// ExitNotFound is the exit code of a repository, repository version or package that does not exist
+	ExitNotFound = 3
+	// ExitInvalidHref is the exit code of an href that cannot be parsed
+	ExitInvalidHref = 4
+)
+
+// usageError is returned for missing or invalid flags and arguments
+type usageError struct {
+	message string
+}
+
+func (e usageError) Error() string {
+	return e.message
+}
+
+func usageErrorf(format string, args ...any) error {
+	return usageError{message: fmt.Sprintf(format, args...)}
+}
+
+// exitCode returns the exit code of an error of a command
+func exitCode(err error) int {
+	var usageErr usageError
+	switch {
+	case err == nil:
+		return ExitOK
+	case errors.As(err, &usageErr):
+		return ExitUsage
+	case errors.Is(err, tangy.ErrInvalidHref):
+		return ExitInvalidHref
+	}
+	switch tangy.ClassifyError(err) {
+	case tangy.ErrorClassNotFound:
+		return ExitNotFound
+	case tangy.ErrorClassInvalid:
+		return ExitUsage
+	default:
+		return ExitError
+	}
+}
+
+// CLI runs the commands of tangy
+type CLI struct {
+	// Tangy returns the Tangy commands call. It is called once the command and its flags were parsed.
+	Tangy  func() (tangy.Tangy, error)
+	Stdout io.Writer
+	Stderr io.Writer
+}
+
+// Run runs the command of args, the arguments of tangy, returning its exit code
+func (c CLI) Run(ctx context.Context, args []string) int {
+	commands := commands()
+	if len(args) == 0 {
+		c.usage(commands)
+		return ExitUsage
+	}
+	if slices.Contains([]string{"help", "-h", "-help", "--help"}, args[0]) {
+		c.usage(commands)
+		return ExitOK
+	}
+	cmd, ok := findCommand(commands, args)
+	if !ok {
+		_, _ = fmt.Fprintf(c.Stderr, "tangy: unknown command %q\n\n", strings.Join(args, " "))
+		c.usage(commands)
+		return ExitUsage
+	}
+
+	fs := flag.NewFlagSet("tangy "+cmd.path, flag.ContinueOnError)
+	fs.SetOutput(c.Stderr)
+	var output string
+	fs.StringVar(&output, "output", string(formatTable), "Output format: "+joinFormats())
+	fs.StringVar(&output, "o", string(formatTable), "Shorthand for -output")
+	h := defineHrefs(fs, cmd.hrefs)
+	run := cmd.define(fs, h)
+	fs.Usage = func() {
+		_, _ = fmt.Fprintf(c.Stderr, "Usage: tangy %s\n\n%s\n\nFlags:\n", strings.TrimSpace(cmd.path+" [flags] "+cmd.args), cmd.summary)
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args[len(strings.Fields(cmd.path)):]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return ExitOK
+		}
+		return ExitUsage
+	}
+	if err := c.check(cmd, fs, h, format(output)); err != nil {
+		_, _ = fmt.Fprintf(c.Stderr, "tangy %s: %v\n", cmd.path, err)
+		fs.Usage()
+		return ExitUsage
+	}
+
+	t, err := c.Tangy()
+	if err != nil {
+		_, _ = fmt.Fprintf(c.Stderr, "tangy: %v\n", err)
+		return exitCode(err)
+	}
+	defer t.Close()
+	if err := run(ctx, t, c.Stdout, format(output)); err != nil {
+		_, _ = fmt.Fprintf(c.Stderr, "tangy %s: %v\n", cmd.path, err)
+		return exitCode(err)
+	}
+	return ExitOK
+}
+
+// check checks the flags and arguments of a command before it runs
+func (c CLI) check(cmd command, fs *flag.FlagSet, h *hrefFlags, f format) error {
+	if !slices.Contains(formats, f) {
+		return usageErrorf("invalid output format %q, must be one of %s", f, joinFormats())
+	}
+	if cmd.args == "" && fs.NArg() > 0 {
+		return usageErrorf("unexpected arguments %q", strings.Join(fs.Args(), " "))
+	}
+	if err := h.check(); err != nil {
+		return err
+	}
+	for _, name := range cmd.required {
+		if fs.Lookup(name).Value.String() == "" {
+			return usageErrorf("-%s is required", name)
+		}
+	}
+	return nil
+}
+
+// usage writes the commands of tangy
+func (c CLI) usage(commands []command) {
+	_, _ = fmt.Fprint(c.Stderr, "Usage: tangy <command> [flags]\n\n"+
+		"The database is configured by configs/config.yaml and DATABASE_* environment variables.\n\nCommands:\n")
+	tw := tabwriter.NewWriter(c.Stderr, 0, 4, 2, ' ', 0)
+	for _, cmd := range commands {
+		_, _ = fmt.Fprintf(tw, "  %s\t%s\n", cmd.path, cmd.summary)
+	}
+	_ = tw.Flush()
+	_, _ = fmt.Fprint(c.Stderr, "\nRun tangy <command> -h for the flags of a command.\n")
+}
+
+// findCommand returns the command whose path starts args
+func findCommand(commands []command, args []string) (command, bool) {
+	for _, cmd := range commands {
+		words := strings.Fields(cmd.path)
+		if len(args) >= len(words) && slices.Equal(args[:len(words)], words) {
+			return cmd, true
+		}
+	}
+	return command{}, false
+}
+
+func joinFormats() string {
+	names := make([]string, len(formats))
+	for i, f := range formats {
+		names[i] = string(f)
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,307 @@
+package tangycli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/content-services/tang/pkg/tangy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testHref = "/pulp/e1c6bee3/api/v3/repositories/rpm/rpm/018c1c95-4281-76eb-b277-842cbad524f4/versions/1/"
+
+// runCLI runs tangy with args calling t, returning its exit code, stdout and stderr
+func runCLI(t tangy.Tangy, args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	cli := CLI{
+		Tangy: func() (tangy.Tangy, error) {
+			if t == nil {
+				return nil, errors.New("the command should not call Tangy")
+			}
+			return t, nil
+		},
+		Stdout: &stdout,
+		Stderr: &stderr,
+	}
+	code := cli.Run(context.Background(), args)
+	return code, stdout.String(), stderr.String()
+}
+
+// newMockTangy returns a mock Tangy expecting to be closed once a command ran
+func newMockTangy(t *testing.T) *tangy.MockTangy {
+	m := tangy.NewMockTangy(t)
+	m.EXPECT().Close().Once()
+	return m
+}
+
+// TestCommandsCoverTangy checks that every Tangy method running queries is called by a command
+func TestCommandsCoverTangy(t *testing.T) {
+	t.Parallel()
+
+	var paths, methods []string
+	for _, cmd := range commands() {
+		assert.NotContains(t, paths, cmd.path)
+		paths = append(paths, cmd.path)
+		methods = append(methods, cmd.methods...)
+	}
+	var expected []string
+	tangyType := reflect.TypeFor[tangy.Tangy]()
+	for i := range tangyType.NumMethod() {
+		if name := tangyType.Method(i).Name; !slices.Contains([]string{"Close", "ReadSnapshot", "Stats"}, name) {
+			expected = append(expected, name)
+		}
+	}
+	assert.ElementsMatch(t, expected, methods)
+}
+
+// TestCommandsRun runs every command with its required flags, checking that it calls its Tangy method. Commands
+// reading a single repository href are run with -repository too.
+func TestCommandsRun(t *testing.T) {
+	t.Parallel()
+
+	for _, cmd := range commands() {
+		if cmd.args != "" {
+			continue
+		}
+		for i, method := range cmd.methods {
+			t.Run(method, func(t *testing.T) {
+				t.Parallel()
+
+				methodType, ok := reflect.TypeFor[tangy.Tangy]().MethodByName(method)
+				require.True(t, ok)
+				args := make([]any, methodType.Type.NumIn())
+				for i := range args {
+					args[i] = mock.Anything
+				}
+				var results []any
+				for i := range methodType.Type.NumOut() {
+					out := methodType.Type.Out(i)
+					switch {
+					case out.Kind() == reflect.Func:
+						// An iterator yielding no item
+						results = append(results, reflect.MakeFunc(out, func([]reflect.Value) []reflect.Value { return nil }).Interface())
+					case out == reflect.TypeFor[error]():
+						results = append(results, nil)
+					default:
+						results = append(results, reflect.Zero(out).Interface())
+					}
+				}
+				m := newMockTangy(t)
+				m.On(method, args...).Return(results...).Once()
+
+				cliArgs := strings.Fields(cmd.path)
+				switch {
+				case cmd.hrefs == hrefsNone:
+				case i == 0:
+					cliArgs = append(cliArgs, "-href", testHref)
+				default:
+					// The methods reading a single repository href follow the method reading repository version hrefs
+					cliArgs = append(cliArgs, "-repository", testHref)
+				}
+				for _, name := range cmd.required {
+					cliArgs = append(cliArgs, "-"+name, "name")
+				}
+				code, _, stderr := runCLI(m, cliArgs...)
+				assert.Equal(t, ExitOK, code, stderr)
+			})
+		}
+	}
+}
+
+func TestPackageListFlags(t *testing.T) {
+	t.Parallel()
+
+	secondHref := strings.Replace(testHref, "versions/1", "versions/2", 1)
+	response := tangy.RpmListResponse{
+		Results:    []tangy.RpmListItem{{Name: "bear", Version: "4.1", Release: "1", Arch: "noarch"}, {Name: "cat", Version: "1.0"}},
+		Total:      2,
+		TotalMode:  tangy.CountExact,
+		Limit:      10,
+		NextCursor: "next",
+	}
+	expectCall := func(m *tangy.MockTangy) {
+		m.EXPECT().RpmRepositoryVersionPackageList(mock.Anything, []string{testHref, secondHref}, tangy.RpmListFilters{Name: "b"},
+			tangy.PageOptions{Offset: 5, Limit: 10, SortBy: "name:desc", Cursor: "cursor", Count: tangy.CountExact}).Return(response, nil).Once()
+	}
+	args := []string{"rpm", "packages", "list", "-href", testHref, "-href", secondHref, "-name", "b",
+		"-offset", "5", "-limit", "10", "-sort-by", "name:desc", "-cursor", "cursor", "-count", "exact"}
+
+	t.Run("table", func(t *testing.T) {
+		t.Parallel()
+
+		m := newMockTangy(t)
+		expectCall(m)
+		code, stdout, _ := runCLI(m, args...)
+		require.Equal(t, ExitOK, code)
+		assert.Equal(t, "ID  NAME  ARCH    VERSION  RELEASE  EPOCH  SUMMARY\n"+
+			"    bear  noarch  4.1      1               \n"+
+			"    cat           1.0                      \n"+
+			"\ntotal: 2, total_mode: exact, limit: 10, next_cursor: next\n", stdout)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		t.Parallel()
+
+		m := newMockTangy(t)
+		expectCall(m)
+		code, stdout, _ := runCLI(m, append(args, "-o", "json")...)
+		require.Equal(t, ExitOK, code)
+		var decoded tangy.RpmListResponse
+		require.NoError(t, json.Unmarshal([]byte(stdout), &decoded))
+		assert.Equal(t, response, decoded)
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		t.Parallel()
+
+		m := newMockTangy(t)
+		expectCall(m)
+		code, stdout, _ := runCLI(m, append(args, "-output", "csv")...)
+		require.Equal(t, ExitOK, code)
+		assert.Equal(t, "Id,Name,Arch,Version,Release,Epoch,Summary\n,bear,noarch,4.1,1,,\n,cat,,1.0,,,\n", stdout)
+	})
+}
+
+func TestDetailOutput(t *testing.T) {
+	t.Parallel()
+
+	detail := tangy.NpmPackageDetail{Name: "bear", Version: "1.0.0", Versions: []string{"0.9.0", "1.0.0"}}
+	m := newMockTangy(t)
+	m.EXPECT().NpmPackageGet(mock.Anything, testHref, "bear", "").Return(detail, nil).Once()
+
+	code, stdout, _ := runCLI(m, "npm", "package", "get", "-repository", testHref, "-name", "bear")
+	require.Equal(t, ExitOK, code)
+	assert.Contains(t, stdout, "name:             bear\n")
+	assert.Contains(t, stdout, "versions:         0.9.0,1.0.0\n")
+	assert.Contains(t, stdout, `tarball:          {"relative_path":"","filename":""`)
+	assert.Contains(t, stdout, "latest_versions:  \n")
+}
+
+func TestItemsOutput(t *testing.T) {
+	t.Parallel()
+
+	streamErr := fmt.Errorf("%w: %s", tangy.ErrRepositoryVersionNotFound, testHref)
+	items := func(yield func(tangy.ErrataListItem, error) bool) {
+		if yield(tangy.ErrataListItem{ErrataId: "RHSA-1", CVEs: []string{"CVE-1", "CVE-2"}}, nil) {
+			yield(tangy.ErrataListItem{}, streamErr)
+		}
+	}
+	m := newMockTangy(t)
+	m.EXPECT().RpmRepositoryVersionErrataAll(mock.Anything, []string{testHref}, tangy.ErrataListFilters{Type: []string{"security"}}).
+		Return(iter.Seq2[tangy.ErrataListItem, error](items)).Once()
+
+	code, stdout, stderr := runCLI(m, "rpm", "errata", "all", "-href", testHref, "-type", "security", "-o", "csv")
+	assert.Equal(t, ExitNotFound, code)
+	// The rows read before the error are written
+	assert.Equal(t, "Id,ErrataId,Title,Summary,Description,IssuedDate,UpdatedDate,Type,Severity,RebootSuggested,CVEs\n"+
+		",RHSA-1,,,,,,,,false,\"CVE-1,CVE-2\"\n", stdout)
+	assert.Contains(t, stderr, streamErr.Error())
+}
+
+func TestExitCodes(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		err  error
+		code int
+	}{
+		{fmt.Errorf("%w: %s", tangy.ErrRepositoryVersionNotFound, testHref), ExitNotFound},
+		{tangy.ErrRepositoryNotFound, ExitNotFound},
+		{tangy.ErrInvalidHref, ExitInvalidHref},
+		{tangy.ErrInvalidCursor, ExitUsage},
+		{tangy.ErrQueryTimeout, ExitError},
+		{errors.New("unexpected"), ExitError},
+	} {
+		t.Run(tc.err.Error(), func(t *testing.T) {
+			t.Parallel()
+
+			m := newMockTangy(t)
+			m.EXPECT().RpmRepositoryVersionPackageSearch(mock.Anything, []string{testHref}, "bear", 0).Return(nil, tc.err).Once()
+
+			code, stdout, stderr := runCLI(m, "rpm", "packages", "search", "-href", testHref, "-search", "bear")
+			assert.Equal(t, tc.code, code)
+			assert.Empty(t, stdout)
+			assert.Equal(t, "tangy rpm packages search: "+tc.err.Error()+"\n", stderr)
+		})
+	}
+}
+
+// TestUsage checks that invalid commands and flags fail before calling Tangy
+func TestUsage(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		name string
+		args []string
+		code int
+	}{
+		{"no command", nil, ExitUsage},
+		{"help", []string{"help"}, ExitOK},
+		{"command help", []string{"rpm", "packages", "list", "-h"}, ExitOK},
+		{"unknown command", []string{"rpm", "packages", "delete"}, ExitUsage},
+		{"unknown flag", []string{"rpm", "packages", "list", "-href", testHref, "-size", "1"}, ExitUsage},
+		{"invalid integer", []string{"rpm", "packages", "list", "-href", testHref, "-limit", "ten"}, ExitUsage},
+		{"missing href", []string{"rpm", "packages", "list"}, ExitUsage},
+		{"missing href or repository", []string{"python", "metrics"}, ExitUsage},
+		{"href and repository", []string{"python", "metrics", "-href", testHref, "-repository", testHref}, ExitUsage},
+		{"missing required flag", []string{"npm", "package", "get", "-href", testHref}, ExitUsage},
+		{"invalid output", []string{"rpm", "packages", "list", "-href", testHref, "-o", "yaml"}, ExitUsage},
+		{"unexpected argument", []string{"rpm", "packages", "list", "-href", testHref, "bear"}, ExitUsage},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			code, stdout, stderr := runCLI(nil, tc.args...)
+			assert.Equal(t, tc.code, code)
+			assert.Empty(t, stdout)
+			assert.Contains(t, stderr, "Usage: tangy")
+		})
+	}
+}
+
+func TestBatch(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid call", func(t *testing.T) {
+		t.Parallel()
+
+		code, stdout, stderr := runCLI(newMockTangy(t), "rpm", "batch", "-href", testHref, "packages list -limit 5", "errata search -search bear")
+		assert.Equal(t, ExitUsage, code)
+		assert.Empty(t, stdout)
+		assert.Contains(t, stderr, `"errata search -search bear" cannot be batched`)
+	})
+
+	t.Run("results", func(t *testing.T) {
+		t.Parallel()
+
+		m := newMockTangy(t)
+		m.EXPECT().RpmRepositoryVersionBatch(mock.Anything, []string{testHref}, mock.Anything).Return(nil).Once()
+
+		// The mock does not run the batch, leaving the calls without results, which is an invalid call
+		code, stdout, stderr := runCLI(m, "rpm", "batch", "-href", testHref, "packages list -limit 5", "errata list -type security")
+		assert.Equal(t, ExitUsage, code)
+		assert.Equal(t, "==> packages list -limit 5 <==\nerror: batch has not run\n\n"+
+			"==> errata list -type security <==\nerror: batch has not run\n", stdout)
+		assert.Contains(t, stderr, tangy.ErrBatchNotRun.Error())
+	})
+
+	t.Run("batch error", func(t *testing.T) {
+		t.Parallel()
+
+		m := newMockTangy(t)
+		m.EXPECT().RpmRepositoryVersionBatch(mock.Anything, []string{"invalid"}, mock.Anything).Return(tangy.ErrInvalidHref).Once()
+
+		code, _, _ := runCLI(m, "rpm", "batch", "-href", "invalid", "-o", "json", "modules list -rpm-name bear")
+		assert.Equal(t, ExitInvalidHref, code)
+	})
+}
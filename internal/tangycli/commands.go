@@ -0,0 +1,581 @@
+package tangycli
+
+import (
+	"context"
+	"flag"
+	"io"
+	"iter"
+	"strings"
+
+	"github.com/content-services/tang/pkg/tangy"
+)
+
+// command is a command of tangy calling Tangy methods
+type command struct {
+	// path is the words of the command, such as rpm packages list
+	path    string
+	summary string
+	// methods are the names of the Tangy methods the command calls
+	methods []string
+	hrefs   hrefsMode
+	// required are the names of the flags that must be set
+	required []string
+	// args describes the arguments of the command, for the commands reading arguments
+	args string
+	// define defines the flags of the command, other than its hrefs and output format, returning the function
+	// running the command with their values
+	define func(fs *flag.FlagSet, h *hrefFlags) run
+	// batch defines the flags of the command other than its hrefs, returning the function adding its call
+	// to a batch, for the commands of the methods of tangy.Batch
+	batch func(fs *flag.FlagSet) func(b *tangy.Batch) func() (any, error)
+}
+
+// run runs a command, writing its result to w in the format f
+type run func(ctx context.Context, t tangy.Tangy, w io.Writer, f format) error
+
+// call is a call of a Tangy method, with the arguments read from the flags of a command
+type call[T any] func(ctx context.Context, t tangy.Tangy) (T, error)
+
+// result returns the definition of a command calling a Tangy method, writing its result
+func result[T any](define func(fs *flag.FlagSet, h *hrefFlags) call[T]) func(fs *flag.FlagSet, h *hrefFlags) run {
+	return func(fs *flag.FlagSet, h *hrefFlags) run {
+		c := define(fs, h)
+		return func(ctx context.Context, t tangy.Tangy, w io.Writer, f format) error {
+			value, err := c(ctx, t)
+			if err != nil {
+				return err
+			}
+			return writeResult(w, f, value)
+		}
+	}
+}
+
+// items returns the definition of a command calling a Tangy iterator, writing its items as they are yielded
+func items[T any](define func(fs *flag.FlagSet, h *hrefFlags) func(ctx context.Context, t tangy.Tangy) iter.Seq2[T, error]) func(fs *flag.FlagSet, h *hrefFlags) run {
+	return func(fs *flag.FlagSet, h *hrefFlags) run {
+		all := define(fs, h)
+		return func(ctx context.Context, t tangy.Tangy, w io.Writer, f format) error {
+			return writeItems(w, f, all(ctx, t))
+		}
+	}
+}
+
+// rpmCall is a call of an RPM method that may be batched, with the arguments read from the flags of a command
+// other than its hrefs
+type rpmCall[T any] func(ctx context.Context, t tangy.Tangy, hrefs []string) (T, error)
+
+// batchCall adds the call of an RPM method to a batch, with the arguments read from the flags of a command
+type batchCall[T any] func(b *tangy.Batch) *tangy.BatchResult[T]
+
+// rpmCommand returns a command calling an RPM method that may be batched. define defines the flags of the
+// command other than its hrefs, returning the functions calling the method and adding its call to a batch.
+func rpmCommand[T any](path, summary, method string, define func(fs *flag.FlagSet) (rpmCall[T], batchCall[T])) command {
+	return command{
+		path:    path,
+		summary: summary,
+		methods: []string{method},
+		hrefs:   hrefsVersions,
+		define: result(func(fs *flag.FlagSet, h *hrefFlags) call[T] {
+			c, _ := define(fs)
+			return func(ctx context.Context, t tangy.Tangy) (T, error) {
+				return c(ctx, t, h.hrefs)
+			}
+		}),
+		batch: func(fs *flag.FlagSet) func(b *tangy.Batch) func() (any, error) {
+			_, add := define(fs)
+			return func(b *tangy.Batch) func() (any, error) {
+				r := add(b)
+				return func() (any, error) {
+					return r.Result()
+				}
+			}
+		},
+	}
+}
+
+// commands returns the commands of tangy, with a command for each Tangy method running queries. The commands
+// of the methods reading a single repository href are the commands of the methods reading repository version
+// hrefs, with -repository rather than -href.
+func commands() []command {
+	commands := rpmCommands()
+	commands = append(commands, batchCommand(commands))
+	commands = append(commands, pythonCommands()...)
+	commands = append(commands, mavenCommands()...)
+	commands = append(commands, npmCommands()...)
+	return append(commands,
+		command{path: "ping", summary: "Check the connection to the database", methods: []string{"Ping"},
+			define: func(_ *flag.FlagSet, _ *hrefFlags) run {
+				return health(tangy.Tangy.Ping)
+			}},
+		command{path: "ready", summary: "Check the database and its replicas are ready to serve queries", methods: []string{"Ready"},
+			define: func(_ *flag.FlagSet, _ *hrefFlags) run {
+				return health(tangy.Tangy.Ready)
+			}},
+	)
+}
+
+// health returns the run of a health check, writing ok when it succeeds
+func health(check func(t tangy.Tangy, ctx context.Context) error) run {
+	return func(ctx context.Context, t tangy.Tangy, w io.Writer, f format) error {
+		if err := check(t, ctx); err != nil {
+			return err
+		}
+		return writeResult(w, f, "ok")
+	}
+}
+
+func rpmCommands() []command {
+	return []command{
+		rpmCommand("rpm packages list", "List RPMs, with an optional name prefix filter", "RpmRepositoryVersionPackageList",
+			func(fs *flag.FlagSet) (rpmCall[tangy.RpmListResponse], batchCall[tangy.RpmListResponse]) {
+				name, page := fs.String("name", "", "Name prefix"), definePage(fs)
+				return func(ctx context.Context, t tangy.Tangy, hrefs []string) (tangy.RpmListResponse, error) {
+						return t.RpmRepositoryVersionPackageList(ctx, hrefs, tangy.RpmListFilters{Name: *name}, page.options())
+					}, func(b *tangy.Batch) *tangy.BatchResult[tangy.RpmListResponse] {
+						return b.RpmRepositoryVersionPackageList(tangy.RpmListFilters{Name: *name}, page.options())
+					}
+			}),
+		rpmCommand("rpm packages search", "Search RPMs by name", "RpmRepositoryVersionPackageSearch",
+			func(fs *flag.FlagSet) (rpmCall[[]tangy.RpmPackageSearch], batchCall[[]tangy.RpmPackageSearch]) {
+				search, limit := defineSearch(fs)
+				return func(ctx context.Context, t tangy.Tangy, hrefs []string) ([]tangy.RpmPackageSearch, error) {
+						return t.RpmRepositoryVersionPackageSearch(ctx, hrefs, *search, *limit)
+					}, func(b *tangy.Batch) *tangy.BatchResult[[]tangy.RpmPackageSearch] {
+						return b.RpmRepositoryVersionPackageSearch(*search, *limit)
+					}
+			}),
+		{
+			path:    "rpm packages all",
+			summary: "List every RPM, with an optional name prefix filter, as the results are read",
+			methods: []string{"RpmRepositoryVersionPackageAll"},
+			hrefs:   hrefsVersions,
+			define: items(func(fs *flag.FlagSet, h *hrefFlags) func(ctx context.Context, t tangy.Tangy) iter.Seq2[tangy.RpmListItem, error] {
+				name := fs.String("name", "", "Name prefix")
+				return func(ctx context.Context, t tangy.Tangy) iter.Seq2[tangy.RpmListItem, error] {
+					return t.RpmRepositoryVersionPackageAll(ctx, h.hrefs, tangy.RpmListFilters{Name: *name})
+				}
+			}),
+		},
+		rpmCommand("rpm groups search", "Search RPM package groups by name", "RpmRepositoryVersionPackageGroupSearch",
+			func(fs *flag.FlagSet) (rpmCall[[]tangy.RpmPackageGroupSearch], batchCall[[]tangy.RpmPackageGroupSearch]) {
+				search, limit := defineSearch(fs)
+				return func(ctx context.Context, t tangy.Tangy, hrefs []string) ([]tangy.RpmPackageGroupSearch, error) {
+						return t.RpmRepositoryVersionPackageGroupSearch(ctx, hrefs, *search, *limit)
+					}, func(b *tangy.Batch) *tangy.BatchResult[[]tangy.RpmPackageGroupSearch] {
+						return b.RpmRepositoryVersionPackageGroupSearch(*search, *limit)
+					}
+			}),
+		rpmCommand("rpm environments search", "Search RPM environments by name", "RpmRepositoryVersionEnvironmentSearch",
+			func(fs *flag.FlagSet) (rpmCall[[]tangy.RpmEnvironmentSearch], batchCall[[]tangy.RpmEnvironmentSearch]) {
+				search, limit := defineSearch(fs)
+				return func(ctx context.Context, t tangy.Tangy, hrefs []string) ([]tangy.RpmEnvironmentSearch, error) {
+						return t.RpmRepositoryVersionEnvironmentSearch(ctx, hrefs, *search, *limit)
+					}, func(b *tangy.Batch) *tangy.BatchResult[[]tangy.RpmEnvironmentSearch] {
+						return b.RpmRepositoryVersionEnvironmentSearch(*search, *limit)
+					}
+			}),
+		rpmCommand("rpm modules list", "List module streams, with optional filters", "RpmRepositoryVersionModuleStreamsList",
+			func(fs *flag.FlagSet) (rpmCall[[]tangy.ModuleStreams], batchCall[[]tangy.ModuleStreams]) {
+				var rpmNames stringsFlag
+				fs.Var(&rpmNames, "rpm-name", "Name of an RPM the module streams contain, repeated for several names")
+				search := fs.String("search", "", "Search term")
+				sortBy := fs.String("sort-by", "", "Direction modules are sorted by name in, such as name:desc")
+				filters := func() tangy.ModuleStreamListFilters {
+					return tangy.ModuleStreamListFilters{RpmNames: rpmNames, Search: *search}
+				}
+				return func(ctx context.Context, t tangy.Tangy, hrefs []string) ([]tangy.ModuleStreams, error) {
+						return t.RpmRepositoryVersionModuleStreamsList(ctx, hrefs, filters(), *sortBy)
+					}, func(b *tangy.Batch) *tangy.BatchResult[[]tangy.ModuleStreams] {
+						return b.RpmRepositoryVersionModuleStreamsList(filters(), *sortBy)
+					}
+			}),
+		rpmCommand("rpm errata list", "List errata, with optional filters", "RpmRepositoryVersionErrataList",
+			func(fs *flag.FlagSet) (rpmCall[tangy.ErrataListResponse], batchCall[tangy.ErrataListResponse]) {
+				filters, page := defineErrataFilters(fs), definePage(fs)
+				return func(ctx context.Context, t tangy.Tangy, hrefs []string) (tangy.ErrataListResponse, error) {
+						return t.RpmRepositoryVersionErrataList(ctx, hrefs, filters(), page.options())
+					}, func(b *tangy.Batch) *tangy.BatchResult[tangy.ErrataListResponse] {
+						return b.RpmRepositoryVersionErrataList(filters(), page.options())
+					}
+			}),
+		{
+			path:    "rpm errata all",
+			summary: "List every erratum, with optional filters, as the results are read",
+			methods: []string{"RpmRepositoryVersionErrataAll"},
+			hrefs:   hrefsVersions,
+			define: items(func(fs *flag.FlagSet, h *hrefFlags) func(ctx context.Context, t tangy.Tangy) iter.Seq2[tangy.ErrataListItem, error] {
+				filters := defineErrataFilters(fs)
+				return func(ctx context.Context, t tangy.Tangy) iter.Seq2[tangy.ErrataListItem, error] {
+					return t.RpmRepositoryVersionErrataAll(ctx, h.hrefs, filters())
+				}
+			}),
+		},
+	}
+}
+
+// defineErrataFilters defines the flags of the filters of errata
+func defineErrataFilters(fs *flag.FlagSet) func() tangy.ErrataListFilters {
+	var types, severities stringsFlag
+	search := fs.String("search", "", "Search term of the id or summary")
+	fs.Var(&types, "type", "Type, other for types that are not security, bugfix or enhancement. Repeated or comma separated.")
+	fs.Var(&severities, "severity", "Severity, Unknown for other severities. Repeated or comma separated.")
+	return func() tangy.ErrataListFilters {
+		return tangy.ErrataListFilters{Search: *search, Type: types, Severity: severities}
+	}
+}
+
+func pythonCommands() []command {
+	return []command{
+		{
+			path:    "python packages list",
+			summary: "List Python packages",
+			methods: []string{"PythonRepositoryVersionPackageList", "PythonPackageList"},
+			hrefs:   hrefsOrRepository,
+			define: result(func(fs *flag.FlagSet, h *hrefFlags) call[tangy.PythonPackageListResponse] {
+				search, page := fs.String("search", "", "Search term"), definePage(fs)
+				return func(ctx context.Context, t tangy.Tangy) (tangy.PythonPackageListResponse, error) {
+					filters := tangy.PythonPackageListFilters{Search: *search}
+					if h.repository != "" {
+						return t.PythonPackageList(ctx, h.repository, filters, page.options())
+					}
+					return t.PythonRepositoryVersionPackageList(ctx, h.hrefs, filters, page.options())
+				}
+			}),
+		},
+		{
+			path:    "python distributions list",
+			summary: "List Python distribution files, of every package or of a package",
+			methods: []string{"PythonRepositoryVersionDistributionList", "PythonDistributionList"},
+			hrefs:   hrefsOrRepository,
+			define: result(func(fs *flag.FlagSet, h *hrefFlags) call[tangy.PythonDistributionListResponse] {
+				name, version, page := fs.String("name", "", "Normalized name of the package"), defineVersion(fs), definePage(fs)
+				return func(ctx context.Context, t tangy.Tangy) (tangy.PythonDistributionListResponse, error) {
+					if h.repository != "" {
+						return t.PythonDistributionList(ctx, h.repository, *name, *version, page.options())
+					}
+					return t.PythonRepositoryVersionDistributionList(ctx, h.hrefs, *name, *version, page.options())
+				}
+			}),
+		},
+		{
+			path:    "python distributions all",
+			summary: "List every Python distribution file, of every package or of a package, as the results are read",
+			methods: []string{"PythonRepositoryVersionDistributionAll"},
+			hrefs:   hrefsVersions,
+			define: items(func(fs *flag.FlagSet, h *hrefFlags) func(ctx context.Context, t tangy.Tangy) iter.Seq2[tangy.PythonDistributionListItem, error] {
+				name, version := fs.String("name", "", "Normalized name of the package"), defineVersion(fs)
+				return func(ctx context.Context, t tangy.Tangy) iter.Seq2[tangy.PythonDistributionListItem, error] {
+					return t.PythonRepositoryVersionDistributionAll(ctx, h.hrefs, *name, *version)
+				}
+			}),
+		},
+		{
+			path:     "python package get",
+			summary:  "Get a version of a Python package, the latest one when -version is not set",
+			methods:  []string{"PythonRepositoryVersionPackageGet", "PythonPackageGet"},
+			hrefs:    hrefsOrRepository,
+			required: []string{"name"},
+			define: result(func(fs *flag.FlagSet, h *hrefFlags) call[tangy.PythonPackageDetail] {
+				name, version := fs.String("name", "", "Normalized name of the package"), defineVersion(fs)
+				return func(ctx context.Context, t tangy.Tangy) (tangy.PythonPackageDetail, error) {
+					if h.repository != "" {
+						return t.PythonPackageGet(ctx, h.repository, *name, *version)
+					}
+					return t.PythonRepositoryVersionPackageGet(ctx, h.hrefs, *name, *version)
+				}
+			}),
+		},
+		{
+			path:     "python package versions",
+			summary:  "Get every version of a Python package",
+			methods:  []string{"PythonRepositoryVersionPackageVersionsGet", "PythonPackageVersionsGet"},
+			hrefs:    hrefsOrRepository,
+			required: []string{"name"},
+			define: result(func(fs *flag.FlagSet, h *hrefFlags) call[[]tangy.PythonPackageDetail] {
+				name := fs.String("name", "", "Normalized name of the package")
+				return func(ctx context.Context, t tangy.Tangy) ([]tangy.PythonPackageDetail, error) {
+					if h.repository != "" {
+						return t.PythonPackageVersionsGet(ctx, h.repository, *name)
+					}
+					return t.PythonRepositoryVersionPackageVersionsGet(ctx, h.hrefs, *name)
+				}
+			}),
+		},
+		{
+			path:    "python builds list",
+			summary: "List Python builds, of every package or of a package",
+			methods: []string{"PythonRepositoryVersionBuildList", "PythonBuildList"},
+			hrefs:   hrefsOrRepository,
+			define: result(func(fs *flag.FlagSet, h *hrefFlags) call[tangy.PythonBuildListResponse] {
+				name, version, page := fs.String("name", "", "Normalized name of the package"), defineVersion(fs), definePage(fs)
+				return func(ctx context.Context, t tangy.Tangy) (tangy.PythonBuildListResponse, error) {
+					if h.repository != "" {
+						return t.PythonBuildList(ctx, h.repository, *name, *version, page.options())
+					}
+					return t.PythonRepositoryVersionBuildList(ctx, h.hrefs, *name, *version, page.options())
+				}
+			}),
+		},
+		{
+			path:    "python metrics",
+			summary: "Count the Python packages and distribution files",
+			methods: []string{"PythonRepositoryVersionMetrics", "PythonRepositoryMetrics"},
+			hrefs:   hrefsOrRepository,
+			define: result(func(_ *flag.FlagSet, h *hrefFlags) call[tangy.PythonRepositoryMetrics] {
+				return func(ctx context.Context, t tangy.Tangy) (tangy.PythonRepositoryMetrics, error) {
+					if h.repository != "" {
+						return t.PythonRepositoryMetrics(ctx, h.repository)
+					}
+					return t.PythonRepositoryVersionMetrics(ctx, h.hrefs)
+				}
+			}),
+		},
+	}
+}
+
+// mavenVersionsFlags are the flags filtering Maven artifact versions
+type mavenVersionsFlags struct {
+	groupID, artifactID, version *string
+}
+
+func defineMavenVersions(fs *flag.FlagSet) mavenVersionsFlags {
+	return mavenVersionsFlags{
+		groupID:    fs.String("group-id", "", "Group id"),
+		artifactID: fs.String("artifact-id", "", "Artifact id"),
+		version:    defineVersion(fs),
+	}
+}
+
+func mavenCommands() []command {
+	return []command{
+		{
+			path:    "maven packages list",
+			summary: "List Maven packages",
+			methods: []string{"MavenRepositoryVersionPackageList", "MavenPackageList"},
+			hrefs:   hrefsOrRepository,
+			define: result(func(fs *flag.FlagSet, h *hrefFlags) call[tangy.MavenPackageListResponse] {
+				search, page := fs.String("search", "", "Search term"), definePage(fs)
+				return func(ctx context.Context, t tangy.Tangy) (tangy.MavenPackageListResponse, error) {
+					filters := tangy.MavenPackageListFilters{Search: *search}
+					if h.repository != "" {
+						return t.MavenPackageList(ctx, h.repository, filters, page.options())
+					}
+					return t.MavenRepositoryVersionPackageList(ctx, h.hrefs, filters, page.options())
+				}
+			}),
+		},
+		{
+			path:    "maven versions list",
+			summary: "List Maven artifact versions, with optional filters",
+			methods: []string{"MavenRepositoryVersionVersionsList", "MavenVersionsList"},
+			hrefs:   hrefsOrRepository,
+			define: result(func(fs *flag.FlagSet, h *hrefFlags) call[tangy.MavenVersionsResponse] {
+				filters, page := defineMavenVersions(fs), definePage(fs)
+				return func(ctx context.Context, t tangy.Tangy) (tangy.MavenVersionsResponse, error) {
+					if h.repository != "" {
+						return t.MavenVersionsList(ctx, h.repository, *filters.groupID, *filters.artifactID, *filters.version, page.options())
+					}
+					return t.MavenRepositoryVersionVersionsList(ctx, h.hrefs, *filters.groupID, *filters.artifactID, *filters.version, page.options())
+				}
+			}),
+		},
+		{
+			path:    "maven versions all",
+			summary: "List every Maven artifact version, with optional filters, as the results are read",
+			methods: []string{"MavenRepositoryVersionVersionsAll"},
+			hrefs:   hrefsVersions,
+			define: items(func(fs *flag.FlagSet, h *hrefFlags) func(ctx context.Context, t tangy.Tangy) iter.Seq2[tangy.MavenVersionsItem, error] {
+				filters := defineMavenVersions(fs)
+				return func(ctx context.Context, t tangy.Tangy) iter.Seq2[tangy.MavenVersionsItem, error] {
+					return t.MavenRepositoryVersionVersionsAll(ctx, h.hrefs, *filters.groupID, *filters.artifactID, *filters.version)
+				}
+			}),
+		},
+		{
+			path:    "maven metrics",
+			summary: "Count the Maven packages and artifacts",
+			methods: []string{"MavenRepositoryVersionMetrics", "MavenRepositoryMetrics"},
+			hrefs:   hrefsOrRepository,
+			define: result(func(_ *flag.FlagSet, h *hrefFlags) call[tangy.MavenRepositoryMetrics] {
+				return func(ctx context.Context, t tangy.Tangy) (tangy.MavenRepositoryMetrics, error) {
+					if h.repository != "" {
+						return t.MavenRepositoryMetrics(ctx, h.repository)
+					}
+					return t.MavenRepositoryVersionMetrics(ctx, h.hrefs)
+				}
+			}),
+		},
+	}
+}
+
+func npmCommands() []command {
+	return []command{
+		{
+			path:    "npm packages list",
+			summary: "List npm packages",
+			methods: []string{"NpmRepositoryVersionPackageList", "NpmPackageList"},
+			hrefs:   hrefsOrRepository,
+			define: result(func(fs *flag.FlagSet, h *hrefFlags) call[tangy.NpmPackageListResponse] {
+				search, page := fs.String("search", "", "Search term of the scope or unscoped name"), definePage(fs)
+				return func(ctx context.Context, t tangy.Tangy) (tangy.NpmPackageListResponse, error) {
+					filters := tangy.NpmPackageListFilters{Search: *search}
+					if h.repository != "" {
+						return t.NpmPackageList(ctx, h.repository, filters, page.options())
+					}
+					return t.NpmRepositoryVersionPackageList(ctx, h.hrefs, filters, page.options())
+				}
+			}),
+		},
+		{
+			path:    "npm packages all",
+			summary: "List every npm package, as the results are read",
+			methods: []string{"NpmRepositoryVersionPackageAll"},
+			hrefs:   hrefsVersions,
+			define: items(func(fs *flag.FlagSet, h *hrefFlags) func(ctx context.Context, t tangy.Tangy) iter.Seq2[tangy.NpmPackageListItem, error] {
+				search := fs.String("search", "", "Search term of the scope or unscoped name")
+				return func(ctx context.Context, t tangy.Tangy) iter.Seq2[tangy.NpmPackageListItem, error] {
+					return t.NpmRepositoryVersionPackageAll(ctx, h.hrefs, tangy.NpmPackageListFilters{Search: *search})
+				}
+			}),
+		},
+		{
+			path:     "npm package get",
+			summary:  "Get a version of an npm package, the latest one when -version is not set",
+			methods:  []string{"NpmRepositoryVersionPackageGet", "NpmPackageGet"},
+			hrefs:    hrefsOrRepository,
+			required: []string{"name"},
+			define: result(func(fs *flag.FlagSet, h *hrefFlags) call[tangy.NpmPackageDetail] {
+				name, version := fs.String("name", "", "Name of the package"), defineVersion(fs)
+				return func(ctx context.Context, t tangy.Tangy) (tangy.NpmPackageDetail, error) {
+					if h.repository != "" {
+						return t.NpmPackageGet(ctx, h.repository, *name, *version)
+					}
+					return t.NpmRepositoryVersionPackageGet(ctx, h.hrefs, *name, *version)
+				}
+			}),
+		},
+		{
+			path:     "npm package versions",
+			summary:  "Get every version of an npm package",
+			methods:  []string{"NpmRepositoryVersionPackageVersionsGet", "NpmPackageVersionsGet"},
+			hrefs:    hrefsOrRepository,
+			required: []string{"name"},
+			define: result(func(fs *flag.FlagSet, h *hrefFlags) call[[]tangy.NpmPackageDetail] {
+				name := fs.String("name", "", "Name of the package")
+				return func(ctx context.Context, t tangy.Tangy) ([]tangy.NpmPackageDetail, error) {
+					if h.repository != "" {
+						return t.NpmPackageVersionsGet(ctx, h.repository, *name)
+					}
+					return t.NpmRepositoryVersionPackageVersionsGet(ctx, h.hrefs, *name)
+				}
+			}),
+		},
+		{
+			path:    "npm builds list",
+			summary: "List npm builds, of every package or of a package",
+			methods: []string{"NpmRepositoryVersionBuildList", "NpmBuildList"},
+			hrefs:   hrefsOrRepository,
+			define: result(func(fs *flag.FlagSet, h *hrefFlags) call[tangy.NpmBuildListResponse] {
+				name, version, page := fs.String("name", "", "Name of the package"), defineVersion(fs), definePage(fs)
+				return func(ctx context.Context, t tangy.Tangy) (tangy.NpmBuildListResponse, error) {
+					if h.repository != "" {
+						return t.NpmBuildList(ctx, h.repository, *name, *version, page.options())
+					}
+					return t.NpmRepositoryVersionBuildList(ctx, h.hrefs, *name, *version, page.options())
+				}
+			}),
+		},
+	}
+}
+
+// hrefsMode is how a command reads the hrefs it calls Tangy with
+type hrefsMode int
+
+const (
+	// hrefsNone commands read no href
+	hrefsNone hrefsMode = iota
+	// hrefsVersions commands read repository version hrefs, with an -href flag for each
+	hrefsVersions
+	// hrefsOrRepository commands read repository version hrefs, or the href of a single repository with -repository
+	hrefsOrRepository
+)
+
+// hrefFlags are the hrefs of a command
+type hrefFlags struct {
+	mode       hrefsMode
+	hrefs      stringsFlag
+	repository string
+}
+
+func defineHrefs(fs *flag.FlagSet, mode hrefsMode) *hrefFlags {
+	h := &hrefFlags{mode: mode}
+	switch mode {
+	case hrefsVersions:
+		fs.Var(&h.hrefs, "href", "Repository version href, repeated for several hrefs")
+	case hrefsOrRepository:
+		fs.Var(&h.hrefs, "href", "Repository version href, or repository href read at its latest version, repeated for several hrefs")
+		fs.StringVar(&h.repository, "repository", "", "Repository href read at its latest version, instead of -href")
+	}
+	return h
+}
+
+// check returns an error unless the hrefs the command reads are set
+func (h *hrefFlags) check() error {
+	switch {
+	case h.mode == hrefsNone:
+		return nil
+	case h.repository != "" && len(h.hrefs) > 0:
+		return usageErrorf("-href and -repository cannot both be set")
+	case h.repository != "":
+		return nil
+	case len(h.hrefs) > 0:
+		return nil
+	case h.mode == hrefsOrRepository:
+		return usageErrorf("-href or -repository is required")
+	default:
+		return usageErrorf("-href is required")
+	}
+}
+
+// pageFlags are the flags of tangy.PageOptions
+type pageFlags struct {
+	offset, limit  *int
+	sortBy, cursor *string
+	count          *string
+}
+
+func definePage(fs *flag.FlagSet) *pageFlags {
+	return &pageFlags{
+		offset: fs.Int("offset", 0, "Number of results skipped, ignored with -cursor"),
+		limit:  fs.Int("limit", 0, "Maximum number of results, 500 when 0"),
+		sortBy: fs.String("sort-by", "", "Sort field and direction, such as name:desc"),
+		cursor: fs.String("cursor", "", "next_cursor of the previous page"),
+		count: fs.String("count", "", "How total is computed: "+strings.Join([]string{
+			string(tangy.CountExact), string(tangy.CountNone), string(tangy.CountEstimated)}, ", ")+", exact by default"),
+	}
+}
+
+func (p *pageFlags) options() tangy.PageOptions {
+	return tangy.PageOptions{Offset: *p.offset, Limit: *p.limit, SortBy: *p.sortBy, Cursor: *p.cursor, Count: tangy.CountMode(*p.count)}
+}
+
+// defineSearch defines the flags of the search methods
+func defineSearch(fs *flag.FlagSet) (search *string, limit *int) {
+	return fs.String("search", "", "Search term"), fs.Int("limit", 0, "Maximum number of results, 500 when 0")
+}
+
+func defineVersion(fs *flag.FlagSet) *string {
+	return fs.String("version", "", "Version")
+}
+
+// stringsFlag is a flag that may be repeated
+type stringsFlag []string
+
+func (s *stringsFlag) String() string {
+	return strings.Join(*s, ",")
+}
+
+func (s *stringsFlag) Set(value string) error {
+	*s = append(*s, value)
+	return nil
+}
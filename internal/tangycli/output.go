@@ -0,0 +1,310 @@
+package tangycli
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+	"time"
+	"unicode/utf8"
+)
+
+// format is the format results are written in
+type format string
+
+const (
+	formatTable format = "table"
+	formatJSON  format = "json"
+	formatCSV   format = "csv"
+)
+
+// formats are the formats of the --output flag
+var formats = []format{formatTable, formatJSON, formatCSV}
+
+// maxTableCell is the maximum number of characters of a cell of a table, longer cells are cut
+const maxTableCell = 60
+
+// resultsField is the JSON name of the field of the results of list responses, such as tangy.RpmListResponse
+const resultsField = "results"
+
+// writeResult writes the result of a call. A table or CSV has a row for each item of a slice or of the
+// results of a list response, and a table is followed by the other fields of a list response such as its total.
+// A table of another result has a line for each field.
+func writeResult(w io.Writer, f format, result any) error {
+	if f == formatJSON {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(result)
+	}
+
+	v := reflect.ValueOf(result)
+	var footer []string
+	if v.Kind() == reflect.Struct {
+		if results, ok := structField(v, resultsField); ok && results.Kind() == reflect.Slice {
+			for name, value := range structFields(v) {
+				if name != resultsField && !value.IsZero() {
+					footer = append(footer, name+": "+cell(value))
+				}
+			}
+			v = results
+		}
+	}
+
+	if v.Kind() != reflect.Slice {
+		if f == formatTable {
+			return writeFields(w, v)
+		}
+		v = reflect.Append(reflect.MakeSlice(reflect.SliceOf(v.Type()), 0, 1), v)
+	}
+	rows := newRowWriter(w, f, v.Type().Elem())
+	for i := range v.Len() {
+		if err := rows.write(v.Index(i)); err != nil {
+			return err
+		}
+	}
+	if err := rows.flush(); err != nil {
+		return err
+	}
+	if f == formatTable && len(footer) > 0 {
+		_, err := fmt.Fprintf(w, "\n%s\n", strings.Join(footer, ", "))
+		return err
+	}
+	return nil
+}
+
+// writeItems writes the items of an iterator as they are yielded, as a row of a table or CSV, or a JSON line.
+// The rows written before an error are flushed.
+func writeItems[T any](w io.Writer, f format, items iter.Seq2[T, error]) error {
+	if f == formatJSON {
+		encoder := json.NewEncoder(w)
+		for item, err := range items {
+			if err != nil {
+				return err
+			}
+			if err := encoder.Encode(item); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	rows := newRowWriter(w, f, reflect.TypeFor[T]())
+	for item, err := range items {
+		if err != nil {
+			_ = rows.flush()
+			return err
+		}
+		if err := rows.write(reflect.ValueOf(item)); err != nil {
+			return err
+		}
+	}
+	return rows.flush()
+}
+
+// writeFields writes a table with a line for each field of a result
+func writeFields(w io.Writer, v reflect.Value) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	if v.Kind() != reflect.Struct {
+		_, _ = fmt.Fprintln(tw, tableCell(v))
+		return tw.Flush()
+	}
+	for name, value := range structFields(v) {
+		_, _ = fmt.Fprintf(tw, "%s:\t%s\n", name, tableCell(value))
+	}
+	return tw.Flush()
+}
+
+// rowWriter writes rows of a table or CSV, with a column for each field of the type of the rows
+type rowWriter struct {
+	format  format
+	table   *tabwriter.Writer
+	csv     *csv.Writer
+	columns []string
+	started bool
+}
+
+func newRowWriter(w io.Writer, f format, rowType reflect.Type) *rowWriter {
+	r := &rowWriter{format: f, columns: []string{"value"}}
+	if rowType.Kind() == reflect.Struct {
+		r.columns = nil
+		for name := range structFields(reflect.Zero(rowType)) {
+			r.columns = append(r.columns, name)
+		}
+	}
+	if f == formatCSV {
+		r.csv = csv.NewWriter(w)
+	} else {
+		r.table = tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	}
+	return r
+}
+
+// write writes a row, after the header for the first row
+func (r *rowWriter) write(v reflect.Value) error {
+	if err := r.writeHeader(); err != nil {
+		return err
+	}
+
+	cells := make([]string, 0, len(r.columns))
+	if v.Kind() == reflect.Struct {
+		for _, value := range structFields(v) {
+			cells = append(cells, r.cell(value))
+		}
+	} else {
+		cells = append(cells, r.cell(v))
+	}
+	return r.writeCells(cells)
+}
+
+// writeHeader writes the header, unless it was written
+func (r *rowWriter) writeHeader() error {
+	if r.started {
+		return nil
+	}
+	r.started = true
+	header := r.columns
+	if r.format == formatTable {
+		header = make([]string, len(r.columns))
+		for i, column := range r.columns {
+			header[i] = strings.ToUpper(column)
+		}
+	}
+	return r.writeCells(header)
+}
+
+func (r *rowWriter) cell(v reflect.Value) string {
+	if r.format == formatTable {
+		return tableCell(v)
+	}
+	return cell(v)
+}
+
+func (r *rowWriter) writeCells(cells []string) error {
+	if r.csv != nil {
+		return r.csv.Write(cells)
+	}
+	_, err := fmt.Fprintln(r.table, strings.Join(cells, "\t"))
+	return err
+}
+
+// flush writes the buffered rows. A CSV without rows has a header, a table without rows is empty.
+func (r *rowWriter) flush() error {
+	if r.csv != nil {
+		if err := r.writeHeader(); err != nil {
+			return err
+		}
+		r.csv.Flush()
+		return r.csv.Error()
+	}
+	return r.table.Flush()
+}
+
+// cell returns the text of a value: scalars as text, slices of scalars separated by commas, and other values
+// as JSON
+func cell(v reflect.Value) string {
+	switch {
+	case !v.IsValid():
+		return ""
+	case v.Type() == reflect.TypeFor[time.Time]():
+		t, _ := v.Interface().(time.Time)
+		return t.Format(time.RFC3339)
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return ""
+		}
+		return cell(v.Elem())
+	case reflect.Map:
+		if v.IsNil() {
+			return ""
+		}
+	case reflect.String:
+		return v.String()
+	case reflect.Slice:
+		if v.IsNil() {
+			return ""
+		}
+		if scalar(v.Type().Elem()) {
+			items := make([]string, v.Len())
+			for i := range v.Len() {
+				items[i] = cell(v.Index(i))
+			}
+			return strings.Join(items, ",")
+		}
+	}
+	if scalar(v.Type()) {
+		return fmt.Sprint(v.Interface())
+	}
+	encoded, err := json.Marshal(v.Interface())
+	if err != nil {
+		return fmt.Sprint(v.Interface())
+	}
+	return string(encoded)
+}
+
+// tableCell returns the text of a value in a table, on a single line and cut to maxTableCell characters
+func tableCell(v reflect.Value) string {
+	text := strings.Join(strings.Fields(cell(v)), " ")
+	if utf8.RuneCountInString(text) <= maxTableCell {
+		return text
+	}
+	return string([]rune(text)[:maxTableCell-1]) + "…"
+}
+
+// scalar returns whether values of t are written as text rather than JSON
+func scalar(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.String, reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// structFields yields the fields of a struct encoded by encoding/json with their JSON names, with the fields of
+// embedded structs
+func structFields(v reflect.Value) iter.Seq2[string, reflect.Value] {
+	return func(yield func(string, reflect.Value) bool) {
+		t := v.Type()
+		for i := range t.NumField() {
+			field := t.Field(i)
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == "-" {
+				continue
+			}
+			if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+				for embeddedName, embedded := range structFields(v.Field(i)) {
+					if !yield(embeddedName, embedded) {
+						return
+					}
+				}
+				continue
+			}
+			if !field.IsExported() {
+				continue
+			}
+			if name == "" {
+				name = field.Name
+			}
+			if !yield(name, v.Field(i)) {
+				return
+			}
+		}
+	}
+}
+
+// structField returns the field of a struct with a JSON name
+func structField(v reflect.Value, name string) (reflect.Value, bool) {
+	for fieldName, value := range structFields(v) {
+		if fieldName == name {
+			return value, true
+		}
+	}
+	return reflect.Value{}, false
+}
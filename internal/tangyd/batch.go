@@ -0,0 +1,110 @@
+package tangyd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+
+	"github.com/content-services/tang/pkg/tangy"
+)
+
+// maxBatchBodySize is the maximum size of the body of a batch, in bytes
+const maxBatchBodySize = 1 << 20
+
+// BatchRequest is the body of a batch of calls of the RPM list and search routes on the same repository versions
+type BatchRequest struct {
+	// Hrefs are the repository version hrefs every call reads
+	Hrefs []string    `json:"hrefs"`
+	Calls []BatchCall `json:"calls"`
+}
+
+// BatchCall is a call of a batch
+type BatchCall struct {
+	// Operation is the operation id of the route called, such as RpmRepositoryVersionPackageList
+	Operation string `json:"operation"`
+	// Params are the query parameters of the route, other than href
+	Params map[string][]string `json:"params,omitempty"`
+}
+
+// BatchResponse is the response of a batch, with the result of each call in order
+type BatchResponse struct {
+	Results []BatchResult `json:"results"`
+}
+
+// BatchResult is the result of a call of a batch: the response of its route, or its error
+type BatchResult struct {
+	Result any    `json:"result,omitempty"`
+	Error  *Error `json:"error,omitempty"`
+}
+
+// batchRoute returns the route running calls of the routes that may be batched with tangy.Tangy.RpmRepositoryVersionBatch.
+// A call failing on its own has an error in its result. An error of the whole batch, such as an invalid href,
+// fails the request.
+func batchRoute(routes []route) route {
+	batchable := make(map[string]route)
+	for _, r := range routes {
+		if r.batch != nil {
+			batchable[r.operation] = r
+		}
+	}
+	return route{
+		method:    http.MethodPost,
+		path:      repositoryVersionsPath + "/rpm/batch",
+		operation: "RpmRepositoryVersionBatch",
+		summary:   "Run several RPM list and search calls on the same repository versions at once",
+		body:      reflect.TypeFor[BatchRequest](),
+		response:  reflect.TypeFor[BatchResponse](),
+		serve: func(t tangy.Tangy, w http.ResponseWriter, r *http.Request) {
+			var request BatchRequest
+			decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBatchBodySize))
+			decoder.DisallowUnknownFields()
+			if err := decoder.Decode(&request); err != nil {
+				writeError(w, fmt.Errorf("%w: invalid batch body: %w", errInvalidParameter, err))
+				return
+			}
+			if len(request.Hrefs) == 0 {
+				writeError(w, fmt.Errorf("%w: hrefs is required", errInvalidParameter))
+				return
+			}
+
+			batch := &tangy.Batch{}
+			response := BatchResponse{Results: make([]BatchResult, len(request.Calls))}
+			results := make([]func() (any, error), len(request.Calls))
+			for i, c := range request.Calls {
+				rt, ok := batchable[c.Operation]
+				if !ok {
+					response.Results[i].Error = &Error{Class: tangy.ErrorClassInvalid, Message: fmt.Sprintf("operation %q cannot be batched", c.Operation)}
+					continue
+				}
+				p := newParams(url.Values(c.Params), rt.params)
+				add := rt.batch(p)
+				if p.err != nil {
+					callErr := newError(p.err)
+					response.Results[i].Error = &callErr
+					continue
+				}
+				results[i] = add(batch)
+			}
+
+			if err := t.RpmRepositoryVersionBatch(r.Context(), request.Hrefs, batch); err != nil {
+				writeError(w, err)
+				return
+			}
+			for i, result := range results {
+				if result == nil {
+					continue
+				}
+				value, err := result()
+				if err != nil {
+					callErr := newError(err)
+					response.Results[i].Error = &callErr
+					continue
+				}
+				response.Results[i].Result = value
+			}
+			writeJSON(w, http.StatusOK, response)
+		},
+	}
+}
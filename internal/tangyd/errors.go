@@ -0,0 +1,58 @@
+package tangyd
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/content-services/tang/pkg/tangy"
+)
+
+// statusClientClosedRequest is the status of a call canceled as the client went away, as logged by nginx
+const statusClientClosedRequest = 499
+
+// errorStatuses maps the classes of the errors of Tangy methods to HTTP statuses
+var errorStatuses = map[tangy.ErrorClass]int{
+	tangy.ErrorClassInvalid:    http.StatusBadRequest,
+	tangy.ErrorClassNotFound:   http.StatusNotFound,
+	tangy.ErrorClassTimeout:    http.StatusGatewayTimeout,
+	tangy.ErrorClassCanceled:   statusClientClosedRequest,
+	tangy.ErrorClassConnection: http.StatusServiceUnavailable,
+	tangy.ErrorClassDatabase:   http.StatusInternalServerError,
+	tangy.ErrorClassOther:      http.StatusInternalServerError,
+}
+
+// ErrorResponse is the body of a failed request
+type ErrorResponse struct {
+	Error Error `json:"error"`
+}
+
+// Error is an error of a request, or of a call of a batch
+type Error struct {
+	// Class is the class of the error, as returned by tangy.ClassifyError
+	Class   tangy.ErrorClass `json:"class"`
+	Message string           `json:"message"`
+}
+
+// classifyError returns the class of an error of a request
+func classifyError(err error) tangy.ErrorClass {
+	if errors.Is(err, errInvalidParameter) {
+		return tangy.ErrorClassInvalid
+	}
+	return tangy.ClassifyError(err)
+}
+
+// newError returns the error of a request, with its class
+func newError(err error) Error {
+	return Error{Class: classifyError(err), Message: err.Error()}
+}
+
+// errorStatus returns the HTTP status of an error of a request
+func errorStatus(err error) int {
+	return errorStatuses[classifyError(err)]
+}
+
+// writeError writes the error of a request with its status. Errors of Tangy calls are logged by
+// tangy.LoggingInterceptor.
+func writeError(w http.ResponseWriter, err error) {
+	writeJSON(w, errorStatus(err), ErrorResponse{Error: newError(err)})
+}
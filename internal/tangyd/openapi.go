@@ -0,0 +1,230 @@
+package tangyd
+
+import (
+	"iter"
+	"maps"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// openAPIVersion is the version of the OpenAPI specification of the document
+const openAPIVersion = "3.0.3"
+
+// schema is a JSON schema of the OpenAPI document
+type schema = map[string]any
+
+// openAPIDocument returns the OpenAPI document of routes, with the schemas of their response types
+// as components
+func openAPIDocument(routes []route) map[string]any {
+	schemas := &schemaGenerator{components: make(map[string]schema)}
+	errorResponse := schema{
+		"description": "Error, with its class",
+		"content":     schema{"application/json": schema{"schema": schemas.schema(reflect.TypeFor[ErrorResponse]())}},
+	}
+
+	paths := make(map[string]schema)
+	for _, r := range routes {
+		contentType := "application/json"
+		description := "Result of " + r.operation
+		if r.stream {
+			contentType = "application/x-ndjson"
+			description = "A JSON line for each item. An error once items were sent ends the stream with a line holding an ErrorResponse."
+		}
+		operation := schema{
+			"operationId": r.operation,
+			"summary":     r.summary,
+			"tags":        []string{routeTag(r.path)},
+			"responses": schema{
+				"200": schema{
+					"description": description,
+					"content":     schema{contentType: schema{"schema": schemas.schema(r.response)}},
+				},
+				"default": errorResponse,
+			},
+		}
+		if len(r.params) > 0 {
+			parameters := make([]schema, len(r.params))
+			for i, p := range r.params {
+				parameters[i] = paramSchema(p)
+			}
+			operation["parameters"] = parameters
+		}
+		if r.body != nil {
+			operation["requestBody"] = schema{
+				"required": true,
+				"content":  schema{"application/json": schema{"schema": schemas.schema(r.body)}},
+			}
+		}
+		if paths[r.path] == nil {
+			paths[r.path] = make(schema)
+		}
+		paths[r.path][strings.ToLower(r.method)] = operation
+	}
+
+	health := schema{
+		"summary": "Check the database",
+		"responses": schema{
+			"200": schema{
+				"description": "The check succeeded",
+				"content":     schema{"application/json": schema{"schema": schemas.schema(reflect.TypeFor[HealthResponse]())}},
+			},
+			"503": errorResponse,
+		},
+	}
+	paths["/healthz"] = schema{"get": withFields(health, schema{"operationId": "Ping", "tags": []string{"health"}})}
+	paths["/readyz"] = schema{"get": withFields(health, schema{"operationId": "Ready", "tags": []string{"health"}})}
+
+	return map[string]any{
+		"openapi": openAPIVersion,
+		"info": schema{
+			"title":       "Tangy",
+			"version":     "v1",
+			"description": "Queries of the content of Pulp repository versions",
+		},
+		"paths":      paths,
+		"components": schema{"schemas": schemas.components},
+	}
+}
+
+// withFields returns a copy of s with the fields of fields
+func withFields(s schema, fields schema) schema {
+	merged := maps.Clone(s)
+	maps.Copy(merged, fields)
+	return merged
+}
+
+// routeTag returns the tag of a route, the content type it reads such as rpm
+func routeTag(path string) string {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) < 3 {
+		return "tangy"
+	}
+	return parts[2]
+}
+
+// paramSchema returns the OpenAPI parameter of a query parameter
+func paramSchema(p param) schema {
+	s := schema{"type": string(p.typ)}
+	if p.typ == paramArray {
+		s["items"] = schema{"type": "string"}
+	}
+	if len(p.enum) > 0 {
+		s["enum"] = p.enum
+	}
+	if p.maximum > 0 {
+		s["maximum"] = p.maximum
+	}
+	parameter := schema{"name": p.name, "in": "query", "description": p.description, "schema": s}
+	if p.required {
+		parameter["required"] = true
+	}
+	return parameter
+}
+
+// schemaGenerator generates the schemas of Go types as encoded by encoding/json. Named struct types are
+// components of the document, referenced by their name.
+type schemaGenerator struct {
+	components map[string]schema
+}
+
+func (g *schemaGenerator) schema(t reflect.Type) schema {
+	switch {
+	case t == reflect.TypeFor[time.Time]():
+		return schema{"type": "string", "format": "date-time"}
+	case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8:
+		return schema{"type": "string", "format": "byte"}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return nullable(g.schema(t.Elem()))
+	case reflect.Bool:
+		return schema{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return schema{"type": "integer"}
+	case reflect.Int64, reflect.Uint64:
+		return schema{"type": "integer", "format": "int64"}
+	case reflect.Float32, reflect.Float64:
+		return schema{"type": "number"}
+	case reflect.String:
+		return schema{"type": "string"}
+	case reflect.Array:
+		return schema{"type": "array", "items": g.schema(t.Elem())}
+	case reflect.Slice:
+		// A nil slice is encoded as null
+		return nullable(schema{"type": "array", "items": g.schema(t.Elem())})
+	case reflect.Map:
+		return schema{"type": "object", "additionalProperties": g.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.object(t)
+		}
+		if _, ok := g.components[t.Name()]; !ok {
+			// Set before the fields are generated, for types referring to themselves
+			g.components[t.Name()] = schema{}
+			g.components[t.Name()] = g.object(t)
+		}
+		return schema{"$ref": "#/components/schemas/" + t.Name()}
+	default:
+		// Interfaces may hold any value
+		return schema{}
+	}
+}
+
+// object returns the schema of the JSON object of a struct. Fields are required unless they are omitted when empty.
+func (g *schemaGenerator) object(t reflect.Type) schema {
+	properties := make(map[string]schema)
+	var requiredFields []string
+	for field := range reflectFields(t) {
+		name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "" {
+			name = field.Name
+		}
+		properties[name] = g.schema(field.Type)
+		if !strings.Contains(options, "omitempty") && !strings.Contains(options, "omitzero") {
+			requiredFields = append(requiredFields, name)
+		}
+	}
+	s := schema{"type": "object", "properties": properties}
+	if len(requiredFields) > 0 {
+		s["required"] = requiredFields
+	}
+	return s
+}
+
+// reflectFields yields the fields of a struct encoded by encoding/json, with the fields of embedded structs
+func reflectFields(t reflect.Type) iter.Seq[reflect.StructField] {
+	return func(yield func(reflect.StructField) bool) {
+		for i := range t.NumField() {
+			field := t.Field(i)
+			tag := field.Tag.Get("json")
+			if tag == "-" {
+				continue
+			}
+			if field.Anonymous && tag == "" && field.Type.Kind() == reflect.Struct {
+				for embedded := range reflectFields(field.Type) {
+					if !yield(embedded) {
+						return
+					}
+				}
+				continue
+			}
+			if !field.IsExported() {
+				continue
+			}
+			if !yield(field) {
+				return
+			}
+		}
+	}
+}
+
+// nullable returns s allowing null. A reference cannot have siblings, so it is wrapped in allOf.
+func nullable(s schema) schema {
+	if _, ok := s["$ref"]; ok {
+		return schema{"allOf": []schema{s}, "nullable": true}
+	}
+	s["nullable"] = true
+	return s
+}
@@ -0,0 +1,132 @@
+package tangyd
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/content-services/tang/pkg/tangy"
+)
+
+// errInvalidParameter is returned when a query parameter is missing or cannot be parsed
+var errInvalidParameter = errors.New("invalid parameter")
+
+// paramType is the type of a query parameter
+type paramType string
+
+const (
+	paramString  paramType = "string"
+	paramInteger paramType = "integer"
+	// paramArray parameters may be repeated
+	paramArray paramType = "array"
+)
+
+// maxLimit is the largest limit of a request, so that a single request cannot read every row of a large repository
+const maxLimit = 1000
+
+// param is a query parameter of a route, as documented in the OpenAPI document
+type param struct {
+	name        string
+	typ         paramType
+	required    bool
+	description string
+	enum        []string
+	// maximum is the largest value of an integer parameter, unbounded when zero
+	maximum int
+}
+
+var (
+	hrefsParam = param{name: "href", typ: paramArray, required: true,
+		description: "Repository version hrefs, or repository hrefs read at their latest version. Repeated for several hrefs."}
+	rpmHrefsParam = param{name: "href", typ: paramArray, required: true,
+		description: "Repository version hrefs. Repeated for several hrefs."}
+	repositoryHrefParam = param{name: "href", typ: paramString, required: true,
+		description: "Repository href, read at its latest version"}
+	searchParam = param{name: "search", typ: paramString, description: "Search term"}
+	limitParam  = param{name: "limit", typ: paramInteger, maximum: maxLimit,
+		description: fmt.Sprintf("Maximum number of results, 500 when 0, and at most %d", maxLimit)}
+	sortByParam = param{name: "sort_by", typ: paramString, description: "Sort field and direction, such as name:desc"}
+	pageParams  = []param{
+		{name: "offset", typ: paramInteger, description: "Number of results skipped, ignored with cursor"},
+		limitParam,
+		sortByParam,
+		{name: "cursor", typ: paramString, description: "next_cursor of the previous page"},
+		{name: "count", typ: paramString, description: "How total is computed, exact by default",
+			enum: []string{string(tangy.CountExact), string(tangy.CountNone), string(tangy.CountEstimated)}},
+	}
+)
+
+// with returns the parameters of a route, appending the parameters of groups such as pageParams
+func with(params []param, groups ...[]param) []param {
+	for _, group := range groups {
+		params = append(params, group...)
+	}
+	return params
+}
+
+// params reads the query parameters of a request, recording the first error of a parameter that is missing
+// or cannot be parsed. Reading a parameter the route does not declare panics, so that the OpenAPI document
+// cannot miss a parameter.
+type params struct {
+	values   url.Values
+	declared []param
+	err      error
+}
+
+func newParams(values url.Values, declared []param) *params {
+	return &params{values: values, declared: declared}
+}
+
+// param returns the declaration of a parameter, recording an error when a required parameter is missing
+func (p *params) param(name string) param {
+	for _, declared := range p.declared {
+		if declared.name == name {
+			if declared.required && len(p.values[name]) == 0 && p.err == nil {
+				p.err = fmt.Errorf("%w: %s is required", errInvalidParameter, name)
+			}
+			return declared
+		}
+	}
+	panic("tangyd: parameter " + name + " is not declared")
+}
+
+func (p *params) string(name string) string {
+	p.param(name)
+	return p.values.Get(name)
+}
+
+func (p *params) strings(name string) []string {
+	p.param(name)
+	return p.values[name]
+}
+
+func (p *params) integer(name string) int {
+	declared := p.param(name)
+	value := p.values.Get(name)
+	if value == "" {
+		return 0
+	}
+	i, err := strconv.Atoi(value)
+	if (err != nil || i < 0) && p.err == nil {
+		p.err = fmt.Errorf("%w: %s must be a non-negative integer, not %q", errInvalidParameter, name, value)
+	}
+	if declared.maximum > 0 && i > declared.maximum && p.err == nil {
+		p.err = fmt.Errorf("%w: %s must be at most %d, not %d", errInvalidParameter, name, declared.maximum, i)
+	}
+	return i
+}
+
+func (p *params) hrefs() []string {
+	return p.strings("href")
+}
+
+func (p *params) page() tangy.PageOptions {
+	return tangy.PageOptions{
+		Offset: p.integer("offset"),
+		Limit:  p.integer("limit"),
+		SortBy: p.string("sort_by"),
+		Cursor: p.string("cursor"),
+		Count:  tangy.CountMode(p.string("count")),
+	}
+}
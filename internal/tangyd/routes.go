@@ -0,0 +1,416 @@
+package tangyd
+
+import (
+	"context"
+	"iter"
+
+	"github.com/content-services/tang/pkg/tangy"
+)
+
+// Routes of the methods reading repository version hrefs start with repositoryVersionsPath, and routes of
+// the methods reading the latest version of a single repository href with repositoriesPath
+const (
+	repositoryVersionsPath = "/v1/repository_versions"
+	repositoriesPath       = "/v1/repositories"
+)
+
+var (
+	nameNormalizedParam = param{name: "name_normalized", typ: paramString, description: "Normalized name of the Python package"}
+	versionParam        = param{name: "version", typ: paramString, description: "Version"}
+	npmNameParam        = param{name: "name", typ: paramString, description: "Name of the npm package"}
+)
+
+// required returns p as a required parameter
+func required(p param) param {
+	p.required = true
+	return p
+}
+
+// apiRoutes returns the routes of the API, one for each Tangy method running queries, and Stats
+func apiRoutes() []route {
+	routes := append(rpmRoutes(), pythonRoutes()...)
+	routes = append(routes, mavenRoutes()...)
+	routes = append(routes, npmRoutes()...)
+	routes = append(routes, get("/v1/stats", "Stats", "Statistics of the connection pools and of the calls of each method", nil,
+		func(_ *params) call[tangy.Stats] {
+			return func(_ context.Context, t tangy.Tangy) (tangy.Stats, error) {
+				return t.Stats(), nil
+			}
+		}))
+	return append(routes, batchRoute(routes))
+}
+
+func rpmRoutes() []route {
+	return []route{
+		batched(get(repositoryVersionsPath+"/rpm/packages/search", "RpmRepositoryVersionPackageSearch", "Search RPMs by name",
+			[]param{rpmHrefsParam, searchParam, limitParam},
+			func(p *params) call[[]tangy.RpmPackageSearch] {
+				hrefs, search, limit := p.hrefs(), p.string("search"), p.integer("limit")
+				return func(ctx context.Context, t tangy.Tangy) ([]tangy.RpmPackageSearch, error) {
+					return t.RpmRepositoryVersionPackageSearch(ctx, hrefs, search, limit)
+				}
+			}),
+			func(p *params) func(b *tangy.Batch) *tangy.BatchResult[[]tangy.RpmPackageSearch] {
+				search, limit := p.string("search"), p.integer("limit")
+				return func(b *tangy.Batch) *tangy.BatchResult[[]tangy.RpmPackageSearch] {
+					return b.RpmRepositoryVersionPackageSearch(search, limit)
+				}
+			}),
+		batched(get(repositoryVersionsPath+"/rpm/package_groups/search", "RpmRepositoryVersionPackageGroupSearch", "Search RPM package groups by name",
+			[]param{rpmHrefsParam, searchParam, limitParam},
+			func(p *params) call[[]tangy.RpmPackageGroupSearch] {
+				hrefs, search, limit := p.hrefs(), p.string("search"), p.integer("limit")
+				return func(ctx context.Context, t tangy.Tangy) ([]tangy.RpmPackageGroupSearch, error) {
+					return t.RpmRepositoryVersionPackageGroupSearch(ctx, hrefs, search, limit)
+				}
+			}),
+			func(p *params) func(b *tangy.Batch) *tangy.BatchResult[[]tangy.RpmPackageGroupSearch] {
+				search, limit := p.string("search"), p.integer("limit")
+				return func(b *tangy.Batch) *tangy.BatchResult[[]tangy.RpmPackageGroupSearch] {
+					return b.RpmRepositoryVersionPackageGroupSearch(search, limit)
+				}
+			}),
+		batched(get(repositoryVersionsPath+"/rpm/environments/search", "RpmRepositoryVersionEnvironmentSearch", "Search RPM environments by name",
+			[]param{rpmHrefsParam, searchParam, limitParam},
+			func(p *params) call[[]tangy.RpmEnvironmentSearch] {
+				hrefs, search, limit := p.hrefs(), p.string("search"), p.integer("limit")
+				return func(ctx context.Context, t tangy.Tangy) ([]tangy.RpmEnvironmentSearch, error) {
+					return t.RpmRepositoryVersionEnvironmentSearch(ctx, hrefs, search, limit)
+				}
+			}),
+			func(p *params) func(b *tangy.Batch) *tangy.BatchResult[[]tangy.RpmEnvironmentSearch] {
+				search, limit := p.string("search"), p.integer("limit")
+				return func(b *tangy.Batch) *tangy.BatchResult[[]tangy.RpmEnvironmentSearch] {
+					return b.RpmRepositoryVersionEnvironmentSearch(search, limit)
+				}
+			}),
+		batched(get(repositoryVersionsPath+"/rpm/packages", "RpmRepositoryVersionPackageList", "List RPMs, with an optional name prefix filter",
+			with([]param{rpmHrefsParam, {name: "name", typ: paramString, description: "Name prefix"}}, pageParams),
+			func(p *params) call[tangy.RpmListResponse] {
+				hrefs, filters, page := p.hrefs(), tangy.RpmListFilters{Name: p.string("name")}, p.page()
+				return func(ctx context.Context, t tangy.Tangy) (tangy.RpmListResponse, error) {
+					return t.RpmRepositoryVersionPackageList(ctx, hrefs, filters, page)
+				}
+			}),
+			func(p *params) func(b *tangy.Batch) *tangy.BatchResult[tangy.RpmListResponse] {
+				filters, page := tangy.RpmListFilters{Name: p.string("name")}, p.page()
+				return func(b *tangy.Batch) *tangy.BatchResult[tangy.RpmListResponse] {
+					return b.RpmRepositoryVersionPackageList(filters, page)
+				}
+			}),
+		batched(get(repositoryVersionsPath+"/rpm/module_streams", "RpmRepositoryVersionModuleStreamsList", "List module streams, with optional filters",
+			[]param{rpmHrefsParam,
+				{name: "rpm_name", typ: paramArray, description: "Names of RPMs the module streams contain. Repeated for several names."},
+				searchParam, {name: "sort_by", typ: paramString, description: "Direction modules are sorted by name in, such as name:desc"}},
+			func(p *params) call[[]tangy.ModuleStreams] {
+				hrefs, filters, sortBy := p.hrefs(), moduleStreamListFilters(p), p.string("sort_by")
+				return func(ctx context.Context, t tangy.Tangy) ([]tangy.ModuleStreams, error) {
+					return t.RpmRepositoryVersionModuleStreamsList(ctx, hrefs, filters, sortBy)
+				}
+			}),
+			func(p *params) func(b *tangy.Batch) *tangy.BatchResult[[]tangy.ModuleStreams] {
+				filters, sortBy := moduleStreamListFilters(p), p.string("sort_by")
+				return func(b *tangy.Batch) *tangy.BatchResult[[]tangy.ModuleStreams] {
+					return b.RpmRepositoryVersionModuleStreamsList(filters, sortBy)
+				}
+			}),
+		batched(get(repositoryVersionsPath+"/rpm/errata", "RpmRepositoryVersionErrataList", "List errata, with optional filters",
+			with([]param{rpmHrefsParam,
+				{name: "search", typ: paramString, description: "Search term of the id or summary"},
+				{name: "type", typ: paramArray, description: "Types, other for types that are not security, bugfix or enhancement. Repeated or comma separated."},
+				{name: "severity", typ: paramArray, description: "Severities, Unknown for other severities. Repeated or comma separated."}},
+				pageParams),
+			func(p *params) call[tangy.ErrataListResponse] {
+				hrefs, filters, page := p.hrefs(), errataListFilters(p), p.page()
+				return func(ctx context.Context, t tangy.Tangy) (tangy.ErrataListResponse, error) {
+					return t.RpmRepositoryVersionErrataList(ctx, hrefs, filters, page)
+				}
+			}),
+			func(p *params) func(b *tangy.Batch) *tangy.BatchResult[tangy.ErrataListResponse] {
+				filters, page := errataListFilters(p), p.page()
+				return func(b *tangy.Batch) *tangy.BatchResult[tangy.ErrataListResponse] {
+					return b.RpmRepositoryVersionErrataList(filters, page)
+				}
+			}),
+		stream(repositoryVersionsPath+"/rpm/packages/all", "RpmRepositoryVersionPackageAll", "Stream every RPM, with an optional name prefix filter",
+			[]param{rpmHrefsParam, {name: "name", typ: paramString, description: "Name prefix"}},
+			func(p *params) func(ctx context.Context, t tangy.Tangy) iter.Seq2[tangy.RpmListItem, error] {
+				hrefs, filters := p.hrefs(), tangy.RpmListFilters{Name: p.string("name")}
+				return func(ctx context.Context, t tangy.Tangy) iter.Seq2[tangy.RpmListItem, error] {
+					return t.RpmRepositoryVersionPackageAll(ctx, hrefs, filters)
+				}
+			}),
+		stream(repositoryVersionsPath+"/rpm/errata/all", "RpmRepositoryVersionErrataAll", "Stream every erratum, with optional filters",
+			[]param{rpmHrefsParam,
+				{name: "search", typ: paramString, description: "Search term of the id or summary"},
+				{name: "type", typ: paramArray, description: "Types, other for types that are not security, bugfix or enhancement. Repeated or comma separated."},
+				{name: "severity", typ: paramArray, description: "Severities, Unknown for other severities. Repeated or comma separated."}},
+			func(p *params) func(ctx context.Context, t tangy.Tangy) iter.Seq2[tangy.ErrataListItem, error] {
+				hrefs, filters := p.hrefs(), errataListFilters(p)
+				return func(ctx context.Context, t tangy.Tangy) iter.Seq2[tangy.ErrataListItem, error] {
+					return t.RpmRepositoryVersionErrataAll(ctx, hrefs, filters)
+				}
+			}),
+	}
+}
+
+func moduleStreamListFilters(p *params) tangy.ModuleStreamListFilters {
+	return tangy.ModuleStreamListFilters{RpmNames: p.strings("rpm_name"), Search: p.string("search")}
+}
+
+func errataListFilters(p *params) tangy.ErrataListFilters {
+	return tangy.ErrataListFilters{Search: p.string("search"), Type: p.strings("type"), Severity: p.strings("severity")}
+}
+
+func pythonRoutes() []route {
+	return []route{
+		get(repositoriesPath+"/python/packages", "PythonPackageList", "List Python packages",
+			with([]param{repositoryHrefParam, searchParam}, pageParams),
+			func(p *params) call[tangy.PythonPackageListResponse] {
+				href, filters, page := p.string("href"), tangy.PythonPackageListFilters{Search: p.string("search")}, p.page()
+				return func(ctx context.Context, t tangy.Tangy) (tangy.PythonPackageListResponse, error) {
+					return t.PythonPackageList(ctx, href, filters, page)
+				}
+			}),
+		get(repositoriesPath+"/python/distributions", "PythonDistributionList", "List Python distribution files, of every package or of a package",
+			with([]param{repositoryHrefParam, nameNormalizedParam, versionParam}, pageParams),
+			func(p *params) call[tangy.PythonDistributionListResponse] {
+				href, name, version, page := p.string("href"), p.string("name_normalized"), p.string("version"), p.page()
+				return func(ctx context.Context, t tangy.Tangy) (tangy.PythonDistributionListResponse, error) {
+					return t.PythonDistributionList(ctx, href, name, version, page)
+				}
+			}),
+		get(repositoriesPath+"/python/package", "PythonPackageGet", "Get a version of a Python package, the latest one when version is empty",
+			[]param{repositoryHrefParam, required(nameNormalizedParam), versionParam},
+			func(p *params) call[tangy.PythonPackageDetail] {
+				href, name, version := p.string("href"), p.string("name_normalized"), p.string("version")
+				return func(ctx context.Context, t tangy.Tangy) (tangy.PythonPackageDetail, error) {
+					return t.PythonPackageGet(ctx, href, name, version)
+				}
+			}),
+		get(repositoriesPath+"/python/package/versions", "PythonPackageVersionsGet", "Get every version of a Python package",
+			[]param{repositoryHrefParam, required(nameNormalizedParam)},
+			func(p *params) call[[]tangy.PythonPackageDetail] {
+				href, name := p.string("href"), p.string("name_normalized")
+				return func(ctx context.Context, t tangy.Tangy) ([]tangy.PythonPackageDetail, error) {
+					return t.PythonPackageVersionsGet(ctx, href, name)
+				}
+			}),
+		get(repositoriesPath+"/python/builds", "PythonBuildList", "List Python builds, of every package or of a package",
+			with([]param{repositoryHrefParam, nameNormalizedParam, versionParam}, pageParams),
+			func(p *params) call[tangy.PythonBuildListResponse] {
+				href, name, version, page := p.string("href"), p.string("name_normalized"), p.string("version"), p.page()
+				return func(ctx context.Context, t tangy.Tangy) (tangy.PythonBuildListResponse, error) {
+					return t.PythonBuildList(ctx, href, name, version, page)
+				}
+			}),
+		get(repositoriesPath+"/python/metrics", "PythonRepositoryMetrics", "Count the Python packages and distribution files",
+			[]param{repositoryHrefParam},
+			func(p *params) call[tangy.PythonRepositoryMetrics] {
+				href := p.string("href")
+				return func(ctx context.Context, t tangy.Tangy) (tangy.PythonRepositoryMetrics, error) {
+					return t.PythonRepositoryMetrics(ctx, href)
+				}
+			}),
+		get(repositoryVersionsPath+"/python/packages", "PythonRepositoryVersionPackageList", "List Python packages",
+			with([]param{hrefsParam, searchParam}, pageParams),
+			func(p *params) call[tangy.PythonPackageListResponse] {
+				hrefs, filters, page := p.hrefs(), tangy.PythonPackageListFilters{Search: p.string("search")}, p.page()
+				return func(ctx context.Context, t tangy.Tangy) (tangy.PythonPackageListResponse, error) {
+					return t.PythonRepositoryVersionPackageList(ctx, hrefs, filters, page)
+				}
+			}),
+		get(repositoryVersionsPath+"/python/distributions", "PythonRepositoryVersionDistributionList", "List Python distribution files, of every package or of a package",
+			with([]param{hrefsParam, nameNormalizedParam, versionParam}, pageParams),
+			func(p *params) call[tangy.PythonDistributionListResponse] {
+				hrefs, name, version, page := p.hrefs(), p.string("name_normalized"), p.string("version"), p.page()
+				return func(ctx context.Context, t tangy.Tangy) (tangy.PythonDistributionListResponse, error) {
+					return t.PythonRepositoryVersionDistributionList(ctx, hrefs, name, version, page)
+				}
+			}),
+		get(repositoryVersionsPath+"/python/package", "PythonRepositoryVersionPackageGet", "Get a version of a Python package, the latest one when version is empty",
+			[]param{hrefsParam, required(nameNormalizedParam), versionParam},
+			func(p *params) call[tangy.PythonPackageDetail] {
+				hrefs, name, version := p.hrefs(), p.string("name_normalized"), p.string("version")
+				return func(ctx context.Context, t tangy.Tangy) (tangy.PythonPackageDetail, error) {
+					return t.PythonRepositoryVersionPackageGet(ctx, hrefs, name, version)
+				}
+			}),
+		get(repositoryVersionsPath+"/python/package/versions", "PythonRepositoryVersionPackageVersionsGet", "Get every version of a Python package",
+			[]param{hrefsParam, required(nameNormalizedParam)},
+			func(p *params) call[[]tangy.PythonPackageDetail] {
+				hrefs, name := p.hrefs(), p.string("name_normalized")
+				return func(ctx context.Context, t tangy.Tangy) ([]tangy.PythonPackageDetail, error) {
+					return t.PythonRepositoryVersionPackageVersionsGet(ctx, hrefs, name)
+				}
+			}),
+		get(repositoryVersionsPath+"/python/builds", "PythonRepositoryVersionBuildList", "List Python builds, of every package or of a package",
+			with([]param{hrefsParam, nameNormalizedParam, versionParam}, pageParams),
+			func(p *params) call[tangy.PythonBuildListResponse] {
+				hrefs, name, version, page := p.hrefs(), p.string("name_normalized"), p.string("version"), p.page()
+				return func(ctx context.Context, t tangy.Tangy) (tangy.PythonBuildListResponse, error) {
+					return t.PythonRepositoryVersionBuildList(ctx, hrefs, name, version, page)
+				}
+			}),
+		get(repositoryVersionsPath+"/python/metrics", "PythonRepositoryVersionMetrics", "Count the Python packages and distribution files",
+			[]param{hrefsParam},
+			func(p *params) call[tangy.PythonRepositoryMetrics] {
+				hrefs := p.hrefs()
+				return func(ctx context.Context, t tangy.Tangy) (tangy.PythonRepositoryMetrics, error) {
+					return t.PythonRepositoryVersionMetrics(ctx, hrefs)
+				}
+			}),
+		stream(repositoryVersionsPath+"/python/distributions/all", "PythonRepositoryVersionDistributionAll", "Stream every Python distribution file, of every package or of a package",
+			[]param{hrefsParam, nameNormalizedParam, versionParam},
+			func(p *params) func(ctx context.Context, t tangy.Tangy) iter.Seq2[tangy.PythonDistributionListItem, error] {
+				hrefs, name, version := p.hrefs(), p.string("name_normalized"), p.string("version")
+				return func(ctx context.Context, t tangy.Tangy) iter.Seq2[tangy.PythonDistributionListItem, error] {
+					return t.PythonRepositoryVersionDistributionAll(ctx, hrefs, name, version)
+				}
+			}),
+	}
+}
+
+var mavenVersionsParams = []param{
+	{name: "group_id", typ: paramString, description: "Group id"},
+	{name: "artifact_id", typ: paramString, description: "Artifact id"},
+	versionParam,
+}
+
+func mavenRoutes() []route {
+	return []route{
+		get(repositoriesPath+"/maven/packages", "MavenPackageList", "List Maven packages",
+			with([]param{repositoryHrefParam, searchParam}, pageParams),
+			func(p *params) call[tangy.MavenPackageListResponse] {
+				href, filters, page := p.string("href"), tangy.MavenPackageListFilters{Search: p.string("search")}, p.page()
+				return func(ctx context.Context, t tangy.Tangy) (tangy.MavenPackageListResponse, error) {
+					return t.MavenPackageList(ctx, href, filters, page)
+				}
+			}),
+		get(repositoriesPath+"/maven/versions", "MavenVersionsList", "List Maven artifact versions, with optional filters",
+			with([]param{repositoryHrefParam}, mavenVersionsParams, pageParams),
+			func(p *params) call[tangy.MavenVersionsResponse] {
+				href, groupID, artifactID, version, page := p.string("href"), p.string("group_id"), p.string("artifact_id"), p.string("version"), p.page()
+				return func(ctx context.Context, t tangy.Tangy) (tangy.MavenVersionsResponse, error) {
+					return t.MavenVersionsList(ctx, href, groupID, artifactID, version, page)
+				}
+			}),
+		get(repositoriesPath+"/maven/metrics", "MavenRepositoryMetrics", "Count the Maven packages and artifacts",
+			[]param{repositoryHrefParam},
+			func(p *params) call[tangy.MavenRepositoryMetrics] {
+				href := p.string("href")
+				return func(ctx context.Context, t tangy.Tangy) (tangy.MavenRepositoryMetrics, error) {
+					return t.MavenRepositoryMetrics(ctx, href)
+				}
+			}),
+		get(repositoryVersionsPath+"/maven/packages", "MavenRepositoryVersionPackageList", "List Maven packages",
+			with([]param{hrefsParam, searchParam}, pageParams),
+			func(p *params) call[tangy.MavenPackageListResponse] {
+				hrefs, filters, page := p.hrefs(), tangy.MavenPackageListFilters{Search: p.string("search")}, p.page()
+				return func(ctx context.Context, t tangy.Tangy) (tangy.MavenPackageListResponse, error) {
+					return t.MavenRepositoryVersionPackageList(ctx, hrefs, filters, page)
+				}
+			}),
+		get(repositoryVersionsPath+"/maven/versions", "MavenRepositoryVersionVersionsList", "List Maven artifact versions, with optional filters",
+			with([]param{hrefsParam}, mavenVersionsParams, pageParams),
+			func(p *params) call[tangy.MavenVersionsResponse] {
+				hrefs, groupID, artifactID, version, page := p.hrefs(), p.string("group_id"), p.string("artifact_id"), p.string("version"), p.page()
+				return func(ctx context.Context, t tangy.Tangy) (tangy.MavenVersionsResponse, error) {
+					return t.MavenRepositoryVersionVersionsList(ctx, hrefs, groupID, artifactID, version, page)
+				}
+			}),
+		get(repositoryVersionsPath+"/maven/metrics", "MavenRepositoryVersionMetrics", "Count the Maven packages and artifacts",
+			[]param{hrefsParam},
+			func(p *params) call[tangy.MavenRepositoryMetrics] {
+				hrefs := p.hrefs()
+				return func(ctx context.Context, t tangy.Tangy) (tangy.MavenRepositoryMetrics, error) {
+					return t.MavenRepositoryVersionMetrics(ctx, hrefs)
+				}
+			}),
+		stream(repositoryVersionsPath+"/maven/versions/all", "MavenRepositoryVersionVersionsAll", "Stream every Maven artifact version, with optional filters",
+			with([]param{hrefsParam}, mavenVersionsParams),
+			func(p *params) func(ctx context.Context, t tangy.Tangy) iter.Seq2[tangy.MavenVersionsItem, error] {
+				hrefs, groupID, artifactID, version := p.hrefs(), p.string("group_id"), p.string("artifact_id"), p.string("version")
+				return func(ctx context.Context, t tangy.Tangy) iter.Seq2[tangy.MavenVersionsItem, error] {
+					return t.MavenRepositoryVersionVersionsAll(ctx, hrefs, groupID, artifactID, version)
+				}
+			}),
+	}
+}
+
+func npmRoutes() []route {
+	return []route{
+		get(repositoriesPath+"/npm/packages", "NpmPackageList", "List npm packages",
+			with([]param{repositoryHrefParam, searchParam}, pageParams),
+			func(p *params) call[tangy.NpmPackageListResponse] {
+				href, filters, page := p.string("href"), tangy.NpmPackageListFilters{Search: p.string("search")}, p.page()
+				return func(ctx context.Context, t tangy.Tangy) (tangy.NpmPackageListResponse, error) {
+					return t.NpmPackageList(ctx, href, filters, page)
+				}
+			}),
+		get(repositoriesPath+"/npm/package", "NpmPackageGet", "Get a version of an npm package, the latest one when version is empty",
+			[]param{repositoryHrefParam, required(npmNameParam), versionParam},
+			func(p *params) call[tangy.NpmPackageDetail] {
+				href, name, version := p.string("href"), p.string("name"), p.string("version")
+				return func(ctx context.Context, t tangy.Tangy) (tangy.NpmPackageDetail, error) {
+					return t.NpmPackageGet(ctx, href, name, version)
+				}
+			}),
+		get(repositoriesPath+"/npm/package/versions", "NpmPackageVersionsGet", "Get every version of an npm package",
+			[]param{repositoryHrefParam, required(npmNameParam)},
+			func(p *params) call[[]tangy.NpmPackageDetail] {
+				href, name := p.string("href"), p.string("name")
+				return func(ctx context.Context, t tangy.Tangy) ([]tangy.NpmPackageDetail, error) {
+					return t.NpmPackageVersionsGet(ctx, href, name)
+				}
+			}),
+		get(repositoriesPath+"/npm/builds", "NpmBuildList", "List npm builds, of every package or of a package",
+			with([]param{repositoryHrefParam, npmNameParam, versionParam}, pageParams),
+			func(p *params) call[tangy.NpmBuildListResponse] {
+				href, name, version, page := p.string("href"), p.string("name"), p.string("version"), p.page()
+				return func(ctx context.Context, t tangy.Tangy) (tangy.NpmBuildListResponse, error) {
+					return t.NpmBuildList(ctx, href, name, version, page)
+				}
+			}),
+		get(repositoryVersionsPath+"/npm/packages", "NpmRepositoryVersionPackageList", "List npm packages",
+			with([]param{hrefsParam, searchParam}, pageParams),
+			func(p *params) call[tangy.NpmPackageListResponse] {
+				hrefs, filters, page := p.hrefs(), tangy.NpmPackageListFilters{Search: p.string("search")}, p.page()
+				return func(ctx context.Context, t tangy.Tangy) (tangy.NpmPackageListResponse, error) {
+					return t.NpmRepositoryVersionPackageList(ctx, hrefs, filters, page)
+				}
+			}),
+		get(repositoryVersionsPath+"/npm/package", "NpmRepositoryVersionPackageGet", "Get a version of an npm package, the latest one when version is empty",
+			[]param{hrefsParam, required(npmNameParam), versionParam},
+			func(p *params) call[tangy.NpmPackageDetail] {
+				hrefs, name, version := p.hrefs(), p.string("name"), p.string("version")
+				return func(ctx context.Context, t tangy.Tangy) (tangy.NpmPackageDetail, error) {
+					return t.NpmRepositoryVersionPackageGet(ctx, hrefs, name, version)
+				}
+			}),
+		get(repositoryVersionsPath+"/npm/package/versions", "NpmRepositoryVersionPackageVersionsGet", "Get every version of an npm package",
+			[]param{hrefsParam, required(npmNameParam)},
+			func(p *params) call[[]tangy.NpmPackageDetail] {
+				hrefs, name := p.hrefs(), p.string("name")
+				return func(ctx context.Context, t tangy.Tangy) ([]tangy.NpmPackageDetail, error) {
+					return t.NpmRepositoryVersionPackageVersionsGet(ctx, hrefs, name)
+				}
+			}),
+		get(repositoryVersionsPath+"/npm/builds", "NpmRepositoryVersionBuildList", "List npm builds, of every package or of a package",
+			with([]param{hrefsParam, npmNameParam, versionParam}, pageParams),
+			func(p *params) call[tangy.NpmBuildListResponse] {
+				hrefs, name, version, page := p.hrefs(), p.string("name"), p.string("version"), p.page()
+				return func(ctx context.Context, t tangy.Tangy) (tangy.NpmBuildListResponse, error) {
+					return t.NpmRepositoryVersionBuildList(ctx, hrefs, name, version, page)
+				}
+			}),
+		stream(repositoryVersionsPath+"/npm/packages/all", "NpmRepositoryVersionPackageAll", "Stream every npm package",
+			[]param{hrefsParam, searchParam},
+			func(p *params) func(ctx context.Context, t tangy.Tangy) iter.Seq2[tangy.NpmPackageListItem, error] {
+				hrefs, filters := p.hrefs(), tangy.NpmPackageListFilters{Search: p.string("search")}
+				return func(ctx context.Context, t tangy.Tangy) iter.Seq2[tangy.NpmPackageListItem, error] {
+					return t.NpmRepositoryVersionPackageAll(ctx, hrefs, filters)
+				}
+			}),
+	}
+}
@@ -0,0 +1,203 @@
+// Package tangyd serves the methods of a Tangy as a versioned REST/JSON API, documented by an OpenAPI document
+// generated from the routes and their response types.
+package tangyd
+
+import (
+	"context"
+	"encoding/json"
+	"iter"
+	"net/http"
+	"reflect"
+
+	"github.com/content-services/tang/pkg/tangy"
+	"github.com/content-services/tang/pkg/tangy/tangyprom"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/rs/zerolog/log"
+)
+
+// RequestIDHeader is the header of the id of a request, passed to tangy.WithRequestID
+const RequestIDHeader = "X-Request-Id"
+
+// OpenAPIPath is the path of the OpenAPI document of the API
+const OpenAPIPath = "/v1/openapi.json"
+
+// route is an endpoint of the API calling a Tangy method
+type route struct {
+	// method is the HTTP method of the route
+	method string
+	path   string
+	// operation is the name of the Tangy method the route calls, the operation id of the route
+	operation string
+	summary   string
+	params    []param
+	// body is the type of the request body, when the route reads one
+	body reflect.Type
+	// response is the type of the response body, or of each line of the response of a stream
+	response reflect.Type
+	// stream is set for routes streaming their results as JSON lines
+	stream bool
+	serve  func(t tangy.Tangy, w http.ResponseWriter, r *http.Request)
+	// batch adds the call of the route to a batch, for the routes of the methods of tangy.Batch
+	batch func(p *params) func(b *tangy.Batch) func() (any, error)
+}
+
+// call is a call of a Tangy method, with the arguments read from the parameters of a request
+type call[T any] func(ctx context.Context, t tangy.Tangy) (T, error)
+
+// get returns a GET route calling a Tangy method with arguments read by handle, responding with its result
+func get[T any](path, operation, summary string, params []param, handle func(p *params) call[T]) route {
+	return route{
+		method:    http.MethodGet,
+		path:      path,
+		operation: operation,
+		summary:   summary,
+		params:    params,
+		response:  reflect.TypeFor[T](),
+		serve: func(t tangy.Tangy, w http.ResponseWriter, r *http.Request) {
+			p := newParams(r.URL.Query(), params)
+			c := handle(p)
+			if p.err != nil {
+				writeError(w, p.err)
+				return
+			}
+			result, err := c(r.Context(), t)
+			if err != nil {
+				writeError(w, err)
+				return
+			}
+			writeJSON(w, http.StatusOK, result)
+		},
+	}
+}
+
+// stream returns a GET route calling a Tangy iterator with arguments read by handle, responding with a JSON line
+// for each item. An error once the first item was sent ends the response with a line holding an ErrorResponse.
+func stream[T any](path, operation, summary string, params []param, handle func(p *params) func(ctx context.Context, t tangy.Tangy) iter.Seq2[T, error]) route {
+	return route{
+		method:    http.MethodGet,
+		path:      path,
+		operation: operation,
+		summary:   summary,
+		params:    params,
+		response:  reflect.TypeFor[T](),
+		stream:    true,
+		serve: func(t tangy.Tangy, w http.ResponseWriter, r *http.Request) {
+			p := newParams(r.URL.Query(), params)
+			items := handle(p)
+			if p.err != nil {
+				writeError(w, p.err)
+				return
+			}
+			encoder := json.NewEncoder(w)
+			started := false
+			for item, err := range items(r.Context(), t) {
+				if err != nil && !started {
+					writeError(w, err)
+					return
+				}
+				if !started {
+					w.Header().Set("Content-Type", "application/x-ndjson")
+					w.WriteHeader(http.StatusOK)
+					started = true
+				}
+				if err != nil {
+					log.Error().Err(err).Str("path", r.URL.Path).Msg("Error streaming tangyd response")
+					_ = encoder.Encode(ErrorResponse{Error: newError(err)})
+					return
+				}
+				if encoder.Encode(item) != nil {
+					// The client went away, breaking the loop ends the iterator
+					return
+				}
+			}
+			if !started {
+				w.Header().Set("Content-Type", "application/x-ndjson")
+				w.WriteHeader(http.StatusOK)
+			}
+		},
+	}
+}
+
+// batched sets the function adding the call of a route to a batch. add reads the arguments of the call
+// from its parameters, and returns a function adding it to a batch.
+func batched[T any](r route, add func(p *params) func(b *tangy.Batch) *tangy.BatchResult[T]) route {
+	r.batch = func(p *params) func(b *tangy.Batch) func() (any, error) {
+		addCall := add(p)
+		return func(b *tangy.Batch) func() (any, error) {
+			result := addCall(b)
+			return func() (any, error) {
+				return result.Result()
+			}
+		}
+	}
+	return r
+}
+
+// New returns the handler of the API serving t: the routes of the Tangy methods under /v1, the OpenAPI document
+// at OpenAPIPath, the /healthz and /readyz health checks, and the Prometheus metrics of t at /metrics
+func New(t tangy.Tangy) http.Handler {
+	mux := http.NewServeMux()
+	routes := apiRoutes()
+	for _, rt := range routes {
+		mux.HandleFunc(rt.method+" "+rt.path, func(w http.ResponseWriter, r *http.Request) {
+			rt.serve(t, w, r)
+		})
+	}
+
+	document, err := json.Marshal(openAPIDocument(routes))
+	if err != nil {
+		panic(err)
+	}
+	mux.HandleFunc("GET "+OpenAPIPath, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(document)
+	})
+
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, r *http.Request) {
+		serveHealth(w, r, t.Ping)
+	})
+	mux.HandleFunc("GET /readyz", func(w http.ResponseWriter, r *http.Request) {
+		serveHealth(w, r, t.Ready)
+	})
+
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(tangyprom.NewCollector(t), collectors.NewGoCollector(), collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
+	mux.Handle("GET /metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requestID := r.Header.Get(RequestIDHeader); requestID != "" {
+			r = r.WithContext(tangy.WithRequestID(r.Context(), requestID))
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+// HealthResponse is the body of a successful health check
+type HealthResponse struct {
+	Status string `json:"status"`
+}
+
+// serveHealth serves a health check. A failed check is unavailable, whatever its error.
+func serveHealth(w http.ResponseWriter, r *http.Request, check func(ctx context.Context) error) {
+	if err := check(r.Context()); err != nil {
+		log.Warn().Err(err).Str("path", r.URL.Path).Msg("tangyd health check failed")
+		writeJSON(w, http.StatusServiceUnavailable, ErrorResponse{Error: newError(err)})
+		return
+	}
+	writeJSON(w, http.StatusOK, HealthResponse{Status: "ok"})
+}
+
+// writeJSON writes v as the JSON body of a response with status
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		log.Error().Err(err).Msg("Error encoding tangyd response")
+		status = http.StatusInternalServerError
+		body, _ = json.Marshal(ErrorResponse{Error: Error{Class: tangy.ErrorClassOther, Message: "error encoding response"}})
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(append(body, '\n'))
+}
@@ -0,0 +1,365 @@
+package tangyd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/content-services/tang/pkg/tangy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testHref = "/pulp/e1c6bee3/api/v3/repositories/rpm/rpm/018c1c95-4281-76eb-b277-842cbad524f4/versions/1/"
+
+// serve serves a request of the API of t, returning the response
+func serve(t tangy.Tangy, method, target, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	New(t).ServeHTTP(w, httptest.NewRequest(method, target, strings.NewReader(body)))
+	return w
+}
+
+// decode decodes the JSON body of a response
+func decode[T any](t *testing.T, w *httptest.ResponseRecorder) T {
+	t.Helper()
+	var v T
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &v), w.Body.String())
+	return v
+}
+
+// TestRoutesCoverTangy checks that every Tangy method running queries has a route, with the method name as operation
+func TestRoutesCoverTangy(t *testing.T) {
+	t.Parallel()
+
+	var operations []string
+	for _, r := range apiRoutes() {
+		assert.NotContains(t, operations, r.operation)
+		operations = append(operations, r.operation)
+	}
+	var methods []string
+	tangyType := reflect.TypeFor[tangy.Tangy]()
+	for i := range tangyType.NumMethod() {
+		if name := tangyType.Method(i).Name; !slices.Contains([]string{"Close", "ReadSnapshot", "Ping", "Ready"}, name) {
+			methods = append(methods, name)
+		}
+	}
+	assert.ElementsMatch(t, methods, operations)
+}
+
+// TestRoutesServe calls every route with its required parameters, checking that the route only reads the
+// parameters it declares and calls its Tangy method
+func TestRoutesServe(t *testing.T) {
+	t.Parallel()
+
+	for _, r := range apiRoutes() {
+		if r.body != nil {
+			continue
+		}
+		t.Run(r.operation, func(t *testing.T) {
+			t.Parallel()
+
+			method, ok := reflect.TypeFor[tangy.Tangy]().MethodByName(r.operation)
+			require.True(t, ok)
+			args := make([]any, method.Type.NumIn())
+			for i := range args {
+				args[i] = mock.Anything
+			}
+			var results []any
+			for i := range method.Type.NumOut() {
+				out := method.Type.Out(i)
+				switch {
+				case out.Kind() == reflect.Func:
+					// An iterator yielding no item
+					results = append(results, reflect.MakeFunc(out, func([]reflect.Value) []reflect.Value { return nil }).Interface())
+				case out == reflect.TypeFor[error]():
+					results = append(results, nil)
+				default:
+					results = append(results, reflect.Zero(out).Interface())
+				}
+			}
+			m := tangy.NewMockTangy(t)
+			m.On(r.operation, args...).Return(results...).Once()
+
+			query := url.Values{}
+			for _, p := range r.params {
+				if p.required {
+					query.Set(p.name, testHref)
+				}
+			}
+			w := serve(m, r.method, r.path+"?"+query.Encode(), "")
+			assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		})
+	}
+}
+
+func TestPackageListParams(t *testing.T) {
+	t.Parallel()
+
+	secondHref := strings.Replace(testHref, "versions/1", "versions/2", 1)
+	response := tangy.RpmListResponse{Results: []tangy.RpmListItem{{Name: "bear", Version: "4.1"}}, Total: 1, TotalMode: tangy.CountNone, Limit: 10}
+	m := tangy.NewMockTangy(t)
+	m.EXPECT().RpmRepositoryVersionPackageList(mock.Anything, []string{testHref, secondHref}, tangy.RpmListFilters{Name: "be"},
+		tangy.PageOptions{Offset: 5, Limit: 10, SortBy: "name:desc", Cursor: "cursor", Count: tangy.CountNone}).Return(response, nil).Once()
+
+	query := url.Values{"href": {testHref, secondHref}, "name": {"be"}, "offset": {"5"}, "limit": {"10"},
+		"sort_by": {"name:desc"}, "cursor": {"cursor"}, "count": {"none"}}
+	w := serve(m, http.MethodGet, repositoryVersionsPath+"/rpm/packages?"+query.Encode(), "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	assert.Equal(t, response, decode[tangy.RpmListResponse](t, w))
+}
+
+func TestInvalidParams(t *testing.T) {
+	t.Parallel()
+
+	for name, target := range map[string]string{
+		"missing href":            repositoryVersionsPath + "/rpm/packages",
+		"invalid limit":           repositoryVersionsPath + "/rpm/packages?href=" + testHref + "&limit=ten",
+		"limit over maximum":      repositoryVersionsPath + "/rpm/packages?href=" + testHref + "&limit=1001",
+		"search limit over max":   repositoryVersionsPath + "/rpm/packages/search?href=" + testHref + "&limit=100000",
+		"negative offset":         repositoryVersionsPath + "/rpm/packages?href=" + testHref + "&offset=-1",
+		"missing name_normalized": repositoriesPath + "/python/package?href=" + testHref,
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			w := serve(tangy.NewMockTangy(t), http.MethodGet, target, "")
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			response := decode[ErrorResponse](t, w)
+			assert.Equal(t, tangy.ErrorClassInvalid, response.Error.Class)
+			assert.Contains(t, response.Error.Message, errInvalidParameter.Error())
+		})
+	}
+}
+
+func TestErrorStatuses(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		err    error
+		status int
+		class  tangy.ErrorClass
+	}{
+		{tangy.ErrInvalidHref, http.StatusBadRequest, tangy.ErrorClassInvalid},
+		{tangy.ErrInvalidCursor, http.StatusBadRequest, tangy.ErrorClassInvalid},
+		{fmt.Errorf("%w: %s", tangy.ErrRepositoryVersionNotFound, testHref), http.StatusNotFound, tangy.ErrorClassNotFound},
+		{tangy.ErrQueryTimeout, http.StatusGatewayTimeout, tangy.ErrorClassTimeout},
+		{context.Canceled, statusClientClosedRequest, tangy.ErrorClassCanceled},
+		{errors.New("unexpected"), http.StatusInternalServerError, tangy.ErrorClassOther},
+	} {
+		t.Run(tc.err.Error(), func(t *testing.T) {
+			t.Parallel()
+
+			m := tangy.NewMockTangy(t)
+			m.EXPECT().RpmRepositoryVersionPackageSearch(mock.Anything, []string{testHref}, "bear", 0).Return(nil, tc.err).Once()
+
+			w := serve(m, http.MethodGet, repositoryVersionsPath+"/rpm/packages/search?search=bear&href="+testHref, "")
+			assert.Equal(t, tc.status, w.Code)
+			assert.Equal(t, Error{Class: tc.class, Message: tc.err.Error()}, decode[ErrorResponse](t, w).Error)
+		})
+	}
+}
+
+func TestStream(t *testing.T) {
+	t.Parallel()
+
+	items := []tangy.RpmListItem{{Name: "bear"}, {Name: "cat"}}
+	streamErr := fmt.Errorf("%w: %s", tangy.ErrRepositoryVersionNotFound, testHref)
+	// yieldItems returns an iterator yielding items, then err when it is not nil
+	yieldItems := func(items []tangy.RpmListItem, err error) iter.Seq2[tangy.RpmListItem, error] {
+		return func(yield func(tangy.RpmListItem, error) bool) {
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			if err != nil {
+				yield(tangy.RpmListItem{}, err)
+			}
+		}
+	}
+	target := repositoryVersionsPath + "/rpm/packages/all?href=" + testHref
+
+	t.Run("items", func(t *testing.T) {
+		t.Parallel()
+
+		m := tangy.NewMockTangy(t)
+		m.EXPECT().RpmRepositoryVersionPackageAll(mock.Anything, []string{testHref}, tangy.RpmListFilters{}).Return(yieldItems(items, nil)).Once()
+
+		w := serve(m, http.MethodGet, target, "")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+		lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+		require.Len(t, lines, len(items))
+		for i, line := range lines {
+			var item tangy.RpmListItem
+			require.NoError(t, json.Unmarshal([]byte(line), &item))
+			assert.Equal(t, items[i], item)
+		}
+	})
+
+	t.Run("error before the first item", func(t *testing.T) {
+		t.Parallel()
+
+		m := tangy.NewMockTangy(t)
+		m.EXPECT().RpmRepositoryVersionPackageAll(mock.Anything, []string{testHref}, tangy.RpmListFilters{}).Return(yieldItems(nil, streamErr)).Once()
+
+		w := serve(m, http.MethodGet, target, "")
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Equal(t, tangy.ErrorClassNotFound, decode[ErrorResponse](t, w).Error.Class)
+	})
+
+	t.Run("error after the first item", func(t *testing.T) {
+		t.Parallel()
+
+		m := tangy.NewMockTangy(t)
+		m.EXPECT().RpmRepositoryVersionPackageAll(mock.Anything, []string{testHref}, tangy.RpmListFilters{}).Return(yieldItems(items[:1], streamErr)).Once()
+
+		w := serve(m, http.MethodGet, target, "")
+		assert.Equal(t, http.StatusOK, w.Code)
+		lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+		require.Len(t, lines, 2)
+		var response ErrorResponse
+		require.NoError(t, json.Unmarshal([]byte(lines[1]), &response))
+		assert.Equal(t, Error{Class: tangy.ErrorClassNotFound, Message: streamErr.Error()}, response.Error)
+	})
+}
+
+func TestBatch(t *testing.T) {
+	t.Parallel()
+
+	target := repositoryVersionsPath + "/rpm/batch"
+
+	t.Run("calls", func(t *testing.T) {
+		t.Parallel()
+
+		m := tangy.NewMockTangy(t)
+		m.EXPECT().RpmRepositoryVersionBatch(mock.Anything, []string{testHref}, mock.Anything).Return(nil).Once()
+
+		body := `{"hrefs": ["` + testHref + `"], "calls": [
+			{"operation": "RpmRepositoryVersionPackageList", "params": {"name": ["bear"], "limit": ["10"]}},
+			{"operation": "PythonPackageList"},
+			{"operation": "RpmRepositoryVersionErrataList", "params": {"limit": ["ten"]}},
+			{"operation": "RpmRepositoryVersionPackageSearch", "params": {"search": ["bear"]}}
+		]}`
+		w := serve(m, http.MethodPost, target, body)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		response := decode[BatchResponse](t, w)
+		require.Len(t, response.Results, 4)
+		// The mock does not run the batch, leaving the calls it added without results
+		for _, i := range []int{0, 3} {
+			require.NotNil(t, response.Results[i].Error)
+			assert.Equal(t, tangy.ErrBatchNotRun.Error(), response.Results[i].Error.Message)
+			assert.Equal(t, tangy.ErrorClassInvalid, response.Results[i].Error.Class)
+		}
+		for _, i := range []int{1, 2} {
+			require.NotNil(t, response.Results[i].Error)
+			assert.Equal(t, tangy.ErrorClassInvalid, response.Results[i].Error.Class)
+		}
+	})
+
+	t.Run("batch error", func(t *testing.T) {
+		t.Parallel()
+
+		m := tangy.NewMockTangy(t)
+		m.EXPECT().RpmRepositoryVersionBatch(mock.Anything, []string{"invalid"}, mock.Anything).Return(tangy.ErrInvalidHref).Once()
+
+		w := serve(m, http.MethodPost, target, `{"hrefs": ["invalid"], "calls": [{"operation": "RpmRepositoryVersionPackageSearch"}]}`)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, tangy.ErrorClassInvalid, decode[ErrorResponse](t, w).Error.Class)
+	})
+
+	for name, body := range map[string]string{
+		"missing hrefs": `{"calls": [{"operation": "RpmRepositoryVersionPackageSearch"}]}`,
+		"unknown field": `{"hrefs": ["` + testHref + `"], "call": []}`,
+		"invalid json":  `{"hrefs": [`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			w := serve(tangy.NewMockTangy(t), http.MethodPost, target, body)
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Equal(t, tangy.ErrorClassInvalid, decode[ErrorResponse](t, w).Error.Class)
+		})
+	}
+}
+
+func TestHealth(t *testing.T) {
+	t.Parallel()
+
+	m := tangy.NewMockTangy(t)
+	m.EXPECT().Ping(mock.Anything).Return(nil).Once()
+	m.EXPECT().Ready(mock.Anything).Return(errors.New("replica lagging")).Once()
+
+	w := serve(m, http.MethodGet, "/healthz", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, HealthResponse{Status: "ok"}, decode[HealthResponse](t, w))
+
+	w = serve(m, http.MethodGet, "/readyz", "")
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Equal(t, "replica lagging", decode[ErrorResponse](t, w).Error.Message)
+}
+
+func TestOpenAPIDocument(t *testing.T) {
+	t.Parallel()
+
+	w := serve(tangy.NewMockTangy(t), http.MethodGet, OpenAPIPath, "")
+	require.Equal(t, http.StatusOK, w.Code)
+	document := decode[map[string]any](t, w)
+	assert.Equal(t, openAPIVersion, document["openapi"])
+
+	paths, _ := document["paths"].(map[string]any)
+	for _, r := range apiRoutes() {
+		path, _ := paths[r.path].(map[string]any)
+		operation, _ := path[strings.ToLower(r.method)].(map[string]any)
+		require.NotNil(t, operation, r.path)
+		assert.Equal(t, r.operation, operation["operationId"])
+	}
+
+	components, _ := document["components"].(map[string]any)
+	schemas, _ := components["schemas"].(map[string]any)
+	for _, name := range []string{"RpmListResponse", "RpmListItem", "ErrorResponse", "BatchRequest", "Stats"} {
+		assert.Contains(t, schemas, name)
+	}
+	// Every reference is to a component of the document
+	for _, ref := range documentRefs(w.Body.String()) {
+		assert.Contains(t, schemas, strings.TrimPrefix(ref, "#/components/schemas/"))
+	}
+
+	listResponse, _ := schemas["RpmListResponse"].(map[string]any)
+	assert.ElementsMatch(t, []any{"results", "total", "total_mode", "limit", "offset"}, listResponse["required"])
+
+	// The maximum of limit is documented
+	packages, _ := paths[repositoryVersionsPath+"/rpm/packages"].(map[string]any)
+	get, _ := packages["get"].(map[string]any)
+	parameters, _ := get["parameters"].([]any)
+	var limit map[string]any
+	for _, parameter := range parameters {
+		if p, _ := parameter.(map[string]any); p["name"] == "limit" {
+			limit = p
+		}
+	}
+	require.NotNil(t, limit)
+	assert.Contains(t, limit["description"], "at most 1000")
+	assert.Equal(t, map[string]any{"type": "integer", "maximum": float64(maxLimit)}, limit["schema"])
+}
+
+// documentRefs returns the references of a JSON document
+func documentRefs(document string) []string {
+	var refs []string
+	for _, part := range strings.Split(document, `"$ref":"`)[1:] {
+		ref, _, _ := strings.Cut(part, `"`)
+		refs = append(refs, ref)
+	}
+	return refs
+}
@@ -163,6 +163,48 @@ func (m *MavenSuite) TestMavenPackageListPagination() {
 	assert.Equal(m.T(), 1, response.Total)
 }
 
+func (m *MavenSuite) TestMavenPackageListVersionHref() {
+	latest, err := m.tangy.MavenPackageList(context.Background(), m.repositoryHref, tangy.MavenPackageListFilters{}, tangy.PageOptions{Limit: 10})
+	require.NoError(m.T(), err)
+	require.NotEmpty(m.T(), latest.Results)
+
+	// the first version holds the synced content, the same as the latest version
+	response, err := m.tangy.MavenPackageList(context.Background(), m.repositoryHref+"versions/1/", tangy.MavenPackageListFilters{}, tangy.PageOptions{Limit: 10})
+	require.NoError(m.T(), err)
+	assert.Equal(m.T(), latest, response)
+
+	// version 0 of a repository is always empty
+	response, err = m.tangy.MavenPackageList(context.Background(), m.repositoryHref+"versions/0/", tangy.MavenPackageListFilters{}, tangy.PageOptions{Limit: 10})
+	require.NoError(m.T(), err)
+	assert.Empty(m.T(), response.Results)
+	assert.Zero(m.T(), response.Total)
+
+	_, err = m.tangy.MavenPackageList(context.Background(), m.repositoryHref+"versions/latest/", tangy.MavenPackageListFilters{}, tangy.PageOptions{Limit: 10})
+	assert.ErrorIs(m.T(), err, tangy.ErrInvalidHref)
+}
+
+func (m *MavenSuite) TestMavenRepositoryVersionPackageList() {
+	hrefs := []string{m.repositoryHref, m.repositoryHref + "versions/1/"}
+
+	single, err := m.tangy.MavenPackageList(context.Background(), m.repositoryHref, tangy.MavenPackageListFilters{}, tangy.PageOptions{Limit: 10})
+	require.NoError(m.T(), err)
+	require.NotEmpty(m.T(), single.Results)
+
+	// both hrefs hold the same content, so every package is returned once
+	response, err := m.tangy.MavenRepositoryVersionPackageList(context.Background(), hrefs, tangy.MavenPackageListFilters{}, tangy.PageOptions{Limit: 10})
+	require.NoError(m.T(), err)
+	assert.Equal(m.T(), single, response)
+	for _, pkg := range response.Results {
+		assert.Equal(m.T(), []string{m.repositoryHref}, pkg.Repositories)
+	}
+
+	metrics, err := m.tangy.MavenRepositoryVersionMetrics(context.Background(), hrefs)
+	require.NoError(m.T(), err)
+	singleMetrics, err := m.tangy.MavenRepositoryMetrics(context.Background(), m.repositoryHref)
+	require.NoError(m.T(), err)
+	assert.Equal(m.T(), singleMetrics, metrics)
+}
+
 func (m *MavenSuite) TestMavenPackageListEmptyHref() {
 	response, err := m.tangy.MavenPackageList(context.Background(), "", tangy.MavenPackageListFilters{}, tangy.PageOptions{Limit: 10})
 	require.NoError(m.T(), err)
@@ -227,6 +269,33 @@ func (m *MavenSuite) TestMavenVersionsListPagination() {
 	assert.Equal(m.T(), 2, response.Total)
 }
 
+func (m *MavenSuite) TestMavenRepositoryVersionVersionsList() {
+	hrefs := []string{m.repositoryHref, m.repositoryHref + "versions/1/"}
+
+	single, err := m.tangy.MavenVersionsList(context.Background(), m.repositoryHref, "", "", "", tangy.PageOptions{Limit: 10})
+	require.NoError(m.T(), err)
+	require.NotEmpty(m.T(), single.Results)
+
+	response, err := m.tangy.MavenRepositoryVersionVersionsList(context.Background(), hrefs, "", "", "", tangy.PageOptions{Limit: 10})
+	require.NoError(m.T(), err)
+	assert.Equal(m.T(), single, response)
+	for _, version := range response.Results {
+		assert.Equal(m.T(), []string{m.repositoryHref}, version.Repositories)
+	}
+}
+
+func (m *MavenSuite) TestMavenRepositoryVersionVersionsAll() {
+	list, err := m.tangy.MavenVersionsList(context.Background(), m.repositoryHref, "", "", "", tangy.PageOptions{Limit: 100})
+	require.NoError(m.T(), err)
+
+	var versions []tangy.MavenVersionsItem
+	for version, err := range m.tangy.MavenRepositoryVersionVersionsAll(context.Background(), []string{m.repositoryHref}, "", "", "") {
+		require.NoError(m.T(), err)
+		versions = append(versions, version)
+	}
+	assert.Equal(m.T(), list.Results, versions)
+}
+
 func (m *MavenSuite) TestMavenVersionsListEmptyHref() {
 	response, err := m.tangy.MavenVersionsList(context.Background(), "", testMavenGroupID, testMavenArtifactID, testMavenBaseVersion100, tangy.PageOptions{Limit: 10})
 	require.NoError(m.T(), err)
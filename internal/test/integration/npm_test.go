@@ -137,6 +137,71 @@ func (n *NpmSuite) TestNpmPackageListPagination() {
 	assert.Equal(n.T(), 1, response.Total)
 }
 
+func (n *NpmSuite) TestNpmPackageListVersionHref() {
+	latest, err := n.tangy.NpmPackageList(context.Background(), n.repositoryHref, tangy.NpmPackageListFilters{}, tangy.PageOptions{Limit: 10})
+	require.NoError(n.T(), err)
+	require.NotEmpty(n.T(), latest.Results)
+
+	// the first version holds the synced content, the same as the latest version
+	response, err := n.tangy.NpmPackageList(context.Background(), n.repositoryHref+"versions/1/", tangy.NpmPackageListFilters{}, tangy.PageOptions{Limit: 10})
+	require.NoError(n.T(), err)
+	assert.Equal(n.T(), latest, response)
+
+	// version 0 of a repository is always empty
+	response, err = n.tangy.NpmPackageList(context.Background(), n.repositoryHref+"versions/0/", tangy.NpmPackageListFilters{}, tangy.PageOptions{Limit: 10})
+	require.NoError(n.T(), err)
+	assert.Empty(n.T(), response.Results)
+	assert.Zero(n.T(), response.Total)
+
+	_, err = n.tangy.NpmPackageList(context.Background(), n.repositoryHref+"versions/latest/", tangy.NpmPackageListFilters{}, tangy.PageOptions{Limit: 10})
+	assert.ErrorIs(n.T(), err, tangy.ErrInvalidHref)
+}
+
+func (n *NpmSuite) TestNpmRepositoryVersionPackageList() {
+	hrefs := []string{n.repositoryHref, n.repositoryHref + "versions/1/"}
+
+	single, err := n.tangy.NpmPackageList(context.Background(), n.repositoryHref, tangy.NpmPackageListFilters{}, tangy.PageOptions{Limit: 10})
+	require.NoError(n.T(), err)
+	require.NotEmpty(n.T(), single.Results)
+
+	// both hrefs hold the same content, so every package is returned once
+	response, err := n.tangy.NpmRepositoryVersionPackageList(context.Background(), hrefs, tangy.NpmPackageListFilters{}, tangy.PageOptions{Limit: 10})
+	require.NoError(n.T(), err)
+	assert.Equal(n.T(), single, response)
+	for _, pkg := range response.Results {
+		assert.Equal(n.T(), []string{n.repositoryHref}, pkg.Repositories)
+	}
+
+	builds, err := n.tangy.NpmRepositoryVersionBuildList(context.Background(), hrefs, testNpmPackageName, "", tangy.PageOptions{Limit: 10})
+	require.NoError(n.T(), err)
+	singleBuilds, err := n.tangy.NpmBuildList(context.Background(), n.repositoryHref, testNpmPackageName, "", tangy.PageOptions{Limit: 10})
+	require.NoError(n.T(), err)
+	assert.Equal(n.T(), singleBuilds, builds)
+
+	detail, err := n.tangy.NpmRepositoryVersionPackageGet(context.Background(), hrefs, testNpmPackageName, testNpmVersion)
+	require.NoError(n.T(), err)
+	assert.Equal(n.T(), testNpmVersion, detail.Version)
+	assert.Equal(n.T(), []string{n.repositoryHref}, detail.Repositories)
+
+	versions, err := n.tangy.NpmRepositoryVersionPackageVersionsGet(context.Background(), hrefs, testNpmPackageName)
+	require.NoError(n.T(), err)
+	singleVersions, err := n.tangy.NpmPackageVersionsGet(context.Background(), n.repositoryHref, testNpmPackageName)
+	require.NoError(n.T(), err)
+	assert.Equal(n.T(), singleVersions, versions)
+}
+
+func (n *NpmSuite) TestNpmRepositoryVersionPackageAll() {
+	list, err := n.tangy.NpmPackageList(context.Background(), n.repositoryHref, tangy.NpmPackageListFilters{}, tangy.PageOptions{Limit: 100})
+	require.NoError(n.T(), err)
+
+	var packages []tangy.NpmPackageListItem
+	for pkg, err := range n.tangy.NpmRepositoryVersionPackageAll(context.Background(), []string{n.repositoryHref}, tangy.NpmPackageListFilters{}) {
+		require.NoError(n.T(), err)
+		packages = append(packages, pkg)
+	}
+	assert.Equal(n.T(), list.Results, packages)
+}
+
 func (n *NpmSuite) TestNpmPackageListEmptyHref() {
 	response, err := n.tangy.NpmPackageList(context.Background(), "", tangy.NpmPackageListFilters{}, tangy.PageOptions{Limit: 10})
 	require.NoError(n.T(), err)
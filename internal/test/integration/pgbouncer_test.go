@@ -0,0 +1,62 @@
+package integration
+
+import (
+	"context"
+	"sync"
+
+	"github.com/content-services/tang/internal/config"
+	"github.com/content-services/tang/pkg/tangy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPgBouncerTransactionMode runs calls concurrently through PgBouncer in transaction pooling mode, where each
+// transaction may run on a different server connection, so statements prepared by a previous transaction are missing
+func (r *RpmSuite) TestPgBouncerTransactionMode() {
+	dbConfig := config.Get().Database
+	if dbConfig.PgBouncerPort == 0 {
+		r.T().Skip("database.pgbouncer_port is not set")
+	}
+
+	hrefs := []string{r.firstVersionHref, r.secondVersionHref}
+	for _, mode := range []string{tangy.QueryExecModeDescribeExec, tangy.QueryExecModeSimpleProtocol} {
+		r.Run(mode, func() {
+			ta, err := tangy.New(tangy.Database{
+				Name:          dbConfig.Name,
+				Host:          dbConfig.Host,
+				Port:          dbConfig.PgBouncerPort,
+				User:          dbConfig.User,
+				Password:      dbConfig.Password,
+				PoolLimit:     8,
+				QueryExecMode: mode,
+			}, tangy.Logger{})
+			require.NoError(r.T(), err)
+			defer ta.Close()
+
+			var wg sync.WaitGroup
+			for range 16 {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for range 5 {
+						list, err := ta.RpmRepositoryVersionPackageList(context.Background(), hrefs, tangy.RpmListFilters{}, tangy.PageOptions{Limit: 5})
+						if assert.NoError(r.T(), err) {
+							assert.Equal(r.T(), 12, list.Total)
+						}
+
+						_, err = ta.RpmRepositoryVersionErrataList(context.Background(), hrefs, tangy.ErrataListFilters{}, tangy.PageOptions{})
+						assert.NoError(r.T(), err)
+
+						err = ta.ReadSnapshot(context.Background(), func(snapshot tangy.Tangy) error {
+							search, err := snapshot.RpmRepositoryVersionPackageSearch(context.Background(), hrefs, "peng", 100)
+							assert.NotEmpty(r.T(), search)
+							return err
+						})
+						assert.NoError(r.T(), err)
+					}
+				}()
+			}
+			wg.Wait()
+		})
+	}
+}
@@ -141,6 +141,98 @@ func (p *PythonSuite) TestPythonPackageListPagination() {
 	assert.Equal(p.T(), 1, response.Total)
 }
 
+func (p *PythonSuite) TestPythonPackageListCursor() {
+	response, err := p.tangy.PythonPackageList(context.Background(), p.repositoryHref, tangy.PythonPackageListFilters{}, tangy.PageOptions{Limit: 1})
+	require.NoError(p.T(), err)
+	assert.Len(p.T(), response.Results, 1)
+	require.NotEmpty(p.T(), response.NextCursor)
+
+	response, err = p.tangy.PythonPackageList(context.Background(), p.repositoryHref, tangy.PythonPackageListFilters{}, tangy.PageOptions{
+		Limit:  1,
+		Cursor: response.NextCursor,
+	})
+	require.NoError(p.T(), err)
+	assert.Empty(p.T(), response.Results)
+	assert.Empty(p.T(), response.NextCursor)
+	assert.Equal(p.T(), 1, response.Total)
+
+	_, err = p.tangy.PythonPackageList(context.Background(), p.repositoryHref, tangy.PythonPackageListFilters{}, tangy.PageOptions{Cursor: "garbage"})
+	assert.ErrorIs(p.T(), err, tangy.ErrInvalidCursor)
+}
+
+func (p *PythonSuite) TestPythonPackageListVersionHref() {
+	latest, err := p.tangy.PythonPackageList(context.Background(), p.repositoryHref, tangy.PythonPackageListFilters{}, tangy.PageOptions{Limit: 10})
+	require.NoError(p.T(), err)
+	require.NotEmpty(p.T(), latest.Results)
+
+	// the first version holds the synced content, the same as the latest version
+	response, err := p.tangy.PythonPackageList(context.Background(), p.repositoryHref+"versions/1/", tangy.PythonPackageListFilters{}, tangy.PageOptions{Limit: 10})
+	require.NoError(p.T(), err)
+	assert.Equal(p.T(), latest, response)
+
+	// version 0 of a repository is always empty
+	response, err = p.tangy.PythonPackageList(context.Background(), p.repositoryHref+"versions/0/", tangy.PythonPackageListFilters{}, tangy.PageOptions{Limit: 10})
+	require.NoError(p.T(), err)
+	assert.Empty(p.T(), response.Results)
+	assert.Zero(p.T(), response.Total)
+
+	_, err = p.tangy.PythonPackageList(context.Background(), p.repositoryHref+"versions/latest/", tangy.PythonPackageListFilters{}, tangy.PageOptions{Limit: 10})
+	assert.ErrorIs(p.T(), err, tangy.ErrInvalidHref)
+}
+
+func (p *PythonSuite) TestPythonRepositoryVersionPackageList() {
+	hrefs := []string{p.repositoryHref, p.repositoryHref + "versions/1/"}
+
+	single, err := p.tangy.PythonPackageList(context.Background(), p.repositoryHref, tangy.PythonPackageListFilters{}, tangy.PageOptions{Limit: 10})
+	require.NoError(p.T(), err)
+	require.NotEmpty(p.T(), single.Results)
+
+	// both hrefs hold the same content, so every package is returned once
+	response, err := p.tangy.PythonRepositoryVersionPackageList(context.Background(), hrefs, tangy.PythonPackageListFilters{}, tangy.PageOptions{Limit: 10})
+	require.NoError(p.T(), err)
+	assert.Equal(p.T(), single.Total, response.Total)
+	require.Len(p.T(), response.Results, len(single.Results))
+	for i, pkg := range response.Results {
+		assert.Equal(p.T(), single.Results[i].NameNormalized, pkg.NameNormalized)
+		assert.Equal(p.T(), single.Results[i].Versions, pkg.Versions)
+		assert.Equal(p.T(), []string{p.repositoryHref}, pkg.Repositories)
+	}
+
+	builds, err := p.tangy.PythonRepositoryVersionBuildList(context.Background(), hrefs, "shelf-reader", "", tangy.PageOptions{Limit: 10})
+	require.NoError(p.T(), err)
+	require.NotEmpty(p.T(), builds.Results)
+	for _, build := range builds.Results {
+		assert.Equal(p.T(), []string{p.repositoryHref}, build.Repositories)
+	}
+
+	distributions, err := p.tangy.PythonRepositoryVersionDistributionList(context.Background(), hrefs, "shelf-reader", "0.1", tangy.PageOptions{Limit: 10})
+	require.NoError(p.T(), err)
+	singleDistributions, err := p.tangy.PythonDistributionList(context.Background(), p.repositoryHref, "shelf-reader", "0.1", tangy.PageOptions{Limit: 10})
+	require.NoError(p.T(), err)
+	assert.Equal(p.T(), singleDistributions, distributions)
+
+	detail, err := p.tangy.PythonRepositoryVersionPackageGet(context.Background(), hrefs, "shelf-reader", "0.1")
+	require.NoError(p.T(), err)
+	assert.Equal(p.T(), []string{p.repositoryHref}, detail.Repositories)
+
+	metrics, err := p.tangy.PythonRepositoryVersionMetrics(context.Background(), hrefs)
+	require.NoError(p.T(), err)
+	singleMetrics, err := p.tangy.PythonRepositoryMetrics(context.Background(), p.repositoryHref)
+	require.NoError(p.T(), err)
+	assert.Equal(p.T(), singleMetrics, metrics)
+}
+
+func (p *PythonSuite) TestPythonPackageListErrors() {
+	_, err := p.tangy.PythonPackageList(context.Background(), "/api/pulp/default/api/v3/repositories/python/python/not-a-uuid/", tangy.PythonPackageListFilters{}, tangy.PageOptions{Limit: 10})
+	assert.ErrorIs(p.T(), err, tangy.ErrInvalidHref)
+
+	_, err = p.tangy.PythonPackageList(context.Background(), "/api/pulp/default/api/v3/repositories/python/python/019f3808-fcc2-716e-a7d3-e5a7ef1522a0/", tangy.PythonPackageListFilters{}, tangy.PageOptions{Limit: 10})
+	assert.ErrorIs(p.T(), err, tangy.ErrRepositoryNotFound)
+
+	_, err = p.tangy.PythonPackageList(context.Background(), p.repositoryHref+"versions/99/", tangy.PythonPackageListFilters{}, tangy.PageOptions{Limit: 10})
+	assert.ErrorIs(p.T(), err, tangy.ErrRepositoryVersionNotFound)
+}
+
 func (p *PythonSuite) TestPythonPackageListEmptyHref() {
 	response, err := p.tangy.PythonPackageList(context.Background(), "", tangy.PythonPackageListFilters{}, tangy.PageOptions{Limit: 10})
 	require.NoError(p.T(), err)
@@ -178,6 +270,26 @@ func (p *PythonSuite) TestPythonDistributionList() {
 	assert.NotEmpty(p.T(), packageTypes)
 }
 
+func (p *PythonSuite) TestPythonRepositoryVersionDistributionAll() {
+	list, err := p.tangy.PythonDistributionList(context.Background(), p.repositoryHref, "shelf-reader", "0.1", tangy.PageOptions{Limit: 100})
+	require.NoError(p.T(), err)
+
+	var distributions []tangy.PythonDistributionListItem
+	for dist, err := range p.tangy.PythonRepositoryVersionDistributionAll(context.Background(), []string{p.repositoryHref}, "shelf-reader", "0.1") {
+		require.NoError(p.T(), err)
+		distributions = append(distributions, dist)
+	}
+	assert.Equal(p.T(), list.Results, distributions)
+
+	// Without a name and version, the distributions of every package are yielded
+	count := 0
+	for _, err := range p.tangy.PythonRepositoryVersionDistributionAll(context.Background(), []string{p.repositoryHref}, "", "") {
+		require.NoError(p.T(), err)
+		count++
+	}
+	assert.GreaterOrEqual(p.T(), count, len(distributions))
+}
+
 func (p *PythonSuite) TestPythonPackageGet() {
 	detail, err := p.tangy.PythonPackageGet(
 		context.Background(),
@@ -5,6 +5,7 @@ import (
 	"math/rand"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/content-services/tang/internal/config"
 	"github.com/content-services/tang/internal/zestwrapper"
@@ -293,66 +294,66 @@ func (r *RpmSuite) TestRpmRepositoryVersionErrataListFilter() {
 	firstVersionHref := resp.LatestVersionHref
 
 	// no filter
-	singleList, total, err := r.tangy.RpmRepositoryVersionErrataList(context.Background(), []string{*firstVersionHref}, tangy.ErrataListFilters{Search: ""}, tangy.PageOptions{})
+	singleList, err := r.tangy.RpmRepositoryVersionErrataList(context.Background(), []string{*firstVersionHref}, tangy.ErrataListFilters{Search: ""}, tangy.PageOptions{})
 	require.NoError(r.T(), err)
-	assert.NotEmpty(r.T(), singleList)
-	assert.Equal(r.T(), total, 6)
+	assert.NotEmpty(r.T(), singleList.Results)
+	assert.Equal(r.T(), singleList.Total, 6)
 
 	// test limit
-	singleList, total, err = r.tangy.RpmRepositoryVersionErrataList(context.Background(), []string{*firstVersionHref}, tangy.ErrataListFilters{}, tangy.PageOptions{Limit: 1})
+	singleList, err = r.tangy.RpmRepositoryVersionErrataList(context.Background(), []string{*firstVersionHref}, tangy.ErrataListFilters{}, tangy.PageOptions{Limit: 1})
 	require.NoError(r.T(), err)
-	assert.NotEmpty(r.T(), singleList)
-	assert.Equal(r.T(), len(singleList), 1)
-	assert.Equal(r.T(), total, 6)
+	assert.NotEmpty(r.T(), singleList.Results)
+	assert.Equal(r.T(), len(singleList.Results), 1)
+	assert.Equal(r.T(), singleList.Total, 6)
 
 	// test offset
-	singleList, total, err = r.tangy.RpmRepositoryVersionErrataList(context.Background(), []string{*firstVersionHref}, tangy.ErrataListFilters{}, tangy.PageOptions{Offset: 3})
+	singleList, err = r.tangy.RpmRepositoryVersionErrataList(context.Background(), []string{*firstVersionHref}, tangy.ErrataListFilters{}, tangy.PageOptions{Offset: 3})
 	require.NoError(r.T(), err)
-	assert.NotEmpty(r.T(), singleList)
-	assert.Equal(r.T(), len(singleList), 3)
-	assert.Equal(r.T(), total, 6)
+	assert.NotEmpty(r.T(), singleList.Results)
+	assert.Equal(r.T(), len(singleList.Results), 3)
+	assert.Equal(r.T(), singleList.Total, 6)
 
 	// id filter partial
-	singleList, total, err = r.tangy.RpmRepositoryVersionErrataList(context.Background(), []string{*firstVersionHref}, tangy.ErrataListFilters{Search: "0055"}, tangy.PageOptions{})
+	singleList, err = r.tangy.RpmRepositoryVersionErrataList(context.Background(), []string{*firstVersionHref}, tangy.ErrataListFilters{Search: "0055"}, tangy.PageOptions{})
 	require.NoError(r.T(), err)
-	assert.NotEmpty(r.T(), singleList)
-	assert.Equal(r.T(), total, 1)
+	assert.NotEmpty(r.T(), singleList.Results)
+	assert.Equal(r.T(), singleList.Total, 1)
 
 	// type filter
-	singleList, total, err = r.tangy.RpmRepositoryVersionErrataList(context.Background(), []string{*firstVersionHref}, tangy.ErrataListFilters{Type: []string{"security"}}, tangy.PageOptions{})
+	singleList, err = r.tangy.RpmRepositoryVersionErrataList(context.Background(), []string{*firstVersionHref}, tangy.ErrataListFilters{Type: []string{"security"}}, tangy.PageOptions{})
 	require.NoError(r.T(), err)
-	assert.NotEmpty(r.T(), singleList)
-	assert.Equal(r.T(), total, 4)
+	assert.NotEmpty(r.T(), singleList.Results)
+	assert.Equal(r.T(), singleList.Total, 4)
 
 	// multiple types filter
-	singleList, total, err = r.tangy.RpmRepositoryVersionErrataList(context.Background(), []string{*firstVersionHref}, tangy.ErrataListFilters{Type: []string{"security", "enhancement"}}, tangy.PageOptions{})
+	singleList, err = r.tangy.RpmRepositoryVersionErrataList(context.Background(), []string{*firstVersionHref}, tangy.ErrataListFilters{Type: []string{"security", "enhancement"}}, tangy.PageOptions{})
 	require.NoError(r.T(), err)
-	assert.NotEmpty(r.T(), singleList)
-	assert.Equal(r.T(), total, 5)
+	assert.NotEmpty(r.T(), singleList.Results)
+	assert.Equal(r.T(), singleList.Total, 5)
 
 	// type filter partial (empty)
-	emptyList, total, err := r.tangy.RpmRepositoryVersionErrataList(context.Background(), []string{*firstVersionHref}, tangy.ErrataListFilters{Type: []string{"secu"}}, tangy.PageOptions{})
+	emptyList, err := r.tangy.RpmRepositoryVersionErrataList(context.Background(), []string{*firstVersionHref}, tangy.ErrataListFilters{Type: []string{"secu"}}, tangy.PageOptions{})
 	require.NoError(r.T(), err)
-	assert.Empty(r.T(), emptyList)
-	assert.Equal(r.T(), total, 0)
+	assert.Empty(r.T(), emptyList.Results)
+	assert.Equal(r.T(), emptyList.Total, 0)
 
 	// severity filter
-	singleList, total, err = r.tangy.RpmRepositoryVersionErrataList(context.Background(), []string{*firstVersionHref}, tangy.ErrataListFilters{Severity: []string{"Low"}}, tangy.PageOptions{})
+	singleList, err = r.tangy.RpmRepositoryVersionErrataList(context.Background(), []string{*firstVersionHref}, tangy.ErrataListFilters{Severity: []string{"Low"}}, tangy.PageOptions{})
 	require.NoError(r.T(), err)
-	assert.NotEmpty(r.T(), singleList)
-	assert.Equal(r.T(), total, 1)
+	assert.NotEmpty(r.T(), singleList.Results)
+	assert.Equal(r.T(), singleList.Total, 1)
 
 	// multiple severities filter
-	singleList, total, err = r.tangy.RpmRepositoryVersionErrataList(context.Background(), []string{*firstVersionHref}, tangy.ErrataListFilters{Severity: []string{"Low", "Unknown"}}, tangy.PageOptions{})
+	singleList, err = r.tangy.RpmRepositoryVersionErrataList(context.Background(), []string{*firstVersionHref}, tangy.ErrataListFilters{Severity: []string{"Low", "Unknown"}}, tangy.PageOptions{})
 	require.NoError(r.T(), err)
-	assert.NotEmpty(r.T(), singleList)
-	assert.Equal(r.T(), total, 3)
+	assert.NotEmpty(r.T(), singleList.Results)
+	assert.Equal(r.T(), singleList.Total, 3)
 
 	// severity filter partial (empty)
-	emptyList, total, err = r.tangy.RpmRepositoryVersionErrataList(context.Background(), []string{*firstVersionHref}, tangy.ErrataListFilters{Severity: []string{"Lo"}}, tangy.PageOptions{})
+	emptyList, err = r.tangy.RpmRepositoryVersionErrataList(context.Background(), []string{*firstVersionHref}, tangy.ErrataListFilters{Severity: []string{"Lo"}}, tangy.PageOptions{})
 	require.NoError(r.T(), err)
-	assert.Empty(r.T(), emptyList)
-	assert.Equal(r.T(), total, 0)
+	assert.Empty(r.T(), emptyList.Results)
+	assert.Equal(r.T(), emptyList.Total, 0)
 }
 
 func (r *RpmSuite) TestRpmRepositoryVersionErrataListSort() {
@@ -362,53 +363,53 @@ func (r *RpmSuite) TestRpmRepositoryVersionErrataListSort() {
 	firstVersionHref := resp.LatestVersionHref
 
 	// no sort specified, defaults to issued_date desc
-	errata, total, err := r.tangy.RpmRepositoryVersionErrataList(context.Background(), []string{*firstVersionHref}, tangy.ErrataListFilters{}, tangy.PageOptions{})
+	errata, err := r.tangy.RpmRepositoryVersionErrataList(context.Background(), []string{*firstVersionHref}, tangy.ErrataListFilters{}, tangy.PageOptions{})
 	require.NoError(r.T(), err)
-	assert.NotEmpty(r.T(), errata)
-	assert.Equal(r.T(), errata[0].IssuedDate, "2013-01-27 16:08:09")
-	assert.Equal(r.T(), total, 6)
+	assert.NotEmpty(r.T(), errata.Results)
+	assert.Equal(r.T(), errata.Results[0].IssuedDate, "2013-01-27 16:08:09")
+	assert.Equal(r.T(), errata.Total, 6)
 
 	// sorting by issued_date asc
-	errata, total, err = r.tangy.RpmRepositoryVersionErrataList(context.Background(), []string{*firstVersionHref}, tangy.ErrataListFilters{}, tangy.PageOptions{SortBy: "issued_date:asc"})
+	errata, err = r.tangy.RpmRepositoryVersionErrataList(context.Background(), []string{*firstVersionHref}, tangy.ErrataListFilters{}, tangy.PageOptions{SortBy: "issued_date:asc"})
 	require.NoError(r.T(), err)
-	assert.NotEmpty(r.T(), errata)
-	assert.Equal(r.T(), errata[0].IssuedDate, "2009-05-20 00:00:00")
-	assert.Equal(r.T(), total, 6)
+	assert.NotEmpty(r.T(), errata.Results)
+	assert.Equal(r.T(), errata.Results[0].IssuedDate, "2009-05-20 00:00:00")
+	assert.Equal(r.T(), errata.Total, 6)
 
 	// sorting by issued_date desc
-	errata, total, err = r.tangy.RpmRepositoryVersionErrataList(context.Background(), []string{*firstVersionHref}, tangy.ErrataListFilters{}, tangy.PageOptions{SortBy: "issued_date:desc"})
+	errata, err = r.tangy.RpmRepositoryVersionErrataList(context.Background(), []string{*firstVersionHref}, tangy.ErrataListFilters{}, tangy.PageOptions{SortBy: "issued_date:desc"})
 	require.NoError(r.T(), err)
-	assert.NotEmpty(r.T(), errata)
-	assert.Equal(r.T(), errata[0].IssuedDate, "2013-01-27 16:08:09")
-	assert.Equal(r.T(), total, 6)
+	assert.NotEmpty(r.T(), errata.Results)
+	assert.Equal(r.T(), errata.Results[0].IssuedDate, "2013-01-27 16:08:09")
+	assert.Equal(r.T(), errata.Total, 6)
 
 	// sorting by type asc
-	errata, total, err = r.tangy.RpmRepositoryVersionErrataList(context.Background(), []string{*firstVersionHref}, tangy.ErrataListFilters{}, tangy.PageOptions{SortBy: "type:asc"})
+	errata, err = r.tangy.RpmRepositoryVersionErrataList(context.Background(), []string{*firstVersionHref}, tangy.ErrataListFilters{}, tangy.PageOptions{SortBy: "type:asc"})
 	require.NoError(r.T(), err)
-	assert.NotEmpty(r.T(), errata)
-	assert.Equal(r.T(), errata[0].Type, "bugfix")
-	assert.Equal(r.T(), total, 6)
+	assert.NotEmpty(r.T(), errata.Results)
+	assert.Equal(r.T(), errata.Results[0].Type, "bugfix")
+	assert.Equal(r.T(), errata.Total, 6)
 
 	// sorting by type desc
-	errata, total, err = r.tangy.RpmRepositoryVersionErrataList(context.Background(), []string{*firstVersionHref}, tangy.ErrataListFilters{}, tangy.PageOptions{SortBy: "type:desc"})
+	errata, err = r.tangy.RpmRepositoryVersionErrataList(context.Background(), []string{*firstVersionHref}, tangy.ErrataListFilters{}, tangy.PageOptions{SortBy: "type:desc"})
 	require.NoError(r.T(), err)
-	assert.NotEmpty(r.T(), errata)
-	assert.Equal(r.T(), errata[0].Type, "security")
-	assert.Equal(r.T(), total, 6)
+	assert.NotEmpty(r.T(), errata.Results)
+	assert.Equal(r.T(), errata.Results[0].Type, "security")
+	assert.Equal(r.T(), errata.Total, 6)
 
 	// sorting by severity asc
-	errata, total, err = r.tangy.RpmRepositoryVersionErrataList(context.Background(), []string{*firstVersionHref}, tangy.ErrataListFilters{}, tangy.PageOptions{SortBy: "severity:asc"})
+	errata, err = r.tangy.RpmRepositoryVersionErrataList(context.Background(), []string{*firstVersionHref}, tangy.ErrataListFilters{}, tangy.PageOptions{SortBy: "severity:asc"})
 	require.NoError(r.T(), err)
-	assert.NotEmpty(r.T(), errata)
-	assert.Equal(r.T(), errata[0].Severity, "") // some errata in this repo have no severity listed, these show up first when sorting ascending
-	assert.Equal(r.T(), total, 6)
+	assert.NotEmpty(r.T(), errata.Results)
+	assert.Equal(r.T(), errata.Results[0].Severity, "") // some errata.Results in this repo have no severity listed, these show up first when sorting ascending
+	assert.Equal(r.T(), errata.Total, 6)
 
 	// sorting by severity desc
-	errata, total, err = r.tangy.RpmRepositoryVersionErrataList(context.Background(), []string{*firstVersionHref}, tangy.ErrataListFilters{}, tangy.PageOptions{SortBy: "severity:desc"})
+	errata, err = r.tangy.RpmRepositoryVersionErrataList(context.Background(), []string{*firstVersionHref}, tangy.ErrataListFilters{}, tangy.PageOptions{SortBy: "severity:desc"})
 	require.NoError(r.T(), err)
-	assert.NotEmpty(r.T(), errata)
-	assert.Equal(r.T(), errata[0].Severity, "Moderate")
-	assert.Equal(r.T(), total, 6)
+	assert.NotEmpty(r.T(), errata.Results)
+	assert.Equal(r.T(), errata.Results[0].Severity, "Moderate")
+	assert.Equal(r.T(), errata.Total, 6)
 }
 
 func (r *RpmSuite) TestRpmRepositoryVersionModuleStreamsList() {
@@ -458,28 +459,28 @@ func (r *RpmSuite) TestRpmRepositoryVersionPackageListNameFilter() {
 	require.NotNil(r.T(), latestVersionHref)
 
 	// no filter
-	singleList, total, err := r.tangy.RpmRepositoryVersionPackageList(context.Background(), []string{*latestVersionHref}, tangy.RpmListFilters{Name: ""}, tangy.PageOptions{})
+	singleList, err := r.tangy.RpmRepositoryVersionPackageList(context.Background(), []string{*latestVersionHref}, tangy.RpmListFilters{Name: ""}, tangy.PageOptions{})
 	require.NoError(r.T(), err)
-	assert.NotEmpty(r.T(), singleList)
-	assert.Equal(r.T(), 9, total)
+	assert.NotEmpty(r.T(), singleList.Results)
+	assert.Equal(r.T(), 9, singleList.Total)
 
 	// exact match
-	singleList, total, err = r.tangy.RpmRepositoryVersionPackageList(context.Background(), []string{*latestVersionHref}, tangy.RpmListFilters{Name: "bear"}, tangy.PageOptions{})
+	singleList, err = r.tangy.RpmRepositoryVersionPackageList(context.Background(), []string{*latestVersionHref}, tangy.RpmListFilters{Name: "bear"}, tangy.PageOptions{})
 	require.NoError(r.T(), err)
-	assert.NotEmpty(r.T(), singleList)
-	assert.Equal(r.T(), total, 1)
+	assert.NotEmpty(r.T(), singleList.Results)
+	assert.Equal(r.T(), singleList.Total, 1)
 
 	// partial match
-	singleList, total, err = r.tangy.RpmRepositoryVersionPackageList(context.Background(), []string{*latestVersionHref}, tangy.RpmListFilters{Name: "bea"}, tangy.PageOptions{})
+	singleList, err = r.tangy.RpmRepositoryVersionPackageList(context.Background(), []string{*latestVersionHref}, tangy.RpmListFilters{Name: "bea"}, tangy.PageOptions{})
 	require.NoError(r.T(), err)
-	assert.NotEmpty(r.T(), singleList)
-	assert.Equal(r.T(), total, 1)
+	assert.NotEmpty(r.T(), singleList.Results)
+	assert.Equal(r.T(), singleList.Total, 1)
 
 	// no match
-	singleList, total, err = r.tangy.RpmRepositoryVersionPackageList(context.Background(), []string{*latestVersionHref}, tangy.RpmListFilters{Name: "bat"}, tangy.PageOptions{})
+	singleList, err = r.tangy.RpmRepositoryVersionPackageList(context.Background(), []string{*latestVersionHref}, tangy.RpmListFilters{Name: "bat"}, tangy.PageOptions{})
 	require.NoError(r.T(), err)
-	assert.Empty(r.T(), singleList)
-	assert.Equal(r.T(), total, 0)
+	assert.Empty(r.T(), singleList.Results)
+	assert.Equal(r.T(), singleList.Total, 0)
 }
 
 // RpmRepositoryVersionPackageList
@@ -487,38 +488,336 @@ func (r *RpmSuite) TestRpmRepositoryVersionPackageListNoDuplicates() {
 	firstVersionHref := r.firstVersionHref
 	secondVersionHref := r.secondVersionHref
 
-	doubleList, total, err := r.tangy.RpmRepositoryVersionPackageList(context.Background(), []string{firstVersionHref, secondVersionHref}, tangy.RpmListFilters{}, tangy.PageOptions{})
+	doubleList, err := r.tangy.RpmRepositoryVersionPackageList(context.Background(), []string{firstVersionHref, secondVersionHref}, tangy.RpmListFilters{}, tangy.PageOptions{})
 	require.NoError(r.T(), err)
-	assert.NotEmpty(r.T(), doubleList)
-	assert.Equal(r.T(), 12, total)
+	assert.NotEmpty(r.T(), doubleList.Results)
+	assert.Equal(r.T(), 12, doubleList.Total)
 
-	singleList, total, err := r.tangy.RpmRepositoryVersionPackageList(context.Background(), []string{firstVersionHref}, tangy.RpmListFilters{}, tangy.PageOptions{})
+	singleList, err := r.tangy.RpmRepositoryVersionPackageList(context.Background(), []string{firstVersionHref}, tangy.RpmListFilters{}, tangy.PageOptions{})
 	require.NoError(r.T(), err)
-	assert.NotEmpty(r.T(), singleList)
-	assert.Equal(r.T(), 7, total)
+	assert.NotEmpty(r.T(), singleList.Results)
+	assert.Equal(r.T(), 7, singleList.Total)
+}
+
+func (r *RpmSuite) TestRpmRepositoryVersionPackageListMixedMethods() {
+	conn := getDBConnection(r.T())
+	defer conn.Close(context.Background())
+
+	// Only the first version uses the old method, the second version keeps its content_ids
+	splitHref := strings.Split(r.firstVersionHref, "/")
+	repoId := splitHref[len(splitHref)-4] // ignore trailing  versions//1/
+	versionNum := splitHref[len(splitHref)-2]
+	var contentIds []string
+	err := conn.QueryRow(context.Background(), "SELECT content_ids FROM core_repositoryversion WHERE repository_id = $1 AND number = $2", repoId, versionNum).Scan(&contentIds)
+	require.NoError(r.T(), err)
+	_, err = conn.Exec(context.Background(), "UPDATE core_repositoryversion SET content_ids = null WHERE repository_id = $1 AND number = $2", repoId, versionNum)
+	require.NoError(r.T(), err)
+	defer func() {
+		_, err := conn.Exec(context.Background(), "UPDATE core_repositoryversion SET content_ids = $3 WHERE repository_id = $1 AND number = $2", repoId, versionNum, contentIds)
+		require.NoError(r.T(), err)
+	}()
+
+	list, err := r.tangy.RpmRepositoryVersionPackageList(context.Background(), []string{r.firstVersionHref, r.secondVersionHref}, tangy.RpmListFilters{}, tangy.PageOptions{})
+	require.NoError(r.T(), err)
+	assert.Equal(r.T(), 12, list.Total)
+
+	list, err = r.tangy.RpmRepositoryVersionPackageList(context.Background(), []string{r.firstVersionHref}, tangy.RpmListFilters{}, tangy.PageOptions{})
+	require.NoError(r.T(), err)
+	assert.Equal(r.T(), 7, list.Total)
+}
+
+func (r *RpmSuite) TestRpmRepositoryVersionPackageListSkipContentIdsCheck() {
+	dbConfig := config.Get().Database
+	ta, err := tangy.New(tangy.Database{
+		Name:                dbConfig.Name,
+		Host:                dbConfig.Host,
+		Port:                dbConfig.Port,
+		User:                dbConfig.User,
+		Password:            dbConfig.Password,
+		SkipContentIdsCheck: true,
+	}, tangy.Logger{})
+	require.NoError(r.T(), err)
+	defer ta.Close()
+
+	list, err := ta.RpmRepositoryVersionPackageList(context.Background(), []string{r.firstVersionHref, r.secondVersionHref}, tangy.RpmListFilters{}, tangy.PageOptions{})
+	require.NoError(r.T(), err)
+	assert.Equal(r.T(), 12, list.Total)
+}
+
+func (r *RpmSuite) TestRpmRepositoryVersionPackageListErrors() {
+	_, err := r.tangy.RpmRepositoryVersionPackageList(context.Background(), []string{"/api/pulp/default/api/v3/repositories/rpm/rpm/"}, tangy.RpmListFilters{}, tangy.PageOptions{})
+	assert.ErrorIs(r.T(), err, tangy.ErrInvalidHref)
+
+	_, err = r.tangy.RpmRepositoryVersionPackageList(context.Background(), []string{r.firstVersionHref, r.repoHref + "versions/99/"}, tangy.RpmListFilters{}, tangy.PageOptions{})
+	assert.ErrorIs(r.T(), err, tangy.ErrRepositoryVersionNotFound)
 }
 
 func (r *RpmSuite) TestRpmRepositoryVersionPackageListOffsetLimit() {
 	firstVersionHref := r.firstVersionHref
 	secondVersionHref := r.secondVersionHref
 
-	list, total, err := r.tangy.RpmRepositoryVersionPackageList(context.Background(), []string{firstVersionHref, secondVersionHref}, tangy.RpmListFilters{}, tangy.PageOptions{Offset: 1, Limit: 4})
+	list, err := r.tangy.RpmRepositoryVersionPackageList(context.Background(), []string{firstVersionHref, secondVersionHref}, tangy.RpmListFilters{}, tangy.PageOptions{Offset: 1, Limit: 4})
 	require.NoError(r.T(), err)
-	assert.NotEmpty(r.T(), list)
-	assert.Equal(r.T(), 4, len(list))
-	assert.Equal(r.T(), 12, total)
+	assert.NotEmpty(r.T(), list.Results)
+	assert.Equal(r.T(), 4, len(list.Results))
+	assert.Equal(r.T(), 12, list.Total)
 
-	list, total, err = r.tangy.RpmRepositoryVersionPackageList(context.Background(), []string{firstVersionHref, secondVersionHref}, tangy.RpmListFilters{}, tangy.PageOptions{Offset: 4, Limit: 1})
+	list, err = r.tangy.RpmRepositoryVersionPackageList(context.Background(), []string{firstVersionHref, secondVersionHref}, tangy.RpmListFilters{}, tangy.PageOptions{Offset: 4, Limit: 1})
 	require.NoError(r.T(), err)
-	assert.NotEmpty(r.T(), list)
-	assert.Equal(r.T(), 1, len(list))
-	assert.Equal(r.T(), 12, total)
+	assert.NotEmpty(r.T(), list.Results)
+	assert.Equal(r.T(), 1, len(list.Results))
+	assert.Equal(r.T(), 12, list.Total)
 
-	list, total, err = r.tangy.RpmRepositoryVersionPackageList(context.Background(), []string{firstVersionHref, secondVersionHref}, tangy.RpmListFilters{}, tangy.PageOptions{Offset: 100, Limit: 100})
+	list, err = r.tangy.RpmRepositoryVersionPackageList(context.Background(), []string{firstVersionHref, secondVersionHref}, tangy.RpmListFilters{}, tangy.PageOptions{Offset: 100, Limit: 100})
 	require.NoError(r.T(), err)
-	assert.Empty(r.T(), list)
-	assert.Equal(r.T(), 0, len(list))
-	assert.Equal(r.T(), 12, total)
+	assert.Empty(r.T(), list.Results)
+	assert.Equal(r.T(), 0, len(list.Results))
+	assert.Equal(r.T(), 12, list.Total)
+}
+
+func (r *RpmSuite) TestRpmRepositoryVersionPackageListCursor() {
+	hrefs := []string{r.firstVersionHref, r.secondVersionHref}
+
+	all, err := r.tangy.RpmRepositoryVersionPackageList(context.Background(), hrefs, tangy.RpmListFilters{}, tangy.PageOptions{})
+	require.NoError(r.T(), err)
+	assert.Empty(r.T(), all.NextCursor)
+
+	var paged []tangy.RpmListItem
+	pageOpts := tangy.PageOptions{Limit: 5}
+	for {
+		page, err := r.tangy.RpmRepositoryVersionPackageList(context.Background(), hrefs, tangy.RpmListFilters{}, pageOpts)
+		require.NoError(r.T(), err)
+		assert.Equal(r.T(), 12, page.Total)
+		paged = append(paged, page.Results...)
+		if page.NextCursor == "" {
+			break
+		}
+		pageOpts.Cursor = page.NextCursor
+	}
+	assert.Equal(r.T(), all.Results, paged)
+
+	// a cursor from another method is rejected
+	_, err = r.tangy.RpmRepositoryVersionErrataList(context.Background(), hrefs, tangy.ErrataListFilters{}, pageOpts)
+	assert.ErrorIs(r.T(), err, tangy.ErrInvalidCursor)
+}
+
+func (r *RpmSuite) TestRpmRepositoryVersionErrataListCursor() {
+	resp, err := r.client.GetRpmRepositoryByName(r.domainName, testRepoNameWithErrata)
+	require.NoError(r.T(), err)
+	require.NotNil(r.T(), resp.LatestVersionHref)
+	hrefs := []string{*resp.LatestVersionHref}
+
+	for _, sortBy := range []string{"", "issued_date:asc", "updated_date:asc", "type:desc", "severity:asc"} {
+		all, err := r.tangy.RpmRepositoryVersionErrataList(context.Background(), hrefs, tangy.ErrataListFilters{}, tangy.PageOptions{SortBy: sortBy})
+		require.NoError(r.T(), err)
+
+		var paged []tangy.ErrataListItem
+		pageOpts := tangy.PageOptions{Limit: 4, SortBy: sortBy}
+		for {
+			page, err := r.tangy.RpmRepositoryVersionErrataList(context.Background(), hrefs, tangy.ErrataListFilters{}, pageOpts)
+			require.NoError(r.T(), err)
+			paged = append(paged, page.Results...)
+			if page.NextCursor == "" {
+				break
+			}
+			pageOpts.Cursor = page.NextCursor
+		}
+		assert.Equal(r.T(), all.Results, paged, sortBy)
+	}
+}
+
+// TestRpmRepositoryVersionPackageAll checks that the iterators yield every row of the lists, in the same order
+func (r *RpmSuite) TestRpmRepositoryVersionPackageAll() {
+	hrefs := []string{r.firstVersionHref, r.secondVersionHref}
+
+	list, err := r.tangy.RpmRepositoryVersionPackageList(context.Background(), hrefs, tangy.RpmListFilters{}, tangy.PageOptions{Limit: 100})
+	require.NoError(r.T(), err)
+	var packages []tangy.RpmListItem
+	for pkg, err := range r.tangy.RpmRepositoryVersionPackageAll(context.Background(), hrefs, tangy.RpmListFilters{}) {
+		require.NoError(r.T(), err)
+		packages = append(packages, pkg)
+	}
+	assert.Equal(r.T(), list.Results, packages)
+
+	// Breaking out of the loop ends the iteration
+	count := 0
+	for _, err := range r.tangy.RpmRepositoryVersionPackageAll(context.Background(), hrefs, tangy.RpmListFilters{}) {
+		require.NoError(r.T(), err)
+		count++
+		if count == 2 {
+			break
+		}
+	}
+	assert.Equal(r.T(), 2, count)
+
+	errata, err := r.tangy.RpmRepositoryVersionErrataList(context.Background(), hrefs, tangy.ErrataListFilters{}, tangy.PageOptions{Limit: 100})
+	require.NoError(r.T(), err)
+	var iterated []tangy.ErrataListItem
+	for erratum, err := range r.tangy.RpmRepositoryVersionErrataAll(context.Background(), hrefs, tangy.ErrataListFilters{}) {
+		require.NoError(r.T(), err)
+		iterated = append(iterated, erratum)
+	}
+	assert.Equal(r.T(), errata.Results, iterated)
+
+	for _, err := range r.tangy.RpmRepositoryVersionPackageAll(context.Background(), []string{r.repoHref + "versions/99/"}, tangy.RpmListFilters{}) {
+		assert.ErrorIs(r.T(), err, tangy.ErrRepositoryVersionNotFound)
+	}
+
+	err = r.tangy.ReadSnapshot(context.Background(), func(snapshot tangy.Tangy) error {
+		for _, err := range snapshot.RpmRepositoryVersionPackageAll(context.Background(), hrefs, tangy.RpmListFilters{}) {
+			assert.ErrorIs(r.T(), err, tangy.ErrIteratorInSnapshot)
+		}
+		return nil
+	})
+	require.NoError(r.T(), err)
+}
+
+func (r *RpmSuite) TestRpmRepositoryVersionPackageListCount() {
+	hrefs := []string{r.firstVersionHref, r.secondVersionHref}
+
+	list, err := r.tangy.RpmRepositoryVersionPackageList(context.Background(), hrefs, tangy.RpmListFilters{}, tangy.PageOptions{Limit: 5})
+	require.NoError(r.T(), err)
+	assert.Equal(r.T(), 12, list.Total)
+	assert.Equal(r.T(), tangy.CountExact, list.TotalMode)
+
+	list, err = r.tangy.RpmRepositoryVersionPackageList(context.Background(), hrefs, tangy.RpmListFilters{}, tangy.PageOptions{Limit: 5, Count: tangy.CountNone})
+	require.NoError(r.T(), err)
+	assert.Equal(r.T(), 0, list.Total)
+	assert.Equal(r.T(), tangy.CountNone, list.TotalMode)
+	assert.Len(r.T(), list.Results, 5)
+
+	// Fewer rows than the limit are counted exactly
+	list, err = r.tangy.RpmRepositoryVersionPackageList(context.Background(), hrefs, tangy.RpmListFilters{}, tangy.PageOptions{Limit: 5, Count: tangy.CountEstimated})
+	require.NoError(r.T(), err)
+	assert.Equal(r.T(), 12, list.Total)
+	assert.Equal(r.T(), tangy.CountExact, list.TotalMode)
+
+	_, err = r.tangy.RpmRepositoryVersionPackageList(context.Background(), hrefs, tangy.RpmListFilters{}, tangy.PageOptions{Count: "approximate"})
+	assert.ErrorIs(r.T(), err, tangy.ErrInvalidCountMode)
+}
+
+func (r *RpmSuite) TestRpmRepositoryVersionPackageListTimeout() {
+	hrefs := []string{r.firstVersionHref, r.secondVersionHref}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+	<-ctx.Done()
+	_, err := r.tangy.RpmRepositoryVersionPackageList(ctx, hrefs, tangy.RpmListFilters{}, tangy.PageOptions{})
+	assert.ErrorIs(r.T(), err, tangy.ErrQueryTimeout)
+
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	_, err = r.tangy.RpmRepositoryVersionPackageList(ctx, hrefs, tangy.RpmListFilters{}, tangy.PageOptions{})
+	assert.ErrorIs(r.T(), err, context.Canceled)
+	assert.NotErrorIs(r.T(), err, tangy.ErrQueryTimeout)
+
+	// A generous deadline is passed to Postgres without failing the call
+	ctx, cancel = context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	list, err := r.tangy.RpmRepositoryVersionPackageList(ctx, hrefs, tangy.RpmListFilters{}, tangy.PageOptions{})
+	require.NoError(r.T(), err)
+	assert.Equal(r.T(), 12, list.Total)
+}
+
+func (r *RpmSuite) TestRpmRepositoryVersionBatch() {
+	hrefs := []string{r.firstVersionHref, r.secondVersionHref}
+
+	batch := &tangy.Batch{}
+	packages := batch.RpmRepositoryVersionPackageList(tangy.RpmListFilters{}, tangy.PageOptions{Limit: 5})
+	errata := batch.RpmRepositoryVersionErrataList(tangy.ErrataListFilters{}, tangy.PageOptions{Count: tangy.CountNone})
+	streams := batch.RpmRepositoryVersionModuleStreamsList(tangy.ModuleStreamListFilters{}, "")
+	environments := batch.RpmRepositoryVersionEnvironmentSearch("", 10)
+	invalid := batch.RpmRepositoryVersionPackageList(tangy.RpmListFilters{}, tangy.PageOptions{Cursor: "invalid"})
+	require.NoError(r.T(), r.tangy.RpmRepositoryVersionBatch(context.Background(), hrefs, batch))
+
+	// Every call returns the result of the method of the same name
+	list, err := r.tangy.RpmRepositoryVersionPackageList(context.Background(), hrefs, tangy.RpmListFilters{}, tangy.PageOptions{Limit: 5})
+	require.NoError(r.T(), err)
+	batchList, err := packages.Result()
+	require.NoError(r.T(), err)
+	assert.Equal(r.T(), list, batchList)
+
+	errataList, err := r.tangy.RpmRepositoryVersionErrataList(context.Background(), hrefs, tangy.ErrataListFilters{}, tangy.PageOptions{Count: tangy.CountNone})
+	require.NoError(r.T(), err)
+	batchErrata, err := errata.Result()
+	require.NoError(r.T(), err)
+	assert.Equal(r.T(), errataList, batchErrata)
+
+	moduleStreams, err := r.tangy.RpmRepositoryVersionModuleStreamsList(context.Background(), hrefs, tangy.ModuleStreamListFilters{}, "")
+	require.NoError(r.T(), err)
+	batchStreams, err := streams.Result()
+	require.NoError(r.T(), err)
+	assert.Equal(r.T(), moduleStreams, batchStreams)
+
+	environmentSearch, err := r.tangy.RpmRepositoryVersionEnvironmentSearch(context.Background(), hrefs, "", 10)
+	require.NoError(r.T(), err)
+	batchEnvironments, err := environments.Result()
+	require.NoError(r.T(), err)
+	assert.Equal(r.T(), environmentSearch, batchEnvironments)
+
+	// A call failing on its own does not fail the others
+	_, err = invalid.Result()
+	assert.ErrorIs(r.T(), err, tangy.ErrInvalidCursor)
+
+	err = r.tangy.RpmRepositoryVersionBatch(context.Background(), []string{r.repoHref + "versions/99/"}, batch)
+	assert.ErrorIs(r.T(), err, tangy.ErrRepositoryVersionNotFound)
+	_, err = packages.Result()
+	assert.ErrorIs(r.T(), err, tangy.ErrRepositoryVersionNotFound)
+
+	err = r.tangy.ReadSnapshot(context.Background(), func(snapshot tangy.Tangy) error {
+		return snapshot.RpmRepositoryVersionBatch(context.Background(), hrefs, batch)
+	})
+	require.NoError(r.T(), err)
+	batchList, err = packages.Result()
+	require.NoError(r.T(), err)
+	assert.Equal(r.T(), list, batchList)
+}
+
+func (r *RpmSuite) TestReadSnapshot() {
+	conn := getDBConnection(r.T())
+	defer conn.Close(context.Background())
+
+	hrefs := []string{r.firstVersionHref, r.secondVersionHref}
+	splitHref := strings.Split(r.secondVersionHref, "/")
+	repoId := splitHref[len(splitHref)-4]
+	versionNum := splitHref[len(splitHref)-2]
+	var contentIds []string
+	err := conn.QueryRow(context.Background(), "SELECT content_ids FROM core_repositoryversion WHERE repository_id = $1 AND number = $2", repoId, versionNum).Scan(&contentIds)
+	require.NoError(r.T(), err)
+
+	var snapshotTangy tangy.Tangy
+	err = r.tangy.ReadSnapshot(context.Background(), func(snapshot tangy.Tangy) error {
+		snapshotTangy = snapshot
+		list, err := snapshot.RpmRepositoryVersionPackageList(context.Background(), hrefs, tangy.RpmListFilters{}, tangy.PageOptions{})
+		require.NoError(r.T(), err)
+		assert.Equal(r.T(), 12, list.Total)
+
+		// Empty the second version while the snapshot is open
+		_, err = conn.Exec(context.Background(), "UPDATE core_repositoryversion SET content_ids = '{}' WHERE repository_id = $1 AND number = $2", repoId, versionNum)
+		require.NoError(r.T(), err)
+		defer func() {
+			_, err := conn.Exec(context.Background(), "UPDATE core_repositoryversion SET content_ids = $3 WHERE repository_id = $1 AND number = $2", repoId, versionNum, contentIds)
+			require.NoError(r.T(), err)
+		}()
+
+		outside, err := r.tangy.RpmRepositoryVersionPackageList(context.Background(), hrefs, tangy.RpmListFilters{}, tangy.PageOptions{})
+		require.NoError(r.T(), err)
+		assert.Equal(r.T(), 7, outside.Total)
+
+		// An error does not end the snapshot
+		_, err = snapshot.RpmRepositoryVersionPackageList(context.Background(), []string{r.repoHref + "versions/99/"}, tangy.RpmListFilters{}, tangy.PageOptions{})
+		assert.ErrorIs(r.T(), err, tangy.ErrRepositoryVersionNotFound)
+
+		list, err = snapshot.RpmRepositoryVersionPackageList(context.Background(), hrefs, tangy.RpmListFilters{}, tangy.PageOptions{Limit: 5})
+		require.NoError(r.T(), err)
+		assert.Equal(r.T(), 12, list.Total)
+		assert.Len(r.T(), list.Results, 5)
+		return nil
+	})
+	require.NoError(r.T(), err)
+
+	_, err = snapshotTangy.RpmRepositoryVersionPackageList(context.Background(), hrefs, tangy.RpmListFilters{}, tangy.PageOptions{})
+	assert.ErrorIs(r.T(), err, tangy.ErrSnapshotClosed)
 }
 
 func RandStringBytes(n int) string {
@@ -529,3 +828,39 @@ func RandStringBytes(n int) string {
 	}
 	return string(b)
 }
+
+func (r *RpmSuite) TestPingReady() {
+	assert.NoError(r.T(), r.tangy.Ping(context.Background()))
+	assert.NoError(r.T(), r.tangy.Ready(context.Background()))
+
+	// New does not connect, so it succeeds while the database cannot be reached
+	ta, err := tangy.New(tangy.Database{Name: "pulp", Host: "127.0.0.1", Port: 1, User: "pulp"}, tangy.Logger{})
+	require.NoError(r.T(), err)
+	defer ta.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	assert.Error(r.T(), ta.Ping(ctx))
+	assert.Error(r.T(), ta.Ready(ctx))
+}
+
+func (r *RpmSuite) TestSQLComments() {
+	dbConfig := config.Get().Database
+	for _, mode := range []string{"", tangy.QueryExecModeSimpleProtocol} {
+		ta, err := tangy.New(tangy.Database{
+			Name:          dbConfig.Name,
+			Host:          dbConfig.Host,
+			Port:          dbConfig.Port,
+			User:          dbConfig.User,
+			Password:      dbConfig.Password,
+			SQLComments:   true,
+			QueryExecMode: mode,
+		}, tangy.Logger{})
+		require.NoError(r.T(), err)
+
+		ctx := tangy.WithSQLComment(tangy.WithRequestID(context.Background(), "it's a request"), "test", "TestSQLComments")
+		list, err := ta.RpmRepositoryVersionPackageList(ctx, []string{r.firstVersionHref}, tangy.RpmListFilters{}, tangy.PageOptions{Limit: 5})
+		assert.NoError(r.T(), err, mode)
+		assert.NotEmpty(r.T(), list.Results, mode)
+		ta.Close()
+	}
+}
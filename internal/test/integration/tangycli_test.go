@@ -0,0 +1,57 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	"github.com/content-services/tang/internal/tangycli"
+	"github.com/content-services/tang/pkg/tangy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// suiteTangy is the Tangy of the suite, which commands must not close
+type suiteTangy struct {
+	tangy.Tangy
+}
+
+func (suiteTangy) Close() {}
+
+// TestTangyCLI runs tangy commands with the suite's Tangy, comparing their output to the results of the methods
+// they call, and checking their exit codes
+func (r *RpmSuite) TestTangyCLI() {
+	// run runs tangy with args, returning its exit code and stdout
+	run := func(args ...string) (int, []byte) {
+		var stdout, stderr bytes.Buffer
+		cli := tangycli.CLI{
+			Tangy: func() (tangy.Tangy, error) {
+				return suiteTangy{r.tangy}, nil
+			},
+			Stdout: &stdout,
+			Stderr: &stderr,
+		}
+		code := cli.Run(context.Background(), args)
+		r.T().Log(stderr.String())
+		return code, stdout.Bytes()
+	}
+
+	expected, err := r.tangy.RpmRepositoryVersionErrataList(context.Background(), []string{r.firstVersionHref, r.secondVersionHref},
+		tangy.ErrataListFilters{}, tangy.PageOptions{Limit: 5})
+	require.NoError(r.T(), err)
+	code, stdout := run("rpm", "errata", "list", "-href", r.firstVersionHref, "-href", r.secondVersionHref, "-limit", "5", "-o", "json")
+	require.Equal(r.T(), tangycli.ExitOK, code)
+	var errata tangy.ErrataListResponse
+	require.NoError(r.T(), json.Unmarshal(stdout, &errata))
+	assert.Equal(r.T(), expected, errata)
+
+	code, _ = run("rpm", "packages", "list", "-href", r.repoHref+"versions/99/")
+	assert.Equal(r.T(), tangycli.ExitNotFound, code)
+
+	code, _ = run("rpm", "packages", "list", "-href", "/api/pulp/default/api/v3/repositories/rpm/rpm/")
+	assert.Equal(r.T(), tangycli.ExitInvalidHref, code)
+
+	code, stdout = run("rpm", "packages", "search", "-href", r.firstVersionHref, "-search", "bear", "-o", "csv")
+	assert.Equal(r.T(), tangycli.ExitOK, code)
+	assert.Contains(r.T(), string(stdout), "Name,Summary\n")
+}
@@ -0,0 +1,76 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+
+	"github.com/content-services/tang/internal/tangyd"
+	"github.com/content-services/tang/pkg/tangy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestTangyd calls the tangyd API served by the suite's Tangy, comparing its responses to the results of the
+// methods they call
+func (r *RpmSuite) TestTangyd() {
+	server := httptest.NewServer(tangyd.New(r.tangy))
+	defer server.Close()
+
+	// get decodes the response of a request of the API into v, returning its status
+	get := func(path string, query url.Values, v any) int {
+		resp, err := http.Get(server.URL + path + "?" + query.Encode())
+		require.NoError(r.T(), err)
+		defer resp.Body.Close()
+		require.NoError(r.T(), json.NewDecoder(resp.Body).Decode(v))
+		return resp.StatusCode
+	}
+	hrefs := []string{r.firstVersionHref, r.secondVersionHref}
+
+	expected, err := r.tangy.RpmRepositoryVersionPackageList(context.Background(), hrefs, tangy.RpmListFilters{Name: "b"}, tangy.PageOptions{Limit: 5})
+	require.NoError(r.T(), err)
+	var packages tangy.RpmListResponse
+	status := get("/v1/repository_versions/rpm/packages", url.Values{"href": hrefs, "name": {"b"}, "limit": {"5"}}, &packages)
+	assert.Equal(r.T(), http.StatusOK, status)
+	assert.Equal(r.T(), expected, packages)
+
+	expectedErrata, err := r.tangy.RpmRepositoryVersionErrataList(context.Background(), hrefs, tangy.ErrataListFilters{Type: []string{"security"}}, tangy.PageOptions{})
+	require.NoError(r.T(), err)
+	var errata tangy.ErrataListResponse
+	status = get("/v1/repository_versions/rpm/errata", url.Values{"href": hrefs, "type": {"security"}}, &errata)
+	assert.Equal(r.T(), http.StatusOK, status)
+	assert.Equal(r.T(), expectedErrata, errata)
+
+	var notFound tangyd.ErrorResponse
+	status = get("/v1/repository_versions/rpm/packages", url.Values{"href": {r.repoHref + "versions/99/"}}, &notFound)
+	assert.Equal(r.T(), http.StatusNotFound, status)
+	assert.Equal(r.T(), tangy.ErrorClassNotFound, notFound.Error.Class)
+
+	var invalid tangyd.ErrorResponse
+	status = get("/v1/repository_versions/rpm/packages", url.Values{"href": {"/invalid/"}}, &invalid)
+	assert.Equal(r.T(), http.StatusBadRequest, status)
+	assert.Equal(r.T(), tangy.ErrorClassInvalid, invalid.Error.Class)
+
+	body, err := json.Marshal(tangyd.BatchRequest{Hrefs: hrefs, Calls: []tangyd.BatchCall{
+		{Operation: "RpmRepositoryVersionPackageList", Params: map[string][]string{"name": {"b"}, "limit": {"5"}}},
+	}})
+	require.NoError(r.T(), err)
+	resp, err := http.Post(server.URL+"/v1/repository_versions/rpm/batch", "application/json", strings.NewReader(string(body)))
+	require.NoError(r.T(), err)
+	defer resp.Body.Close()
+	assert.Equal(r.T(), http.StatusOK, resp.StatusCode)
+	var batch struct {
+		Results []struct {
+			Result tangy.RpmListResponse `json:"result"`
+		} `json:"results"`
+	}
+	require.NoError(r.T(), json.NewDecoder(resp.Body).Decode(&batch))
+	require.Len(r.T(), batch.Results, 1)
+	assert.Equal(r.T(), expected, batch.Results[0].Result)
+
+	var health tangyd.HealthResponse
+	assert.Equal(r.T(), http.StatusOK, get("/readyz", nil, &health))
+}
@@ -0,0 +1,57 @@
+package integration
+
+import (
+	"context"
+
+	"github.com/content-services/tang/internal/config"
+	"github.com/content-services/tang/pkg/tangy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// TestTracing checks that a call is traced with a span, whose children are the spans of the SQL statements it runs
+func (r *RpmSuite) TestTracing() {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	defer func() { _ = provider.Shutdown(context.Background()) }()
+
+	dbConfig := config.Get().Database
+	ta, err := tangy.New(tangy.Database{
+		Name:     dbConfig.Name,
+		Host:     dbConfig.Host,
+		Port:     dbConfig.Port,
+		User:     dbConfig.User,
+		Password: dbConfig.Password,
+	}, tangy.Logger{TracerProvider: provider})
+	require.NoError(r.T(), err)
+	defer ta.Close()
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "request")
+	list, err := ta.RpmRepositoryVersionPackageList(ctx, []string{r.firstVersionHref}, tangy.RpmListFilters{Name: "peng"}, tangy.PageOptions{Limit: 5})
+	require.NoError(r.T(), err)
+	parent.End()
+
+	var call sdktrace.ReadOnlySpan
+	var statements []sdktrace.ReadOnlySpan
+	for _, span := range recorder.Ended() {
+		switch {
+		case span.Name() == "RpmRepositoryVersionPackageList":
+			call = span
+		case span.Name() != "request":
+			statements = append(statements, span)
+		}
+	}
+	require.NotNil(r.T(), call)
+	assert.Equal(r.T(), parent.SpanContext().SpanID(), call.Parent().SpanID())
+	assert.Contains(r.T(), call.Attributes(), attribute.String("tangy.filter.name", "peng"))
+	assert.Contains(r.T(), call.Attributes(), attribute.Int("tangy.rows", len(list.Results)))
+	assert.Contains(r.T(), call.Attributes(), attribute.Int("tangy.href_count", 1))
+
+	require.NotEmpty(r.T(), statements)
+	for _, span := range statements {
+		assert.Equal(r.T(), call.SpanContext().SpanID(), span.Parent().SpanID())
+	}
+}
@@ -6,7 +6,7 @@
 
 .PHONY: compose-up
 compose-up: ## Start up service dependencies using podman(docker)-compose
-	PULP_DATABASE_PORT=5434 PULP_API_PORT=8087 PULP_CONTENT_PORT=8088 $(PULP_COMPOSE_COMMAND)
+	PULP_DATABASE_PORT=5434 PULP_PGBOUNCER_PORT=5435 PULP_API_PORT=8087 PULP_CONTENT_PORT=8088 $(PULP_COMPOSE_COMMAND)
 
 .PHONY: compose-down
 compose-down: ## Shut down service  dependencies using podman(docker)-compose
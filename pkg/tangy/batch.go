@@ -0,0 +1,325 @@
+package tangy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// ErrBatchNotRun is returned by the result of a call added to a Batch that did not run yet
+var ErrBatchNotRun = errors.New("batch has not run")
+
+// Batch is a set of calls of the RPM list and search methods on the same repository versions,
+// run at once by RpmRepositoryVersionBatch. Each method of Batch adds a call of the Tangy method of the same name,
+// with its arguments other than ctx and hrefs, and returns the result of the call, set once the batch ran.
+// A Batch may be run again, which sets the results again. It is not safe for concurrent use.
+type Batch struct {
+	requests []batchRequest
+}
+
+// BatchResult is the result of a call added to a Batch
+type BatchResult[T any] struct {
+	value T
+	err   error
+	ran   bool
+}
+
+// Result returns the result of the call, as returned by the Tangy method of the same name,
+// or ErrBatchNotRun before the batch ran
+func (r *BatchResult[T]) Result() (T, error) {
+	if !r.ran {
+		var zero T
+		return zero, ErrBatchNotRun
+	}
+	return r.value, r.err
+}
+
+// batchRequest is a call added to a Batch
+type batchRequest struct {
+	method string
+	// queue queues the queries of the call, and returns a function setting its result once the batch ran,
+	// given the error that stopped the batch
+	queue func(ctx context.Context, batch *pgx.Batch, m membership) func(err error)
+	// set sets the result of the call without running it: its empty result without repository versions when err is nil
+	set func(err error)
+}
+
+// batchCall is the state of a call of a Batch while its queries run
+type batchCall struct {
+	// pending is the number of queries of the call that did not run yet
+	pending int
+	err     error
+}
+
+// ran records that a query of the call ran, failing the call with err when it is set
+func (c *batchCall) ran(err error) {
+	c.pending--
+	if err != nil && c.err == nil {
+		c.err = err
+	}
+}
+
+// addBatchRequest adds a call of method to b, returning its result. queue queues the queries of the call,
+// whose callbacks set its result, or returns an error failing the call before any query ran.
+// empty is the result of the call without repository versions.
+func addBatchRequest[T any](b *Batch, method string, empty T, queue func(ctx context.Context, batch *pgx.Batch, m membership, call *batchCall, result *T) error) *BatchResult[T] {
+	result := &BatchResult[T]{}
+	b.requests = append(b.requests, batchRequest{
+		method: method,
+		queue: func(ctx context.Context, batch *pgx.Batch, m membership) func(err error) {
+			call := &batchCall{}
+			var value T
+			if err := queue(ctx, batch, m, call, &value); err != nil {
+				call.err = err
+			}
+			return func(err error) {
+				// A call whose queries all ran is not failed by a later query of the batch
+				if call.err != nil || call.pending == 0 {
+					err = call.err
+				}
+				if err != nil {
+					*result = BatchResult[T]{err: err, ran: true}
+					return
+				}
+				*result = BatchResult[T]{value: value, ran: true}
+			}
+		},
+		set: func(err error) {
+			if err != nil {
+				*result = BatchResult[T]{err: err, ran: true}
+				return
+			}
+			*result = BatchResult[T]{value: empty, ran: true}
+		},
+	})
+	return result
+}
+
+// methods returns the methods of the calls of the batch, in order
+func (b *Batch) methods() []string {
+	methods := make([]string, len(b.requests))
+	for i, request := range b.requests {
+		methods[i] = request.method
+	}
+	return methods
+}
+
+// fail fails every call of the batch with err, and returns err
+func (b *Batch) fail(err error) error {
+	for _, request := range b.requests {
+		request.set(err)
+	}
+	return err
+}
+
+// queueRows queues query for call, passing its rows scanned by fn to done. An error returned by done fails the call.
+func queueRows[T any](ctx context.Context, batch *pgx.Batch, call *batchCall, query sqlQuery, fn pgx.RowToFunc[T], done func([]T) error) {
+	call.pending++
+	batch.Queue(query.SQL, query.Args).Query(func(rows pgx.Rows) error {
+		collected, err := collectRows(ctx, rows, fn)
+		if err == nil {
+			err = done(collected)
+		}
+		call.ran(err)
+		// The error of the query itself is returned by rows, and stops the batch
+		return nil
+	})
+}
+
+// queueListTotal queues the count query of a list for call like listTotal, passing the total
+// with the mode that produced it to done
+func queueListTotal(batch *pgx.Batch, call *batchCall, mode CountMode, countQuery func(limit int) sqlQuery, done func(total int, totalMode CountMode)) error {
+	limit, counted, err := listCountLimit(mode)
+	if err != nil {
+		return err
+	}
+	if !counted {
+		done(0, CountNone)
+		return nil
+	}
+
+	query := countQuery(limit)
+	call.pending++
+	batch.Queue(query.SQL, query.Args).QueryRow(func(row pgx.Row) error {
+		var total int
+		err := row.Scan(&total)
+		if err == nil {
+			done(listCountTotal(total, limit))
+		}
+		call.ran(err)
+		return err
+	})
+	return nil
+}
+
+// RpmRepositoryVersionBatch runs the calls of batch on the same repository versions in a single transaction:
+// hrefs are parsed and the content of the repository versions is resolved once, then the queries of every call
+// are sent to the database at once. The result of each call is set on the BatchResult returned when it was added.
+// A call failing on its own, such as with an invalid cursor, does not fail the others. The error returned,
+// such as ErrInvalidHref or the error of a query stopping the batch, fails every call that did not complete.
+func (t *tangyImpl) RpmRepositoryVersionBatch(ctx context.Context, hrefs []string, batch *Batch) (err error) {
+	ctx, done := t.call(ctx, "RpmRepositoryVersionBatch", arg("methods", batch.methods()))
+	defer done(&err)
+
+	if len(batch.requests) == 0 {
+		return nil
+	}
+	if len(hrefs) == 0 {
+		batch.fail(nil)
+		return nil
+	}
+
+	repoVerMap, err := parseRepositoryVersionHrefsMap(hrefs)
+	if err != nil {
+		return batch.fail(fmt.Errorf("error parsing repository version hrefs: %w", err))
+	}
+
+	tx, end, err := t.begin(ctx)
+	if err != nil {
+		return batch.fail(queryTimeoutError(ctx, err))
+	}
+	defer end()
+
+	m, err := t.contentMembership(ctx, tx, repoVerMap)
+	if err != nil {
+		return batch.fail(queryTimeoutError(ctx, err))
+	}
+
+	queued := &pgx.Batch{}
+	finish := make([]func(error), len(batch.requests))
+	for i, request := range batch.requests {
+		finish[i] = request.queue(ctx, queued, m)
+	}
+	if queued.Len() > 0 {
+		err = queryTimeoutError(ctx, tx.SendBatch(ctx, queued).Close())
+	}
+	for _, f := range finish {
+		f(err)
+	}
+	return err
+}
+
+// RpmRepositoryVersionPackageSearch adds a call of Tangy.RpmRepositoryVersionPackageSearch to the batch
+func (b *Batch) RpmRepositoryVersionPackageSearch(search string, limit int) *BatchResult[[]RpmPackageSearch] {
+	return addBatchRequest(b, "RpmRepositoryVersionPackageSearch", []RpmPackageSearch{}, func(ctx context.Context, batch *pgx.Batch, m membership, call *batchCall, result *[]RpmPackageSearch) error {
+		if limit == 0 {
+			limit = DefaultLimit
+		}
+		queueRows(ctx, batch, call, rpmPackageSearchQuery(m, search, limit), pgx.RowToStructByName[RpmPackageSearch], func(rpms []RpmPackageSearch) error {
+			*result = rpms
+			return nil
+		})
+		return nil
+	})
+}
+
+// RpmRepositoryVersionPackageGroupSearch adds a call of Tangy.RpmRepositoryVersionPackageGroupSearch to the batch
+func (b *Batch) RpmRepositoryVersionPackageGroupSearch(search string, limit int) *BatchResult[[]RpmPackageGroupSearch] {
+	return addBatchRequest(b, "RpmRepositoryVersionPackageGroupSearch", []RpmPackageGroupSearch{}, func(ctx context.Context, batch *pgx.Batch, m membership, call *batchCall, result *[]RpmPackageGroupSearch) error {
+		if limit == 0 {
+			limit = DefaultLimit
+		}
+		queueRows(ctx, batch, call, rpmPackageGroupSearchQuery(m, search), pgx.RowToStructByName[rpmPackageGroupSearchQueryReturn], func(rpms []rpmPackageGroupSearchQueryReturn) error {
+			groups, err := rpmPackageGroupSearchResults(rpms, limit)
+			*result = groups
+			return err
+		})
+		return nil
+	})
+}
+
+// RpmRepositoryVersionEnvironmentSearch adds a call of Tangy.RpmRepositoryVersionEnvironmentSearch to the batch
+func (b *Batch) RpmRepositoryVersionEnvironmentSearch(search string, limit int) *BatchResult[[]RpmEnvironmentSearch] {
+	return addBatchRequest(b, "RpmRepositoryVersionEnvironmentSearch", []RpmEnvironmentSearch{}, func(ctx context.Context, batch *pgx.Batch, m membership, call *batchCall, result *[]RpmEnvironmentSearch) error {
+		if limit == 0 {
+			limit = DefaultLimit
+		}
+		queueRows(ctx, batch, call, rpmEnvironmentSearchQuery(m, search, limit), pgx.RowToStructByName[RpmEnvironmentSearch], func(environments []RpmEnvironmentSearch) error {
+			*result = environments
+			return nil
+		})
+		return nil
+	})
+}
+
+// RpmRepositoryVersionErrataList adds a call of Tangy.RpmRepositoryVersionErrataList to the batch
+func (b *Batch) RpmRepositoryVersionErrataList(filterOpts ErrataListFilters, pageOpts PageOptions) *BatchResult[ErrataListResponse] {
+	return addBatchRequest(b, "RpmRepositoryVersionErrataList", ErrataListResponse{Results: []ErrataListItem{}}, func(ctx context.Context, batch *pgx.Batch, m membership, call *batchCall, result *ErrataListResponse) error {
+		if pageOpts.Limit == 0 {
+			pageOpts.Limit = DefaultLimit
+		}
+		sortField, order := errataListSort(pageOpts.SortBy)
+		cursorKind := cursorKindRpmErrataList + ":" + sortField
+		if order.Desc {
+			cursorKind += ":desc"
+		}
+		cursor, err := decodeCursor(pageOpts.Cursor, cursorKind, len(order.Columns))
+		if err != nil {
+			return err
+		}
+
+		*result = ErrataListResponse{Limit: pageOpts.Limit, Offset: pageOpts.Offset}
+		err = queueListTotal(batch, call, pageOpts.Count, func(limit int) sqlQuery {
+			return rpmErrataListCountQuery(m, filterOpts, limit)
+		}, func(total int, totalMode CountMode) {
+			result.Total, result.TotalMode = total, totalMode
+		})
+		if err != nil {
+			return err
+		}
+		queueRows(ctx, batch, call, rpmErrataListQuery(m, filterOpts, order, pageOpts, cursor), pgx.RowToStructByName[ErrataListItem], func(errata []ErrataListItem) error {
+			result.Results = errata
+			if len(errata) > 0 {
+				last := errata[len(errata)-1]
+				result.NextCursor = nextCursor(cursorKind, len(errata), pageOpts.Limit, nil, errataSortValue(last, sortField), last.Id)
+			}
+			return nil
+		})
+		return nil
+	})
+}
+
+// RpmRepositoryVersionModuleStreamsList adds a call of Tangy.RpmRepositoryVersionModuleStreamsList to the batch
+func (b *Batch) RpmRepositoryVersionModuleStreamsList(filterOpts ModuleStreamListFilters, sortBy string) *BatchResult[[]ModuleStreams] {
+	return addBatchRequest(b, "RpmRepositoryVersionModuleStreamsList", []ModuleStreams{}, func(ctx context.Context, batch *pgx.Batch, m membership, call *batchCall, result *[]ModuleStreams) error {
+		queueRows(ctx, batch, call, rpmModuleStreamsListQuery(m, filterOpts, sortBy), pgx.RowToStructByName[ModuleStreams], func(moduleStreams []ModuleStreams) error {
+			*result = moduleStreams
+			return nil
+		})
+		return nil
+	})
+}
+
+// RpmRepositoryVersionPackageList adds a call of Tangy.RpmRepositoryVersionPackageList to the batch
+func (b *Batch) RpmRepositoryVersionPackageList(filterOpts RpmListFilters, pageOpts PageOptions) *BatchResult[RpmListResponse] {
+	return addBatchRequest(b, "RpmRepositoryVersionPackageList", RpmListResponse{Results: []RpmListItem{}}, func(ctx context.Context, batch *pgx.Batch, m membership, call *batchCall, result *RpmListResponse) error {
+		if pageOpts.Limit == 0 {
+			pageOpts.Limit = DefaultLimit
+		}
+		cursor, err := decodeCursor(pageOpts.Cursor, cursorKindRpmPackageList, len(rpmPackageListSort.Columns))
+		if err != nil {
+			return err
+		}
+
+		*result = RpmListResponse{Limit: pageOpts.Limit, Offset: pageOpts.Offset}
+		err = queueListTotal(batch, call, pageOpts.Count, func(limit int) sqlQuery {
+			return rpmPackageListCountQuery(m, filterOpts, limit)
+		}, func(total int, totalMode CountMode) {
+			result.Total, result.TotalMode = total, totalMode
+		})
+		if err != nil {
+			return err
+		}
+		queueRows(ctx, batch, call, rpmPackageListQuery(m, filterOpts, pageOpts, cursor), pgx.RowToStructByName[RpmListItem], func(rpms []RpmListItem) error {
+			result.Results = rpms
+			if len(rpms) > 0 {
+				last := rpms[len(rpms)-1]
+				result.NextCursor = nextCursor(cursorKindRpmPackageList, len(rpms), pageOpts.Limit, nil, last.Name, last.Version, last.Release, last.Arch, last.Id)
+			}
+			return nil
+		})
+		return nil
+	})
+}
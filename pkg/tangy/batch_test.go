@@ -0,0 +1,68 @@
+package tangy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchResults(t *testing.T) {
+	t.Parallel()
+
+	batch := &Batch{}
+	list := batch.RpmRepositoryVersionPackageList(RpmListFilters{}, PageOptions{Cursor: "invalid"})
+	errata := batch.RpmRepositoryVersionErrataList(ErrataListFilters{}, PageOptions{Count: "all"})
+	search := batch.RpmRepositoryVersionPackageSearch("kernel", 0)
+	_, err := search.Result()
+	require.ErrorIs(t, err, ErrBatchNotRun)
+	assert.Equal(t, []string{"RpmRepositoryVersionPackageList", "RpmRepositoryVersionErrataList", "RpmRepositoryVersionPackageSearch"}, batch.methods())
+
+	// Calls failing before their queries run are not queued, and keep their own error
+	queued := &pgx.Batch{}
+	var finish []func(error)
+	for _, request := range batch.requests {
+		finish = append(finish, request.queue(context.Background(), queued, goldenSingle))
+	}
+	require.Equal(t, 1, queued.Len())
+	args, _ := queued.QueuedQueries[0].Arguments[0].(pgx.NamedArgs)
+	assert.Equal(t, DefaultLimit, args["limit"])
+	for _, f := range finish {
+		f(assert.AnError)
+	}
+	_, err = list.Result()
+	require.ErrorIs(t, err, ErrInvalidCursor)
+	_, err = errata.Result()
+	require.ErrorIs(t, err, ErrInvalidCountMode)
+	rpms, err := search.Result()
+	require.ErrorIs(t, err, assert.AnError)
+	assert.Nil(t, rpms)
+}
+
+func TestBatchWithoutHrefs(t *testing.T) {
+	t.Parallel()
+
+	tangy := &tangyImpl{stats: newCallStats()}
+	batch := &Batch{}
+	list := batch.RpmRepositoryVersionPackageList(RpmListFilters{}, PageOptions{})
+	streams := batch.RpmRepositoryVersionModuleStreamsList(ModuleStreamListFilters{}, "")
+
+	require.NoError(t, tangy.RpmRepositoryVersionBatch(context.Background(), nil, batch))
+	response, err := list.Result()
+	require.NoError(t, err)
+	assert.Equal(t, RpmListResponse{Results: []RpmListItem{}}, response)
+	moduleStreams, err := streams.Result()
+	require.NoError(t, err)
+	assert.Equal(t, []ModuleStreams{}, moduleStreams)
+
+	// Invalid hrefs fail every call before connecting
+	err = tangy.RpmRepositoryVersionBatch(context.Background(), []string{"/pulp/api/v3/invalid/"}, batch)
+	require.ErrorIs(t, err, ErrInvalidHref)
+	_, err = list.Result()
+	require.ErrorIs(t, err, ErrInvalidHref)
+	_, err = streams.Result()
+	require.ErrorIs(t, err, ErrInvalidHref)
+	assert.Equal(t, uint64(2), tangy.stats.snapshot()["RpmRepositoryVersionBatch"].Calls)
+}
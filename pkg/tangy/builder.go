@@ -0,0 +1,157 @@
+package tangy
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// sqlQuery is a SQL statement and the named arguments it references
+type sqlQuery struct {
+	SQL  string
+	Args pgx.NamedArgs
+}
+
+// queryBuilder collects the named arguments of a SQL statement while its clauses are rendered.
+//
+//	Arguments are named deterministically, so that building a statement twice from the same input
+//	returns the same SQL. Clauses that can be included several times in a statement, such as membership
+//	subqueries or cursor keys, number their arguments in the order they are rendered.
+type queryBuilder struct {
+	args     pgx.NamedArgs
+	prefixes map[string]int
+}
+
+func newQueryBuilder() *queryBuilder {
+	return &queryBuilder{args: pgx.NamedArgs{}, prefixes: map[string]int{}}
+}
+
+// bind adds a named argument and returns its placeholder
+func (b *queryBuilder) bind(name string, value any) string {
+	b.args[name] = value
+	return "@" + name
+}
+
+// bindNext adds a named argument named after prefix and the number of arguments previously named after it,
+// such as cursorKey0, cursorKey1, and returns its placeholder
+func (b *queryBuilder) bindNext(prefix string, value any) string {
+	name := fmt.Sprintf("%s%d", prefix, b.prefixes[prefix])
+	b.prefixes[prefix]++
+	return b.bind(name, value)
+}
+
+var namedArgPlaceholder = regexp.MustCompile(`@(\w+)`)
+
+// query returns the statement with the arguments it references
+func (b *queryBuilder) query(sql string) sqlQuery {
+	args := pgx.NamedArgs{}
+	for _, match := range namedArgPlaceholder.FindAllStringSubmatch(sql, -1) {
+		if value, ok := b.args[match[1]]; ok {
+			args[match[1]] = value
+		}
+	}
+	return sqlQuery{SQL: sql, Args: args}
+}
+
+// conditions is a list of SQL boolean expressions that all have to match
+type conditions []string
+
+// and renders the conditions as the continuation of a WHERE clause that is already open,
+// such as the one ending the membership join
+func (c conditions) and() string {
+	var sql strings.Builder
+	for _, condition := range c {
+		sql.WriteString("\n\t\tAND " + condition)
+	}
+	return sql.String()
+}
+
+// clause renders the conditions as a clause introduced by keyword, such as WHERE or HAVING,
+// or returns an empty string when there are no conditions
+func (c conditions) clause(keyword string) string {
+	if len(c) == 0 {
+		return ""
+	}
+	return "\n\t\t" + keyword + " " + strings.Join(c, "\n\t\tAND ")
+}
+
+// sortOrder is a sort key, sorted in one direction, that can be used for keyset pagination
+type sortOrder struct {
+	Columns []keysetColumn
+	Desc    bool
+}
+
+// orderBy returns the ORDER BY expression list of the sort order.
+// NULLs sort after every value, as postgres sorts them by default, and as after compares them.
+func (s sortOrder) orderBy() string {
+	direction, nulls := " ASC", " NULLS LAST"
+	if s.Desc {
+		direction, nulls = " DESC", " NULLS FIRST"
+	}
+	exprs := make([]string, len(s.Columns))
+	for i, column := range s.Columns {
+		exprs[i] = column.Expr + direction
+		if column.Nullable {
+			exprs[i] += nulls
+		}
+	}
+	return strings.Join(exprs, ", ")
+}
+
+// orderBy returns an ORDER BY clause sorting by each of the sort orders in turn
+func orderBy(orders ...sortOrder) string {
+	exprs := make([]string, len(orders))
+	for i, order := range orders {
+		exprs[i] = order.orderBy()
+	}
+	return "ORDER BY " + strings.Join(exprs, ", ")
+}
+
+// after returns a row comparison that only matches rows sorted after key by the sort order,
+// and adds the key values as cursorKeyN arguments.
+// A nullable column is compared as (column IS NULL, column), so that NULLs sort after every value, as in orderBy,
+// and a cursorNull key value matches the NULL rows.
+func (b *queryBuilder) after(order sortOrder, key []string) string {
+	var exprs, params []string
+	for i, column := range order.Columns {
+		if !column.Nullable {
+			exprs = append(exprs, column.Expr)
+			params = append(params, b.bindNext("cursorKey", key[i])+"::"+column.Type)
+			continue
+		}
+		value, isNull := key[i], key[i] == cursorNull
+		if isNull {
+			value = ""
+		}
+		exprs = append(exprs, column.Expr+" IS NULL", fmt.Sprintf("COALESCE(%s, '')", column.Expr))
+		params = append(params, b.bindNext("cursorKey", isNull)+"::boolean", b.bindNext("cursorKey", value)+"::"+column.Type)
+	}
+
+	op := ">"
+	if order.Desc {
+		op = "<"
+	}
+	return fmt.Sprintf("(%s) %s (%s)", strings.Join(exprs, ", "), op, strings.Join(params, ", "))
+}
+
+// pageAfter returns the condition that starts a page after the cursor key, or no condition without a cursor
+func (b *queryBuilder) pageAfter(order sortOrder, cursor pageCursor) conditions {
+	if !cursor.isSet() {
+		return nil
+	}
+	return conditions{b.after(order, cursor.Key)}
+}
+
+// page returns the LIMIT clause of a page. The offset is ignored when the page starts after a cursor.
+// Every row is selected when limit is 0, as by iterators.
+func (b *queryBuilder) page(limit, offset int, cursor pageCursor) string {
+	if limit == 0 {
+		return ""
+	}
+	if cursor.isSet() {
+		offset = 0
+	}
+	return "LIMIT " + b.bind("limit", limit) + " OFFSET " + b.bind("offset", offset)
+}
@@ -0,0 +1,102 @@
+package tangy
+
+import (
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryBuilderBindNext(t *testing.T) {
+	t.Parallel()
+
+	b := newQueryBuilder()
+	assert.Equal(t, "@cursorKey0", b.bindNext("cursorKey", "a"))
+	assert.Equal(t, "@cursorKey1", b.bindNext("cursorKey", "b"))
+	assert.Equal(t, "@repoIds0", b.bindNext("repoIds", []string{}))
+	assert.Equal(t, "@limit", b.bind("limit", 10))
+	assert.Equal(t, pgx.NamedArgs{"cursorKey0": "a", "cursorKey1": "b", "repoIds0": []string{}, "limit": 10}, b.args)
+}
+
+func TestQueryBuilderQuery(t *testing.T) {
+	t.Parallel()
+
+	b := newQueryBuilder()
+	b.bind("unused", 1)
+	query := b.query("SELECT 1 WHERE a = " + b.bind("a", "x") + "::text AND b = @a")
+
+	assert.Equal(t, "SELECT 1 WHERE a = @a::text AND b = @a", query.SQL)
+	assert.Equal(t, pgx.NamedArgs{"a": "x"}, query.Args)
+}
+
+func TestConditions(t *testing.T) {
+	t.Parallel()
+
+	where := conditions{"a = 1", "b = 2"}
+	assert.Equal(t, "\n\t\tAND a = 1\n\t\tAND b = 2", where.and())
+	assert.Equal(t, "\n\t\tHAVING a = 1\n\t\tAND b = 2", where.clause("HAVING"))
+
+	var none conditions
+	assert.Empty(t, none.and())
+	assert.Empty(t, none.clause("WHERE"))
+}
+
+func TestQueryBuilderAfter(t *testing.T) {
+	t.Parallel()
+
+	order := sortOrder{Columns: []keysetColumn{
+		{Expr: "rp.updated_date", Type: "text", Nullable: true},
+		{Expr: "rp.content_ptr_id", Type: "uuid"},
+	}}
+
+	b := newQueryBuilder()
+	condition := b.after(order, []string{"2024-01-01", testRepoVersionUUID})
+	assert.Equal(t, "(rp.updated_date IS NULL, COALESCE(rp.updated_date, ''), rp.content_ptr_id) > "+
+		"(@cursorKey0::boolean, @cursorKey1::text, @cursorKey2::uuid)", condition)
+	assert.Equal(t, pgx.NamedArgs{"cursorKey0": false, "cursorKey1": "2024-01-01", "cursorKey2": testRepoVersionUUID}, b.args)
+
+	// A NULL key value is told apart from an empty string
+	b = newQueryBuilder()
+	b.after(order, []string{cursorNull, testRepoVersionUUID})
+	assert.Equal(t, pgx.NamedArgs{"cursorKey0": true, "cursorKey1": "", "cursorKey2": testRepoVersionUUID}, b.args)
+
+	order.Desc = true
+	condition = newQueryBuilder().after(order, []string{"2024-01-01", testRepoVersionUUID})
+	assert.Contains(t, condition, ") < (")
+}
+
+func TestSortOrderOrderBy(t *testing.T) {
+	t.Parallel()
+
+	order := sortOrder{Columns: []keysetColumn{
+		{Expr: "rp.updated_date", Type: "text", Nullable: true},
+		{Expr: "rp.content_ptr_id", Type: "uuid"},
+	}}
+	assert.Equal(t, "rp.updated_date ASC NULLS LAST, rp.content_ptr_id ASC", order.orderBy())
+
+	order.Desc = true
+	assert.Equal(t, "rp.updated_date DESC NULLS FIRST, rp.content_ptr_id DESC", order.orderBy())
+
+	assert.Equal(t, "ORDER BY rp.name DESC, rp.stream ASC", orderBy(
+		sortOrder{Columns: []keysetColumn{{Expr: "rp.name"}}, Desc: true},
+		sortOrder{Columns: []keysetColumn{{Expr: "rp.stream"}}},
+	))
+}
+
+func TestQueryBuilderPage(t *testing.T) {
+	t.Parallel()
+
+	order := sortOrder{Columns: []keysetColumn{{Expr: "rp.name", Type: "text"}}}
+
+	b := newQueryBuilder()
+	assert.Empty(t, b.pageAfter(order, pageCursor{}))
+	assert.Equal(t, "LIMIT @limit OFFSET @offset", b.page(10, 20, pageCursor{}))
+	assert.Equal(t, pgx.NamedArgs{"limit": 10, "offset": 20}, b.args)
+
+	// The offset is ignored after a cursor
+	cursor := pageCursor{Kind: cursorKindRpmPackageList, Key: []string{"bear"}}
+	b = newQueryBuilder()
+	assert.Equal(t, conditions{"(rp.name) > (@cursorKey0::text)"}, b.pageAfter(order, cursor))
+	assert.Equal(t, "LIMIT @limit OFFSET @offset", b.page(10, 20, cursor))
+	assert.Equal(t, pgx.NamedArgs{"cursorKey0": "bear", "limit": 10, "offset": 0}, b.args)
+}
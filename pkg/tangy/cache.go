@@ -0,0 +1,402 @@
+package tangy
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// DefaultCacheLatestTTL is the default time the results of calls reading the latest version of a repository are cached
+const DefaultCacheLatestTTL = 10 * time.Second
+
+// CacheStore stores the encoded results of cached calls, such as NewMemoryCacheStore or an external store like Redis.
+// Get returns false when key is missing or expired. Errors are logged, and the call then runs uncached.
+type CacheStore interface {
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+}
+
+// CacheOptions configures CacheInterceptor
+type CacheOptions struct {
+	// TTL is the time the results of calls are cached. As a complete repository version never changes, zero caches
+	// them until the store evicts them. The results of calls reading a repository version that is not complete yet,
+	// whose content is still being written, are not cached.
+	TTL time.Duration
+	// LatestTTL is the time the latest version of a repository is cached for calls reading a repository href,
+	// as it changes when the repository is synced. DefaultCacheLatestTTL when zero.
+	LatestTTL time.Duration
+	// Logger logs the errors of the store, and of encoding results. Nothing is logged when it is the zero Logger.
+	Logger zerolog.Logger
+}
+
+// CacheInterceptor returns an interceptor caching the results of calls in store, keyed on the method,
+// the repository versions read, and the other arguments, such as filters and page options.
+// Repository hrefs are keyed on the latest version of the repository the call read, which is cached for LatestTTL,
+// so that a call reading a repository href shares its result with the calls reading the same version by its href.
+// Failed calls, and calls reading a repository version that is not complete, are not cached. Calls reading
+// a repository href with a context returned by WithPrimary, which reads a repository version right after
+// it was synced, resolve the latest version again.
+func CacheInterceptor(store CacheStore, options CacheOptions) Interceptor {
+	if options.LatestTTL == 0 {
+		options.LatestTTL = DefaultCacheLatestTTL
+	}
+	logger := options.Logger
+	return func(ctx context.Context, call *Call, invoke func(ctx context.Context) error) error {
+		cached, ok := parseCachedCall(call)
+		resultType, found := cachedResultType(call.Method)
+		if !ok || !found {
+			return invoke(ctx)
+		}
+
+		versions := cached.versions
+		if cached.latest() {
+			versions = nil
+			if !usePrimary(ctx) {
+				versions = cachedLatestVersions(ctx, store, logger, cached.versions)
+			}
+		}
+		if versions != nil {
+			value, hit, err := store.Get(ctx, cached.key(versions))
+			if err != nil {
+				logger.Warn().Err(err).Str("method", call.Method).Msg("Error reading Tangy cache")
+			}
+			if hit {
+				result := reflect.New(resultType)
+				err = json.Unmarshal(value, result.Interface())
+				if err == nil {
+					call.Result = result.Elem().Interface()
+					return nil
+				}
+				logger.Warn().Err(err).Str("method", call.Method).Msg("Error decoding Tangy cache entry")
+			}
+		}
+
+		ctx, resolved := withResolvedVersions(ctx)
+		if err := invoke(ctx); err != nil {
+			return err
+		}
+		versions = cached.versions
+		if cached.latest() {
+			// Calls whose method did not resolve the latest versions are not cached
+			if versions = resolved.replace(cached.versions); versions == nil {
+				return nil
+			}
+			resolved.store(ctx, store, logger, options.LatestTTL)
+		}
+		if !resolved.complete() {
+			return nil
+		}
+
+		value, err := json.Marshal(call.Result)
+		if err != nil {
+			logger.Warn().Err(err).Str("method", call.Method).Msg("Error encoding Tangy cache entry")
+			return nil
+		}
+		if err := store.Set(ctx, cached.key(versions), value, options.TTL); err != nil {
+			logger.Warn().Err(err).Str("method", call.Method).Msg("Error writing Tangy cache")
+		}
+		return nil
+	}
+}
+
+// cachedResultType returns the type of the result of a query method
+func cachedResultType(method string) (reflect.Type, bool) {
+	if slices.Contains(nonQueryMethods, method) {
+		return nil, false
+	}
+	m, ok := reflect.TypeFor[Tangy]().MethodByName(method)
+	if !ok || m.Type.NumOut() != 2 {
+		return nil, false
+	}
+	return m.Type.Out(0), true
+}
+
+// cachedCall is a call whose result can be cached, with the repository versions its hrefs refer to,
+// in order and without duplicates. The versions of repository hrefs are latestRepositoryVersion.
+type cachedCall struct {
+	method       string
+	versions     []ParsedRepoVersion
+	repositories []string
+	args         json.RawMessage
+}
+
+// parseCachedCall parses the hrefs and the other arguments of a call. Calls whose hrefs cannot be parsed
+// are not cached, as they fail with ErrInvalidHref.
+func parseCachedCall(call *Call) (cachedCall, bool) {
+	if len(call.Args) == 0 {
+		return cachedCall{}, false
+	}
+	var hrefs []string
+	switch first := call.Args[0].(type) {
+	case []string:
+		hrefs = first
+	case string:
+		hrefs = []string{first}
+	default:
+		return cachedCall{}, false
+	}
+	if len(hrefs) == 0 || slices.Contains(hrefs, "") {
+		return cachedCall{}, false
+	}
+
+	cached := cachedCall{method: call.Method}
+	for _, href := range hrefs {
+		repoVersion, err := parseRepositoryOrVersionHref(href)
+		if err != nil {
+			return cachedCall{}, false
+		}
+		if !slices.Contains(cached.versions, repoVersion) {
+			cached.versions = append(cached.versions, repoVersion)
+		}
+		cached.repositories = append(cached.repositories, repositoryHrefOf(href))
+	}
+	slices.Sort(cached.repositories)
+	cached.repositories = slices.Compact(cached.repositories)
+
+	args, err := json.Marshal(call.Args[1:])
+	if err != nil {
+		return cachedCall{}, false
+	}
+	cached.args = args
+	return cached, true
+}
+
+// latest returns true if the call reads the latest version of a repository
+func (c cachedCall) latest() bool {
+	return slices.ContainsFunc(c.versions, func(v ParsedRepoVersion) bool {
+		return v.Version == latestRepositoryVersion
+	})
+}
+
+// key returns the key of the result of the call, reading versions, the versions of the call with the latest versions
+// of repositories resolved. The versions are sorted, and keyed along with the repository hrefs reported in results.
+func (c cachedCall) key(versions []ParsedRepoVersion) string {
+	keyed := make([]string, 0, len(versions))
+	for _, v := range versions {
+		keyed = append(keyed, v.RepositoryUUID+"@"+strconv.Itoa(v.Version))
+	}
+	slices.Sort(keyed)
+
+	key, _ := json.Marshal([]any{c.method, slices.Compact(keyed), c.repositories, c.args})
+	sum := sha256.Sum256(key)
+	return "tangy:" + hex.EncodeToString(sum[:])
+}
+
+// latestVersionKey is the key of the latest version of a repository, as resolved by the last call reading it
+func latestVersionKey(repositoryUUID string) string {
+	return "tangy:latest:" + repositoryUUID
+}
+
+// cachedLatestVersions returns versions with the latest versions of repositories replaced by their cached
+// versions, or nil when one of them is not cached
+func cachedLatestVersions(ctx context.Context, store CacheStore, logger zerolog.Logger, versions []ParsedRepoVersion) []ParsedRepoVersion {
+	resolved := slices.Clone(versions)
+	for i, v := range resolved {
+		if v.Version != latestRepositoryVersion {
+			continue
+		}
+		value, hit, err := store.Get(ctx, latestVersionKey(v.RepositoryUUID))
+		if err != nil {
+			logger.Warn().Err(err).Str("repository", v.RepositoryUUID).Msg("Error reading Tangy cache")
+		}
+		if !hit {
+			return nil
+		}
+		if resolved[i].Version, err = strconv.Atoi(string(value)); err != nil {
+			return nil
+		}
+	}
+	return resolved
+}
+
+type resolvedVersionsContextKey struct{}
+
+// resolvedVersions records the versions the repository hrefs of a call were resolved to, for CacheInterceptor
+// to key the result of the call on them. Versions pinned by a page cursor are not the latest versions
+// of their repositories: the result is keyed on them, but they are not cached as the latest versions.
+// It also records whether the versions read by the call are complete, as only then is the result cached.
+type resolvedVersions struct {
+	mu         sync.Mutex
+	latest     map[string]int
+	pinned     bool
+	checked    bool
+	incomplete bool
+}
+
+// withResolvedVersions returns a context recording the versions the repository hrefs of a call are resolved to
+func withResolvedVersions(ctx context.Context) (context.Context, *resolvedVersions) {
+	resolved := &resolvedVersions{latest: make(map[string]int)}
+	return context.WithValue(ctx, resolvedVersionsContextKey{}, resolved), resolved
+}
+
+// recordResolvedVersions records the versions that the repository hrefs of parsed were resolved to, when ctx
+// was returned by withResolvedVersions
+func recordResolvedVersions(ctx context.Context, parsed, resolved []ParsedRepoVersion, pinned bool) {
+	recorded, _ := ctx.Value(resolvedVersionsContextKey{}).(*resolvedVersions)
+	if recorded == nil {
+		return
+	}
+	recorded.mu.Lock()
+	defer recorded.mu.Unlock()
+	recorded.pinned = recorded.pinned || pinned
+	for i, v := range parsed {
+		if v.Version == latestRepositoryVersion {
+			recorded.latest[v.RepositoryUUID] = resolved[i].Version
+		}
+	}
+}
+
+// recordCompleteVersions records whether the repository versions read by the call are all complete,
+// when ctx was returned by withResolvedVersions
+func recordCompleteVersions(ctx context.Context, complete bool) {
+	recorded, _ := ctx.Value(resolvedVersionsContextKey{}).(*resolvedVersions)
+	if recorded == nil {
+		return
+	}
+	recorded.mu.Lock()
+	defer recorded.mu.Unlock()
+	recorded.checked = true
+	recorded.incomplete = recorded.incomplete || !complete
+}
+
+// recordsResolvedVersions returns true if ctx was returned by withResolvedVersions
+func recordsResolvedVersions(ctx context.Context) bool {
+	recorded, _ := ctx.Value(resolvedVersionsContextKey{}).(*resolvedVersions)
+	return recorded != nil
+}
+
+// complete returns true if the repository versions read by the call were checked, and are all complete
+func (r *resolvedVersions) complete() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.checked && !r.incomplete
+}
+
+// replace returns versions with the latest versions of repositories replaced by the recorded versions,
+// or nil when one of them was not recorded
+func (r *resolvedVersions) replace(versions []ParsedRepoVersion) []ParsedRepoVersion {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	resolved := slices.Clone(versions)
+	for i, v := range resolved {
+		if v.Version != latestRepositoryVersion {
+			continue
+		}
+		version, ok := r.latest[v.RepositoryUUID]
+		if !ok {
+			return nil
+		}
+		resolved[i].Version = version
+	}
+	return resolved
+}
+
+// store caches the recorded latest versions of repositories for ttl, unless they were pinned by a cursor
+func (r *resolvedVersions) store(ctx context.Context, store CacheStore, logger zerolog.Logger, ttl time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.pinned {
+		return
+	}
+	for repositoryUUID, version := range r.latest {
+		if err := store.Set(ctx, latestVersionKey(repositoryUUID), []byte(strconv.Itoa(version)), ttl); err != nil {
+			logger.Warn().Err(err).Str("repository", repositoryUUID).Msg("Error writing Tangy cache")
+		}
+	}
+}
+
+// MemoryCacheStore is an in-memory CacheStore evicting the least recently used entries
+// beyond its maximum number of entries or bytes
+type MemoryCacheStore struct {
+	maxEntries int
+	maxBytes   int64
+
+	mu      sync.Mutex
+	bytes   int64
+	entries map[string]*list.Element
+	lru     *list.List
+}
+
+type memoryCacheEntry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+// NewMemoryCacheStore returns an in-memory CacheStore holding at most maxEntries entries, and at most maxBytes bytes
+// of keys and values. Zero leaves either unbounded.
+func NewMemoryCacheStore(maxEntries int, maxBytes int64) (*MemoryCacheStore, error) {
+	if maxEntries < 0 || maxBytes < 0 {
+		return nil, fmt.Errorf("cache size bounds cannot be negative: %d entries, %d bytes", maxEntries, maxBytes)
+	}
+	return &MemoryCacheStore{
+		maxEntries: maxEntries,
+		maxBytes:   maxBytes,
+		entries:    make(map[string]*list.Element),
+		lru:        list.New(),
+	}, nil
+}
+
+func (s *MemoryCacheStore) Get(_ context.Context, key string) ([]byte, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	element, ok := s.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	entry, _ := element.Value.(*memoryCacheEntry)
+	if !entry.expiresAt.IsZero() && time.Now().After(entry.expiresAt) {
+		s.remove(element)
+		return nil, false, nil
+	}
+	s.lru.MoveToFront(element)
+	return entry.value, true, nil
+}
+
+func (s *MemoryCacheStore) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if element, ok := s.entries[key]; ok {
+		s.remove(element)
+	}
+	size := int64(len(key) + len(value))
+	if s.maxBytes > 0 && size > s.maxBytes {
+		return nil
+	}
+
+	entry := &memoryCacheEntry{key: key, value: value}
+	if ttl > 0 {
+		entry.expiresAt = time.Now().Add(ttl)
+	}
+	s.entries[key] = s.lru.PushFront(entry)
+	s.bytes += size
+	for (s.maxEntries > 0 && s.lru.Len() > s.maxEntries) || (s.maxBytes > 0 && s.bytes > s.maxBytes) {
+		s.remove(s.lru.Back())
+	}
+	return nil
+}
+
+// Len returns the number of entries in the store, including expired entries not evicted yet
+func (s *MemoryCacheStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lru.Len()
+}
+
+func (s *MemoryCacheStore) remove(element *list.Element) {
+	entry, _ := s.lru.Remove(element).(*memoryCacheEntry)
+	delete(s.entries, entry.key)
+	s.bytes -= int64(len(entry.key) + len(entry.value))
+}
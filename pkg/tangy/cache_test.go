@@ -0,0 +1,218 @@
+package tangy
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	cacheTestVersionHref    = "/api/pulp/default/api/v3/repositories/rpm/rpm/018c1c95-4281-76eb-b277-842cbad524f4/versions/3/"
+	cacheTestOtherHref      = "/api/pulp/default/api/v3/repositories/rpm/rpm/018c1c95-4281-76eb-b277-842cbad524f5/versions/1/"
+	cacheTestRepositoryHref = "/api/pulp/default/api/v3/repositories/npm/npm/018c1c95-4281-76eb-b277-842cbad524f4/"
+	cacheTestNpmVersionHref = "/api/pulp/default/api/v3/repositories/npm/npm/018c1c95-4281-76eb-b277-842cbad524f4/versions/3/"
+)
+
+func TestCacheKey(t *testing.T) {
+	t.Parallel()
+
+	key := func(method string, args ...any) string {
+		cached, ok := parseCachedCall(&Call{Method: method, Args: args})
+		require.True(t, ok)
+		return cached.key(cached.versions)
+	}
+	filters := ErrataListFilters{Search: "CVE"}
+	page := PageOptions{Limit: 10}
+	base := key("RpmRepositoryVersionErrataList", []string{cacheTestVersionHref, cacheTestOtherHref}, filters, page)
+
+	// The order and duplicates of hrefs do not matter
+	assert.Equal(t, base, key("RpmRepositoryVersionErrataList", []string{cacheTestOtherHref, cacheTestVersionHref, cacheTestOtherHref}, filters, page))
+	// The method, versions, filters and page do
+	assert.NotEqual(t, base, key("RpmRepositoryVersionPackageList", []string{cacheTestVersionHref, cacheTestOtherHref}, filters, page))
+	assert.NotEqual(t, base, key("RpmRepositoryVersionErrataList", []string{cacheTestVersionHref}, filters, page))
+	assert.NotEqual(t, base, key("RpmRepositoryVersionErrataList", []string{cacheTestVersionHref, cacheTestOtherHref}, ErrataListFilters{Search: "CVE-2024"}, page))
+	assert.NotEqual(t, base, key("RpmRepositoryVersionErrataList", []string{cacheTestVersionHref, cacheTestOtherHref}, filters, PageOptions{Limit: 10, Offset: 10}))
+
+	// A repository href is keyed on the version it was resolved to, as the href of that version
+	cached, ok := parseCachedCall(&Call{Method: "NpmPackageList", Args: []any{cacheTestRepositoryHref, NpmPackageListFilters{}, page}})
+	require.True(t, ok)
+	assert.True(t, cached.latest())
+	resolved := []ParsedRepoVersion{{RepositoryUUID: cached.versions[0].RepositoryUUID, Version: 3}}
+	assert.Equal(t, key("NpmPackageList", cacheTestNpmVersionHref, NpmPackageListFilters{}, page), cached.key(resolved))
+	cached, ok = parseCachedCall(&Call{Method: "RpmRepositoryVersionErrataList", Args: []any{[]string{cacheTestVersionHref}, filters, page}})
+	require.True(t, ok)
+	assert.False(t, cached.latest())
+
+	// Calls without hrefs, or with invalid hrefs, are not cached
+	for _, hrefs := range []any{[]string{}, "", []string{"/api/pulp/default/api/v3/repositories/rpm/rpm/not-a-uuid/versions/1/"}} {
+		_, ok = parseCachedCall(&Call{Method: "RpmRepositoryVersionErrataList", Args: []any{hrefs, filters, page}})
+		assert.False(t, ok)
+	}
+}
+
+func TestCacheInterceptor(t *testing.T) {
+	t.Parallel()
+
+	store, err := NewMemoryCacheStore(10, 0)
+	require.NoError(t, err)
+	next := NewMockTangy(t)
+	var logs bytes.Buffer
+	cached := Wrap(next, CacheInterceptor(store, CacheOptions{LatestTTL: 50 * time.Millisecond, Logger: zerolog.New(&logs)}))
+	ctx := context.Background()
+
+	// Results are cached, and returned with their type
+	hrefs := []string{cacheTestVersionHref}
+	response := ErrataListResponse{Total: 1, Results: []ErrataListItem{{ErrataId: "RHSA-2024:0001"}}}
+	next.On("RpmRepositoryVersionErrataList", mock.Anything, hrefs, ErrataListFilters{}, PageOptions{}).Return(response, nil).Run(completeVersions(true)).Once()
+	for range 2 {
+		list, err := cached.RpmRepositoryVersionErrataList(ctx, hrefs, ErrataListFilters{}, PageOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, response, list)
+	}
+
+	// Errors are not cached
+	next.On("RpmRepositoryVersionPackageSearch", mock.Anything, hrefs, "kern", 10).Return(nil, ErrQueryTimeout).Run(completeVersions(true)).Once()
+	next.On("RpmRepositoryVersionPackageSearch", mock.Anything, hrefs, "kern", 10).Return([]RpmPackageSearch{{Name: "kernel"}}, nil).Run(completeVersions(true)).Once()
+	_, err = cached.RpmRepositoryVersionPackageSearch(ctx, hrefs, "kern", 10)
+	require.ErrorIs(t, err, ErrQueryTimeout)
+	search, err := cached.RpmRepositoryVersionPackageSearch(ctx, hrefs, "kern", 10)
+	require.NoError(t, err)
+	assert.Equal(t, []RpmPackageSearch{{Name: "kernel"}}, search)
+
+	// resolveTo records the version a repository href is resolved to, as the methods of tangyImpl do
+	repository := ParsedRepoVersion{RepositoryUUID: "018c1c95-4281-76eb-b277-842cbad524f4", Version: latestRepositoryVersion}
+	resolveTo := func(version int) func(mock.Arguments) {
+		return func(args mock.Arguments) {
+			ctx, _ := args.Get(0).(context.Context)
+			recordResolvedVersions(ctx, []ParsedRepoVersion{repository}, []ParsedRepoVersion{{RepositoryUUID: repository.RepositoryUUID, Version: version}}, false)
+			recordCompleteVersions(ctx, true)
+		}
+	}
+
+	// Results of a version that is not complete, or whose method did not check it is complete, are not cached
+	next.On("RpmRepositoryVersionPackageSearch", mock.Anything, hrefs, "glib", 10).Return([]RpmPackageSearch{{Name: "glibc"}}, nil).Run(completeVersions(false)).Once()
+	next.On("RpmRepositoryVersionPackageSearch", mock.Anything, hrefs, "glib", 10).Return([]RpmPackageSearch{{Name: "glibc"}}, nil).Once()
+	next.On("RpmRepositoryVersionPackageSearch", mock.Anything, hrefs, "glib", 10).Return([]RpmPackageSearch{{Name: "glibc"}, {Name: "glib2"}}, nil).Run(completeVersions(true)).Once()
+	for _, names := range [][]string{{"glibc"}, {"glibc"}, {"glibc", "glib2"}, {"glibc", "glib2"}} {
+		search, err := cached.RpmRepositoryVersionPackageSearch(ctx, hrefs, "glib", 10)
+		require.NoError(t, err)
+		assert.Len(t, search, len(names))
+	}
+
+	// Results of a repository href are keyed on the latest version of the repository, shared with its version href
+	filters := NpmPackageListFilters{Search: "left"}
+	next.On("NpmPackageList", mock.Anything, cacheTestRepositoryHref, filters, PageOptions{}).Return(NpmPackageListResponse{Total: 1}, nil).Run(resolveTo(3)).Once()
+	for range 2 {
+		list, err := cached.NpmPackageList(ctx, cacheTestRepositoryHref, filters, PageOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, 1, list.Total)
+	}
+	list, err := cached.NpmPackageList(ctx, cacheTestNpmVersionHref, filters, PageOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, list.Total)
+
+	// The latest version expires after LatestTTL, and is resolved again with WithPrimary
+	time.Sleep(60 * time.Millisecond)
+	next.On("NpmPackageList", mock.Anything, cacheTestRepositoryHref, filters, PageOptions{}).Return(NpmPackageListResponse{Total: 2}, nil).Run(resolveTo(4)).Once()
+	for range 2 {
+		list, err := cached.NpmPackageList(ctx, cacheTestRepositoryHref, filters, PageOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, 2, list.Total)
+	}
+	next.On("NpmPackageList", mock.Anything, cacheTestRepositoryHref, filters, PageOptions{}).Return(NpmPackageListResponse{Total: 3}, nil).Run(resolveTo(5)).Once()
+	list, err = cached.NpmPackageList(WithPrimary(ctx), cacheTestRepositoryHref, filters, PageOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 3, list.Total)
+	list, err = cached.NpmPackageList(ctx, cacheTestRepositoryHref, filters, PageOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 3, list.Total)
+
+	// Calls whose method does not report the version a repository href was resolved to are not cached
+	next.On("NpmPackageList", mock.Anything, cacheTestRepositoryHref, NpmPackageListFilters{}, PageOptions{}).Return(NpmPackageListResponse{}, nil).Twice()
+	for range 2 {
+		_, err := cached.NpmPackageList(ctx, cacheTestRepositoryHref, NpmPackageListFilters{}, PageOptions{})
+		require.NoError(t, err)
+	}
+	assert.Empty(t, logs.String())
+}
+
+func TestCacheInterceptorLogger(t *testing.T) {
+	t.Parallel()
+
+	next := NewMockTangy(t)
+	var logs bytes.Buffer
+	cached := Wrap(next, CacheInterceptor(failingCacheStore{}, CacheOptions{Logger: zerolog.New(&logs)}))
+
+	hrefs := []string{cacheTestVersionHref}
+	next.On("RpmRepositoryVersionErrataList", mock.Anything, hrefs, ErrataListFilters{}, PageOptions{}).Return(ErrataListResponse{}, nil).Run(completeVersions(true)).Once()
+	_, err := cached.RpmRepositoryVersionErrataList(context.Background(), hrefs, ErrataListFilters{}, PageOptions{})
+	require.NoError(t, err)
+	assert.Contains(t, logs.String(), "Error reading Tangy cache")
+	assert.Contains(t, logs.String(), "Error writing Tangy cache")
+}
+
+// completeVersions records whether the repository versions read by a call are complete, as the methods of tangyImpl do
+func completeVersions(complete bool) func(mock.Arguments) {
+	return func(args mock.Arguments) {
+		ctx, _ := args.Get(0).(context.Context)
+		recordCompleteVersions(ctx, complete)
+	}
+}
+
+// failingCacheStore is a CacheStore failing every operation
+type failingCacheStore struct{}
+
+func (failingCacheStore) Get(context.Context, string) ([]byte, bool, error) {
+	return nil, false, assert.AnError
+}
+
+func (failingCacheStore) Set(context.Context, string, []byte, time.Duration) error {
+	return assert.AnError
+}
+
+func TestMemoryCacheStore(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	_, err := NewMemoryCacheStore(-1, 0)
+	require.Error(t, err)
+
+	// The least recently used entries are evicted beyond the maximum number of entries
+	store, err := NewMemoryCacheStore(2, 0)
+	require.NoError(t, err)
+	require.NoError(t, store.Set(ctx, "a", []byte("1"), 0))
+	require.NoError(t, store.Set(ctx, "b", []byte("2"), 0))
+	_, hit, err := store.Get(ctx, "a")
+	require.NoError(t, err)
+	assert.True(t, hit)
+	require.NoError(t, store.Set(ctx, "c", []byte("3"), 0))
+	_, hit, _ = store.Get(ctx, "b")
+	assert.False(t, hit)
+	value, hit, _ := store.Get(ctx, "a")
+	assert.True(t, hit)
+	assert.Equal(t, []byte("1"), value)
+	assert.Equal(t, 2, store.Len())
+
+	// and beyond the maximum number of bytes, counting keys and values
+	store, err = NewMemoryCacheStore(0, 8)
+	require.NoError(t, err)
+	require.NoError(t, store.Set(ctx, "a", []byte("123"), 0))
+	require.NoError(t, store.Set(ctx, "b", []byte("123"), 0))
+	require.NoError(t, store.Set(ctx, "c", []byte("123"), 0))
+	assert.Equal(t, 2, store.Len())
+	require.NoError(t, store.Set(ctx, "d", []byte("too large"), 0))
+	_, hit, _ = store.Get(ctx, "d")
+	assert.False(t, hit)
+
+	// Expired entries are missing
+	require.NoError(t, store.Set(ctx, "e", []byte("1"), time.Millisecond))
+	time.Sleep(2 * time.Millisecond)
+	_, hit, _ = store.Get(ctx, "e")
+	assert.False(t, hit)
+}
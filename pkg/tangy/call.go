@@ -0,0 +1,121 @@
+package tangy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// ErrQueryTimeout is returned when a query is canceled by the statement timeout, or by the deadline of the context
+var ErrQueryTimeout = errors.New("query timeout")
+
+// queryCanceledCode is the SQLSTATE of a statement canceled by statement_timeout or a cancel request
+const queryCanceledCode = "57014"
+
+type callContextKey struct{}
+
+// callState is what is recorded about a call while it runs, to be counted in the statistics of its method
+type callState struct {
+	method string
+	// retry is set when the call is an attempt of the retry policy after the first one
+	retry bool
+	// rows is the number of result rows read by the queries of the call
+	rows atomic.Uint64
+	// contentIds and legacy are set when repository versions were read through content_ids,
+	// and through core_repositorycontent
+	contentIds atomic.Bool
+	legacy     atomic.Bool
+	// loop is the time spent in the body of the loop over an iterator, which is not part of the latency of the call
+	loop atomic.Int64
+}
+
+// call starts a call of the Tangy method, and returns the context of the call with a function ending it
+// with the error returned by the method. Calls made by another method are part of the call of that method.
+// args are the arguments of the method recorded on the span of the call, see argAttributes.
+func (t *tangyImpl) call(ctx context.Context, method string, args ...any) (context.Context, func(*error)) {
+	if callOf(ctx) != nil {
+		return ctx, func(*error) {}
+	}
+	state := &callState{method: method, retry: isRetry(ctx)}
+	ctx = context.WithValue(ctx, callContextKey{}, state)
+	ctx, span := t.startCallSpan(ctx, method, args)
+	start := time.Now()
+	return ctx, func(err *error) {
+		*err = queryTimeoutError(ctx, *err)
+		t.stats.record(state, time.Since(start)-time.Duration(state.loop.Load()), *err)
+		endCallSpan(span, state, *err)
+	}
+}
+
+// callOf returns the state of the call ctx belongs to, nil outside a call
+func callOf(ctx context.Context) *callState {
+	state, _ := ctx.Value(callContextKey{}).(*callState)
+	return state
+}
+
+// callMethod returns the name of the Tangy method whose call ctx belongs to
+func callMethod(ctx context.Context) string {
+	if state := callOf(ctx); state != nil {
+		return state.method
+	}
+	return ""
+}
+
+// collectRows collects the rows of a query like pgx.CollectRows, counting them as result rows of the call
+func collectRows[T any](ctx context.Context, rows pgx.Rows, fn pgx.RowToFunc[T]) ([]T, error) {
+	collected, err := pgx.CollectRows(rows, fn)
+	if state := callOf(ctx); state != nil {
+		state.rows.Add(uint64(len(collected)))
+	}
+	return collected, err
+}
+
+// queryTimeoutError wraps err with ErrQueryTimeout when it was returned because a timeout was hit
+func queryTimeoutError(ctx context.Context, err error) error {
+	if err == nil || errors.Is(err, ErrQueryTimeout) {
+		return err
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("%w: %w", ErrQueryTimeout, err)
+	}
+	// A statement canceled while the context is still active was canceled by statement_timeout
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == queryCanceledCode && !errors.Is(ctx.Err(), context.Canceled) {
+		return fmt.Errorf("%w: %w", ErrQueryTimeout, err)
+	}
+	return err
+}
+
+// callStatementTimeout returns the statement timeout of the queries of a call: the timeout of its method, capped by
+// the time left before the deadline of the context. No timeout when zero.
+func (t *tangyImpl) callStatementTimeout(ctx context.Context) time.Duration {
+	timeout := t.statementTimeout
+	if override, ok := t.methodStatementTimeouts[callMethod(ctx)]; ok {
+		timeout = override
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		if left := time.Until(deadline); timeout == 0 || left < timeout {
+			timeout = max(left, time.Millisecond)
+		}
+	}
+	return timeout
+}
+
+// setStatementTimeout sets the statement timeout of the transaction a call runs in, so that Postgres stops
+// running the queries of the call once the caller gave up on it
+func (t *tangyImpl) setStatementTimeout(ctx context.Context, tx pgx.Tx) error {
+	timeout := t.callStatementTimeout(ctx)
+	if timeout == 0 {
+		return nil
+	}
+	milliseconds := min((timeout+time.Millisecond-1)/time.Millisecond, math.MaxInt32)
+	_, err := tx.Exec(ctx, "SELECT set_config('statement_timeout', $1, true)", strconv.FormatInt(int64(milliseconds), 10))
+	return err
+}
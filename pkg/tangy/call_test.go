@@ -0,0 +1,88 @@
+package tangy
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCallNested(t *testing.T) {
+	t.Parallel()
+
+	tangy := &tangyImpl{}
+	ctx, done := tangy.call(context.Background(), "MavenPackageList")
+	assert.Equal(t, "MavenPackageList", callMethod(ctx))
+
+	// A method called by another method is part of its call
+	inner, innerDone := tangy.call(ctx, "MavenRepositoryVersionPackageList")
+	assert.Equal(t, "MavenPackageList", callMethod(inner))
+
+	err := error(&pgconn.PgError{Code: queryCanceledCode})
+	innerDone(&err)
+	assert.NotErrorIs(t, err, ErrQueryTimeout)
+	done(&err)
+	assert.ErrorIs(t, err, ErrQueryTimeout)
+}
+
+func TestQueryTimeoutError(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, queryTimeoutError(context.Background(), nil))
+
+	other := errors.New("connection refused")
+	assert.Equal(t, other, queryTimeoutError(context.Background(), other))
+
+	canceledByTimeout := &pgconn.PgError{Code: queryCanceledCode, Message: "canceling statement due to statement timeout"}
+	err := queryTimeoutError(context.Background(), canceledByTimeout)
+	assert.ErrorIs(t, err, ErrQueryTimeout)
+	assert.ErrorAs(t, err, &canceledByTimeout)
+
+	err = queryTimeoutError(context.Background(), context.DeadlineExceeded)
+	assert.ErrorIs(t, err, ErrQueryTimeout)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// Statements canceled because the caller canceled the context did not time out
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.NotErrorIs(t, queryTimeoutError(ctx, &pgconn.PgError{Code: queryCanceledCode}), ErrQueryTimeout)
+	assert.NotErrorIs(t, queryTimeoutError(ctx, context.Canceled), ErrQueryTimeout)
+}
+
+func TestCallStatementTimeout(t *testing.T) {
+	t.Parallel()
+
+	tangy := &tangyImpl{
+		statementTimeout:        30 * time.Second,
+		methodStatementTimeouts: map[string]time.Duration{"RpmRepositoryVersionErrataList": time.Minute, "NpmPackageList": 0},
+	}
+
+	ctx, _ := tangy.call(context.Background(), "RpmRepositoryVersionPackageList")
+	assert.Equal(t, 30*time.Second, tangy.callStatementTimeout(ctx))
+
+	ctx, _ = tangy.call(context.Background(), "RpmRepositoryVersionErrataList")
+	assert.Equal(t, time.Minute, tangy.callStatementTimeout(ctx))
+
+	ctx, _ = tangy.call(context.Background(), "NpmPackageList")
+	assert.Zero(t, tangy.callStatementTimeout(ctx))
+
+	// An earlier deadline of the context takes over
+	deadlineCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	ctx, _ = tangy.call(deadlineCtx, "RpmRepositoryVersionErrataList")
+	timeout := tangy.callStatementTimeout(ctx)
+	assert.LessOrEqual(t, timeout, 5*time.Second)
+	assert.Greater(t, timeout, 4*time.Second)
+
+	ctx, _ = tangy.call(deadlineCtx, "NpmPackageList")
+	assert.LessOrEqual(t, tangy.callStatementTimeout(ctx), 5*time.Second)
+
+	// A later deadline does not
+	lateCtx, cancel := context.WithTimeout(context.Background(), time.Hour)
+	defer cancel()
+	ctx, _ = tangy.call(lateCtx, "RpmRepositoryVersionPackageList")
+	assert.Equal(t, 30*time.Second, tangy.callStatementTimeout(ctx))
+}
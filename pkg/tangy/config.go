@@ -1,9 +1,20 @@
 package tangy
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"maps"
+	"math"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const DefaultMaxPoolLimit = 20
@@ -13,35 +24,282 @@ type Logger struct {
 	Logger   *zerolog.Logger
 	LogLevel string
 	Enabled  bool
+	// TracerProvider traces the calls of Tangy methods with OpenTelemetry, with a span for each call
+	// and a child span for each SQL statement it runs. Calls are not traced when nil.
+	TracerProvider trace.TracerProvider
+}
+
+// logger returns the logger Tangy writes its messages to, which discards them unless logging is enabled
+func (l Logger) logger() zerolog.Logger {
+	if l.Logger == nil || !l.Enabled {
+		return zerolog.Nop()
+	}
+	return *l.Logger
+}
+
+// SSL modes of the connection, as defined by libpq
+const (
+	SSLModeDisable    = "disable"
+	SSLModeAllow      = "allow"
+	SSLModePrefer     = "prefer"
+	SSLModeRequire    = "require"
+	SSLModeVerifyCA   = "verify-ca"
+	SSLModeVerifyFull = "verify-full"
+)
+
+var sslModes = []string{SSLModeDisable, SSLModeAllow, SSLModePrefer, SSLModeRequire, SSLModeVerifyCA, SSLModeVerifyFull}
+
+// Query exec modes of the connections, as defined by pgx
+const (
+	QueryExecModeCacheStatement = "cache_statement"
+	QueryExecModeCacheDescribe  = "cache_describe"
+	QueryExecModeDescribeExec   = "describe_exec"
+	QueryExecModeExec           = "exec"
+	QueryExecModeSimpleProtocol = "simple_protocol"
+)
+
+var queryExecModes = map[string]pgx.QueryExecMode{
+	QueryExecModeCacheStatement: pgx.QueryExecModeCacheStatement,
+	QueryExecModeCacheDescribe:  pgx.QueryExecModeCacheDescribe,
+	QueryExecModeDescribeExec:   pgx.QueryExecModeDescribeExec,
+	QueryExecModeExec:           pgx.QueryExecModeExec,
+	QueryExecModeSimpleProtocol: pgx.QueryExecModeSimpleProtocol,
 }
 
 // Database configuration options for connection to a pulp database
 type Database struct {
-	Name       string
-	Host       string
-	Port       int
-	User       string
-	Password   string
+	// DSN is a libpq connection string, either keyword/value ("host=localhost dbname=pulp") or a
+	// postgres:// URL. When set, it replaces the connection fields from Name to ClientKeyPath, which must be empty.
+	DSN  string `mapstructure:"dsn"`
+	Name string
+	// Host is the host name or unix socket directory of the database, the libpq default (PGHOST or the
+	// unix socket) when empty
+	Host     string
+	Port     int
+	User     string
+	Password string
+	// PassFile is a libpq password file (.pgpass), used when Password is empty. As with libpq, a missing file is ignored.
+	PassFile string `mapstructure:"pass_file"`
+	// SSLMode is one of the libpq SSL modes. It defaults to verify-full when CACertPath is set, and to disable otherwise.
+	SSLMode    string `mapstructure:"ssl_mode"`
 	CACertPath string `mapstructure:"ca_cert_path"`
-	PoolLimit  int    `mapstructure:"pool_limit"`
+	// ClientCertPath and ClientKeyPath are the certificate and key authenticating the client, set together
+	ClientCertPath string `mapstructure:"client_cert_path"`
+	ClientKeyPath  string `mapstructure:"client_key_path"`
+	// ApplicationName is reported by the connections in pg_stat_activity
+	ApplicationName string `mapstructure:"application_name"`
+	// ConnectTimeout is the time allowed to establish a connection, rounded up to a second. No timeout when zero.
+	ConnectTimeout time.Duration `mapstructure:"connect_timeout"`
+	// MaxConnectBackoff is the longest wait between two attempts to connect while the database cannot be reached,
+	// DefaultMaxConnectBackoff when zero
+	MaxConnectBackoff time.Duration `mapstructure:"max_connect_backoff"`
+	// BeforeConnect is called before each connection is established, and may update its configuration,
+	// for example to set a password that is rotated
+	BeforeConnect func(ctx context.Context, config *pgx.ConnConfig) error `mapstructure:"-"`
+	PoolLimit     int                                                     `mapstructure:"pool_limit"`
+	// QueryExecMode is how pgx runs queries, QueryExecModeCacheStatement by default. Behind PgBouncer in
+	// transaction pooling mode, where prepared statements do not outlive a transaction, use
+	// QueryExecModeDescribeExec or QueryExecModeSimpleProtocol. Statements are not cached in these modes.
+	QueryExecMode string `mapstructure:"query_exec_mode"`
+	// SQLComments adds a sqlcommenter comment to every statement, with the method, the traceparent and the tags set
+	// with WithRequestID and WithSQLComment, so that statements seen in pg_stat_activity can be traced back to their
+	// caller. As commented statements are all different, QueryExecMode defaults to QueryExecModeDescribeExec, and
+	// cannot be one of the modes caching statements.
+	SQLComments bool `mapstructure:"sql_comments"`
+	// SkipContentIdsCheck skips checking which repository versions lack content_ids before each query,
+	// and always uses content_ids. Only set it once every repository version has content_ids populated,
+	// as content of older versions is not found otherwise. Missing repository versions are not reported either.
+	SkipContentIdsCheck bool `mapstructure:"skip_content_ids_check"`
+	// StatementTimeout is the time Postgres allows each query of a call to run, no timeout when zero.
+	// The deadline of the context of a call is also enforced by Postgres, when it is earlier.
+	StatementTimeout time.Duration `mapstructure:"statement_timeout"`
+	// MethodStatementTimeouts overrides StatementTimeout for the Tangy methods it is keyed by,
+	// such as "RpmRepositoryVersionErrataList". A zero timeout disables the timeout of a method.
+	MethodStatementTimeouts map[string]time.Duration `mapstructure:"method_statement_timeouts"`
+	// Retry is the policy retrying calls that fail with a transient error, such as a restart of Postgres.
+	// Calls are not retried by default.
+	Retry RetryPolicy `mapstructure:"retry"`
+	// Replicas are streaming replicas of the database. Queries are sent to the healthy replicas in turn,
+	// and to this database when no replica is healthy or the context was returned by WithPrimary.
+	// Fields left empty in a replica are taken from this database.
+	Replicas []Database `mapstructure:"replicas"`
+	// MaxReplicaLag is the replication lag past which a replica is not used. Replicas are used whatever their lag when zero.
+	MaxReplicaLag time.Duration `mapstructure:"max_replica_lag"`
+	// ReplicaCheckInterval is how often the health and lag of replicas are checked, DefaultReplicaCheckInterval when zero
+	ReplicaCheckInterval time.Duration `mapstructure:"replica_check_interval"`
 }
 
-// Url return url of database
+// Url returns the libpq connection string of the database: DSN when set, or a keyword/value string built from the fields
 func (d Database) Url() string {
-	connectStr := fmt.Sprintf(
-		"user=%s password=%s dbname=%s host=%s port=%d",
-		d.User,
-		d.Password,
-		d.Name,
-		d.Host,
-		d.Port,
-	)
-
-	var sslStr string
-	if d.CACertPath == "" {
-		sslStr = " sslmode=disable"
+	if d.DSN != "" {
+		return d.DSN
+	}
+
+	keywords := []string{
+		"user=" + quoteConnValue(d.User),
+		"dbname=" + quoteConnValue(d.Name),
+	}
+	if d.Host != "" {
+		keywords = append(keywords, "host="+quoteConnValue(d.Host))
+	}
+	if d.Password != "" {
+		keywords = append(keywords, "password="+quoteConnValue(d.Password))
+	}
+	if d.Port != 0 {
+		keywords = append(keywords, fmt.Sprintf("port=%d", d.Port))
+	}
+	if d.PassFile != "" {
+		keywords = append(keywords, "passfile="+quoteConnValue(d.PassFile))
+	}
+
+	keywords = append(keywords, "sslmode="+d.sslMode())
+	if d.CACertPath != "" {
+		keywords = append(keywords, "sslrootcert="+quoteConnValue(d.CACertPath))
+	}
+	if d.ClientCertPath != "" {
+		keywords = append(keywords, "sslcert="+quoteConnValue(d.ClientCertPath))
+	}
+	if d.ClientKeyPath != "" {
+		keywords = append(keywords, "sslkey="+quoteConnValue(d.ClientKeyPath))
+	}
+
+	if d.ApplicationName != "" {
+		keywords = append(keywords, "application_name="+quoteConnValue(d.ApplicationName))
+	}
+	if d.ConnectTimeout > 0 {
+		keywords = append(keywords, fmt.Sprintf("connect_timeout=%d", connectTimeoutSeconds(d.ConnectTimeout)))
+	}
+	return strings.Join(keywords, " ")
+}
+
+// sslMode returns the SSL mode of the connection, defaulting to verify-full when a CA certificate is set
+func (d Database) sslMode() string {
+	if d.SSLMode != "" {
+		return d.SSLMode
+	}
+	if d.CACertPath != "" {
+		return SSLModeVerifyFull
+	}
+	return SSLModeDisable
+}
+
+// quoteConnValue quotes a keyword/value connection string value when it is empty or contains
+// spaces, quotes or backslashes, escaping quotes and backslashes
+func quoteConnValue(value string) string {
+	if value != "" && !strings.ContainsAny(value, " \t\n\r\v\f'\\") {
+		return value
+	}
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value) + "'"
+}
+
+// connectTimeoutSeconds rounds a connect timeout up to the whole seconds of the connect_timeout keyword
+func connectTimeoutSeconds(timeout time.Duration) int {
+	return int((timeout + time.Second - 1) / time.Second)
+}
+
+// Validate returns every problem of the configuration at once, joined in a single error, or nil if there is none
+func (d Database) Validate() error {
+	var errs []error
+	if d.DSN != "" {
+		for _, field := range []struct {
+			name string
+			set  bool
+		}{
+			{"name", d.Name != ""}, {"host", d.Host != ""}, {"port", d.Port != 0}, {"user", d.User != ""},
+			{"password", d.Password != ""}, {"pass_file", d.PassFile != ""}, {"ssl_mode", d.SSLMode != ""},
+			{"ca_cert_path", d.CACertPath != ""}, {"client_cert_path", d.ClientCertPath != ""}, {"client_key_path", d.ClientKeyPath != ""},
+		} {
+			if field.set {
+				errs = append(errs, fmt.Errorf("%s cannot be set with dsn", field.name))
+			}
+		}
+		if _, err := pgx.ParseConfig(d.DSN); err != nil {
+			errs = append(errs, fmt.Errorf("dsn is invalid: %w", err))
+		}
 	} else {
-		sslStr = fmt.Sprintf(" sslmode=verify-full sslrootcert=%s", d.CACertPath)
+		if d.Name == "" {
+			errs = append(errs, errors.New("name is required"))
+		}
+		if d.User == "" {
+			errs = append(errs, errors.New("user is required"))
+		}
+		if d.Port < 0 || d.Port > math.MaxUint16 {
+			errs = append(errs, fmt.Errorf("port is invalid: %d (must be between 0 and %d)", d.Port, math.MaxUint16))
+		}
+		if !slices.Contains(sslModes, d.sslMode()) {
+			errs = append(errs, fmt.Errorf("ssl_mode is invalid: %q (must be one of %s)", d.SSLMode, strings.Join(sslModes, ", ")))
+		}
+		if (d.ClientCertPath == "") != (d.ClientKeyPath == "") {
+			errs = append(errs, errors.New("client_cert_path and client_key_path must be set together"))
+		}
+		if d.sslMode() == SSLModeDisable && (d.CACertPath != "" || d.ClientCertPath != "") {
+			errs = append(errs, errors.New("ca_cert_path and client_cert_path cannot be used with ssl_mode disable"))
+		}
+		// A missing pass_file is ignored by libpq, unlike missing certificates and keys
+		for _, file := range []struct{ name, path string }{
+			{"ca_cert_path", d.CACertPath}, {"client_cert_path", d.ClientCertPath}, {"client_key_path", d.ClientKeyPath},
+		} {
+			if file.path == "" {
+				continue
+			}
+			if _, err := os.Stat(file.path); err != nil {
+				errs = append(errs, fmt.Errorf("%s is invalid: %w", file.name, err))
+			}
+		}
+	}
+
+	if d.ConnectTimeout < 0 {
+		errs = append(errs, fmt.Errorf("connect_timeout is invalid: %v (must not be negative)", d.ConnectTimeout))
+	}
+	if d.MaxConnectBackoff < 0 {
+		errs = append(errs, fmt.Errorf("max_connect_backoff is invalid: %v (must not be negative)", d.MaxConnectBackoff))
+	}
+	if _, ok := queryExecModes[d.QueryExecMode]; d.QueryExecMode != "" && !ok {
+		errs = append(errs, fmt.Errorf("query_exec_mode is invalid: %q (must be one of %s)", d.QueryExecMode,
+			strings.Join(slices.Sorted(maps.Keys(queryExecModes)), ", ")))
+	}
+	if d.SQLComments && (d.QueryExecMode == QueryExecModeCacheStatement || d.QueryExecMode == QueryExecModeCacheDescribe) {
+		errs = append(errs, fmt.Errorf("sql_comments cannot be used with query_exec_mode %s, as commented statements are not cached", d.QueryExecMode))
+	}
+	if d.StatementTimeout < 0 {
+		errs = append(errs, fmt.Errorf("statement_timeout is invalid: %v (must not be negative)", d.StatementTimeout))
+	}
+	for _, method := range slices.Sorted(maps.Keys(d.MethodStatementTimeouts)) {
+		if !slices.Contains(queryMethods(), method) {
+			errs = append(errs, fmt.Errorf("method_statement_timeouts is invalid: %q is not a Tangy method", method))
+		} else if timeout := d.MethodStatementTimeouts[method]; timeout < 0 {
+			errs = append(errs, fmt.Errorf("method_statement_timeouts is invalid: %v for %s (must not be negative)", timeout, method))
+		}
+	}
+	if d.PoolLimit < 0 || d.PoolLimit > math.MaxInt32 {
+		errs = append(errs, fmt.Errorf("pool limit size is invalid: %d (must be between 0 and %d)", d.PoolLimit, math.MaxInt32))
+	}
+	if err := d.Retry.validate(); err != nil {
+		errs = append(errs, err)
+	}
+	if d.MaxReplicaLag < 0 {
+		errs = append(errs, fmt.Errorf("max_replica_lag is invalid: %v (must not be negative)", d.MaxReplicaLag))
+	}
+	for i, replica := range d.Replicas {
+		if err := replica.replicaOf(d).Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("replica %d: %w", i, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// nonQueryMethods are the methods of the Tangy interface that do not run queries of their own,
+// or only check the database
+var nonQueryMethods = []string{"Close", "Ping", "ReadSnapshot", "Ready", "Stats"}
+
+// queryMethods returns the names of the methods of the Tangy interface that run queries
+func queryMethods() []string {
+	tangyType := reflect.TypeFor[Tangy]()
+	var methods []string
+	for i := range tangyType.NumMethod() {
+		if method := tangyType.Method(i).Name; !slices.Contains(nonQueryMethods, method) {
+			methods = append(methods, method)
+		}
 	}
-	return connectStr + sslStr
+	return methods
 }
@@ -0,0 +1,142 @@
+package tangy
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDatabaseUrl(t *testing.T) {
+	t.Parallel()
+
+	d := Database{Name: "pulp", Host: "localhost", Port: 5434, User: "pulp", Password: "password"}
+	assert.Equal(t, "user=pulp dbname=pulp host=localhost password=password port=5434 sslmode=disable", d.Url())
+
+	d.CACertPath = "/etc/ssl/ca.pem"
+	assert.Equal(t, "user=pulp dbname=pulp host=localhost password=password port=5434 sslmode=verify-full sslrootcert=/etc/ssl/ca.pem", d.Url())
+
+	d = Database{Name: "pulp", Host: "localhost", User: "pulp", SSLMode: SSLModeRequire, ClientCertPath: "/tls/client.crt", ClientKeyPath: "/tls/client.key",
+		PassFile: "/secrets/pgpass", ApplicationName: "content sources", ConnectTimeout: 1500 * time.Millisecond}
+	assert.Equal(t, "user=pulp dbname=pulp host=localhost passfile=/secrets/pgpass sslmode=require sslcert=/tls/client.crt sslkey=/tls/client.key "+
+		"application_name='content sources' connect_timeout=2", d.Url())
+
+	// Without host, libpq defaults to PGHOST or the unix socket
+	d = Database{Name: "pulp", User: "pulp"}
+	assert.Equal(t, "user=pulp dbname=pulp sslmode=disable", d.Url())
+	_, err := pgx.ParseConfig(d.Url())
+	require.NoError(t, err)
+
+	d = Database{DSN: "postgres://pulp@localhost/pulp?sslmode=prefer", Host: "ignored"}
+	assert.Equal(t, "postgres://pulp@localhost/pulp?sslmode=prefer", d.Url())
+}
+
+func TestDatabaseUrlQuoting(t *testing.T) {
+	t.Parallel()
+
+	for _, password := range []string{"pass word", `it's`, `back\slash`, `' OR 'a'='a`, "tab\tbed"} {
+		d := Database{Name: "pulp", Host: "localhost", Port: 5434, User: "pulp", Password: password}
+		config, err := pgx.ParseConfig(d.Url())
+		require.NoError(t, err, password)
+		assert.Equal(t, password, config.Password)
+		assert.Equal(t, "pulp", config.Database)
+		assert.Equal(t, "pulp", config.User)
+	}
+
+	assert.Equal(t, "''", quoteConnValue(""))
+	assert.Equal(t, `'it\'s'`, quoteConnValue("it's"))
+	assert.Equal(t, "plain", quoteConnValue("plain"))
+}
+
+func TestDatabaseValidate(t *testing.T) {
+	t.Parallel()
+
+	certPath := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(certPath, []byte{}, 0o600))
+
+	valid := Database{Name: "pulp", Host: "localhost", Port: 5434, User: "pulp", Password: "password"}
+	assert.NoError(t, valid.Validate())
+
+	valid.CACertPath = certPath
+	assert.NoError(t, valid.Validate())
+	assert.NoError(t, Database{DSN: "postgres://pulp@localhost:5434/pulp", ApplicationName: "tangy", PoolLimit: 5}.Validate())
+
+	// Every problem is reported at once
+	err := Database{Port: 70000, SSLMode: "always", ClientCertPath: certPath, ConnectTimeout: -time.Second, PoolLimit: -1}.Validate()
+	require.Error(t, err)
+	for _, problem := range []string{
+		"name is required",
+		"user is required",
+		"port is invalid: 70000",
+		`ssl_mode is invalid: "always"`,
+		"client_cert_path and client_key_path must be set together",
+		"connect_timeout is invalid",
+		"pool limit size is invalid: -1",
+	} {
+		assert.ErrorContains(t, err, problem)
+	}
+
+	err = Database{Name: "pulp", Host: "localhost", User: "pulp", CACertPath: filepath.Join(t.TempDir(), "missing.pem")}.Validate()
+	assert.ErrorContains(t, err, "ca_cert_path is invalid")
+
+	// Settings libpq accepts are valid: a missing pass file is ignored, and the host defaults to PGHOST or the unix socket
+	assert.NoError(t, Database{Name: "pulp", Host: "localhost", User: "pulp", PassFile: filepath.Join(t.TempDir(), "missing")}.Validate())
+	assert.NoError(t, Database{Name: "pulp", User: "pulp"}.Validate())
+
+	err = Database{Name: "pulp", Host: "localhost", User: "pulp", SSLMode: SSLModeDisable, CACertPath: certPath}.Validate()
+	assert.ErrorContains(t, err, "cannot be used with ssl_mode disable")
+
+	err = Database{DSN: "postgres://pulp@localhost/pulp", Host: "localhost", Password: "password"}.Validate()
+	assert.ErrorContains(t, err, "host cannot be set with dsn")
+	assert.ErrorContains(t, err, "password cannot be set with dsn")
+
+	err = Database{Name: "pulp", Host: "localhost", User: "pulp", StatementTimeout: -time.Second,
+		MethodStatementTimeouts: map[string]time.Duration{"RpmPackageList": time.Second, "NpmPackageList": -time.Second, "Close": time.Second}}.Validate()
+	assert.ErrorContains(t, err, "statement_timeout is invalid: -1s")
+	assert.ErrorContains(t, err, `"RpmPackageList" is not a Tangy method`)
+	assert.ErrorContains(t, err, `"Close" is not a Tangy method`)
+	assert.ErrorContains(t, err, "-1s for NpmPackageList")
+	assert.NoError(t, Database{Name: "pulp", Host: "localhost", User: "pulp",
+		MethodStatementTimeouts: map[string]time.Duration{"RpmRepositoryVersionErrataList": time.Minute}}.Validate())
+
+	err = Database{Name: "pulp", Host: "localhost", User: "pulp", Replicas: []Database{{SSLMode: "sometimes"}}}.Validate()
+	assert.ErrorContains(t, err, `replica 0: ssl_mode is invalid: "sometimes"`)
+}
+
+func TestNewInvalidConfiguration(t *testing.T) {
+	t.Parallel()
+
+	_, err := New(Database{Host: "localhost"}, Logger{})
+	assert.ErrorContains(t, err, "invalid database configuration")
+	assert.ErrorContains(t, err, "name is required")
+}
+
+func TestConfigureQueryExecMode(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		mode     string
+		execMode pgx.QueryExecMode
+		cached   bool
+	}{
+		{"", pgx.QueryExecModeCacheStatement, true},
+		{QueryExecModeCacheDescribe, pgx.QueryExecModeCacheDescribe, true},
+		{QueryExecModeDescribeExec, pgx.QueryExecModeDescribeExec, false},
+		{QueryExecModeSimpleProtocol, pgx.QueryExecModeSimpleProtocol, false},
+	} {
+		config, err := pgx.ParseConfig(Database{Name: "pulp", Host: "localhost", User: "pulp"}.Url())
+		require.NoError(t, err)
+
+		configureQueryExecMode(config, tt.mode)
+		assert.Equal(t, tt.execMode, config.DefaultQueryExecMode, tt.mode)
+		assert.Equal(t, tt.cached, config.StatementCacheCapacity > 0, tt.mode)
+		assert.Equal(t, tt.cached, config.DescriptionCacheCapacity > 0, tt.mode)
+	}
+
+	err := Database{Name: "pulp", Host: "localhost", User: "pulp", QueryExecMode: "transaction"}.Validate()
+	assert.ErrorContains(t, err, `query_exec_mode is invalid: "transaction"`)
+}
@@ -0,0 +1,143 @@
+package tangy
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Backoff between attempts to connect to a database while connections fail
+const (
+	initialConnectBackoff    = 100 * time.Millisecond
+	DefaultMaxConnectBackoff = 5 * time.Second
+)
+
+// readyQuery checks that a database can be queried and holds the tables of Pulp
+const readyQuery = "SELECT 1 FROM core_repositoryversion LIMIT 1"
+
+// connectBackoff spaces out the attempts to connect to a database while it cannot be reached, so that calls
+// made during an outage do not all try to connect at once. Attempts are only delayed after one failed: every failed
+// attempt delays the next ones, doubling the delay up to maxBackoff, until an attempt succeeds. The first connection
+// to succeed lets waiting attempts through.
+type connectBackoff struct {
+	maxBackoff time.Duration
+
+	mu        sync.Mutex
+	attempts  int
+	retryAt   time.Time
+	connected chan struct{}
+}
+
+func newConnectBackoff(maxBackoff time.Duration) *connectBackoff {
+	if maxBackoff <= 0 {
+		maxBackoff = DefaultMaxConnectBackoff
+	}
+	return &connectBackoff{maxBackoff: maxBackoff}
+}
+
+// wait waits until connecting is allowed, a connection was established, or ctx is done.
+// It does not wait unless an attempt failed since the last connection was established.
+func (b *connectBackoff) wait(ctx context.Context) error {
+	b.mu.Lock()
+	wait, connected := time.Until(b.retryAt), b.connected
+	b.mu.Unlock()
+
+	if wait > 0 {
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("error waiting to connect: %w", ctx.Err())
+		case <-timer.C:
+		case <-connected:
+		}
+	}
+	return nil
+}
+
+// failed records a failed attempt to connect, delaying the next attempts until one succeeds
+func (b *connectBackoff) failed() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	backoff := b.maxBackoff
+	if b.attempts < 32 && initialConnectBackoff<<b.attempts < b.maxBackoff {
+		backoff = initialConnectBackoff << b.attempts
+	}
+	b.attempts++
+	b.retryAt = time.Now().Add(backoff)
+	if b.connected == nil {
+		b.connected = make(chan struct{})
+	}
+}
+
+// succeeded lets the attempts waiting for the backoff connect right away
+func (b *connectBackoff) succeeded() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.attempts = 0
+	b.retryAt = time.Time{}
+	if b.connected != nil {
+		close(b.connected)
+		b.connected = nil
+	}
+}
+
+// configure waits for the backoff before each connection of a pool, starts or extends the backoff when the database
+// cannot be dialed, and ends the backoff once a connection is established
+func (b *connectBackoff) configure(pxConfig *pgxpool.Config) {
+	beforeConnect := pxConfig.BeforeConnect
+	pxConfig.BeforeConnect = func(ctx context.Context, config *pgx.ConnConfig) error {
+		if err := b.wait(ctx); err != nil {
+			return err
+		}
+		// config is a copy made for this connection, so its dial function is only wrapped once
+		dial := config.DialFunc
+		config.DialFunc = func(ctx context.Context, network, addr string) (net.Conn, error) {
+			conn, err := dial(ctx, network, addr)
+			if err != nil && ctx.Err() == nil {
+				b.failed()
+			}
+			return conn, err
+		}
+		if beforeConnect != nil {
+			return beforeConnect(ctx, config)
+		}
+		return nil
+	}
+	afterConnect := pxConfig.AfterConnect
+	pxConfig.AfterConnect = func(ctx context.Context, conn *pgx.Conn) error {
+		b.succeeded()
+		if afterConnect != nil {
+			return afterConnect(ctx, conn)
+		}
+		return nil
+	}
+}
+
+// Ping checks that a connection to the primary database can be established
+func (t *tangyImpl) Ping(ctx context.Context) error {
+	if err := t.pool.Ping(ctx); err != nil {
+		return fmt.Errorf("error pinging database: %w", err)
+	}
+	return nil
+}
+
+// Ready checks that calls can be served: that the database the queries of a call are sent to, a replica or
+// the primary, can be queried and holds the tables of Pulp
+func (t *tangyImpl) Ready(ctx context.Context) error {
+	tx, end, err := t.begin(ctx)
+	if err != nil {
+		return fmt.Errorf("error checking database readiness: %w", err)
+	}
+	defer end()
+
+	if _, err := tx.Exec(ctx, readyQuery); err != nil {
+		return fmt.Errorf("error checking database readiness: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package tangy
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConnectBackoff(t *testing.T) {
+	t.Parallel()
+
+	b := newConnectBackoff(300 * time.Millisecond)
+
+	// Attempts are not delayed before one failed
+	for range 3 {
+		require.NoError(t, b.wait(context.Background()))
+	}
+	assert.Zero(t, b.attempts)
+	assert.Zero(t, b.retryAt)
+
+	// Each failed attempt doubles the backoff of the next one, up to maxBackoff
+	for _, backoff := range []time.Duration{initialConnectBackoff, 200 * time.Millisecond, 300 * time.Millisecond, 300 * time.Millisecond} {
+		b.failed()
+		assert.WithinDuration(t, time.Now().Add(backoff), b.retryAt, 50*time.Millisecond)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, b.wait(ctx), context.DeadlineExceeded)
+
+	b.succeeded()
+	assert.Zero(t, b.attempts)
+	assert.Zero(t, b.retryAt)
+}
+
+func TestConnectBackoffSucceeded(t *testing.T) {
+	t.Parallel()
+
+	b := newConnectBackoff(time.Minute)
+	b.attempts = 10
+	b.failed()
+
+	// A connection established meanwhile lets waiting attempts through
+	waited := make(chan error)
+	go func() {
+		waited <- b.wait(context.Background())
+	}()
+	time.Sleep(10 * time.Millisecond)
+	b.succeeded()
+	select {
+	case err := <-waited:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("attempt still waiting after a connection was established")
+	}
+}
+
+func TestConnectBackoffConfigure(t *testing.T) {
+	t.Parallel()
+
+	b := newConnectBackoff(time.Minute)
+	pxConfig, err := pgxpool.ParseConfig("postgres://localhost:5432/pulp")
+	require.NoError(t, err)
+	b.configure(pxConfig)
+
+	// A connection that cannot be dialed delays the next attempts
+	connConfig := pxConfig.ConnConfig.Copy()
+	connConfig.DialFunc = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return nil, assert.AnError
+	}
+	require.NoError(t, pxConfig.BeforeConnect(context.Background(), connConfig))
+	_, err = connConfig.DialFunc(context.Background(), "tcp", "localhost:5432")
+	require.ErrorIs(t, err, assert.AnError)
+	assert.Equal(t, 1, b.attempts)
+	assert.True(t, b.retryAt.After(time.Now()))
+
+	// A connection established ends the backoff
+	require.NoError(t, pxConfig.AfterConnect(context.Background(), nil))
+	assert.Zero(t, b.attempts)
+	assert.Zero(t, b.retryAt)
+}
+
+func TestPingReadyUnreachable(t *testing.T) {
+	t.Parallel()
+
+	impl := &tangyImpl{pool: unreachablePool(t)}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	assert.ErrorContains(t, impl.Ping(ctx), "error pinging database")
+	assert.ErrorContains(t, impl.Ready(ctx), "error checking database readiness")
+}
+
+func TestWrappedTangyPingReady(t *testing.T) {
+	t.Parallel()
+
+	next := NewMockTangy(t)
+	next.On("Ping", context.Background()).Return(nil).Once()
+	next.On("Ready", context.Background()).Return(assert.AnError).Once()
+	w := &wrappedTangy{next: next}
+	assert.NoError(t, w.Ping(context.Background()))
+	assert.ErrorIs(t, w.Ready(context.Background()), assert.AnError)
+}
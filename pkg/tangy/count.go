@@ -0,0 +1,84 @@
+package tangy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// CountMode selects how the Total of a list response is computed
+type CountMode string
+
+const (
+	// CountExact counts every matching row. This is the default.
+	CountExact CountMode = "exact"
+	// CountNone skips counting, Total is always 0. Useful for views that only follow NextCursor.
+	CountNone CountMode = "none"
+	// CountEstimated counts at most EstimatedCountLimit rows. When more rows match, Total is
+	// EstimatedCountLimit and the response mode is CountEstimated, to be shown as "10000+".
+	// Otherwise the count is exact, and the response mode is CountExact.
+	CountEstimated CountMode = "estimated"
+)
+
+// EstimatedCountLimit is the number of rows counted at most with CountEstimated
+const EstimatedCountLimit = 10000
+
+// ErrInvalidCountMode is returned when PageOptions.Count is not one of the count modes
+var ErrInvalidCountMode = errors.New("invalid count mode")
+
+// count returns a statement counting the rows selected by rows. When limit is positive,
+// at most limit + 1 rows are counted, so that a count over the limit can be told apart.
+func (b *queryBuilder) count(rows string, limit int) string {
+	if limit > 0 {
+		rows += "\n\t\tLIMIT " + b.bind("countLimit", limit+1)
+	}
+	return `
+		SELECT COUNT(*) AS total
+		FROM (` + rows + `
+		) counted`
+}
+
+// listTotal runs the count query of a list in the given mode, and returns the total with the mode that produced it.
+// countQuery returns the count query counting at most its limit, or every row when the limit is 0.
+func listTotal(ctx context.Context, tx pgx.Tx, mode CountMode, countQuery func(limit int) sqlQuery) (int, CountMode, error) {
+	limit, counted, err := listCountLimit(mode)
+	if err != nil {
+		return 0, "", err
+	}
+	if !counted {
+		return 0, CountNone, nil
+	}
+
+	query := countQuery(limit)
+	var total int
+	if err := tx.QueryRow(ctx, query.SQL, query.Args).Scan(&total); err != nil {
+		return 0, "", err
+	}
+	total, totalMode := listCountTotal(total, limit)
+	return total, totalMode, nil
+}
+
+// listCountLimit returns the number of rows counted at most in the given mode, or 0 to count every row,
+// and false when the mode skips counting
+func listCountLimit(mode CountMode) (int, bool, error) {
+	switch mode {
+	case "", CountExact:
+		return 0, true, nil
+	case CountNone:
+		return 0, false, nil
+	case CountEstimated:
+		return EstimatedCountLimit, true, nil
+	default:
+		return 0, false, fmt.Errorf("%w: %q", ErrInvalidCountMode, mode)
+	}
+}
+
+// listCountTotal returns the total of a count query counting at most limit rows, with the mode that produced it
+func listCountTotal(total, limit int) (int, CountMode) {
+	if limit > 0 && total > limit {
+		return limit, CountEstimated
+	}
+	return total, CountExact
+}
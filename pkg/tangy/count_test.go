@@ -0,0 +1,42 @@
+package tangy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryBuilderCount(t *testing.T) {
+	t.Parallel()
+
+	b := newQueryBuilder()
+	query := b.query(b.count("SELECT DISTINCT rp.name FROM rpm_package rp", 0))
+	assert.Contains(t, query.SQL, "SELECT COUNT(*) AS total")
+	assert.NotContains(t, query.SQL, "LIMIT")
+	assert.Empty(t, query.Args)
+
+	b = newQueryBuilder()
+	query = b.query(b.count("SELECT DISTINCT rp.name FROM rpm_package rp", 100))
+	assert.Contains(t, query.SQL, "SELECT DISTINCT rp.name FROM rpm_package rp\n\t\tLIMIT @countLimit\n\t\t) counted")
+	assert.Equal(t, pgx.NamedArgs{"countLimit": 101}, query.Args)
+}
+
+func TestListTotalWithoutQuery(t *testing.T) {
+	t.Parallel()
+
+	countQuery := func(limit int) sqlQuery {
+		require.Fail(t, "the count query should not be built")
+		return sqlQuery{}
+	}
+
+	total, mode, err := listTotal(context.Background(), nil, CountNone, countQuery)
+	require.NoError(t, err)
+	assert.Equal(t, 0, total)
+	assert.Equal(t, CountNone, mode)
+
+	_, _, err = listTotal(context.Background(), nil, CountMode("approximate"), countQuery)
+	assert.ErrorIs(t, err, ErrInvalidCountMode)
+}
@@ -0,0 +1,118 @@
+package tangy
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrInvalidCursor = errors.New("invalid page cursor")
+
+const (
+	cursorKindRpmPackageList         = "rpm_package_list"
+	cursorKindRpmErrataList          = "rpm_errata_list"
+	cursorKindPythonPackageList      = "python_package_list"
+	cursorKindPythonDistributionList = "python_distribution_list"
+	cursorKindPythonBuildList        = "python_build_list"
+	cursorKindMavenPackageList       = "maven_package_list"
+	cursorKindMavenVersionsList      = "maven_versions_list"
+	cursorKindNpmPackageList         = "npm_package_list"
+	cursorKindNpmBuildList           = "npm_build_list"
+)
+
+// pageCursor is the decoded form of PageOptions.Cursor and NextCursor.
+// Kind ties the cursor to the method (and sort order) that issued it, Key holds the sort key values
+// of the last row of the page, and Versions pins the repository versions that were resolved for the
+// first page, so that following pages keep reading the same content while new versions are created.
+type pageCursor struct {
+	Kind     string              `json:"k"`
+	Versions []ParsedRepoVersion `json:"v,omitempty"`
+	Key      []string            `json:"key"`
+}
+
+// keysetColumn is one column of a sort key used for keyset pagination.
+// Type is the postgres type the cursor value is cast to when compared with Expr.
+// Nullable columns sort NULLs after every value, and take cursorNull as the key value of a NULL.
+type keysetColumn struct {
+	Expr     string
+	Type     string
+	Nullable bool
+}
+
+// cursorNull is the key value of a NULL in a nullable keysetColumn.
+// Postgres text values cannot contain NUL characters, so it is never confused with a value.
+const cursorNull = "\x00"
+
+func encodeCursor(c pageCursor) string {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeCursor decodes an opaque cursor issued for the given kind. An empty cursor decodes to the zero pageCursor.
+func decodeCursor(cursor string, kind string, keyLen int) (pageCursor, error) {
+	if cursor == "" {
+		return pageCursor{}, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return pageCursor{}, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+
+	var c pageCursor
+	if err := json.Unmarshal(data, &c); err != nil {
+		return pageCursor{}, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+	if c.Kind != kind {
+		return pageCursor{}, fmt.Errorf("%w: cursor was issued for %s, not %s", ErrInvalidCursor, c.Kind, kind)
+	}
+	if len(c.Key) != keyLen {
+		return pageCursor{}, fmt.Errorf("%w: expected %d key values, got %d", ErrInvalidCursor, keyLen, len(c.Key))
+	}
+	return c, nil
+}
+
+// isSet returns true if the cursor was decoded from a non-empty PageOptions.Cursor
+func (c pageCursor) isSet() bool {
+	return c.Kind != ""
+}
+
+// pinnedVersions returns the repository versions recorded in the cursor, in place of repoVerMap, the versions parsed
+// from the hrefs of the request. It returns nil when no cursor was passed, in which case the caller resolves them itself.
+// The cursor only pins the versions of repository hrefs: a version named by an href must be the version of the cursor,
+// as the cursor is controlled by the client.
+func (c pageCursor) pinnedVersions(repoVerMap []ParsedRepoVersion) ([]ParsedRepoVersion, error) {
+	if !c.isSet() {
+		return nil, nil
+	}
+	if len(c.Versions) != len(repoVerMap) {
+		return nil, fmt.Errorf("%w: cursor was issued for different repositories", ErrInvalidCursor)
+	}
+	for i, repoVersion := range repoVerMap {
+		if c.Versions[i].RepositoryUUID != repoVersion.RepositoryUUID {
+			return nil, fmt.Errorf("%w: cursor was issued for different repositories", ErrInvalidCursor)
+		}
+		if repoVersion.Version != latestRepositoryVersion && c.Versions[i].Version != repoVersion.Version {
+			return nil, fmt.Errorf("%w: cursor was issued for version %d, not %d", ErrInvalidCursor, c.Versions[i].Version, repoVersion.Version)
+		}
+	}
+	return c.Versions, nil
+}
+
+// nextCursor returns the cursor for the page following a page of resultCount rows, or an empty string
+// when the page was not full, meaning there are no more rows to read.
+func nextCursor(kind string, resultCount, limit int, versions []ParsedRepoVersion, key ...string) string {
+	if resultCount == 0 || resultCount < limit {
+		return ""
+	}
+	return encodeCursor(pageCursor{Kind: kind, Versions: versions, Key: key})
+}
+
+func formatCursorTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
@@ -0,0 +1,143 @@
+package tangy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	versions := []ParsedRepoVersion{{RepositoryUUID: testRepoVersionUUID, Version: 4}}
+	encoded := nextCursor(cursorKindPythonPackageList, 2, 2, versions, "requests")
+	require.NotEmpty(t, encoded)
+
+	decoded, err := decodeCursor(encoded, cursorKindPythonPackageList, 1)
+	require.NoError(t, err)
+	assert.True(t, decoded.isSet())
+	assert.Equal(t, []string{"requests"}, decoded.Key)
+
+	pinned, err := decoded.pinnedVersions([]ParsedRepoVersion{{RepositoryUUID: testRepoVersionUUID, Version: latestRepositoryVersion}})
+	require.NoError(t, err)
+	assert.Equal(t, versions, pinned)
+}
+
+func TestDecodeCursor(t *testing.T) {
+	t.Parallel()
+
+	valid := encodeCursor(pageCursor{Kind: cursorKindNpmPackageList, Key: []string{"left-pad"}})
+
+	tests := []struct {
+		name      string
+		cursor    string
+		kind      string
+		keyLen    int
+		expectErr bool
+	}{
+		{name: "empty", cursor: "", kind: cursorKindNpmPackageList, keyLen: 1},
+		{name: "valid", cursor: valid, kind: cursorKindNpmPackageList, keyLen: 1},
+		{name: "not base64", cursor: "not a cursor!", kind: cursorKindNpmPackageList, keyLen: 1, expectErr: true},
+		{name: "not json", cursor: "bm90IGpzb24", kind: cursorKindNpmPackageList, keyLen: 1, expectErr: true},
+		{name: "other method", cursor: valid, kind: cursorKindNpmBuildList, keyLen: 1, expectErr: true},
+		{name: "key length mismatch", cursor: valid, kind: cursorKindNpmPackageList, keyLen: 3, expectErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			c, err := decodeCursor(tt.cursor, tt.kind, tt.keyLen)
+			if tt.expectErr {
+				require.ErrorIs(t, err, ErrInvalidCursor)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.cursor != "", c.isSet())
+		})
+	}
+}
+
+func TestPinnedVersions(t *testing.T) {
+	t.Parallel()
+
+	requested := []ParsedRepoVersion{{RepositoryUUID: testRepoVersionUUID, Version: latestRepositoryVersion}}
+
+	pinned, err := pageCursor{}.pinnedVersions(requested)
+	require.NoError(t, err)
+	assert.Nil(t, pinned)
+
+	c := pageCursor{
+		Kind:     cursorKindMavenPackageList,
+		Versions: []ParsedRepoVersion{{RepositoryUUID: testRepoVersionUUID, Version: 2}},
+	}
+	pinned, err = c.pinnedVersions(requested)
+	require.NoError(t, err)
+	assert.Equal(t, c.Versions, pinned)
+
+	_, err = c.pinnedVersions([]ParsedRepoVersion{{RepositoryUUID: "019f3808-fcc2-716e-a7d3-e5a7ef1522a0", Version: latestRepositoryVersion}})
+	require.ErrorIs(t, err, ErrInvalidCursor)
+
+	_, err = c.pinnedVersions(append(requested, requested[0]))
+	require.ErrorIs(t, err, ErrInvalidCursor)
+
+	// A version href is only read at its own version, whatever the cursor holds
+	pinned, err = c.pinnedVersions([]ParsedRepoVersion{{RepositoryUUID: testRepoVersionUUID, Version: 2}})
+	require.NoError(t, err)
+	assert.Equal(t, c.Versions, pinned)
+
+	_, err = c.pinnedVersions([]ParsedRepoVersion{{RepositoryUUID: testRepoVersionUUID, Version: 3}})
+	require.ErrorIs(t, err, ErrInvalidCursor)
+}
+
+func TestNextCursorPartialPage(t *testing.T) {
+	t.Parallel()
+
+	assert.Empty(t, nextCursor(cursorKindRpmPackageList, 0, 10, nil))
+	assert.Empty(t, nextCursor(cursorKindRpmPackageList, 9, 10, nil, "a", "b", "c", "d", "e"))
+	assert.NotEmpty(t, nextCursor(cursorKindRpmPackageList, 10, 10, nil, "a", "b", "c", "d", "e"))
+}
+
+func TestErrataListSort(t *testing.T) {
+	t.Parallel()
+
+	updated := "2024-02-01"
+	erratum := ErrataListItem{IssuedDate: "2024-01-01", UpdatedDate: &updated, Type: "security", Severity: "Low"}
+
+	tests := []struct {
+		sortBy        string
+		expectedField string
+		expectedExpr  string
+		expectedDesc  bool
+		expectedValue string
+	}{
+		{sortBy: "", expectedField: "issued_date", expectedExpr: "rp.issued_date", expectedDesc: true, expectedValue: "2024-01-01"},
+		{sortBy: "issued_date:asc", expectedField: "issued_date", expectedExpr: "rp.issued_date", expectedValue: "2024-01-01"},
+		{sortBy: "updated_date:desc", expectedField: "updated_date", expectedExpr: "rp.updated_date", expectedDesc: true, expectedValue: updated},
+		{sortBy: "type:asc", expectedField: "type", expectedExpr: "rp.type", expectedValue: "security"},
+		{sortBy: "severity:desc", expectedField: "severity", expectedExpr: "rp.severity", expectedDesc: true, expectedValue: "Low"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sortBy, func(t *testing.T) {
+			t.Parallel()
+			field, order := errataListSort(tt.sortBy)
+			assert.Equal(t, tt.expectedField, field)
+			require.Len(t, order.Columns, 2)
+			assert.Equal(t, tt.expectedExpr, order.Columns[0].Expr)
+			assert.Equal(t, "rp.content_ptr_id", order.Columns[1].Expr)
+			assert.Equal(t, tt.expectedDesc, order.Desc)
+			assert.Equal(t, tt.expectedValue, errataSortValue(erratum, field))
+		})
+	}
+
+	assert.Equal(t, cursorNull, errataSortValue(ErrataListItem{}, "updated_date"))
+}
+
+func TestFormatCursorTime(t *testing.T) {
+	t.Parallel()
+
+	ts := time.Date(2024, 5, 1, 12, 30, 0, 123456000, time.FixedZone("EST", -5*3600))
+	assert.Equal(t, "2024-05-01T17:30:00.123456Z", formatCursorTime(ts))
+}
@@ -0,0 +1,16 @@
+package tangy
+
+import "errors"
+
+// Errors returned by every method that takes repository or repository version hrefs, wrapped with details
+// about the offending href. Use errors.Is to check for them.
+var (
+	// ErrInvalidHref is returned when a repository or repository version href cannot be parsed
+	ErrInvalidHref = errors.New("invalid href")
+	// ErrRepositoryNotFound is returned when a repository href refers to a repository that does not exist
+	ErrRepositoryNotFound = errors.New("repository not found")
+	// ErrNoCompleteVersion is returned when a repository href refers to a repository without any complete version
+	ErrNoCompleteVersion = errors.New("repository has no complete version")
+	// ErrRepositoryVersionNotFound is returned when a repository version href refers to a version that does not exist
+	ErrRepositoryVersionNotFound = errors.New("repository version not found")
+)
@@ -0,0 +1,173 @@
+package tangy
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// parseRepositoryHref extracts the repository UUID from a repository href
+// Example: /api/pulp/default/api/v3/repositories/maven/maven/018c1c95-4281-76eb-b277-842cbad524f4/
+func parseRepositoryHref(href string) (string, error) {
+	parts := strings.Split(href, "/")
+	// Filter out empty parts
+	var nonEmptyParts []string
+	for _, part := range parts {
+		if part != "" {
+			nonEmptyParts = append(nonEmptyParts, part)
+		}
+	}
+
+	// Expected format: api/pulp/{domain}/api/v3/repositories/{plugin}/{type}/{uuid}
+	// So we need at least 8 parts
+	if len(nonEmptyParts) < 8 {
+		return "", fmt.Errorf("%w: invalid repository href format: %s", ErrInvalidHref, href)
+	}
+
+	// The UUID should be the last part (or second to last if there's a trailing slash)
+	repoUUID := nonEmptyParts[len(nonEmptyParts)-1]
+	if _, err := uuid.Parse(repoUUID); err != nil {
+		return "", fmt.Errorf("%w: %v is not a valid uuid", ErrInvalidHref, repoUUID)
+	}
+
+	return repoUUID, nil
+}
+
+// getLatestRepositoryVersion gets the highest complete version number for a repository.
+// Returns ErrRepositoryNotFound if the repository does not exist and ErrNoCompleteVersion if it has no complete version.
+func getLatestRepositoryVersion(ctx context.Context, tx pgx.Tx, repoUUID string) (int, error) {
+	query := `
+		SELECT
+			EXISTS (SELECT 1 FROM core_repository WHERE pulp_id = $1),
+			(SELECT MAX(number) FROM core_repositoryversion WHERE repository_id = $1 AND complete = true)
+	`
+
+	var repositoryExists bool
+	var latestVersion *int
+	err := tx.QueryRow(ctx, query, repoUUID).Scan(&repositoryExists, &latestVersion)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get latest version for repository %s: %w", repoUUID, err)
+	}
+	if !repositoryExists {
+		return 0, fmt.Errorf("%w: %s", ErrRepositoryNotFound, repoUUID)
+	}
+	if latestVersion == nil {
+		return 0, fmt.Errorf("%w: %s", ErrNoCompleteVersion, repoUUID)
+	}
+
+	return *latestVersion, nil
+}
+
+// latestRepositoryVersion is the Version of a ParsedRepoVersion parsed from a repository href,
+// until resolveRepositoryVersions replaces it with the latest complete version number
+const latestRepositoryVersion = -1
+
+// isRepositoryVersionHref returns true for repository version hrefs, such as
+// /api/pulp/default/api/v3/repositories/python/python/018c1c95-4281-76eb-b277-842cbad524f4/versions/2/
+func isRepositoryVersionHref(href string) bool {
+	return strings.Contains(href, "/versions/")
+}
+
+// parseRepositoryOrVersionHref parses either a repository href, using parseRepositoryHref, or a repository version href,
+// the same way as parseRepositoryVersionHrefsMap. Repository hrefs are returned with latestRepositoryVersion.
+func parseRepositoryOrVersionHref(href string) (ParsedRepoVersion, error) {
+	if isRepositoryVersionHref(href) {
+		parsed, err := parseRepositoryVersionHrefsMap([]string{href})
+		if err != nil {
+			return ParsedRepoVersion{}, err
+		}
+		return parsed[0], nil
+	}
+
+	repoUUID, err := parseRepositoryHref(href)
+	if err != nil {
+		return ParsedRepoVersion{}, err
+	}
+	return ParsedRepoVersion{RepositoryUUID: repoUUID, Version: latestRepositoryVersion}, nil
+}
+
+// resolveRepositoryVersions replaces latestRepositoryVersion with the latest complete version of each repository
+func resolveRepositoryVersions(ctx context.Context, tx pgx.Tx, repoVerMap []ParsedRepoVersion) ([]ParsedRepoVersion, error) {
+	resolved := make([]ParsedRepoVersion, len(repoVerMap))
+	for i, repoVer := range repoVerMap {
+		resolved[i] = repoVer
+		if repoVer.Version != latestRepositoryVersion {
+			continue
+		}
+		latestVersion, err := getLatestRepositoryVersion(ctx, tx, repoVer.RepositoryUUID)
+		if err != nil {
+			return nil, err
+		}
+		resolved[i].Version = latestVersion
+	}
+	return resolved, nil
+}
+
+// repositoryHrefMap maps repository uuids, in lowercase as returned by Postgres, to the repository hrefs
+// reported in the Repositories field of results
+type repositoryHrefMap map[string]string
+
+// hrefs returns the sorted, deduplicated repository hrefs of the given repository uuids, or nil if there are none.
+// Unknown uuids are returned as is.
+func (m repositoryHrefMap) hrefs(repositoryIDs ...[]string) []string {
+	var hrefs []string
+	for _, ids := range repositoryIDs {
+		for _, id := range ids {
+			href, ok := m[strings.ToLower(id)]
+			if !ok {
+				href = id
+			}
+			if !slices.Contains(hrefs, href) {
+				hrefs = append(hrefs, href)
+			}
+		}
+	}
+	slices.Sort(hrefs)
+	return hrefs
+}
+
+// repositoryHrefOf returns the repository href of a repository href or a repository version href
+func repositoryHrefOf(href string) string {
+	if i := strings.Index(href, "/versions/"); i >= 0 {
+		return href[:i+1]
+	}
+	return href
+}
+
+// resolveRepositoryHrefs parses the repository and repository version hrefs passed to a method and resolves
+// the repository versions to query, without duplicates. When a cursor is set, the versions pinned by the cursor
+// are used instead of resolving the latest versions again.
+func resolveRepositoryHrefs(ctx context.Context, tx pgx.Tx, hrefs []string, cursor pageCursor) ([]ParsedRepoVersion, repositoryHrefMap, error) {
+	parsed := []ParsedRepoVersion{}
+	repositories := repositoryHrefMap{}
+	for _, href := range hrefs {
+		repoVersion, err := parseRepositoryOrVersionHref(href)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error parsing repository href: %w", err)
+		}
+		if !slices.Contains(parsed, repoVersion) {
+			parsed = append(parsed, repoVersion)
+		}
+		if id := strings.ToLower(repoVersion.RepositoryUUID); repositories[id] == "" {
+			repositories[id] = repositoryHrefOf(href)
+		}
+	}
+
+	repoVerMap, err := cursor.pinnedVersions(parsed)
+	if err != nil {
+		return nil, nil, err
+	}
+	pinned := repoVerMap != nil
+	if !pinned {
+		repoVerMap, err = resolveRepositoryVersions(ctx, tx, parsed)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error getting latest repository version: %w", err)
+		}
+	}
+	recordResolvedVersions(ctx, parsed, repoVerMap, pinned)
+	return repoVerMap, repositories, nil
+}
@@ -0,0 +1,178 @@
+package tangy
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRepositoryHref(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		href    string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "valid maven repository href",
+			href: "/api/pulp/default/api/v3/repositories/maven/maven/018c1c95-4281-76eb-b277-842cbad524f4/",
+			want: "018c1c95-4281-76eb-b277-842cbad524f4",
+		},
+		{
+			name: "valid python repository href",
+			href: "/api/pulp/default/api/v3/repositories/python/python/018c1c95-4281-76eb-b277-842cbad524f4/",
+			want: "018c1c95-4281-76eb-b277-842cbad524f4",
+		},
+		{
+			name: "valid npm repository href",
+			href: "/api/pulp/default/api/v3/repositories/npm/npm/018c1c95-4281-76eb-b277-842cbad524f4/",
+			want: "018c1c95-4281-76eb-b277-842cbad524f4",
+		},
+		{
+			name: "valid href without trailing slash",
+			href: "/api/pulp/default/api/v3/repositories/maven/maven/018c1c95-4281-76eb-b277-842cbad524f4",
+			want: "018c1c95-4281-76eb-b277-842cbad524f4",
+		},
+		{
+			name:    "invalid href",
+			href:    "/api/pulp/default/api/v3/repositories/maven/",
+			wantErr: true,
+		},
+		{
+			name:    "invalid uuid",
+			href:    "/api/pulp/default/api/v3/repositories/maven/maven/not-a-uuid/",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := parseRepositoryHref(tt.href)
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrInvalidHref)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseRepositoryOrVersionHref(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		href    string
+		want    ParsedRepoVersion
+		wantErr bool
+	}{
+		{
+			name: "repository href resolves to the latest version",
+			href: "/api/pulp/default/api/v3/repositories/maven/maven/018c1c95-4281-76eb-b277-842cbad524f4/",
+			want: ParsedRepoVersion{RepositoryUUID: "018c1c95-4281-76eb-b277-842cbad524f4", Version: latestRepositoryVersion},
+		},
+		{
+			name: "repository version href",
+			href: "/api/pulp/default/api/v3/repositories/maven/maven/018c1c95-4281-76eb-b277-842cbad524f4/versions/3/",
+			want: ParsedRepoVersion{RepositoryUUID: "018c1c95-4281-76eb-b277-842cbad524f4", Version: 3},
+		},
+		{
+			name:    "repository version href with invalid number",
+			href:    "/api/pulp/default/api/v3/repositories/maven/maven/018c1c95-4281-76eb-b277-842cbad524f4/versions/latest/",
+			wantErr: true,
+		},
+		{
+			name:    "repository version href with invalid uuid",
+			href:    "/api/pulp/default/api/v3/repositories/maven/maven/not-a-uuid/versions/3/",
+			wantErr: true,
+		},
+		{
+			name:    "invalid href",
+			href:    "/api/pulp/default/api/v3/repositories/maven/",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := parseRepositoryOrVersionHref(tt.href)
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrInvalidHref)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRepositoryHrefOf(t *testing.T) {
+	t.Parallel()
+
+	repositoryHref := "/api/pulp/default/api/v3/repositories/maven/maven/018c1c95-4281-76eb-b277-842cbad524f4/"
+	assert.Equal(t, repositoryHref, repositoryHrefOf(repositoryHref))
+	assert.Equal(t, repositoryHref, repositoryHrefOf(repositoryHref+"versions/3/"))
+}
+
+func TestRepositoryHrefMapHrefs(t *testing.T) {
+	t.Parallel()
+
+	repositories := repositoryHrefMap{
+		"018c1c95-4281-76eb-b277-842cbad524f4": "/api/pulp/default/api/v3/repositories/maven/maven/018c1c95-4281-76eb-b277-842cbad524f4/",
+		"019f3808-fcc2-716e-a7d3-e5a7ef1522a0": "/api/pulp/default/api/v3/repositories/maven/maven/019f3808-fcc2-716e-a7d3-e5a7ef1522a0/",
+	}
+
+	assert.Nil(t, repositories.hrefs(nil))
+	assert.Equal(t, []string{
+		"/api/pulp/default/api/v3/repositories/maven/maven/018c1c95-4281-76eb-b277-842cbad524f4/",
+		"/api/pulp/default/api/v3/repositories/maven/maven/019f3808-fcc2-716e-a7d3-e5a7ef1522a0/",
+	}, repositories.hrefs(
+		[]string{"019f3808-fcc2-716e-a7d3-e5a7ef1522a0", "018c1c95-4281-76eb-b277-842cbad524f4"},
+		[]string{"018c1c95-4281-76eb-b277-842cbad524f4"},
+	))
+	assert.Equal(t, []string{"unknown"}, repositories.hrefs([]string{"unknown"}))
+}
+
+func TestResolveRepositoryHrefsUppercaseUUID(t *testing.T) {
+	t.Parallel()
+
+	// Repositories are keyed on the uuids returned by Postgres, in lowercase, whatever the case of the href
+	repositoryHref := "/api/pulp/default/api/v3/repositories/npm/npm/" + strings.ToUpper(testRepoVersionUUID) + "/"
+	cursor := pageCursor{Kind: cursorKindNpmPackageList, Versions: []ParsedRepoVersion{{RepositoryUUID: strings.ToUpper(testRepoVersionUUID), Version: 4}}}
+	_, repositories, err := resolveRepositoryHrefs(context.Background(), nil, []string{repositoryHref}, cursor)
+	require.NoError(t, err)
+	assert.Equal(t, []string{repositoryHref}, repositories.hrefs([]string{testRepoVersionUUID}))
+}
+
+func TestResolveRepositoryHrefsRecordsVersions(t *testing.T) {
+	t.Parallel()
+
+	repositoryHref := "/api/pulp/default/api/v3/repositories/npm/npm/" + testRepoVersionUUID + "/"
+	cursor := pageCursor{Kind: cursorKindNpmPackageList, Versions: []ParsedRepoVersion{{RepositoryUUID: testRepoVersionUUID, Version: 4}}}
+
+	// Versions pinned by a cursor are recorded for the cache to key on, but not as the latest versions
+	ctx, resolved := withResolvedVersions(context.Background())
+	repoVerMap, _, err := resolveRepositoryHrefs(ctx, nil, []string{repositoryHref}, cursor)
+	require.NoError(t, err)
+	assert.Equal(t, cursor.Versions, repoVerMap)
+	assert.Equal(t, cursor.Versions, resolved.replace([]ParsedRepoVersion{{RepositoryUUID: testRepoVersionUUID, Version: latestRepositoryVersion}}))
+
+	store, err := NewMemoryCacheStore(0, 0)
+	require.NoError(t, err)
+	resolved.store(ctx, store, zerolog.Nop(), time.Minute)
+	assert.Zero(t, store.Len())
+}
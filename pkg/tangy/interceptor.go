@@ -0,0 +1,79 @@
+package tangy
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// Call is a call of a Tangy method, as seen by interceptors
+type Call struct {
+	// Method is the name of the method, such as "RpmRepositoryVersionErrataList"
+	Method string
+	// Args are the arguments of the method after ctx, in order. Changing them does not change the arguments
+	// the method is called with.
+	Args []any
+	// Result is the result of the method other than its error, set by invoke. An interceptor that does not call
+	// invoke, such as a cache, sets Result to a value of the result type of the method, returned by the call.
+	// Result is not set for RpmRepositoryVersionBatch, whose results are set on its Batch.
+	Result any
+}
+
+// Interceptor runs a call of a Tangy method by calling invoke, which runs the method on the wrapped Tangy
+// and sets call.Result. invoke may be called several times, or not at all, and returns the error of the method.
+// The error returned by the interceptor is returned by the call.
+type Interceptor func(ctx context.Context, call *Call, invoke func(ctx context.Context) error) error
+
+// Wrap returns a Tangy running every call of the methods of t that run queries through the interceptors,
+// the first interceptor being the outermost one. Iterators, ReadSnapshot, Ping, Ready, Stats and Close are called
+// on t directly. Calls made by the Tangy passed to the function given to ReadSnapshot are not intercepted.
+func Wrap(t Tangy, interceptors ...Interceptor) Tangy {
+	if len(interceptors) == 0 {
+		return t
+	}
+	return &wrappedTangy{next: t, intercept: chainInterceptors(interceptors)}
+}
+
+// chainInterceptors returns an interceptor running the interceptors, the first one being the outermost one
+func chainInterceptors(interceptors []Interceptor) Interceptor {
+	if len(interceptors) == 1 {
+		return interceptors[0]
+	}
+	return func(ctx context.Context, call *Call, invoke func(ctx context.Context) error) error {
+		next := invoke
+		for i := len(interceptors) - 1; i > 0; i-- {
+			interceptor, inner := interceptors[i], next
+			next = func(ctx context.Context) error {
+				return interceptor(ctx, call, inner)
+			}
+		}
+		return interceptors[0](ctx, call, next)
+	}
+}
+
+// LoggingInterceptor logs every call with its arguments and duration: calls that failed at the error level,
+// and the others at the debug level
+func LoggingInterceptor(logger zerolog.Logger) Interceptor {
+	return func(ctx context.Context, call *Call, invoke func(ctx context.Context) error) error {
+		start := time.Now()
+		err := invoke(ctx)
+		event := logger.Debug()
+		if err != nil {
+			event = logger.Error().Err(err)
+		}
+		event.Str("method", call.Method).Interface("args", call.Args).Dur("duration", time.Since(start)).Msg("Tangy call")
+		return err
+	}
+}
+
+// TimingInterceptor calls observe with the method, duration and error of every call once it returned,
+// for example to record the duration of calls in a metric
+func TimingInterceptor(observe func(method string, duration time.Duration, err error)) Interceptor {
+	return func(ctx context.Context, call *Call, invoke func(ctx context.Context) error) error {
+		start := time.Now()
+		err := invoke(ctx)
+		observe(call.Method, time.Since(start), err)
+		return err
+	}
+}
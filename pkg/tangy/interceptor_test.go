@@ -0,0 +1,74 @@
+package tangy
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrap(t *testing.T) {
+	t.Parallel()
+
+	next := NewMockTangy(t)
+	assert.Same(t, next, Wrap(next))
+
+	var order []string
+	interceptor := func(name string) Interceptor {
+		return func(ctx context.Context, call *Call, invoke func(ctx context.Context) error) error {
+			order = append(order, name+" before")
+			err := invoke(ctx)
+			order = append(order, name+" after")
+			return err
+		}
+	}
+	wrapped := Wrap(next, interceptor("first"), interceptor("second"), interceptor("third"))
+	filters := NpmPackageListFilters{Search: "left"}
+	next.On("NpmPackageList", context.Background(), "/href", filters, PageOptions{}).Return(NpmPackageListResponse{Total: 1}, nil).Once()
+
+	list, err := wrapped.NpmPackageList(context.Background(), "/href", filters, PageOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, NpmPackageListResponse{Total: 1}, list)
+	assert.Equal(t, []string{"first before", "second before", "third before", "third after", "second after", "first after"}, order)
+}
+
+func TestLoggingInterceptor(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+	interceptor := LoggingInterceptor(zerolog.New(&out).Level(zerolog.DebugLevel))
+	call := &Call{Method: "NpmPackageList", Args: []any{"/href", 10}}
+
+	require.NoError(t, interceptor(context.Background(), call, func(ctx context.Context) error { return nil }))
+	assert.Contains(t, out.String(), `"level":"debug","method":"NpmPackageList","args":["/href",10]`)
+
+	out.Reset()
+	err := interceptor(context.Background(), call, func(ctx context.Context) error { return ErrRepositoryVersionNotFound })
+	require.ErrorIs(t, err, ErrRepositoryVersionNotFound)
+	assert.Contains(t, out.String(), `"level":"error"`)
+	assert.Contains(t, out.String(), ErrRepositoryVersionNotFound.Error())
+}
+
+func TestTimingInterceptor(t *testing.T) {
+	t.Parallel()
+
+	var method string
+	var duration time.Duration
+	var observedErr error
+	interceptor := TimingInterceptor(func(m string, d time.Duration, err error) {
+		method, duration, observedErr = m, d, err
+	})
+
+	err := interceptor(context.Background(), &Call{Method: "NpmPackageList"}, func(ctx context.Context) error {
+		time.Sleep(time.Millisecond)
+		return ErrRepositoryVersionNotFound
+	})
+	require.ErrorIs(t, err, ErrRepositoryVersionNotFound)
+	assert.Equal(t, "NpmPackageList", method)
+	assert.GreaterOrEqual(t, duration, time.Millisecond)
+	assert.ErrorIs(t, observedErr, ErrRepositoryVersionNotFound)
+}
@@ -3,15 +3,73 @@ package tangy
 import (
 	"context"
 	"fmt"
+	"iter"
 	"math"
+	"time"
 
 	zerologadapter "github.com/jackc/pgx-zerolog"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/multitracer"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/jackc/pgx/v5/tracelog"
 	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func New(dbConfig Database, logConfig Logger) (Tangy, error) {
+	if err := dbConfig.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid database configuration: %w", err)
+	}
+
+	pool, err := newPool(dbConfig, logConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	t := tangyImpl{
+		pool:                    pool,
+		logger:                  logConfig,
+		skipContentIdsCheck:     dbConfig.SkipContentIdsCheck,
+		statementTimeout:        dbConfig.StatementTimeout,
+		methodStatementTimeouts: dbConfig.MethodStatementTimeouts,
+		stats:                   newCallStats(),
+		sqlComments:             dbConfig.SQLComments,
+	}
+	if logConfig.TracerProvider != nil {
+		t.tracer = logConfig.TracerProvider.Tracer(instrumentationName)
+	}
+
+	if len(dbConfig.Replicas) > 0 {
+		t.replicas, err = newReplicaSet(dbConfig, logConfig)
+		if err != nil {
+			pool.Close()
+			return nil, err
+		}
+	}
+
+	if dbConfig.Retry.MaxAttempts > 1 {
+		return Wrap(&t, dbConfig.Retry.Interceptor(logConfig.logger())), nil
+	}
+	return &t, nil
+}
+
+// configureQueryExecMode sets the query exec mode of the connections. Modes that do not prepare statements
+// disable the statement and description caches, so that no statement outlives a transaction behind PgBouncer.
+func configureQueryExecMode(connConfig *pgx.ConnConfig, mode string) {
+	execMode, ok := queryExecModes[mode]
+	if !ok {
+		return
+	}
+	connConfig.DefaultQueryExecMode = execMode
+	switch execMode {
+	case pgx.QueryExecModeDescribeExec, pgx.QueryExecModeExec, pgx.QueryExecModeSimpleProtocol:
+		connConfig.StatementCacheCapacity = 0
+		connConfig.DescriptionCacheCapacity = 0
+	}
+}
+
+// newPool creates the connection pool of a database
+func newPool(dbConfig Database, logConfig Logger) (*pgxpool.Pool, error) {
 	pxConfig, err := pgxpool.ParseConfig(dbConfig.Url())
 	if err != nil {
 		return nil, err
@@ -22,66 +80,124 @@ func New(dbConfig Database, logConfig Logger) (Tangy, error) {
 	}
 
 	// Validate pool limit is within 32-bit integer range
-	if dbConfig.PoolLimit < math.MinInt32 || dbConfig.PoolLimit > math.MaxInt32 {
-		return nil, fmt.Errorf("pool limit size is invalid: %d (must be between %d and %d)",
-			dbConfig.PoolLimit, math.MinInt32, math.MaxInt32)
+	if dbConfig.PoolLimit < 0 || dbConfig.PoolLimit > math.MaxInt32 {
+		return nil, fmt.Errorf("pool limit size is invalid: %d (must be between 0 and %d)", dbConfig.PoolLimit, math.MaxInt32)
 	}
 
 	pxConfig.MaxConns = int32(dbConfig.PoolLimit)
 
+	// Fields that can be combined with a DSN
+	if dbConfig.ApplicationName != "" {
+		pxConfig.ConnConfig.RuntimeParams["application_name"] = dbConfig.ApplicationName
+	}
+	if dbConfig.ConnectTimeout > 0 {
+		pxConfig.ConnConfig.ConnectTimeout = time.Duration(connectTimeoutSeconds(dbConfig.ConnectTimeout)) * time.Second
+	}
+	pxConfig.BeforeConnect = dbConfig.BeforeConnect
+	newConnectBackoff(dbConfig.MaxConnectBackoff).configure(pxConfig)
+	// Commented statements are all different, so they are not prepared and cached by default
+	if dbConfig.SQLComments && dbConfig.QueryExecMode == "" {
+		dbConfig.QueryExecMode = QueryExecModeDescribeExec
+	}
+	configureQueryExecMode(pxConfig.ConnConfig, dbConfig.QueryExecMode)
+
+	var tracers []pgx.QueryTracer
 	if logConfig.Logger != nil && logConfig.Enabled {
 		zlog := zerologadapter.NewLogger(*logConfig.Logger)
 		level, err := tracelog.LogLevelFromString(logConfig.LogLevel)
 		if err != nil {
 			log.Error().Err(err).Msg("Error setting Pgx log level")
 		}
-		pxConfig.ConnConfig.Tracer = &tracelog.TraceLog{
+		tracers = append(tracers, &tracelog.TraceLog{
 			Logger:   zlog,
 			LogLevel: level,
-		}
+		})
+	}
+	if logConfig.TracerProvider != nil {
+		tracers = append(tracers, &queryTracer{tracer: logConfig.TracerProvider.Tracer(instrumentationName)})
+	}
+	switch len(tracers) {
+	case 0:
+	case 1:
+		pxConfig.ConnConfig.Tracer = tracers[0]
+	default:
+		pxConfig.ConnConfig.Tracer = multitracer.New(tracers...)
 	}
 
+	// The pool connects on first use, so that New succeeds while the database is down
 	pool, err := pgxpool.NewWithConfig(context.Background(), pxConfig)
 	if err != nil {
 		return nil, fmt.Errorf("error establishing connection: %w", err)
 	}
-
-	t := tangyImpl{
-		pool:   pool,
-		logger: logConfig,
-	}
-	return &t, nil
+	return pool, nil
 }
 
 type tangyImpl struct {
-	pool   *pgxpool.Pool
-	logger Logger
+	pool                    *pgxpool.Pool
+	logger                  Logger
+	skipContentIdsCheck     bool
+	statementTimeout        time.Duration
+	methodStatementTimeouts map[string]time.Duration
+	replicas                *replicaSet
+	snapshot                *snapshot
+	stats                   *callStats
+	tracer                  trace.Tracer
+	sqlComments             bool
 }
 
 type Tangy interface {
 	RpmRepositoryVersionPackageSearch(ctx context.Context, hrefs []string, search string, limit int) ([]RpmPackageSearch, error)
 	RpmRepositoryVersionPackageGroupSearch(ctx context.Context, hrefs []string, search string, limit int) ([]RpmPackageGroupSearch, error)
 	RpmRepositoryVersionEnvironmentSearch(ctx context.Context, hrefs []string, search string, limit int) ([]RpmEnvironmentSearch, error)
-	RpmRepositoryVersionPackageList(ctx context.Context, hrefs []string, filterOpts RpmListFilters, pageOpts PageOptions) ([]RpmListItem, int, error)
+	RpmRepositoryVersionPackageList(ctx context.Context, hrefs []string, filterOpts RpmListFilters, pageOpts PageOptions) (RpmListResponse, error)
 	RpmRepositoryVersionModuleStreamsList(ctx context.Context, hrefs []string, filterOpts ModuleStreamListFilters, sortBy string) ([]ModuleStreams, error)
-	RpmRepositoryVersionErrataList(ctx context.Context, hrefs []string, filterOpts ErrataListFilters, pageOpts PageOptions) ([]ErrataListItem, int, error)
+	RpmRepositoryVersionErrataList(ctx context.Context, hrefs []string, filterOpts ErrataListFilters, pageOpts PageOptions) (ErrataListResponse, error)
 	PythonPackageList(ctx context.Context, repositoryHref string, filterOpts PythonPackageListFilters, pageOpts PageOptions) (PythonPackageListResponse, error)
 	PythonDistributionList(ctx context.Context, repositoryHref, nameNormalized, version string, pageOpts PageOptions) (PythonDistributionListResponse, error)
 	PythonPackageGet(ctx context.Context, repositoryHref, nameNormalized, version string) (PythonPackageDetail, error)
 	PythonPackageVersionsGet(ctx context.Context, repositoryHref, nameNormalized string) ([]PythonPackageDetail, error)
 	PythonBuildList(ctx context.Context, repositoryHref, nameNormalized, version string, pageOpts PageOptions) (PythonBuildListResponse, error)
 	PythonRepositoryMetrics(ctx context.Context, repositoryHref string) (PythonRepositoryMetrics, error)
+	PythonRepositoryVersionPackageList(ctx context.Context, hrefs []string, filterOpts PythonPackageListFilters, pageOpts PageOptions) (PythonPackageListResponse, error)
+	PythonRepositoryVersionDistributionList(ctx context.Context, hrefs []string, nameNormalized, version string, pageOpts PageOptions) (PythonDistributionListResponse, error)
+	PythonRepositoryVersionPackageGet(ctx context.Context, hrefs []string, nameNormalized, version string) (PythonPackageDetail, error)
+	PythonRepositoryVersionPackageVersionsGet(ctx context.Context, hrefs []string, nameNormalized string) ([]PythonPackageDetail, error)
+	PythonRepositoryVersionBuildList(ctx context.Context, hrefs []string, nameNormalized, version string, pageOpts PageOptions) (PythonBuildListResponse, error)
+	PythonRepositoryVersionMetrics(ctx context.Context, hrefs []string) (PythonRepositoryMetrics, error)
 	MavenPackageList(ctx context.Context, repositoryHref string, filterOpts MavenPackageListFilters, pageOpts PageOptions) (MavenPackageListResponse, error)
 	MavenVersionsList(ctx context.Context, repositoryHref, groupID, artifactID, version string, pageOpts PageOptions) (MavenVersionsResponse, error)
 	MavenRepositoryMetrics(ctx context.Context, repositoryHref string) (MavenRepositoryMetrics, error)
+	MavenRepositoryVersionPackageList(ctx context.Context, hrefs []string, filterOpts MavenPackageListFilters, pageOpts PageOptions) (MavenPackageListResponse, error)
+	MavenRepositoryVersionVersionsList(ctx context.Context, hrefs []string, groupID, artifactID, version string, pageOpts PageOptions) (MavenVersionsResponse, error)
+	MavenRepositoryVersionMetrics(ctx context.Context, hrefs []string) (MavenRepositoryMetrics, error)
 	NpmPackageList(ctx context.Context, repositoryHref string, filterOpts NpmPackageListFilters, pageOpts PageOptions) (NpmPackageListResponse, error)
 	NpmPackageGet(ctx context.Context, repositoryHref, name, version string) (NpmPackageDetail, error)
 	NpmPackageVersionsGet(ctx context.Context, repositoryHref, name string) ([]NpmPackageDetail, error)
 	NpmBuildList(ctx context.Context, repositoryHref, name, version string, pageOpts PageOptions) (NpmBuildListResponse, error)
+	NpmRepositoryVersionPackageList(ctx context.Context, hrefs []string, filterOpts NpmPackageListFilters, pageOpts PageOptions) (NpmPackageListResponse, error)
+	NpmRepositoryVersionPackageGet(ctx context.Context, hrefs []string, name, version string) (NpmPackageDetail, error)
+	NpmRepositoryVersionPackageVersionsGet(ctx context.Context, hrefs []string, name string) ([]NpmPackageDetail, error)
+	NpmRepositoryVersionBuildList(ctx context.Context, hrefs []string, name, version string, pageOpts PageOptions) (NpmBuildListResponse, error)
+	RpmRepositoryVersionBatch(ctx context.Context, hrefs []string, batch *Batch) error
+	RpmRepositoryVersionPackageAll(ctx context.Context, hrefs []string, filterOpts RpmListFilters) iter.Seq2[RpmListItem, error]
+	RpmRepositoryVersionErrataAll(ctx context.Context, hrefs []string, filterOpts ErrataListFilters) iter.Seq2[ErrataListItem, error]
+	PythonRepositoryVersionDistributionAll(ctx context.Context, hrefs []string, nameNormalized, version string) iter.Seq2[PythonDistributionListItem, error]
+	MavenRepositoryVersionVersionsAll(ctx context.Context, hrefs []string, groupID, artifactID, version string) iter.Seq2[MavenVersionsItem, error]
+	NpmRepositoryVersionPackageAll(ctx context.Context, hrefs []string, filterOpts NpmPackageListFilters) iter.Seq2[NpmPackageListItem, error]
+	ReadSnapshot(ctx context.Context, fn func(snapshot Tangy) error) error
+	Ping(ctx context.Context) error
+	Ready(ctx context.Context) error
+	Stats() Stats
 	Close()
 }
 
-// Close closes the DB connection pool
+// Close closes the DB connection pools. It does nothing on the Tangy passed to ReadSnapshot.
 func (t *tangyImpl) Close() {
+	if t.snapshot != nil {
+		return
+	}
+	if t.replicas != nil {
+		t.replicas.close()
+	}
 	t.pool.Close()
 }
@@ -0,0 +1,252 @@
+package tangy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// ErrIteratorInSnapshot is returned by the iterators of the Tangy passed to ReadSnapshot, as an iterator holds
+// the transaction of the snapshot until it is done, while the loop over it may call the snapshot
+var ErrIteratorInSnapshot = errors.New("iterators cannot be used within a read snapshot")
+
+// iteratorFetchSize is the number of rows an iterator fetches from its server-side cursor at a time
+const iteratorFetchSize = 1000
+
+// iteratorCursorName is the name of the server-side cursor of an iterator, declared in a transaction of its own
+const iteratorCursorName = "tangy_iterator"
+
+// iterate returns an iterator running a call of the Tangy method in a transaction of its own, which lasts until
+// the loop over the iterator ends. run yields the items of the call, and returns false once yield returned false.
+// An error ends the iteration, yielded with the zero value of T.
+//
+// The time spent in the body of the loop over the iterator is not counted in the latency of the call, and the span
+// of the call ends with the last FETCH of the rows, rather than with the loop.
+func iterate[T any](ctx context.Context, t *tangyImpl, method string, run func(ctx context.Context, tx pgx.Tx, yield func(T) bool) (bool, error), args ...any) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		more, err := t.runIterator(ctx, method, func(ctx context.Context, tx pgx.Tx) (bool, error) {
+			state := callOf(ctx)
+			return run(ctx, tx, func(item T) bool {
+				start := time.Now()
+				more := yield(item, nil)
+				state.loop.Add(int64(time.Since(start)))
+				return more
+			})
+		}, args)
+		if err != nil && more {
+			var zero T
+			yield(zero, err)
+		}
+	}
+}
+
+type iteratorFetchedContextKey struct{}
+
+// runIterator runs the call of an iterator, returning the error of the call and whether yield may still be called.
+// The call ends once every row was fetched, see fetchedRows, or when run returns.
+func (t *tangyImpl) runIterator(ctx context.Context, method string, run func(ctx context.Context, tx pgx.Tx) (bool, error), args []any) (bool, error) {
+	ctx, done := t.call(ctx, method, args...)
+	var once sync.Once
+	var callErr error
+	finish := func(err error) error {
+		once.Do(func() {
+			callErr = err
+			done(&callErr)
+		})
+		return callErr
+	}
+
+	ctx = context.WithValue(ctx, iteratorFetchedContextKey{}, func() { finish(nil) })
+	more, err := t.runIteratorTx(ctx, run)
+	return more, finish(err)
+}
+
+// fetchedRows ends the call of the iterator ctx belongs to, once its last rows were fetched
+func fetchedRows(ctx context.Context) {
+	if fetched, ok := ctx.Value(iteratorFetchedContextKey{}).(func()); ok {
+		fetched()
+	}
+}
+
+// runIteratorTx runs the call of an iterator in a transaction of its own
+func (t *tangyImpl) runIteratorTx(ctx context.Context, run func(ctx context.Context, tx pgx.Tx) (bool, error)) (bool, error) {
+	if t.snapshot != nil {
+		return true, ErrIteratorInSnapshot
+	}
+	tx, end, err := t.begin(ctx)
+	if err != nil {
+		return true, err
+	}
+	defer end()
+	return run(ctx, tx)
+}
+
+// declareIteratorCursor returns the statement declaring the server-side cursor of an iterator running query
+func declareIteratorCursor(query sqlQuery) sqlQuery {
+	return sqlQuery{SQL: "DECLARE " + iteratorCursorName + " NO SCROLL CURSOR FOR " + query.SQL, Args: query.Args}
+}
+
+// fetchRows runs query in a server-side cursor, and yields its rows scanned by fn, fetching iteratorFetchSize rows
+// at a time. Returns false once yield returned false.
+func fetchRows[T any](ctx context.Context, tx pgx.Tx, query sqlQuery, fn pgx.RowToFunc[T], yield func(T) bool) (bool, error) {
+	declare := declareIteratorCursor(query)
+	if _, err := tx.Exec(ctx, declare.SQL, declare.Args); err != nil {
+		return true, err
+	}
+	fetch := "FETCH FORWARD " + strconv.Itoa(iteratorFetchSize) + " FROM " + iteratorCursorName
+	for {
+		rows, err := tx.Query(ctx, fetch)
+		if err != nil {
+			return true, err
+		}
+		batch, err := collectRows(ctx, rows, fn)
+		if err != nil {
+			return true, err
+		}
+		if len(batch) < iteratorFetchSize {
+			fetchedRows(ctx)
+		}
+		for _, item := range batch {
+			if !yield(item) {
+				return false, nil
+			}
+		}
+		if len(batch) < iteratorFetchSize {
+			return true, nil
+		}
+	}
+}
+
+// RpmRepositoryVersionPackageAll iterates over the RPMs within repository versions, with an optional name filter,
+// in the order of RpmRepositoryVersionPackageList
+func (t *tangyImpl) RpmRepositoryVersionPackageAll(ctx context.Context, hrefs []string, filterOpts RpmListFilters) iter.Seq2[RpmListItem, error] {
+	return iterate(ctx, t, "RpmRepositoryVersionPackageAll", func(ctx context.Context, tx pgx.Tx, yield func(RpmListItem) bool) (bool, error) {
+		if len(hrefs) == 0 {
+			return true, nil
+		}
+		repoVerMap, err := parseRepositoryVersionHrefsMap(hrefs)
+		if err != nil {
+			return true, fmt.Errorf("error parsing repository version hrefs: %w", err)
+		}
+		m, err := t.contentMembership(ctx, tx, repoVerMap)
+		if err != nil {
+			return true, err
+		}
+		return fetchRows(ctx, tx, rpmPackageListQuery(m, filterOpts, PageOptions{}, pageCursor{}), pgx.RowToStructByName[RpmListItem], yield)
+	}, filterOpts)
+}
+
+// RpmRepositoryVersionErrataAll iterates over the errata within repository versions, with optional filters,
+// in the default order of RpmRepositoryVersionErrataList
+func (t *tangyImpl) RpmRepositoryVersionErrataAll(ctx context.Context, hrefs []string, filterOpts ErrataListFilters) iter.Seq2[ErrataListItem, error] {
+	return iterate(ctx, t, "RpmRepositoryVersionErrataAll", func(ctx context.Context, tx pgx.Tx, yield func(ErrataListItem) bool) (bool, error) {
+		if len(hrefs) == 0 {
+			return true, nil
+		}
+		repoVerMap, err := parseRepositoryVersionHrefsMap(hrefs)
+		if err != nil {
+			return true, fmt.Errorf("error parsing repository version hrefs: %w", err)
+		}
+		m, err := t.contentMembership(ctx, tx, repoVerMap)
+		if err != nil {
+			return true, err
+		}
+		_, order := errataListSort("")
+		return fetchRows(ctx, tx, rpmErrataListQuery(m, filterOpts, order, PageOptions{}, pageCursor{}), pgx.RowToStructByName[ErrataListItem], yield)
+	}, filterOpts)
+}
+
+// PythonRepositoryVersionDistributionAll iterates over the Python distribution files within repository versions,
+// or the latest versions of repositories, of every package, or of a package name and version when set.
+// Distribution files are in the order of PythonRepositoryVersionDistributionList.
+func (t *tangyImpl) PythonRepositoryVersionDistributionAll(ctx context.Context, hrefs []string, nameNormalized, version string) iter.Seq2[PythonDistributionListItem, error] {
+	return iterate(ctx, t, "PythonRepositoryVersionDistributionAll", func(ctx context.Context, tx pgx.Tx, yield func(PythonDistributionListItem) bool) (bool, error) {
+		if len(hrefs) == 0 {
+			return true, nil
+		}
+		repoVerMap, repositories, err := resolveRepositoryHrefs(ctx, tx, hrefs, pageCursor{})
+		if err != nil {
+			return true, err
+		}
+		m, err := t.contentMembership(ctx, tx, repoVerMap)
+		if err != nil {
+			return true, err
+		}
+		return fetchRows(ctx, tx, pythonDistributionAllQuery(m, nameNormalized, version), pgx.RowToStructByName[pythonDistributionRow], func(row pythonDistributionRow) bool {
+			return yield(pythonDistributionRowToItem(row, repositories))
+		})
+	}, arg("name_normalized", nameNormalized), arg("version", version))
+}
+
+// MavenRepositoryVersionVersionsAll iterates over the Maven artifacts (builds) within repository versions,
+// or the latest versions of repositories, optionally filtered by group_id, artifact_id, and version,
+// in the order of MavenRepositoryVersionVersionsList
+func (t *tangyImpl) MavenRepositoryVersionVersionsAll(ctx context.Context, hrefs []string, groupID, artifactID, version string) iter.Seq2[MavenVersionsItem, error] {
+	return iterate(ctx, t, "MavenRepositoryVersionVersionsAll", func(ctx context.Context, tx pgx.Tx, yield func(MavenVersionsItem) bool) (bool, error) {
+		if len(hrefs) == 0 {
+			return true, nil
+		}
+		repoVerMap, repositories, err := resolveRepositoryHrefs(ctx, tx, hrefs, pageCursor{})
+		if err != nil {
+			return true, err
+		}
+		m, err := t.contentMembership(ctx, tx, repoVerMap)
+		if err != nil {
+			return true, err
+		}
+		var itemErr error
+		more, err := fetchRows(ctx, tx, mavenVersionsListQuery(m, groupID, artifactID, version, PageOptions{}, pageCursor{}), pgx.RowToStructByName[mavenVersionsRow], func(row mavenVersionsRow) bool {
+			item, err := mavenVersionsItemFromRow(row, repositories)
+			if err != nil {
+				itemErr = err
+				return false
+			}
+			return yield(item)
+		})
+		if itemErr != nil {
+			return true, itemErr
+		}
+		return more, err
+	}, arg("group_id", groupID), arg("artifact_id", artifactID), arg("version", version))
+}
+
+// NpmRepositoryVersionPackageAll iterates over the npm packages within repository versions, or the latest versions
+// of repositories, grouped by name, in the order of NpmRepositoryVersionPackageList
+func (t *tangyImpl) NpmRepositoryVersionPackageAll(ctx context.Context, hrefs []string, filterOpts NpmPackageListFilters) iter.Seq2[NpmPackageListItem, error] {
+	return iterate(ctx, t, "NpmRepositoryVersionPackageAll", func(ctx context.Context, tx pgx.Tx, yield func(NpmPackageListItem) bool) (bool, error) {
+		if len(hrefs) == 0 {
+			return true, nil
+		}
+		repoVerMap, repositories, err := resolveRepositoryHrefs(ctx, tx, hrefs, pageCursor{})
+		if err != nil {
+			return true, err
+		}
+		m, err := t.contentMembership(ctx, tx, repoVerMap)
+		if err != nil {
+			return true, err
+		}
+
+		// The rows of a package are consecutive, and assembled into a package once the next package starts
+		var packageRows []npmPackageVersionRow
+		more, err := fetchRows(ctx, tx, npmPackageListQuery(m, filterOpts, PageOptions{}, pageCursor{}), pgx.RowToStructByName[npmPackageVersionRow], func(row npmPackageVersionRow) bool {
+			if len(packageRows) > 0 && row.Name != packageRows[0].Name {
+				if !yield(assembleNpmPackageListFromRows(packageRows, repositories)[0]) {
+					return false
+				}
+				packageRows = packageRows[:0]
+			}
+			packageRows = append(packageRows, row)
+			return true
+		})
+		if err != nil || !more || len(packageRows) == 0 {
+			return more, err
+		}
+		return yield(assembleNpmPackageListFromRows(packageRows, repositories)[0]), nil
+	}, filterOpts)
+}
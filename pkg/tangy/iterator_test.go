@@ -0,0 +1,62 @@
+package tangy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIteratorInSnapshot(t *testing.T) {
+	t.Parallel()
+
+	tangy := &tangyImpl{snapshot: &snapshot{}, stats: newCallStats()}
+	var errs []error
+	for pkg, err := range tangy.RpmRepositoryVersionPackageAll(context.Background(), []string{"/href"}, RpmListFilters{}) {
+		assert.Zero(t, pkg)
+		errs = append(errs, err)
+	}
+	require.Len(t, errs, 1)
+	assert.ErrorIs(t, errs[0], ErrIteratorInSnapshot)
+
+	// The call is counted in the statistics of the iterator
+	stats := tangy.stats.snapshot()["RpmRepositoryVersionPackageAll"]
+	assert.Equal(t, uint64(1), stats.Calls)
+	assert.Equal(t, uint64(1), stats.ErrorsByClass[ErrorClassInvalid])
+}
+
+func TestIteratorLoopLatency(t *testing.T) {
+	t.Parallel()
+
+	// The time spent in the loop over an iterator is not part of the latency of its call
+	tangy := &tangyImpl{stats: newCallStats()}
+	ctx, done := tangy.call(context.Background(), "NpmRepositoryVersionPackageAll")
+	time.Sleep(20 * time.Millisecond)
+	callOf(ctx).loop.Add(int64(20 * time.Millisecond))
+	err := error(nil)
+	done(&err)
+
+	stats := tangy.stats.snapshot()["NpmRepositoryVersionPackageAll"]
+	assert.Equal(t, uint64(1), stats.Calls)
+	assert.Less(t, stats.Latency.Sum, 20*time.Millisecond)
+}
+
+func TestFetchedRows(t *testing.T) {
+	t.Parallel()
+
+	// Fetching the last rows ends the call of the iterator, outside of an iterator it does nothing
+	fetched := 0
+	fetchedRows(context.WithValue(context.Background(), iteratorFetchedContextKey{}, func() { fetched++ }))
+	fetchedRows(context.Background())
+	assert.Equal(t, 1, fetched)
+}
+
+func TestDeclareIteratorCursor(t *testing.T) {
+	t.Parallel()
+
+	query := declareIteratorCursor(sqlQuery{SQL: "SELECT name FROM npm_package WHERE name = @name", Args: map[string]any{"name": "is-odd"}})
+	assert.Equal(t, "DECLARE tangy_iterator NO SCROLL CURSOR FOR SELECT name FROM npm_package WHERE name = @name", query.SQL)
+	assert.Equal(t, "is-odd", query.Args["name"])
+}
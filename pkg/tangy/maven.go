@@ -5,11 +5,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"regexp"
-	"strings"
 	"time"
 
 	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type MavenReleaseInfo struct {
@@ -23,6 +21,7 @@ type MavenPackageListItem struct {
 	ArtifactID     string             `json:"artifact_id"`
 	Versions       []string           `json:"versions"`
 	LatestReleases []MavenReleaseInfo `json:"latest_releases"`
+	Repositories   []string           `json:"repositories,omitempty"`
 }
 
 type MavenPackageListFilters struct {
@@ -30,10 +29,12 @@ type MavenPackageListFilters struct {
 }
 
 type MavenPackageListResponse struct {
-	Results []MavenPackageListItem `json:"results"`
-	Total   int                    `json:"total"`
-	Limit   int                    `json:"limit"`
-	Offset  int                    `json:"offset"`
+	Results    []MavenPackageListItem `json:"results"`
+	Total      int                    `json:"total"`
+	TotalMode  CountMode              `json:"total_mode"`
+	Limit      int                    `json:"limit"`
+	Offset     int                    `json:"offset"`
+	NextCursor string                 `json:"next_cursor,omitempty"`
 }
 
 type MavenBuildInfo struct {
@@ -44,17 +45,20 @@ type MavenBuildInfo struct {
 }
 
 type MavenVersionsItem struct {
-	GroupID    string           `json:"group_id"`
-	ArtifactID string           `json:"artifact_id"`
-	Version    string           `json:"version"`
-	Builds     []MavenBuildInfo `json:"builds"`
+	GroupID      string           `json:"group_id"`
+	ArtifactID   string           `json:"artifact_id"`
+	Version      string           `json:"version"`
+	Builds       []MavenBuildInfo `json:"builds"`
+	Repositories []string         `json:"repositories,omitempty"`
 }
 
 type MavenVersionsResponse struct {
-	Results []MavenVersionsItem `json:"results"`
-	Total   int                 `json:"total"`
-	Limit   int                 `json:"limit"`
-	Offset  int                 `json:"offset"`
+	Results    []MavenVersionsItem `json:"results"`
+	Total      int                 `json:"total"`
+	TotalMode  CountMode           `json:"total_mode"`
+	Limit      int                 `json:"limit"`
+	Offset     int                 `json:"offset"`
+	NextCursor string              `json:"next_cursor,omitempty"`
 }
 
 type MavenRepositoryMetrics struct {
@@ -63,127 +67,66 @@ type MavenRepositoryMetrics struct {
 	VersionCount int `json:"version_count"`
 }
 
-// MavenPackageList lists Maven packages from the latest version of a repository, grouped by group_id and artifact_id
+// MavenPackageList lists Maven packages from a repository version, or the latest version of a repository, grouped by group_id and artifact_id
 // Only includes artifacts with .pom files
-func (t *tangyImpl) MavenPackageList(ctx context.Context, repositoryHref string, filterOpts MavenPackageListFilters, pageOpts PageOptions) (MavenPackageListResponse, error) {
+func (t *tangyImpl) MavenPackageList(ctx context.Context, repositoryHref string, filterOpts MavenPackageListFilters, pageOpts PageOptions) (_ MavenPackageListResponse, err error) {
+	ctx, done := t.call(ctx, "MavenPackageList", filterOpts, pageOpts)
+	defer done(&err)
+
 	if repositoryHref == "" {
 		return MavenPackageListResponse{}, nil
 	}
+	return t.MavenRepositoryVersionPackageList(ctx, []string{repositoryHref}, filterOpts, pageOpts)
+}
+
+// MavenRepositoryVersionPackageList lists Maven packages merged across several repository versions, or the latest
+// versions of several repositories, grouped by group_id and artifact_id. Each package reports the repositories that contain it.
+// Only includes artifacts with .pom files
+func (t *tangyImpl) MavenRepositoryVersionPackageList(ctx context.Context, hrefs []string, filterOpts MavenPackageListFilters, pageOpts PageOptions) (_ MavenPackageListResponse, err error) {
+	ctx, done := t.call(ctx, "MavenRepositoryVersionPackageList", filterOpts, pageOpts)
+	defer done(&err)
+
+	if len(hrefs) == 0 {
+		return MavenPackageListResponse{}, nil
+	}
 
-	conn, err := t.pool.Acquire(ctx)
+	tx, end, err := t.begin(ctx)
 	if err != nil {
 		return MavenPackageListResponse{}, err
 	}
-	defer conn.Release()
+	defer end()
 
 	if pageOpts.Limit == 0 {
 		pageOpts.Limit = DefaultLimit
 	}
 
-	// Parse repository UUID from href
-	repoUUID, err := parseRepositoryHref(repositoryHref)
+	cursor, err := decodeCursor(pageOpts.Cursor, cursorKindMavenPackageList, len(mavenPackageListSort.Columns))
 	if err != nil {
-		return MavenPackageListResponse{}, fmt.Errorf("error parsing repository href: %w", err)
+		return MavenPackageListResponse{}, err
 	}
 
-	// Get the latest version for this repository
-	latestVersion, err := getLatestRepositoryVersion(ctx, conn, repoUUID)
+	// Resolve the repository versions, unless the cursor pins the versions of a previous page
+	repoVerMap, repositories, err := resolveRepositoryHrefs(ctx, tx, hrefs, cursor)
 	if err != nil {
-		return MavenPackageListResponse{}, fmt.Errorf("error getting latest repository version: %w", err)
+		return MavenPackageListResponse{}, err
 	}
 
-	repoVerMap := []ParsedRepoVersion{{
-		RepositoryUUID: repoUUID,
-		Version:        latestVersion,
-	}}
-
-	args := pgx.NamedArgs{}
-	pomFilter := ` AND rp.filename LIKE '%.pom'`
-	searchFilter := ""
-	if filterOpts.Search != "" {
-		args["searchFilter"] = filterOpts.Search
-		searchFilter = ` AND (rp.group_id ILIKE CONCAT('%', @searchFilter::text, '%')
-			OR rp.artifact_id ILIKE CONCAT(@searchFilter::text, '%'))`
-	}
-	innerUnion, err := contentIdsInVersions(ctx, conn, repoVerMap, &args)
+	m, err := t.contentMembership(ctx, tx, repoVerMap)
 	if err != nil {
 		return MavenPackageListResponse{}, err
 	}
 
-	artifactFilters := pomFilter + searchFilter
-
 	// Count query for total grouped packages
-	// Note: using 'rp' alias as required by contentIdsInVersions function
-	countQuery := `
-		SELECT COUNT(DISTINCT (rp.group_id, rp.artifact_id))
-		FROM maven_mavenartifact rp
-	` + innerUnion + artifactFilters
-
-	var countTotal int
-	err = conn.QueryRow(ctx, countQuery, args).Scan(&countTotal)
+	total, totalMode, err := listTotal(ctx, tx, pageOpts.Count, func(limit int) sqlQuery {
+		return mavenPackageListCountQuery(m, filterOpts, limit)
+	})
 	if err != nil {
 		return MavenPackageListResponse{}, err
 	}
 
 	// Main query using SQL aggregation and pagination
-	// This query groups by group_id/artifact_id, collects versions, and finds latest release per version
-	args["limit"] = pageOpts.Limit
-	args["offset"] = pageOpts.Offset
-
-	query := `
-		WITH package_versions AS (
-			SELECT
-				rp.group_id,
-				rp.artifact_id,
-				regexp_replace(rp.version, '\.` + mavenReleaseQualifierPattern + `$', '') as base_version,
-				rp.filename,
-				cc.pulp_created,
-				ROW_NUMBER() OVER (PARTITION BY rp.group_id, rp.artifact_id, regexp_replace(rp.version, '\.` + mavenReleaseQualifierPattern + `$', '') ORDER BY cc.pulp_created DESC) as rn
-			FROM maven_mavenartifact rp
-			INNER JOIN core_content cc ON rp.content_ptr_id = cc.pulp_id
-		` + innerUnion + artifactFilters + `
-		),
-		latest_per_version AS (
-			SELECT
-				group_id,
-				artifact_id,
-				base_version,
-				filename,
-				pulp_created
-			FROM package_versions
-			WHERE rn = 1
-		),
-		packages AS (
-			SELECT
-				group_id,
-				artifact_id,
-				ARRAY_AGG(DISTINCT base_version ORDER BY base_version) as versions
-			FROM latest_per_version
-			GROUP BY group_id, artifact_id
-			ORDER BY group_id, artifact_id
-			LIMIT @limit OFFSET @offset
-		)
-		SELECT
-			p.group_id,
-			p.artifact_id,
-			p.versions,
-			COALESCE(
-				JSON_AGG(
-					JSON_BUILD_OBJECT(
-						'version', lpv.base_version,
-						'release', '',
-						'filename', lpv.filename,
-						'created_at', lpv.pulp_created
-					) ORDER BY lpv.base_version
-				) FILTER (WHERE lpv.base_version IS NOT NULL),
-				'[]'::json
-			) as latest_releases_json
-		FROM packages p
-		LEFT JOIN latest_per_version lpv ON p.group_id = lpv.group_id AND p.artifact_id = lpv.artifact_id
-		GROUP BY p.group_id, p.artifact_id, p.versions
-		ORDER BY p.group_id, p.artifact_id`
-
-	rows, err := conn.Query(ctx, query, args)
+	query := mavenPackageListQuery(m, filterOpts, pageOpts, cursor)
+	rows, err := tx.Query(ctx, query.SQL, query.Args)
 	if err != nil {
 		return MavenPackageListResponse{}, err
 	}
@@ -192,10 +135,11 @@ func (t *tangyImpl) MavenPackageList(ctx context.Context, repositoryHref string,
 		GroupID            string
 		ArtifactID         string
 		Versions           []string
+		RepositoryIds      []string
 		LatestReleasesJSON []byte
 	}
 
-	queryResults, err := pgx.CollectRows(rows, pgx.RowToStructByName[queryResult])
+	queryResults, err := collectRows(ctx, rows, pgx.RowToStructByName[queryResult])
 	if err != nil {
 		return MavenPackageListResponse{}, err
 	}
@@ -229,17 +173,118 @@ func (t *tangyImpl) MavenPackageList(ctx context.Context, repositoryHref string,
 			ArtifactID:     qr.ArtifactID,
 			Versions:       qr.Versions,
 			LatestReleases: releaseInfos,
+			Repositories:   repositories.hrefs(qr.RepositoryIds),
 		})
 	}
 
-	return MavenPackageListResponse{
-		Results: results,
-		Total:   countTotal,
-		Limit:   pageOpts.Limit,
-		Offset:  pageOpts.Offset,
-	}, nil
+	response := MavenPackageListResponse{
+		Results:   results,
+		Total:     total,
+		TotalMode: totalMode,
+		Limit:     pageOpts.Limit,
+		Offset:    pageOpts.Offset,
+	}
+	if len(results) > 0 {
+		last := results[len(results)-1]
+		response.NextCursor = nextCursor(cursorKindMavenPackageList, len(results), pageOpts.Limit, repoVerMap, last.GroupID, last.ArtifactID)
+	}
+	return response, nil
+}
+
+func mavenPackageListCountQuery(m membership, filterOpts MavenPackageListFilters, countLimit int) sqlQuery {
+	b := newQueryBuilder()
+	// Note: using 'rp' alias as required by membershipJoin
+	return b.query(b.count(`
+		SELECT DISTINCT rp.group_id, rp.artifact_id
+		FROM maven_mavenartifact rp`+b.membershipJoin(m)+mavenPackageListFilters(b, filterOpts).and(), countLimit))
+}
+
+// mavenPackageListQuery groups by group_id/artifact_id, collects versions, and finds latest release per version
+func mavenPackageListQuery(m membership, filterOpts MavenPackageListFilters, pageOpts PageOptions, cursor pageCursor) sqlQuery {
+	b := newQueryBuilder()
+	join := b.membershipJoin(m)
+	where := mavenPackageListFilters(b, filterOpts)
+	return b.query(`
+		WITH package_versions AS (
+			SELECT
+				rp.group_id,
+				rp.artifact_id,
+				regexp_replace(rp.version, '` + mavenReleaseVersionSuffixPattern + `', '') as base_version,
+				rp.filename,
+				cc.pulp_created,
+				crv.repository_id,
+				ROW_NUMBER() OVER (PARTITION BY rp.group_id, rp.artifact_id, regexp_replace(rp.version, '` + mavenReleaseVersionSuffixPattern + `', '') ORDER BY cc.pulp_created DESC) as rn
+			FROM maven_mavenartifact rp
+			INNER JOIN core_content cc ON rp.content_ptr_id = cc.pulp_id` + join + where.and() + `
+		),
+		latest_per_version AS (
+			SELECT
+				group_id,
+				artifact_id,
+				base_version,
+				filename,
+				pulp_created
+			FROM package_versions
+			WHERE rn = 1
+		),
+		packages AS (
+			SELECT
+				group_id,
+				artifact_id,
+				ARRAY_AGG(DISTINCT base_version ORDER BY base_version) as versions,
+				ARRAY_AGG(DISTINCT repository_id) as repository_ids
+			FROM package_versions` + b.pageAfter(mavenPackageListSort, cursor).clause("WHERE") + `
+			GROUP BY group_id, artifact_id
+			` + orderBy(mavenPackageListSort) + `
+			` + b.page(pageOpts.Limit, pageOpts.Offset, cursor) + `
+		)
+		SELECT
+			p.group_id,
+			p.artifact_id,
+			p.versions,
+			p.repository_ids,
+			COALESCE(
+				JSON_AGG(
+					JSON_BUILD_OBJECT(
+						'version', lpv.base_version,
+						'release', '',
+						'filename', lpv.filename,
+						'created_at', lpv.pulp_created
+					) ORDER BY lpv.base_version
+				) FILTER (WHERE lpv.base_version IS NOT NULL),
+				'[]'::json
+			) as latest_releases_json
+		FROM packages p
+		LEFT JOIN latest_per_version lpv ON p.group_id = lpv.group_id AND p.artifact_id = lpv.artifact_id
+		GROUP BY p.group_id, p.artifact_id, p.versions, p.repository_ids
+		ORDER BY p.group_id, p.artifact_id`)
+}
+
+// mavenPackageListFilters only selects .pom artifacts, matching the search on the group or artifact id
+func mavenPackageListFilters(b *queryBuilder, filterOpts MavenPackageListFilters) conditions {
+	where := conditions{"rp.filename LIKE '%.pom'"}
+	if filterOpts.Search != "" {
+		search := b.bind("searchFilter", filterOpts.Search)
+		where = append(where, "(rp.group_id ILIKE CONCAT('%', "+search+"::text, '%') OR rp.artifact_id ILIKE CONCAT("+search+"::text, '%'))")
+	}
+	return where
 }
 
+// mavenPackageListSort is the sort key of MavenPackageList, applied to the package_versions CTE
+var mavenPackageListSort = sortOrder{Columns: []keysetColumn{
+	{Expr: "group_id", Type: "text"},
+	{Expr: "artifact_id", Type: "text"},
+}}
+
+// mavenVersionsListSort is the sort key of MavenVersionsList, applied to the version_builds CTE,
+// most recently created versions first
+var mavenVersionsListSort = sortOrder{Columns: []keysetColumn{
+	{Expr: "MAX(created_at)", Type: "timestamptz"},
+	{Expr: "group_id", Type: "text"},
+	{Expr: "artifact_id", Type: "text"},
+	{Expr: "base_version", Type: "text"},
+}, Desc: true}
+
 const mavenReleaseQualifierPattern = `[a-zA-Z]+-\d+`
 
 const mavenReleaseVersionSuffixPattern = `\.` + mavenReleaseQualifierPattern + `$`
@@ -266,148 +311,183 @@ func extractRelease(filename string) string {
 	return ""
 }
 
-// parseRepositoryHref extracts the repository UUID from a repository href
-// Example: /api/pulp/default/api/v3/repositories/maven/maven/018c1c95-4281-76eb-b277-842cbad524f4/
-func parseRepositoryHref(href string) (string, error) {
-	parts := strings.Split(href, "/")
-	// Filter out empty parts
-	var nonEmptyParts []string
-	for _, part := range parts {
-		if part != "" {
-			nonEmptyParts = append(nonEmptyParts, part)
-		}
-	}
+// MavenVersionsList lists all Maven artifacts (builds), optionally filtered by group_id, artifact_id, and version
+// from a repository version, or the latest version of a repository
+func (t *tangyImpl) MavenVersionsList(ctx context.Context, repositoryHref, groupID, artifactID, version string, pageOpts PageOptions) (_ MavenVersionsResponse, err error) {
+	ctx, done := t.call(ctx, "MavenVersionsList", arg("group_id", groupID), arg("artifact_id", artifactID), arg("version", version), pageOpts)
+	defer done(&err)
 
-	// Expected format: api/pulp/{domain}/api/v3/repositories/maven/maven/{uuid}
-	// So we need at least 8 parts
-	if len(nonEmptyParts) < 8 {
-		return "", fmt.Errorf("invalid repository href format: %s", href)
+	if repositoryHref == "" {
+		return MavenVersionsResponse{}, nil
 	}
-
-	// The UUID should be the last part (or second to last if there's a trailing slash)
-	repoUUID := nonEmptyParts[len(nonEmptyParts)-1]
-
-	return repoUUID, nil
+	return t.MavenRepositoryVersionVersionsList(ctx, []string{repositoryHref}, groupID, artifactID, version, pageOpts)
 }
 
-// getLatestRepositoryVersion gets the highest version number for a repository
-func getLatestRepositoryVersion(ctx context.Context, conn *pgxpool.Conn, repoUUID string) (int, error) {
-	query := `
-		SELECT MAX(number)
-		FROM core_repositoryversion
-		WHERE repository_id = $1 AND complete = true
-	`
-
-	var latestVersion int
-	err := conn.QueryRow(ctx, query, repoUUID).Scan(&latestVersion)
-	if err != nil {
-		return 0, fmt.Errorf("failed to get latest version for repository %s: %w", repoUUID, err)
-	}
+// MavenRepositoryVersionVersionsList lists all Maven artifacts (builds) merged across several repository versions,
+// or the latest versions of several repositories, optionally filtered by group_id, artifact_id, and version.
+// Builds found in several repositories are listed once, and each version reports the repositories that contain it.
+func (t *tangyImpl) MavenRepositoryVersionVersionsList(ctx context.Context, hrefs []string, groupID, artifactID, version string, pageOpts PageOptions) (_ MavenVersionsResponse, err error) {
+	ctx, done := t.call(ctx, "MavenRepositoryVersionVersionsList", arg("group_id", groupID), arg("artifact_id", artifactID), arg("version", version), pageOpts)
+	defer done(&err)
 
-	return latestVersion, nil
-}
-
-// MavenVersionsList lists all Maven artifacts (builds), optionally filtered by group_id, artifact_id, and version
-// from the latest version of a repository
-func (t *tangyImpl) MavenVersionsList(ctx context.Context, repositoryHref, groupID, artifactID, version string, pageOpts PageOptions) (MavenVersionsResponse, error) {
-	if repositoryHref == "" {
+	if len(hrefs) == 0 {
 		return MavenVersionsResponse{}, nil
 	}
 
-	conn, err := t.pool.Acquire(ctx)
+	tx, end, err := t.begin(ctx)
 	if err != nil {
 		return MavenVersionsResponse{}, err
 	}
-	defer conn.Release()
+	defer end()
 
 	if pageOpts.Limit == 0 {
 		pageOpts.Limit = DefaultLimit
 	}
 
-	// Parse repository UUID from href
-	repoUUID, err := parseRepositoryHref(repositoryHref)
+	cursor, err := decodeCursor(pageOpts.Cursor, cursorKindMavenVersionsList, len(mavenVersionsListSort.Columns))
 	if err != nil {
-		return MavenVersionsResponse{}, fmt.Errorf("error parsing repository href: %w", err)
+		return MavenVersionsResponse{}, err
 	}
 
-	// Get the latest version for this repository
-	latestVersion, err := getLatestRepositoryVersion(ctx, conn, repoUUID)
+	// Resolve the repository versions, unless the cursor pins the versions of a previous page
+	repoVerMap, repositories, err := resolveRepositoryHrefs(ctx, tx, hrefs, cursor)
 	if err != nil {
-		return MavenVersionsResponse{}, fmt.Errorf("error getting latest repository version: %w", err)
+		return MavenVersionsResponse{}, err
 	}
 
-	repoVerMap := []ParsedRepoVersion{{
-		RepositoryUUID: repoUUID,
-		Version:        latestVersion,
-	}}
-
-	args := pgx.NamedArgs{}
-
-	// Build WHERE clause conditionally based on provided parameters
-	var whereClause string
-	if groupID != "" {
-		args["group_id"] = groupID
-		whereClause += "\n\t\tAND rp.group_id = @group_id"
+	m, err := t.contentMembership(ctx, tx, repoVerMap)
+	if err != nil {
+		return MavenVersionsResponse{}, err
 	}
-	if artifactID != "" {
-		args["artifact_id"] = artifactID
-		whereClause += "\n\t\tAND rp.artifact_id = @artifact_id"
+
+	// Count query for total distinct versions
+	total, totalMode, err := listTotal(ctx, tx, pageOpts.Count, func(limit int) sqlQuery {
+		return mavenVersionsListCountQuery(m, groupID, artifactID, version, limit)
+	})
+	if err != nil {
+		return MavenVersionsResponse{}, err
 	}
-	if version != "" {
-		args["version"] = version
-		whereClause += "\n\t\tAND regexp_replace(rp.version, '\\." + mavenReleaseQualifierPattern + "$', '') = @version"
+
+	query := mavenVersionsListQuery(m, groupID, artifactID, version, pageOpts, cursor)
+	rows, err := tx.Query(ctx, query.SQL, query.Args)
+	if err != nil {
+		return MavenVersionsResponse{}, err
 	}
 
-	innerUnion, err := contentIdsInVersions(ctx, conn, repoVerMap, &args)
+	queryResults, err := collectRows(ctx, rows, pgx.RowToStructByName[mavenVersionsRow])
 	if err != nil {
 		return MavenVersionsResponse{}, err
 	}
 
-	pomFilter := `
-		AND rp.filename LIKE '%.pom'`
+	results := make([]MavenVersionsItem, 0, len(queryResults))
+	for _, qr := range queryResults {
+		item, err := mavenVersionsItemFromRow(qr, repositories)
+		if err != nil {
+			return MavenVersionsResponse{}, err
+		}
+		results = append(results, item)
+	}
 
-	// Count query for total distinct versions
-	countQuery := `
-		SELECT COUNT(DISTINCT (rp.group_id, rp.artifact_id, regexp_replace(rp.version, '\.` + mavenReleaseQualifierPattern + `$', '')))
-		FROM maven_mavenartifact rp
-	` + innerUnion + whereClause + pomFilter
+	response := MavenVersionsResponse{
+		Results:   results,
+		Total:     total,
+		TotalMode: totalMode,
+		Limit:     pageOpts.Limit,
+		Offset:    pageOpts.Offset,
+	}
+	if len(queryResults) > 0 {
+		last := queryResults[len(queryResults)-1]
+		response.NextCursor = nextCursor(cursorKindMavenVersionsList, len(queryResults), pageOpts.Limit, repoVerMap, formatCursorTime(last.LatestCreatedAt), last.GroupID, last.ArtifactID, last.Version)
+	}
+	return response, nil
+}
 
-	var countTotal int
-	err = conn.QueryRow(ctx, countQuery, args).Scan(&countTotal)
-	if err != nil {
-		return MavenVersionsResponse{}, err
+// mavenVersionsRow is a row of mavenVersionsListQuery
+type mavenVersionsRow struct {
+	GroupID         string
+	ArtifactID      string
+	Version         string
+	LatestCreatedAt time.Time
+	RepositoryIds   []string
+	BuildsJSON      []byte
+}
+
+func mavenVersionsItemFromRow(qr mavenVersionsRow, repositories repositoryHrefMap) (MavenVersionsItem, error) {
+	var builds []struct {
+		Version   string    `json:"version"`
+		Filename  string    `json:"filename"`
+		CreatedAt time.Time `json:"created_at"`
 	}
 
-	args["limit"] = pageOpts.Limit
-	args["offset"] = pageOpts.Offset
+	if err := json.Unmarshal(qr.BuildsJSON, &builds); err != nil {
+		return MavenVersionsItem{}, fmt.Errorf("failed to parse builds: %w", err)
+	}
+
+	buildInfos := make([]MavenBuildInfo, 0, len(builds))
+	for _, b := range builds {
+		buildInfos = append(buildInfos, MavenBuildInfo{
+			Version:   b.Version,
+			Release:   extractRelease(b.Filename),
+			Filename:  b.Filename,
+			CreatedAt: b.CreatedAt.Format(time.RFC3339),
+		})
+	}
+
+	return MavenVersionsItem{
+		GroupID:      qr.GroupID,
+		ArtifactID:   qr.ArtifactID,
+		Version:      qr.Version,
+		Builds:       buildInfos,
+		Repositories: repositories.hrefs(qr.RepositoryIds),
+	}, nil
+}
 
-	query := `
+func mavenVersionsListCountQuery(m membership, groupID, artifactID, version string, countLimit int) sqlQuery {
+	b := newQueryBuilder()
+	return b.query(b.count(`
+		SELECT DISTINCT rp.group_id, rp.artifact_id, regexp_replace(rp.version, '`+mavenReleaseVersionSuffixPattern+`', '')
+		FROM maven_mavenartifact rp`+b.membershipJoin(m)+mavenVersionsListFilters(b, groupID, artifactID, version).and(), countLimit))
+}
+
+func mavenVersionsListQuery(m membership, groupID, artifactID, version string, pageOpts PageOptions, cursor pageCursor) sqlQuery {
+	b := newQueryBuilder()
+	join := b.membershipJoin(m)
+	where := mavenVersionsListFilters(b, groupID, artifactID, version)
+	return b.query(`
 		WITH version_builds AS (
 			SELECT
+				rp.content_ptr_id,
+				crv.repository_id,
 				rp.group_id,
 				rp.artifact_id,
-				regexp_replace(rp.version, '\.` + mavenReleaseQualifierPattern + `$', '') as base_version,
+				regexp_replace(rp.version, '` + mavenReleaseVersionSuffixPattern + `', '') as base_version,
 				rp.filename,
 				cc.pulp_created as created_at
 			FROM maven_mavenartifact rp
-			INNER JOIN core_content cc ON rp.content_ptr_id = cc.pulp_id
-		` + innerUnion + whereClause + pomFilter + `
+			INNER JOIN core_content cc ON rp.content_ptr_id = cc.pulp_id` + join + where.and() + `
 		),
 		distinct_versions AS (
 			SELECT
 				group_id,
 				artifact_id,
 				base_version,
-				MAX(created_at) as latest_created_at
+				MAX(created_at) as latest_created_at,
+				ARRAY_AGG(DISTINCT repository_id) as repository_ids
+			FROM version_builds
+			GROUP BY group_id, artifact_id, base_version` + b.pageAfter(mavenVersionsListSort, cursor).clause("HAVING") + `
+			` + orderBy(mavenVersionsListSort) + `
+			` + b.page(pageOpts.Limit, pageOpts.Offset, cursor) + `
+		),
+		builds AS (
+			SELECT DISTINCT content_ptr_id, group_id, artifact_id, base_version, filename, created_at
 			FROM version_builds
-			GROUP BY group_id, artifact_id, base_version
-			ORDER BY latest_created_at DESC
-			LIMIT @limit OFFSET @offset
 		)
 		SELECT
 			dv.group_id,
 			dv.artifact_id,
 			dv.base_version as version,
+			dv.latest_created_at,
+			dv.repository_ids,
 			COALESCE(
 				JSON_AGG(
 					JSON_BUILD_OBJECT(
@@ -419,123 +499,92 @@ func (t *tangyImpl) MavenVersionsList(ctx context.Context, repositoryHref, group
 				'[]'::json
 			) as builds_json
 		FROM distinct_versions dv
-		INNER JOIN version_builds vb ON dv.group_id = vb.group_id AND dv.artifact_id = vb.artifact_id AND dv.base_version = vb.base_version
-		GROUP BY dv.group_id, dv.artifact_id, dv.base_version, dv.latest_created_at
-		ORDER BY dv.latest_created_at DESC`
-
-	rows, err := conn.Query(ctx, query, args)
-	if err != nil {
-		return MavenVersionsResponse{}, err
-	}
+		INNER JOIN builds vb ON dv.group_id = vb.group_id AND dv.artifact_id = vb.artifact_id AND dv.base_version = vb.base_version
+		GROUP BY dv.group_id, dv.artifact_id, dv.base_version, dv.latest_created_at, dv.repository_ids
+		ORDER BY dv.latest_created_at DESC, dv.group_id DESC, dv.artifact_id DESC, dv.base_version DESC`)
+}
 
-	type buildQueryResult struct {
-		GroupID    string
-		ArtifactID string
-		Version    string
-		BuildsJSON []byte
+// mavenVersionsListFilters only selects .pom artifacts, optionally of a group id, artifact id and base version
+func mavenVersionsListFilters(b *queryBuilder, groupID, artifactID, version string) conditions {
+	var where conditions
+	if groupID != "" {
+		where = append(where, "rp.group_id = "+b.bind("group_id", groupID))
 	}
-
-	queryResults, err := pgx.CollectRows(rows, pgx.RowToStructByName[buildQueryResult])
-	if err != nil {
-		return MavenVersionsResponse{}, err
+	if artifactID != "" {
+		where = append(where, "rp.artifact_id = "+b.bind("artifact_id", artifactID))
 	}
-
-	results := make([]MavenVersionsItem, 0, len(queryResults))
-	for _, qr := range queryResults {
-		var builds []struct {
-			Version   string    `json:"version"`
-			Filename  string    `json:"filename"`
-			CreatedAt time.Time `json:"created_at"`
-		}
-
-		if err := json.Unmarshal(qr.BuildsJSON, &builds); err != nil {
-			return MavenVersionsResponse{}, fmt.Errorf("failed to parse builds: %w", err)
-		}
-
-		buildInfos := make([]MavenBuildInfo, 0, len(builds))
-		for _, b := range builds {
-			buildInfos = append(buildInfos, MavenBuildInfo{
-				Version:   b.Version,
-				Release:   extractRelease(b.Filename),
-				Filename:  b.Filename,
-				CreatedAt: b.CreatedAt.Format(time.RFC3339),
-			})
-		}
-
-		results = append(results, MavenVersionsItem{
-			GroupID:    qr.GroupID,
-			ArtifactID: qr.ArtifactID,
-			Version:    qr.Version,
-			Builds:     buildInfos,
-		})
+	if version != "" {
+		where = append(where, "regexp_replace(rp.version, '"+mavenReleaseVersionSuffixPattern+"', '') = "+b.bind("version", version))
 	}
-
-	return MavenVersionsResponse{
-		Results: results,
-		Total:   countTotal,
-		Limit:   pageOpts.Limit,
-		Offset:  pageOpts.Offset,
-	}, nil
+	return append(where, "rp.filename LIKE '%.pom'")
 }
 
-// MavenRepositoryMetrics returns package, build, and version counts for the latest version of a repository.
+// MavenRepositoryMetrics returns package, build, and version counts for a repository version, or the latest version of a repository.
 // All counts are based on .jar artifacts. Builds are distinct full versions (e.g. 5.3.18.rhlw-00003);
 // versions are distinct base versions with release qualifiers stripped (e.g. 5.3.18).
-func (t *tangyImpl) MavenRepositoryMetrics(ctx context.Context, repositoryHref string) (MavenRepositoryMetrics, error) {
+func (t *tangyImpl) MavenRepositoryMetrics(ctx context.Context, repositoryHref string) (_ MavenRepositoryMetrics, err error) {
+	ctx, done := t.call(ctx, "MavenRepositoryMetrics")
+	defer done(&err)
+
 	if repositoryHref == "" {
 		return MavenRepositoryMetrics{}, nil
 	}
+	return t.MavenRepositoryVersionMetrics(ctx, []string{repositoryHref})
+}
 
-	conn, err := t.pool.Acquire(ctx)
+// MavenRepositoryVersionMetrics returns package, build, and version counts for the union of several repository
+// versions, or the latest versions of several repositories. Artifacts found in several repositories are counted once.
+func (t *tangyImpl) MavenRepositoryVersionMetrics(ctx context.Context, hrefs []string) (_ MavenRepositoryMetrics, err error) {
+	ctx, done := t.call(ctx, "MavenRepositoryVersionMetrics")
+	defer done(&err)
+
+	if len(hrefs) == 0 {
+		return MavenRepositoryMetrics{}, nil
+	}
+
+	tx, end, err := t.begin(ctx)
 	if err != nil {
 		return MavenRepositoryMetrics{}, err
 	}
-	defer conn.Release()
+	defer end()
 
-	repoUUID, err := parseRepositoryHref(repositoryHref)
+	repoVerMap, _, err := resolveRepositoryHrefs(ctx, tx, hrefs, pageCursor{})
 	if err != nil {
-		return MavenRepositoryMetrics{}, fmt.Errorf("error parsing repository href: %w", err)
+		return MavenRepositoryMetrics{}, err
 	}
 
-	latestVersion, err := getLatestRepositoryVersion(ctx, conn, repoUUID)
+	m, err := t.contentMembership(ctx, tx, repoVerMap)
 	if err != nil {
-		return MavenRepositoryMetrics{}, fmt.Errorf("error getting latest repository version: %w", err)
+		return MavenRepositoryMetrics{}, err
 	}
 
-	repoVerMap := []ParsedRepoVersion{{
-		RepositoryUUID: repoUUID,
-		Version:        latestVersion,
-	}}
-
-	args := pgx.NamedArgs{}
-	innerUnion, err := contentIdsInVersions(ctx, conn, repoVerMap, &args)
+	metricsQuery := mavenRepositoryMetricsQuery(m)
+	var metrics MavenRepositoryMetrics
+	err = tx.QueryRow(ctx, metricsQuery.SQL, metricsQuery.Args).Scan(&metrics.PackageCount, &metrics.BuildCount, &metrics.VersionCount)
 	if err != nil {
 		return MavenRepositoryMetrics{}, err
 	}
 
-	jarFilter := ` AND rp.filename LIKE '%.jar'`
-	jarFrom := `
-		FROM maven_mavenartifact rp
-	` + innerUnion + jarFilter
+	return metrics, nil
+}
 
-	metricsQuery := `
+// mavenRepositoryMetricsQuery counts packages, builds and versions of .jar artifacts.
+// Each subquery joins the membership on its own, and numbers its membership arguments accordingly.
+func mavenRepositoryMetricsQuery(m membership) sqlQuery {
+	b := newQueryBuilder()
+	jarFilter := conditions{"rp.filename LIKE '%.jar'"}
+	jarFrom := func() string {
+		return `
+			FROM maven_mavenartifact rp` + b.membershipJoin(m) + jarFilter.and()
+	}
+
+	return b.query(`
 		SELECT
-			(SELECT COUNT(DISTINCT (rp.group_id, rp.artifact_id))
-			` + jarFrom + `) AS package_count,
-			(SELECT COUNT(DISTINCT (rp.group_id, rp.artifact_id, rp.version))
-			` + jarFrom + `) AS build_count,
+			(SELECT COUNT(DISTINCT (rp.group_id, rp.artifact_id))` + jarFrom() + `) AS package_count,
+			(SELECT COUNT(DISTINCT (rp.group_id, rp.artifact_id, rp.version))` + jarFrom() + `) AS build_count,
 			(SELECT COUNT(DISTINCT (
 				rp.group_id,
 				rp.artifact_id,
 				regexp_replace(rp.version, '` + mavenReleaseVersionSuffixPattern + `', '')
-			))
-			` + jarFrom + `) AS version_count`
-
-	var metrics MavenRepositoryMetrics
-	err = conn.QueryRow(ctx, metricsQuery, args).Scan(&metrics.PackageCount, &metrics.BuildCount, &metrics.VersionCount)
-	if err != nil {
-		return MavenRepositoryMetrics{}, err
-	}
-
-	return metrics, nil
+			))` + jarFrom() + `) AS version_count`)
 }
@@ -8,48 +8,6 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-func TestParseRepositoryHref(t *testing.T) {
-	t.Parallel()
-
-	tests := []struct {
-		name    string
-		href    string
-		want    string
-		wantErr bool
-	}{
-		{
-			name: "valid maven repository href",
-			href: "/api/pulp/default/api/v3/repositories/maven/maven/018c1c95-4281-76eb-b277-842cbad524f4/",
-			want: "018c1c95-4281-76eb-b277-842cbad524f4",
-		},
-		{
-			name: "valid href without trailing slash",
-			href: "/api/pulp/default/api/v3/repositories/maven/maven/018c1c95-4281-76eb-b277-842cbad524f4",
-			want: "018c1c95-4281-76eb-b277-842cbad524f4",
-		},
-		{
-			name:    "invalid href",
-			href:    "/api/pulp/default/api/v3/repositories/maven/",
-			wantErr: true,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			t.Parallel()
-
-			got, err := parseRepositoryHref(tt.href)
-			if tt.wantErr {
-				require.Error(t, err)
-				return
-			}
-
-			require.NoError(t, err)
-			assert.Equal(t, tt.want, got)
-		})
-	}
-}
-
 func TestStripMavenReleaseVersion(t *testing.T) {
 	t.Parallel()
 
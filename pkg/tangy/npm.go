@@ -6,11 +6,11 @@ import (
 	"errors"
 	"fmt"
 	"path"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type NpmVersionInfo struct {
@@ -22,13 +22,16 @@ type NpmPackageListItem struct {
 	Name           string           `json:"name"`
 	Versions       []string         `json:"versions"`
 	LatestVersions []NpmVersionInfo `json:"latest_versions"`
+	Repositories   []string         `json:"repositories,omitempty"`
 }
 
 type NpmPackageListResponse struct {
-	Results []NpmPackageListItem `json:"results"`
-	Total   int                  `json:"total"`
-	Limit   int                  `json:"limit"`
-	Offset  int                  `json:"offset"`
+	Results    []NpmPackageListItem `json:"results"`
+	Total      int                  `json:"total"`
+	TotalMode  CountMode            `json:"total_mode"`
+	Limit      int                  `json:"limit"`
+	Offset     int                  `json:"offset"`
+	NextCursor string               `json:"next_cursor,omitempty"`
 }
 
 type NpmPackageListFilters struct {
@@ -36,16 +39,19 @@ type NpmPackageListFilters struct {
 }
 
 type NpmBuildListItem struct {
-	Name      string `json:"name"`
-	Version   string `json:"version"`
-	CreatedAt string `json:"created_at"`
+	Name         string   `json:"name"`
+	Version      string   `json:"version"`
+	CreatedAt    string   `json:"created_at"`
+	Repositories []string `json:"repositories,omitempty"`
 }
 
 type NpmBuildListResponse struct {
-	Results []NpmBuildListItem `json:"results"`
-	Total   int                `json:"total"`
-	Limit   int                `json:"limit"`
-	Offset  int                `json:"offset"`
+	Results    []NpmBuildListItem `json:"results"`
+	Total      int                `json:"total"`
+	TotalMode  CountMode          `json:"total_mode"`
+	Limit      int                `json:"limit"`
+	Offset     int                `json:"offset"`
+	NextCursor string             `json:"next_cursor,omitempty"`
 }
 
 type NpmTarballInfo struct {
@@ -62,14 +68,16 @@ type NpmPackageDetail struct {
 	Tarball        NpmTarballInfo   `json:"tarball"`
 	Versions       []string         `json:"versions"`
 	LatestVersions []NpmVersionInfo `json:"latest_versions"`
+	Repositories   []string         `json:"repositories,omitempty"`
 }
 
 var ErrNpmPackageNotFound = errors.New("npm package not found")
 
 type npmPackageVersionRow struct {
-	Name      string
-	Version   string
-	CreatedAt time.Time
+	Name          string
+	Version       string
+	CreatedAt     time.Time
+	RepositoryIds []string
 }
 
 type npmPackageDetailRow struct {
@@ -81,121 +89,167 @@ type npmPackageDetailRow struct {
 	Size               *int64
 	Versions           []string
 	LatestVersionsJSON []byte
+	RepositoryIds      []string
 }
 
-// NpmPackageList lists npm packages from the latest version of a repository,
+// NpmPackageList lists npm packages from a repository version, or the latest version of a repository,
 // grouped by name with SQL-level pagination.
-func (t *tangyImpl) NpmPackageList(ctx context.Context, repositoryHref string, filterOpts NpmPackageListFilters, pageOpts PageOptions) (NpmPackageListResponse, error) {
+func (t *tangyImpl) NpmPackageList(ctx context.Context, repositoryHref string, filterOpts NpmPackageListFilters, pageOpts PageOptions) (_ NpmPackageListResponse, err error) {
+	ctx, done := t.call(ctx, "NpmPackageList", filterOpts, pageOpts)
+	defer done(&err)
+
 	if repositoryHref == "" {
 		return NpmPackageListResponse{}, nil
 	}
+	return t.NpmRepositoryVersionPackageList(ctx, []string{repositoryHref}, filterOpts, pageOpts)
+}
 
-	conn, err := t.pool.Acquire(ctx)
+// NpmRepositoryVersionPackageList lists npm packages merged across several repository versions, or the latest
+// versions of several repositories, grouped by name with SQL-level pagination.
+// Each package reports the repositories that contain it.
+func (t *tangyImpl) NpmRepositoryVersionPackageList(ctx context.Context, hrefs []string, filterOpts NpmPackageListFilters, pageOpts PageOptions) (_ NpmPackageListResponse, err error) {
+	ctx, done := t.call(ctx, "NpmRepositoryVersionPackageList", filterOpts, pageOpts)
+	defer done(&err)
+
+	if len(hrefs) == 0 {
+		return NpmPackageListResponse{}, nil
+	}
+
+	tx, end, err := t.begin(ctx)
 	if err != nil {
 		return NpmPackageListResponse{}, err
 	}
-	defer conn.Release()
+	defer end()
 
 	if pageOpts.Limit == 0 {
 		pageOpts.Limit = DefaultLimit
 	}
 
-	repoUUID, err := parseNpmRepositoryHref(repositoryHref)
+	cursor, err := decodeCursor(pageOpts.Cursor, cursorKindNpmPackageList, len(npmPackageListSort.Columns))
 	if err != nil {
-		return NpmPackageListResponse{}, fmt.Errorf("error parsing repository href: %w", err)
+		return NpmPackageListResponse{}, err
 	}
 
-	latestVersion, err := getLatestRepositoryVersion(ctx, conn, repoUUID)
+	repoVerMap, repositories, err := resolveRepositoryHrefs(ctx, tx, hrefs, cursor)
 	if err != nil {
-		return NpmPackageListResponse{}, fmt.Errorf("error getting latest repository version: %w", err)
+		return NpmPackageListResponse{}, err
 	}
 
-	repoVerMap := []ParsedRepoVersion{{
-		RepositoryUUID: repoUUID,
-		Version:        latestVersion,
-	}}
-
-	args := pgx.NamedArgs{
-		"limit":  pageOpts.Limit,
-		"offset": pageOpts.Offset,
-	}
-	searchFilter := ""
-	if filterOpts.Search != "" {
-		args["searchFilter"] = filterOpts.Search
-		searchFilter = npmPackageListSearchFilter()
+	m, err := t.contentMembership(ctx, tx, repoVerMap)
+	if err != nil {
+		return NpmPackageListResponse{}, err
 	}
-	innerUnion, err := contentIdsInVersions(ctx, conn, repoVerMap, &args)
+
+	total, totalMode, err := listTotal(ctx, tx, pageOpts.Count, func(limit int) sqlQuery {
+		return npmPackageListCountQuery(m, filterOpts, limit)
+	})
 	if err != nil {
 		return NpmPackageListResponse{}, err
 	}
 
-	countQuery := `
-		SELECT COUNT(DISTINCT rp.name)
-		FROM npm_package rp
-	` + innerUnion + searchFilter
+	query := npmPackageListQuery(m, filterOpts, pageOpts, cursor)
+	rows, err := tx.Query(ctx, query.SQL, query.Args)
+	if err != nil {
+		return NpmPackageListResponse{}, err
+	}
 
-	var countTotal int
-	err = conn.QueryRow(ctx, countQuery, args).Scan(&countTotal)
+	versionRows, err := collectRows(ctx, rows, pgx.RowToStructByName[npmPackageVersionRow])
 	if err != nil {
 		return NpmPackageListResponse{}, err
 	}
 
-	query := `
+	response := NpmPackageListResponse{
+		Results:   assembleNpmPackageListFromRows(versionRows, repositories),
+		Total:     total,
+		TotalMode: totalMode,
+		Limit:     pageOpts.Limit,
+		Offset:    pageOpts.Offset,
+	}
+	if len(response.Results) > 0 {
+		last := response.Results[len(response.Results)-1]
+		response.NextCursor = nextCursor(cursorKindNpmPackageList, len(response.Results), pageOpts.Limit, repoVerMap, last.Name)
+	}
+	return response, nil
+}
+
+func npmPackageListCountQuery(m membership, filterOpts NpmPackageListFilters, countLimit int) sqlQuery {
+	b := newQueryBuilder()
+	return b.query(b.count(`
+		SELECT DISTINCT rp.name
+		FROM npm_package rp`+b.membershipJoin(m)+npmPackageListFilters(b, filterOpts).and(), countLimit))
+}
+
+func npmPackageListQuery(m membership, filterOpts NpmPackageListFilters, pageOpts PageOptions, cursor pageCursor) sqlQuery {
+	b := newQueryBuilder()
+	join := b.membershipJoin(m)
+	where := npmPackageListFilters(b, filterOpts)
+	return b.query(`
 		WITH filtered AS (
-			SELECT rp.name, rp.version, cc.pulp_created
+			SELECT rp.name, rp.version, cc.pulp_created, crv.repository_id
 			FROM npm_package rp
-			INNER JOIN core_content cc ON rp.content_ptr_id = cc.pulp_id
-	` + innerUnion + searchFilter + `
+			INNER JOIN core_content cc ON rp.content_ptr_id = cc.pulp_id` + join + where.and() + `
 		),
 		package_versions AS (
-			SELECT name, version, MAX(pulp_created) AS created_at
+			SELECT name, version, MAX(pulp_created) AS created_at,
+			       ARRAY_AGG(DISTINCT repository_id) AS repository_ids
 			FROM filtered
 			GROUP BY name, version
 		),
 		paginated_packages AS (
 			SELECT name
-			FROM package_versions
+			FROM package_versions` + b.pageAfter(npmPackageListSort, cursor).clause("WHERE") + `
 			GROUP BY name
-			ORDER BY name
-			LIMIT @limit OFFSET @offset
+			` + orderBy(npmPackageListSort) + `
+			` + b.page(pageOpts.Limit, pageOpts.Offset, cursor) + `
 		)
-		SELECT pv.name, pv.version, pv.created_at
+		SELECT pv.name, pv.version, pv.created_at, pv.repository_ids
 		FROM package_versions pv
 		INNER JOIN paginated_packages pp ON pv.name = pp.name
-		ORDER BY pv.name, pv.version`
+		ORDER BY pv.name, pv.version`)
+}
 
-	rows, err := conn.Query(ctx, query, args)
-	if err != nil {
-		return NpmPackageListResponse{}, err
-	}
+// npmPackageListSort is the sort key of NpmPackageList, applied to the package_versions CTE
+var npmPackageListSort = sortOrder{Columns: []keysetColumn{
+	{Expr: "name", Type: "text"},
+}}
 
-	versionRows, err := pgx.CollectRows(rows, pgx.RowToStructByName[npmPackageVersionRow])
-	if err != nil {
-		return NpmPackageListResponse{}, err
-	}
-
-	return NpmPackageListResponse{
-		Results: assembleNpmPackageListFromRows(versionRows),
-		Total:   countTotal,
-		Limit:   pageOpts.Limit,
-		Offset:  pageOpts.Offset,
-	}, nil
-}
+// npmBuildListSort is the sort key of NpmBuildList, most recently created builds first
+var npmBuildListSort = sortOrder{Columns: []keysetColumn{
+	{Expr: "MAX(cc.pulp_created)", Type: "timestamptz"},
+	{Expr: "rp.name", Type: "text"},
+	{Expr: "rp.version", Type: "text"},
+}, Desc: true}
 
 // NpmPackageGet returns tarball info and timestamps for a specific package name and version
-// from the latest version of a repository, plus all other versions available in that repository.
-func (t *tangyImpl) NpmPackageGet(ctx context.Context, repositoryHref, name, version string) (NpmPackageDetail, error) {
+// from a repository version, or the latest version of a repository, plus all other versions available in that repository.
+func (t *tangyImpl) NpmPackageGet(ctx context.Context, repositoryHref, name, version string) (_ NpmPackageDetail, err error) {
+	ctx, done := t.call(ctx, "NpmPackageGet", arg("name", name), arg("version", version))
+	defer done(&err)
+
 	if repositoryHref == "" {
 		return NpmPackageDetail{}, nil
 	}
+	return t.NpmRepositoryVersionPackageGet(ctx, []string{repositoryHref}, name, version)
+}
+
+// NpmRepositoryVersionPackageGet returns tarball info and timestamps for a specific package name and version
+// merged across several repository versions, or the latest versions of several repositories,
+// plus all other versions available in any of them.
+func (t *tangyImpl) NpmRepositoryVersionPackageGet(ctx context.Context, hrefs []string, name, version string) (_ NpmPackageDetail, err error) {
+	ctx, done := t.call(ctx, "NpmRepositoryVersionPackageGet", arg("name", name), arg("version", version))
+	defer done(&err)
 
-	conn, innerUnion, args, err := t.prepareNpmPackageQuery(ctx, repositoryHref, name)
+	if len(hrefs) == 0 {
+		return NpmPackageDetail{}, nil
+	}
+
+	tx, end, m, repositories, err := t.prepareNpmPackageQuery(ctx, hrefs)
 	if err != nil {
 		return NpmPackageDetail{}, err
 	}
-	defer conn.Release()
+	defer end()
 
-	detailRows, err := fetchNpmPackageDetailRows(ctx, conn, innerUnion, args, version)
+	detailRows, err := fetchNpmPackageDetailRows(ctx, tx, npmPackageDetailQuery(m, name, version))
 	if err != nil {
 		return NpmPackageDetail{}, err
 	}
@@ -209,23 +263,39 @@ func (t *tangyImpl) NpmPackageGet(ctx context.Context, repositoryHref, name, ver
 		return NpmPackageDetail{}, err
 	}
 
-	return npmPackageDetailFromRow(row, latestVersions), nil
+	return npmPackageDetailFromRow(row, latestVersions, repositories), nil
 }
 
 // NpmPackageVersionsGet returns tarball info for every version of a package name
-// from the latest version of a repository.
-func (t *tangyImpl) NpmPackageVersionsGet(ctx context.Context, repositoryHref, name string) ([]NpmPackageDetail, error) {
+// from a repository version, or the latest version of a repository.
+func (t *tangyImpl) NpmPackageVersionsGet(ctx context.Context, repositoryHref, name string) (_ []NpmPackageDetail, err error) {
+	ctx, done := t.call(ctx, "NpmPackageVersionsGet", arg("name", name))
+	defer done(&err)
+
 	if repositoryHref == "" {
 		return nil, nil
 	}
+	return t.NpmRepositoryVersionPackageVersionsGet(ctx, []string{repositoryHref}, name)
+}
+
+// NpmRepositoryVersionPackageVersionsGet returns tarball info for every version of a package name merged across
+// several repository versions, or the latest versions of several repositories. Versions found in several repositories
+// are returned once and report the repositories that contain them.
+func (t *tangyImpl) NpmRepositoryVersionPackageVersionsGet(ctx context.Context, hrefs []string, name string) (_ []NpmPackageDetail, err error) {
+	ctx, done := t.call(ctx, "NpmRepositoryVersionPackageVersionsGet", arg("name", name))
+	defer done(&err)
+
+	if len(hrefs) == 0 {
+		return nil, nil
+	}
 
-	conn, innerUnion, args, err := t.prepareNpmPackageQuery(ctx, repositoryHref, name)
+	tx, end, m, repositories, err := t.prepareNpmPackageQuery(ctx, hrefs)
 	if err != nil {
 		return nil, err
 	}
-	defer conn.Release()
+	defer end()
 
-	detailRows, err := fetchNpmPackageDetailRows(ctx, conn, innerUnion, args, "")
+	detailRows, err := fetchNpmPackageDetailRows(ctx, tx, npmPackageDetailQuery(m, name, ""))
 	if err != nil {
 		return nil, err
 	}
@@ -240,96 +310,74 @@ func (t *tangyImpl) NpmPackageVersionsGet(ctx context.Context, repositoryHref, n
 
 	results := make([]NpmPackageDetail, len(detailRows))
 	for i, row := range detailRows {
-		results[i] = npmPackageDetailFromRow(row, latestVersions)
+		results[i] = npmPackageDetailFromRow(row, latestVersions, repositories)
 	}
 
 	return results, nil
 }
 
 // NpmBuildList lists all npm package builds (name + version pairs), optionally filtered by name
-// and version, from the latest version of a repository.
-func (t *tangyImpl) NpmBuildList(ctx context.Context, repositoryHref, name, version string, pageOpts PageOptions) (NpmBuildListResponse, error) {
+// and version, from a repository version, or the latest version of a repository.
+func (t *tangyImpl) NpmBuildList(ctx context.Context, repositoryHref, name, version string, pageOpts PageOptions) (_ NpmBuildListResponse, err error) {
+	ctx, done := t.call(ctx, "NpmBuildList", arg("name", name), arg("version", version), pageOpts)
+	defer done(&err)
+
 	if repositoryHref == "" {
 		return NpmBuildListResponse{}, nil
 	}
+	return t.NpmRepositoryVersionBuildList(ctx, []string{repositoryHref}, name, version, pageOpts)
+}
+
+// NpmRepositoryVersionBuildList lists all npm package builds (name + version pairs) merged across several repository
+// versions, or the latest versions of several repositories, optionally filtered by name and version.
+// Each build reports the repositories that contain it.
+func (t *tangyImpl) NpmRepositoryVersionBuildList(ctx context.Context, hrefs []string, name, version string, pageOpts PageOptions) (_ NpmBuildListResponse, err error) {
+	ctx, done := t.call(ctx, "NpmRepositoryVersionBuildList", arg("name", name), arg("version", version), pageOpts)
+	defer done(&err)
 
-	conn, err := t.pool.Acquire(ctx)
+	if len(hrefs) == 0 {
+		return NpmBuildListResponse{}, nil
+	}
+
+	tx, end, err := t.begin(ctx)
 	if err != nil {
 		return NpmBuildListResponse{}, err
 	}
-	defer conn.Release()
+	defer end()
 
 	if pageOpts.Limit == 0 {
 		pageOpts.Limit = DefaultLimit
 	}
 
-	repoUUID, err := parseNpmRepositoryHref(repositoryHref)
+	cursor, err := decodeCursor(pageOpts.Cursor, cursorKindNpmBuildList, len(npmBuildListSort.Columns))
 	if err != nil {
-		return NpmBuildListResponse{}, fmt.Errorf("error parsing repository href: %w", err)
+		return NpmBuildListResponse{}, err
 	}
 
-	latestVersion, err := getLatestRepositoryVersion(ctx, conn, repoUUID)
+	repoVerMap, repositories, err := resolveRepositoryHrefs(ctx, tx, hrefs, cursor)
 	if err != nil {
-		return NpmBuildListResponse{}, fmt.Errorf("error getting latest repository version: %w", err)
-	}
-
-	repoVerMap := []ParsedRepoVersion{{
-		RepositoryUUID: repoUUID,
-		Version:        latestVersion,
-	}}
-
-	args := pgx.NamedArgs{}
-
-	var whereClause string
-	if name != "" {
-		args["name"] = name
-		whereClause += "\n\t\tAND rp.name = @name"
-	}
-	if version != "" {
-		args["version"] = version
-		whereClause += "\n\t\tAND rp.version = @version"
+		return NpmBuildListResponse{}, err
 	}
 
-	innerUnion, err := contentIdsInVersions(ctx, conn, repoVerMap, &args)
+	m, err := t.contentMembership(ctx, tx, repoVerMap)
 	if err != nil {
 		return NpmBuildListResponse{}, err
 	}
 
-	buildFrom := `
-		FROM npm_package rp
-		INNER JOIN core_content cc ON rp.content_ptr_id = cc.pulp_id
-	` + innerUnion + whereClause
-
-	countQuery := `
-		SELECT COUNT(*)
-		FROM (
-			SELECT rp.name, rp.version
-		` + buildFrom + `
-			GROUP BY rp.name, rp.version
-		) builds`
-
-	var countTotal int
-	err = conn.QueryRow(ctx, countQuery, args).Scan(&countTotal)
+	total, totalMode, err := listTotal(ctx, tx, pageOpts.Count, func(limit int) sqlQuery {
+		return npmBuildListCountQuery(m, name, version, limit)
+	})
 	if err != nil {
 		return NpmBuildListResponse{}, err
 	}
 
-	args["limit"] = pageOpts.Limit
-	args["offset"] = pageOpts.Offset
-
-	query := `
-		SELECT rp.name, rp.version, MAX(cc.pulp_created) AS created_at
-	` + buildFrom + `
-		GROUP BY rp.name, rp.version
-		ORDER BY created_at DESC
-		LIMIT @limit OFFSET @offset`
-
-	rows, err := conn.Query(ctx, query, args)
+	query := npmBuildListQuery(m, name, version, pageOpts, cursor)
+	rows, err := tx.Query(ctx, query.SQL, query.Args)
 	if err != nil {
 		return NpmBuildListResponse{}, err
 	}
 
-	buildRows, err := pgx.CollectRows(rows, pgx.RowToStructByName[npmPackageVersionRow])
+	buildRows, err := collectRows(ctx, rows, pgx.RowToStructByName[npmPackageVersionRow])
 	if err != nil {
 		return NpmBuildListResponse{}, err
 	}
@@ -337,74 +385,111 @@ func (t *tangyImpl) NpmBuildList(ctx context.Context, repositoryHref, name, vers
 	results := make([]NpmBuildListItem, len(buildRows))
 	for i, row := range buildRows {
 		results[i] = NpmBuildListItem{
-			Name:      row.Name,
-			Version:   row.Version,
-			CreatedAt: row.CreatedAt.Format(time.RFC3339),
+			Name:         row.Name,
+			Version:      row.Version,
+			CreatedAt:    row.CreatedAt.Format(time.RFC3339),
+			Repositories: repositories.hrefs(row.RepositoryIds),
 		}
 	}
 
-	return NpmBuildListResponse{
-		Results: results,
-		Total:   countTotal,
-		Limit:   pageOpts.Limit,
-		Offset:  pageOpts.Offset,
-	}, nil
+	response := NpmBuildListResponse{
+		Results:   results,
+		Total:     total,
+		TotalMode: totalMode,
+		Limit:     pageOpts.Limit,
+		Offset:    pageOpts.Offset,
+	}
+	if len(buildRows) > 0 {
+		last := buildRows[len(buildRows)-1]
+		response.NextCursor = nextCursor(cursorKindNpmBuildList, len(buildRows), pageOpts.Limit, repoVerMap, formatCursorTime(last.CreatedAt), last.Name, last.Version)
+	}
+	return response, nil
+}
+
+func npmBuildListCountQuery(m membership, name, version string, countLimit int) sqlQuery {
+	b := newQueryBuilder()
+	return b.query(b.count(`
+		SELECT rp.name, rp.version
+		FROM npm_package rp`+b.membershipJoin(m)+npmBuildListFilters(b, name, version).and()+`
+		GROUP BY rp.name, rp.version`, countLimit))
+}
+
+func npmBuildListQuery(m membership, name, version string, pageOpts PageOptions, cursor pageCursor) sqlQuery {
+	b := newQueryBuilder()
+	join := b.membershipJoin(m)
+	where := npmBuildListFilters(b, name, version)
+	return b.query(`
+		SELECT rp.name, rp.version, MAX(cc.pulp_created) AS created_at,
+		       ARRAY_AGG(DISTINCT crv.repository_id) AS repository_ids
+		FROM npm_package rp
+		INNER JOIN core_content cc ON rp.content_ptr_id = cc.pulp_id` + join + where.and() + `
+		GROUP BY rp.name, rp.version` + b.pageAfter(npmBuildListSort, cursor).clause("HAVING") + `
+		` + orderBy(npmBuildListSort) + `
+		` + b.page(pageOpts.Limit, pageOpts.Offset, cursor))
 }
 
-func (t *tangyImpl) prepareNpmPackageQuery(ctx context.Context, repositoryHref, name string) (*pgxpool.Conn, string, pgx.NamedArgs, error) {
-	conn, err := t.pool.Acquire(ctx)
+func npmBuildListFilters(b *queryBuilder, name, version string) conditions {
+	var where conditions
+	if name != "" {
+		where = append(where, "rp.name = "+b.bind("name", name))
+	}
+	if version != "" {
+		where = append(where, "rp.version = "+b.bind("version", version))
+	}
+	return where
+}
+
+func (t *tangyImpl) prepareNpmPackageQuery(ctx context.Context, hrefs []string) (pgx.Tx, func(), membership, repositoryHrefMap, error) {
+	tx, end, err := t.begin(ctx)
 	if err != nil {
-		return nil, "", nil, err
+		return nil, nil, membership{}, nil, err
 	}
 
-	repoUUID, err := parseNpmRepositoryHref(repositoryHref)
+	repoVerMap, repositories, err := resolveRepositoryHrefs(ctx, tx, hrefs, pageCursor{})
 	if err != nil {
-		conn.Release()
-		return nil, "", nil, fmt.Errorf("error parsing repository href: %w", err)
+		end()
+		return nil, nil, membership{}, nil, err
 	}
 
-	latestVersion, err := getLatestRepositoryVersion(ctx, conn, repoUUID)
+	m, err := t.contentMembership(ctx, tx, repoVerMap)
 	if err != nil {
-		conn.Release()
-		return nil, "", nil, fmt.Errorf("error getting latest repository version: %w", err)
+		end()
+		return nil, nil, membership{}, nil, err
 	}
 
-	repoVerMap := []ParsedRepoVersion{{
-		RepositoryUUID: repoUUID,
-		Version:        latestVersion,
-	}}
+	return tx, end, m, repositories, nil
+}
 
-	args := pgx.NamedArgs{
-		"name": name,
-	}
-	innerUnion, err := contentIdsInVersions(ctx, conn, repoVerMap, &args)
+func fetchNpmPackageDetailRows(ctx context.Context, tx pgx.Tx, query sqlQuery) ([]npmPackageDetailRow, error) {
+	rows, err := tx.Query(ctx, query.SQL, query.Args)
 	if err != nil {
-		conn.Release()
-		return nil, "", nil, err
+		return nil, err
 	}
 
-	return conn, innerUnion, args, nil
+	return collectRows(ctx, rows, pgx.RowToStructByName[npmPackageDetailRow])
 }
 
-func fetchNpmPackageDetailRows(ctx context.Context, conn *pgxpool.Conn, innerUnion string, args pgx.NamedArgs, version string) ([]npmPackageDetailRow, error) {
-	detailFilter := ""
-	orderBy := "ORDER BY d.version"
+// npmPackageDetailQuery selects the tarball of every version of a package, or only of the given version
+func npmPackageDetailQuery(m membership, name, version string) sqlQuery {
+	b := newQueryBuilder()
+	join := b.membershipJoin(m)
+	where := conditions{"rp.name = " + b.bind("name", name)}
+
+	var detailFilter conditions
+	order := "ORDER BY d.version"
 	if version != "" {
-		args["version"] = version
-		detailFilter = "WHERE f.version = @version"
-		orderBy = ""
+		detailFilter = append(detailFilter, "f.version = "+b.bind("version", version))
+		order = ""
 	}
 
-	query := `
+	return b.query(`
 		WITH filtered AS (
-			SELECT rp.name, rp.version, cc.pulp_created,
-			       cca.relative_path, ca.sha256, ca.size
+			SELECT rp.content_ptr_id, rp.name, rp.version, cc.pulp_created,
+			       cca.relative_path, ca.sha256, ca.size, crv.repository_id
 			FROM npm_package rp
 			INNER JOIN core_content cc ON rp.content_ptr_id = cc.pulp_id
 			LEFT JOIN core_contentartifact cca ON cca.content_id = rp.content_ptr_id
-			LEFT JOIN core_artifact ca ON ca.pulp_id = cca.artifact_id
-	` + innerUnion + `
-			AND rp.name = @name
+			LEFT JOIN core_artifact ca ON ca.pulp_id = cca.artifact_id` + join + where.and() + `
 		),
 		version_agg AS (
 			SELECT
@@ -423,26 +508,22 @@ func fetchNpmPackageDetailRows(ctx context.Context, conn *pgxpool.Conn, innerUni
 			) v
 		),
 		detail AS (
-			SELECT f.*
-			FROM filtered f
-			` + detailFilter + `
+			SELECT f.content_ptr_id, f.name, f.version, f.pulp_created,
+			       f.relative_path, f.sha256, f.size,
+			       ARRAY_AGG(DISTINCT f.repository_id) AS repository_ids
+			FROM filtered f` + detailFilter.clause("WHERE") + `
+			GROUP BY f.content_ptr_id, f.name, f.version, f.pulp_created,
+			         f.relative_path, f.sha256, f.size
 		)
 		SELECT d.name, d.version, d.pulp_created AS created_at,
 		       d.relative_path, d.sha256, d.size,
-		       va.versions, va.latest_versions_json
+		       va.versions, va.latest_versions_json, d.repository_ids
 		FROM detail d
 		CROSS JOIN version_agg va
-		` + orderBy
-
-	rows, err := conn.Query(ctx, query, args)
-	if err != nil {
-		return nil, err
-	}
-
-	return pgx.CollectRows(rows, pgx.RowToStructByName[npmPackageDetailRow])
+		` + order)
 }
 
-func npmPackageDetailFromRow(row npmPackageDetailRow, latestVersions []NpmVersionInfo) NpmPackageDetail {
+func npmPackageDetailFromRow(row npmPackageDetailRow, latestVersions []NpmVersionInfo, repositories repositoryHrefMap) NpmPackageDetail {
 	return NpmPackageDetail{
 		Name:           row.Name,
 		Version:        row.Version,
@@ -450,6 +531,7 @@ func npmPackageDetailFromRow(row npmPackageDetailRow, latestVersions []NpmVersio
 		Tarball:        npmTarballFromRow(row.RelativePath, row.Sha256, row.Size),
 		Versions:       row.Versions,
 		LatestVersions: latestVersions,
+		Repositories:   repositories.hrefs(row.RepositoryIds),
 	}
 }
 
@@ -491,19 +573,21 @@ func parseNpmLatestVersionsJSON(data []byte) ([]NpmVersionInfo, error) {
 	return latestVersions, nil
 }
 
-func assembleNpmPackageListFromRows(rows []npmPackageVersionRow) []NpmPackageListItem {
+func assembleNpmPackageListFromRows(rows []npmPackageVersionRow, repositories repositoryHrefMap) []NpmPackageListItem {
 	if len(rows) == 0 {
 		return nil
 	}
 
 	results := make([]NpmPackageListItem, 0)
 	var current NpmPackageListItem
+	var currentRepositoryIds []string
 
 	for i, row := range rows {
 		if i == 0 || row.Name != current.Name {
 			if i > 0 {
 				results = append(results, current)
 			}
+			currentRepositoryIds = slices.Clone(row.RepositoryIds)
 			current = NpmPackageListItem{
 				Name:     row.Name,
 				Versions: []string{row.Version},
@@ -511,6 +595,7 @@ func assembleNpmPackageListFromRows(rows []npmPackageVersionRow) []NpmPackageLis
 					Version:   row.Version,
 					CreatedAt: row.CreatedAt.Format(time.RFC3339),
 				}},
+				Repositories: repositories.hrefs(row.RepositoryIds),
 			}
 			continue
 		}
@@ -520,23 +605,29 @@ func assembleNpmPackageListFromRows(rows []npmPackageVersionRow) []NpmPackageLis
 			Version:   row.Version,
 			CreatedAt: row.CreatedAt.Format(time.RFC3339),
 		})
+		currentRepositoryIds = append(currentRepositoryIds, row.RepositoryIds...)
+		current.Repositories = repositories.hrefs(currentRepositoryIds)
 	}
 
 	return append(results, current)
 }
 
-// npmPackageListSearchFilter returns SQL that prefix-matches the search term against
+// npmPackageListFilters prefix-matches the search term against
 // the npm scope (text before the first '/') and the unscoped package name (text after
 // the first '/'), similar to MavenPackageList matching group_id OR artifact_id.
 // Unscoped packages only have a scope segment (the full name).
-func npmPackageListSearchFilter() string {
-	return ` AND (
-		split_part(rp.name, '/', 1) ILIKE CONCAT(@searchFilter::text, '%')
-		OR (
-			POSITION('/' IN rp.name) > 0
-			AND split_part(rp.name, '/', 2) ILIKE CONCAT(@searchFilter::text, '%')
-		)
-	)`
+func npmPackageListFilters(b *queryBuilder, filterOpts NpmPackageListFilters) conditions {
+	if filterOpts.Search == "" {
+		return nil
+	}
+	search := b.bind("searchFilter", filterOpts.Search)
+	return conditions{`(
+			split_part(rp.name, '/', 1) ILIKE CONCAT(` + search + `::text, '%')
+			OR (
+				POSITION('/' IN rp.name) > 0
+				AND split_part(rp.name, '/', 2) ILIKE CONCAT(` + search + `::text, '%')
+			)
+		)`}
 }
 
 // splitNpmPackageName splits a package name into scope and unscoped name segments.
@@ -547,7 +638,7 @@ func splitNpmPackageName(name string) (scope, unscopedName string, scoped bool)
 	return name, "", false
 }
 
-// npmPackageMatchesSearch mirrors npmPackageListSearchFilter for unit tests.
+// npmPackageMatchesSearch mirrors npmPackageListFilters for unit tests.
 func npmPackageMatchesSearch(name, search string) bool {
 	if search == "" {
 		return true
@@ -563,21 +654,3 @@ func npmPackageMatchesSearch(name, search string) bool {
 	}
 	return false
 }
-
-// parseNpmRepositoryHref extracts the repository UUID from an npm repository href.
-// Example: /api/pulp/default/api/v3/repositories/npm/npm/018c1c95-4281-76eb-b277-842cbad524f4/
-func parseNpmRepositoryHref(href string) (string, error) {
-	parts := strings.Split(href, "/")
-	var nonEmptyParts []string
-	for _, part := range parts {
-		if part != "" {
-			nonEmptyParts = append(nonEmptyParts, part)
-		}
-	}
-
-	if len(nonEmptyParts) < 8 {
-		return "", fmt.Errorf("invalid repository href format: %s", href)
-	}
-
-	return nonEmptyParts[len(nonEmptyParts)-1], nil
-}
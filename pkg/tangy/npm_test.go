@@ -9,48 +9,6 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-func TestParseNpmRepositoryHref(t *testing.T) {
-	t.Parallel()
-
-	tests := []struct {
-		name    string
-		href    string
-		want    string
-		wantErr bool
-	}{
-		{
-			name: "valid npm repository href",
-			href: "/api/pulp/default/api/v3/repositories/npm/npm/018c1c95-4281-76eb-b277-842cbad524f4/",
-			want: "018c1c95-4281-76eb-b277-842cbad524f4",
-		},
-		{
-			name: "valid href without trailing slash",
-			href: "/api/pulp/default/api/v3/repositories/npm/npm/018c1c95-4281-76eb-b277-842cbad524f4",
-			want: "018c1c95-4281-76eb-b277-842cbad524f4",
-		},
-		{
-			name:    "invalid href",
-			href:    "/api/pulp/default/api/v3/repositories/npm/",
-			wantErr: true,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			t.Parallel()
-
-			got, err := parseNpmRepositoryHref(tt.href)
-			if tt.wantErr {
-				require.Error(t, err)
-				return
-			}
-
-			require.NoError(t, err)
-			assert.Equal(t, tt.want, got)
-		})
-	}
-}
-
 func TestAssembleNpmPackageListFromRows(t *testing.T) {
 	t.Parallel()
 
@@ -127,7 +85,7 @@ func TestAssembleNpmPackageListFromRows(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			assert.Equal(t, tt.want, assembleNpmPackageListFromRows(tt.rows))
+			assert.Equal(t, tt.want, assembleNpmPackageListFromRows(tt.rows, nil))
 		})
 	}
 }
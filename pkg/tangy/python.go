@@ -6,11 +6,11 @@ import (
 	"errors"
 	"fmt"
 	"net/mail"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type PythonVersionInfo struct {
@@ -23,13 +23,16 @@ type PythonPackageListItem struct {
 	NameNormalized string              `json:"name_normalized"`
 	Versions       []string            `json:"versions"`
 	LatestVersions []PythonVersionInfo `json:"latest_versions"`
+	Repositories   []string            `json:"repositories,omitempty"`
 }
 
 type PythonPackageListResponse struct {
-	Results []PythonPackageListItem `json:"results"`
-	Total   int                     `json:"total"`
-	Limit   int                     `json:"limit"`
-	Offset  int                     `json:"offset"`
+	Results    []PythonPackageListItem `json:"results"`
+	Total      int                     `json:"total"`
+	TotalMode  CountMode               `json:"total_mode"`
+	Limit      int                     `json:"limit"`
+	Offset     int                     `json:"offset"`
+	NextCursor string                  `json:"next_cursor,omitempty"`
 }
 
 type PythonPackageListFilters struct {
@@ -37,17 +40,20 @@ type PythonPackageListFilters struct {
 }
 
 type PythonBuildListItem struct {
-	Name           string `json:"name"`
-	NameNormalized string `json:"name_normalized"`
-	Version        string `json:"version"`
-	CreatedAt      string `json:"created_at"`
+	Name           string   `json:"name"`
+	NameNormalized string   `json:"name_normalized"`
+	Version        string   `json:"version"`
+	CreatedAt      string   `json:"created_at"`
+	Repositories   []string `json:"repositories,omitempty"`
 }
 
 type PythonBuildListResponse struct {
-	Results []PythonBuildListItem `json:"results"`
-	Total   int                   `json:"total"`
-	Limit   int                   `json:"limit"`
-	Offset  int                   `json:"offset"`
+	Results    []PythonBuildListItem `json:"results"`
+	Total      int                   `json:"total"`
+	TotalMode  CountMode             `json:"total_mode"`
+	Limit      int                   `json:"limit"`
+	Offset     int                   `json:"offset"`
+	NextCursor string                `json:"next_cursor,omitempty"`
 }
 
 type PythonRepositoryMetrics struct {
@@ -57,22 +63,25 @@ type PythonRepositoryMetrics struct {
 }
 
 type PythonDistributionListItem struct {
-	Name           string `json:"name"`
-	NameNormalized string `json:"name_normalized"`
-	Version        string `json:"version"`
-	Filename       string `json:"filename"`
-	PackageType    string `json:"packagetype"`
-	PythonVersion  string `json:"python_version"`
-	Sha256         string `json:"sha256"`
-	Size           int64  `json:"size"`
-	CreatedAt      string `json:"created_at"`
+	Name           string   `json:"name"`
+	NameNormalized string   `json:"name_normalized"`
+	Version        string   `json:"version"`
+	Filename       string   `json:"filename"`
+	PackageType    string   `json:"packagetype"`
+	PythonVersion  string   `json:"python_version"`
+	Sha256         string   `json:"sha256"`
+	Size           int64    `json:"size"`
+	CreatedAt      string   `json:"created_at"`
+	Repositories   []string `json:"repositories,omitempty"`
 }
 
 type PythonDistributionListResponse struct {
-	Results []PythonDistributionListItem `json:"results"`
-	Total   int                          `json:"total"`
-	Limit   int                          `json:"limit"`
-	Offset  int                          `json:"offset"`
+	Results    []PythonDistributionListItem `json:"results"`
+	Total      int                          `json:"total"`
+	TotalMode  CountMode                    `json:"total_mode"`
+	Limit      int                          `json:"limit"`
+	Offset     int                          `json:"offset"`
+	NextCursor string                       `json:"next_cursor,omitempty"`
 }
 
 // PythonPackageDetail holds metadata for a specific package name and version in a repository.
@@ -101,6 +110,7 @@ type PythonPackageDetail struct {
 	Versions               []string                     `json:"versions"`
 	LatestVersions         []PythonVersionInfo          `json:"latest_versions"`
 	Distributions          []PythonDistributionListItem `json:"distributions"`
+	Repositories           []string                     `json:"repositories,omitempty"`
 }
 
 var (
@@ -113,6 +123,7 @@ type pythonPackageVersionRow struct {
 	Name           string
 	Version        string
 	CreatedAt      time.Time
+	RepositoryIds  []string
 }
 
 type pythonBuildRow struct {
@@ -120,9 +131,11 @@ type pythonBuildRow struct {
 	NameNormalized string
 	Version        string
 	CreatedAt      time.Time
+	RepositoryIds  []string
 }
 
 type pythonDistributionRow struct {
+	ContentPtrID   string
 	Name           string
 	NameNormalized string
 	Version        string
@@ -132,6 +145,7 @@ type pythonDistributionRow struct {
 	Sha256         string
 	Size           int64
 	CreatedAt      time.Time
+	RepositoryIds  []string
 }
 
 type pythonPackageDetailRow struct {
@@ -157,191 +171,255 @@ type pythonPackageDetailRow struct {
 	LastUpdated            time.Time
 	Versions               []string
 	LatestVersionsJSON     []byte
+	RepositoryIds          []string
 }
 
-// PythonPackageList lists Python packages from the latest version of a repository,
+// PythonPackageList lists Python packages from a repository version, or the latest version of a repository,
 // grouped by name_normalized with SQL-level pagination.
-func (t *tangyImpl) PythonPackageList(ctx context.Context, repositoryHref string, filterOpts PythonPackageListFilters, pageOpts PageOptions) (PythonPackageListResponse, error) {
+func (t *tangyImpl) PythonPackageList(ctx context.Context, repositoryHref string, filterOpts PythonPackageListFilters, pageOpts PageOptions) (_ PythonPackageListResponse, err error) {
+	ctx, done := t.call(ctx, "PythonPackageList", filterOpts, pageOpts)
+	defer done(&err)
+
 	if repositoryHref == "" {
 		return PythonPackageListResponse{}, nil
 	}
+	return t.PythonRepositoryVersionPackageList(ctx, []string{repositoryHref}, filterOpts, pageOpts)
+}
+
+// PythonRepositoryVersionPackageList lists Python packages merged across several repository versions, or the latest
+// versions of several repositories, grouped by name_normalized with SQL-level pagination.
+// Each package reports the repositories that contain it.
+func (t *tangyImpl) PythonRepositoryVersionPackageList(ctx context.Context, hrefs []string, filterOpts PythonPackageListFilters, pageOpts PageOptions) (_ PythonPackageListResponse, err error) {
+	ctx, done := t.call(ctx, "PythonRepositoryVersionPackageList", filterOpts, pageOpts)
+	defer done(&err)
+
+	if len(hrefs) == 0 {
+		return PythonPackageListResponse{}, nil
+	}
 
-	conn, err := t.pool.Acquire(ctx)
+	tx, end, err := t.begin(ctx)
 	if err != nil {
 		return PythonPackageListResponse{}, err
 	}
-	defer conn.Release()
+	defer end()
 
 	if pageOpts.Limit == 0 {
 		pageOpts.Limit = DefaultLimit
 	}
 
-	repoUUID, err := parsePythonRepositoryHref(repositoryHref)
+	cursor, err := decodeCursor(pageOpts.Cursor, cursorKindPythonPackageList, len(pythonPackageListSort.Columns))
 	if err != nil {
-		return PythonPackageListResponse{}, fmt.Errorf("error parsing repository href: %w", err)
+		return PythonPackageListResponse{}, err
 	}
 
-	latestVersion, err := getLatestPythonRepositoryVersion(ctx, conn, repoUUID)
+	repoVerMap, repositories, err := resolveRepositoryHrefs(ctx, tx, hrefs, cursor)
 	if err != nil {
-		return PythonPackageListResponse{}, fmt.Errorf("error getting latest repository version: %w", err)
+		return PythonPackageListResponse{}, err
 	}
 
-	repoVerMap := []ParsedRepoVersion{{
-		RepositoryUUID: repoUUID,
-		Version:        latestVersion,
-	}}
-
-	args := pgx.NamedArgs{
-		"limit":  pageOpts.Limit,
-		"offset": pageOpts.Offset,
-	}
-	searchFilter := ""
-	if filterOpts.Search != "" {
-		args["searchFilter"] = filterOpts.Search
-		searchFilter = ` AND (rp.name ILIKE CONCAT(@searchFilter::text, '%')
-			OR rp.name_normalized ILIKE CONCAT(@searchFilter::text, '%'))`
+	m, err := t.contentMembership(ctx, tx, repoVerMap)
+	if err != nil {
+		return PythonPackageListResponse{}, err
 	}
-	innerUnion, err := contentIdsInVersions(ctx, conn, repoVerMap, &args)
+
+	total, totalMode, err := listTotal(ctx, tx, pageOpts.Count, func(limit int) sqlQuery {
+		return pythonPackageListCountQuery(m, filterOpts, limit)
+	})
 	if err != nil {
 		return PythonPackageListResponse{}, err
 	}
 
-	countQuery := `
-		SELECT COUNT(DISTINCT rp.name_normalized)
-		FROM python_pythonpackagecontent rp
-	` + innerUnion + searchFilter
+	query := pythonPackageListQuery(m, filterOpts, pageOpts, cursor)
+	rows, err := tx.Query(ctx, query.SQL, query.Args)
+	if err != nil {
+		return PythonPackageListResponse{}, err
+	}
 
-	var countTotal int
-	err = conn.QueryRow(ctx, countQuery, args).Scan(&countTotal)
+	versionRows, err := collectRows(ctx, rows, pgx.RowToStructByName[pythonPackageVersionRow])
 	if err != nil {
 		return PythonPackageListResponse{}, err
 	}
 
-	query := `
+	response := PythonPackageListResponse{
+		Results:   assemblePythonPackageListFromRows(versionRows, repositories),
+		Total:     total,
+		TotalMode: totalMode,
+		Limit:     pageOpts.Limit,
+		Offset:    pageOpts.Offset,
+	}
+	if len(response.Results) > 0 {
+		last := response.Results[len(response.Results)-1]
+		response.NextCursor = nextCursor(cursorKindPythonPackageList, len(response.Results), pageOpts.Limit, repoVerMap, last.NameNormalized)
+	}
+	return response, nil
+}
+
+func pythonPackageListCountQuery(m membership, filterOpts PythonPackageListFilters, countLimit int) sqlQuery {
+	b := newQueryBuilder()
+	return b.query(b.count(`
+		SELECT DISTINCT rp.name_normalized
+		FROM python_pythonpackagecontent rp`+b.membershipJoin(m)+pythonPackageListFilters(b, filterOpts).and(), countLimit))
+}
+
+func pythonPackageListQuery(m membership, filterOpts PythonPackageListFilters, pageOpts PageOptions, cursor pageCursor) sqlQuery {
+	b := newQueryBuilder()
+	join := b.membershipJoin(m)
+	where := pythonPackageListFilters(b, filterOpts)
+	return b.query(`
 		WITH filtered AS (
-			SELECT rp.name_normalized, rp.name, rp.version, cc.pulp_created
+			SELECT rp.name_normalized, rp.name, rp.version, cc.pulp_created, crv.repository_id
 			FROM python_pythonpackagecontent rp
-			INNER JOIN core_content cc ON rp.content_ptr_id = cc.pulp_id
-	` + innerUnion + searchFilter + `
+			INNER JOIN core_content cc ON rp.content_ptr_id = cc.pulp_id` + join + where.and() + `
 		),
 		package_versions AS (
-			SELECT name_normalized, MIN(name) AS name, version, MAX(pulp_created) AS created_at
+			SELECT name_normalized, MIN(name) AS name, version, MAX(pulp_created) AS created_at,
+			       ARRAY_AGG(DISTINCT repository_id) AS repository_ids
 			FROM filtered
 			GROUP BY name_normalized, version
 		),
 		paginated_packages AS (
 			SELECT name_normalized, MIN(name) AS name
-			FROM package_versions
+			FROM package_versions` + b.pageAfter(pythonPackageListSort, cursor).clause("WHERE") + `
 			GROUP BY name_normalized
-			ORDER BY name_normalized
-			LIMIT @limit OFFSET @offset
+			` + orderBy(pythonPackageListSort) + `
+			` + b.page(pageOpts.Limit, pageOpts.Offset, cursor) + `
 		)
-		SELECT pv.name_normalized, pv.name, pv.version, pv.created_at
+		SELECT pv.name_normalized, pv.name, pv.version, pv.created_at, pv.repository_ids
 		FROM package_versions pv
 		INNER JOIN paginated_packages pp ON pv.name_normalized = pp.name_normalized
-		ORDER BY pv.name_normalized, pv.version`
+		ORDER BY pv.name_normalized, pv.version`)
+}
 
-	rows, err := conn.Query(ctx, query, args)
-	if err != nil {
-		return PythonPackageListResponse{}, err
+func pythonPackageListFilters(b *queryBuilder, filterOpts PythonPackageListFilters) conditions {
+	if filterOpts.Search == "" {
+		return nil
 	}
+	search := b.bind("searchFilter", filterOpts.Search)
+	return conditions{"(rp.name ILIKE CONCAT(" + search + "::text, '%') OR rp.name_normalized ILIKE CONCAT(" + search + "::text, '%'))"}
+}
 
-	versionRows, err := pgx.CollectRows(rows, pgx.RowToStructByName[pythonPackageVersionRow])
-	if err != nil {
-		return PythonPackageListResponse{}, err
-	}
+// pythonPackageListSort is the sort key of PythonPackageList, applied to the package_versions CTE
+var pythonPackageListSort = sortOrder{Columns: []keysetColumn{
+	{Expr: "name_normalized", Type: "text"},
+}}
 
-	return PythonPackageListResponse{
-		Results: assemblePythonPackageListFromRows(versionRows),
-		Total:   countTotal,
-		Limit:   pageOpts.Limit,
-		Offset:  pageOpts.Offset,
-	}, nil
-}
+// pythonDistributionListSort is the sort key of PythonDistributionList, newest distributions first
+var pythonDistributionListSort = sortOrder{Columns: []keysetColumn{
+	{Expr: "cc.pulp_created", Type: "timestamptz"},
+	{Expr: "rp.content_ptr_id", Type: "uuid"},
+}, Desc: true}
+
+// pythonBuildListSort is the sort key of PythonBuildList, most recently created builds first
+var pythonBuildListSort = sortOrder{Columns: []keysetColumn{
+	{Expr: "MAX(cc.pulp_created)", Type: "timestamptz"},
+	{Expr: "rp.name_normalized", Type: "text"},
+	{Expr: "rp.version", Type: "text"},
+}, Desc: true}
 
 // PythonDistributionList lists all distribution files for a specific package name and version
-// from the latest version of a repository. The name filter uses name_normalized (PEP 503).
-func (t *tangyImpl) PythonDistributionList(ctx context.Context, repositoryHref, nameNormalized, version string, pageOpts PageOptions) (PythonDistributionListResponse, error) {
+// from a repository version, or the latest version of a repository. The name filter uses name_normalized (PEP 503).
+func (t *tangyImpl) PythonDistributionList(ctx context.Context, repositoryHref, nameNormalized, version string, pageOpts PageOptions) (_ PythonDistributionListResponse, err error) {
+	ctx, done := t.call(ctx, "PythonDistributionList", arg("name_normalized", nameNormalized), arg("version", version), pageOpts)
+	defer done(&err)
+
 	if repositoryHref == "" {
 		return PythonDistributionListResponse{}, nil
 	}
+	return t.PythonRepositoryVersionDistributionList(ctx, []string{repositoryHref}, nameNormalized, version, pageOpts)
+}
+
+// PythonRepositoryVersionDistributionList lists all distribution files for a specific package name and version
+// merged across several repository versions, or the latest versions of several repositories.
+// Each distribution file is listed once and reports the repositories that contain it.
+func (t *tangyImpl) PythonRepositoryVersionDistributionList(ctx context.Context, hrefs []string, nameNormalized, version string, pageOpts PageOptions) (_ PythonDistributionListResponse, err error) {
+	ctx, done := t.call(ctx, "PythonRepositoryVersionDistributionList", arg("name_normalized", nameNormalized), arg("version", version), pageOpts)
+	defer done(&err)
+
+	if len(hrefs) == 0 {
+		return PythonDistributionListResponse{}, nil
+	}
 
-	conn, err := t.pool.Acquire(ctx)
+	tx, end, err := t.begin(ctx)
 	if err != nil {
 		return PythonDistributionListResponse{}, err
 	}
-	defer conn.Release()
+	defer end()
 
 	if pageOpts.Limit == 0 {
 		pageOpts.Limit = DefaultLimit
 	}
 
-	repoUUID, err := parsePythonRepositoryHref(repositoryHref)
+	cursor, err := decodeCursor(pageOpts.Cursor, cursorKindPythonDistributionList, len(pythonDistributionListSort.Columns))
 	if err != nil {
-		return PythonDistributionListResponse{}, fmt.Errorf("error parsing repository href: %w", err)
+		return PythonDistributionListResponse{}, err
 	}
 
-	latestVersion, err := getLatestPythonRepositoryVersion(ctx, conn, repoUUID)
+	repoVerMap, repositories, err := resolveRepositoryHrefs(ctx, tx, hrefs, cursor)
 	if err != nil {
-		return PythonDistributionListResponse{}, fmt.Errorf("error getting latest repository version: %w", err)
+		return PythonDistributionListResponse{}, err
 	}
 
-	repoVerMap := []ParsedRepoVersion{{
-		RepositoryUUID: repoUUID,
-		Version:        latestVersion,
-	}}
-
-	args := pgx.NamedArgs{
-		"name_normalized": nameNormalized,
-		"version":         version,
-		"limit":           pageOpts.Limit,
-		"offset":          pageOpts.Offset,
-	}
-	innerUnion, err := contentIdsInVersions(ctx, conn, repoVerMap, &args)
+	m, err := t.contentMembership(ctx, tx, repoVerMap)
 	if err != nil {
 		return PythonDistributionListResponse{}, err
 	}
 
-	countQuery := `
-		SELECT COUNT(*)
-		FROM python_pythonpackagecontent rp
-	` + innerUnion + `
-		AND rp.name_normalized = @name_normalized
-		AND rp.version = @version`
-
-	var countTotal int
-	err = conn.QueryRow(ctx, countQuery, args).Scan(&countTotal)
+	total, totalMode, err := listTotal(ctx, tx, pageOpts.Count, func(limit int) sqlQuery {
+		return pythonDistributionListCountQuery(m, nameNormalized, version, limit)
+	})
 	if err != nil {
 		return PythonDistributionListResponse{}, err
 	}
 
-	distributions, err := fetchPythonDistributionRows(ctx, conn, innerUnion, args, pageOpts.Limit, pageOpts.Offset)
+	distributions, err := fetchPythonDistributionRows(ctx, tx, pythonDistributionQuery(m, nameNormalized, version, pageOpts.Limit, pageOpts.Offset, cursor))
 	if err != nil {
 		return PythonDistributionListResponse{}, err
 	}
 
-	return PythonDistributionListResponse{
-		Results: pythonDistributionRowsToItems(distributions),
-		Total:   countTotal,
-		Limit:   pageOpts.Limit,
-		Offset:  pageOpts.Offset,
-	}, nil
+	response := PythonDistributionListResponse{
+		Results:   pythonDistributionRowsToItems(distributions, repositories),
+		Total:     total,
+		TotalMode: totalMode,
+		Limit:     pageOpts.Limit,
+		Offset:    pageOpts.Offset,
+	}
+	if len(distributions) > 0 {
+		last := distributions[len(distributions)-1]
+		response.NextCursor = nextCursor(cursorKindPythonDistributionList, len(distributions), pageOpts.Limit, repoVerMap, formatCursorTime(last.CreatedAt), last.ContentPtrID)
+	}
+	return response, nil
 }
 
 // PythonPackageGet returns metadata for a specific package name_normalized and version
-// from the latest version of a repository, plus all other versions available in that repository.
-func (t *tangyImpl) PythonPackageGet(ctx context.Context, repositoryHref, nameNormalized, version string) (PythonPackageDetail, error) {
+// from a repository version, or the latest version of a repository, plus all other versions available in that repository.
+func (t *tangyImpl) PythonPackageGet(ctx context.Context, repositoryHref, nameNormalized, version string) (_ PythonPackageDetail, err error) {
+	ctx, done := t.call(ctx, "PythonPackageGet", arg("name_normalized", nameNormalized), arg("version", version))
+	defer done(&err)
+
 	if repositoryHref == "" {
 		return PythonPackageDetail{}, nil
 	}
+	return t.PythonRepositoryVersionPackageGet(ctx, []string{repositoryHref}, nameNormalized, version)
+}
+
+// PythonRepositoryVersionPackageGet returns metadata for a specific package name_normalized and version
+// merged across several repository versions, or the latest versions of several repositories,
+// plus all other versions available in any of them.
+func (t *tangyImpl) PythonRepositoryVersionPackageGet(ctx context.Context, hrefs []string, nameNormalized, version string) (_ PythonPackageDetail, err error) {
+	ctx, done := t.call(ctx, "PythonRepositoryVersionPackageGet", arg("name_normalized", nameNormalized), arg("version", version))
+	defer done(&err)
 
-	conn, innerUnion, args, err := t.preparePythonPackageQuery(ctx, repositoryHref, nameNormalized)
+	if len(hrefs) == 0 {
+		return PythonPackageDetail{}, nil
+	}
+
+	tx, end, m, repositories, err := t.preparePythonPackageQuery(ctx, hrefs)
 	if err != nil {
 		return PythonPackageDetail{}, err
 	}
-	defer conn.Release()
+	defer end()
 
-	detailRows, err := fetchPythonPackageDetailRows(ctx, conn, innerUnion, args, version)
+	detailRows, err := fetchPythonPackageDetailRows(ctx, tx, pythonPackageDetailQuery(m, nameNormalized, version))
 	if err != nil {
 		return PythonPackageDetail{}, err
 	}
@@ -356,32 +434,48 @@ func (t *tangyImpl) PythonPackageGet(ctx context.Context, repositoryHref, nameNo
 		return PythonPackageDetail{}, err
 	}
 
-	distributions, err := fetchPythonDistributionRows(ctx, conn, innerUnion, args, 0, 0)
+	distributions, err := fetchPythonDistributionRows(ctx, tx, pythonDistributionQuery(m, nameNormalized, version, 0, 0, pageCursor{}))
 	if err != nil {
 		return PythonPackageDetail{}, err
 	}
 
-	return pythonPackageDetailFromRow(row, latestVersions, pythonDistributionRowsToItems(distributions)), nil
+	return pythonPackageDetailFromRow(row, latestVersions, pythonDistributionRowsToItems(distributions, repositories), repositories), nil
 }
 
-// PythonPackageVersionsGet returns metadata for every version of a package from the latest version
-// of a repository. Metadata for each version is taken from one representative distribution
+// PythonPackageVersionsGet returns metadata for every version of a package from a repository version,
+// or the latest version of a repository. Metadata for each version is taken from one representative distribution
 // (sdist preferred, then most recently synced).
-func (t *tangyImpl) PythonPackageVersionsGet(ctx context.Context, repositoryHref, nameNormalized string) ([]PythonPackageDetail, error) {
+func (t *tangyImpl) PythonPackageVersionsGet(ctx context.Context, repositoryHref, nameNormalized string) (_ []PythonPackageDetail, err error) {
+	ctx, done := t.call(ctx, "PythonPackageVersionsGet", arg("name_normalized", nameNormalized))
+	defer done(&err)
+
 	if repositoryHref == "" {
 		return nil, nil
 	}
+	return t.PythonRepositoryVersionPackageVersionsGet(ctx, []string{repositoryHref}, nameNormalized)
+}
+
+// PythonRepositoryVersionPackageVersionsGet returns metadata for every version of a package merged across several
+// repository versions, or the latest versions of several repositories. Versions found in several repositories are
+// returned once and report the repositories that contain them.
+func (t *tangyImpl) PythonRepositoryVersionPackageVersionsGet(ctx context.Context, hrefs []string, nameNormalized string) (_ []PythonPackageDetail, err error) {
+	ctx, done := t.call(ctx, "PythonRepositoryVersionPackageVersionsGet", arg("name_normalized", nameNormalized))
+	defer done(&err)
+
+	if len(hrefs) == 0 {
+		return nil, nil
+	}
 	if nameNormalized == "" {
 		return nil, ErrPythonNameNormalizedRequired
 	}
 
-	conn, innerUnion, args, err := t.preparePythonPackageQuery(ctx, repositoryHref, nameNormalized)
+	tx, end, m, repositories, err := t.preparePythonPackageQuery(ctx, hrefs)
 	if err != nil {
 		return nil, err
 	}
-	defer conn.Release()
+	defer end()
 
-	detailRows, err := fetchPythonPackageDetailRows(ctx, conn, innerUnion, args, "")
+	detailRows, err := fetchPythonPackageDetailRows(ctx, tx, pythonPackageDetailQuery(m, nameNormalized, ""))
 	if err != nil {
 		return nil, err
 	}
@@ -394,7 +488,7 @@ func (t *tangyImpl) PythonPackageVersionsGet(ctx context.Context, repositoryHref
 		return nil, err
 	}
 
-	allDistributions, err := fetchAllPythonDistributionRowsForPackage(ctx, conn, innerUnion, args)
+	allDistributions, err := fetchPythonDistributionRows(ctx, tx, pythonPackageDistributionsQuery(m, nameNormalized))
 	if err != nil {
 		return nil, err
 	}
@@ -403,102 +497,80 @@ func (t *tangyImpl) PythonPackageVersionsGet(ctx context.Context, repositoryHref
 	for _, dist := range allDistributions {
 		distributionsByVersion[dist.Version] = append(
 			distributionsByVersion[dist.Version],
-			pythonDistributionRowToItem(dist),
+			pythonDistributionRowToItem(dist, repositories),
 		)
 	}
 
 	results := make([]PythonPackageDetail, len(detailRows))
 	for i, row := range detailRows {
-		results[i] = pythonPackageDetailFromRow(row, latestVersions, distributionsByVersion[row.Version])
+		results[i] = pythonPackageDetailFromRow(row, latestVersions, distributionsByVersion[row.Version], repositories)
 	}
 
 	return results, nil
 }
 
 // PythonBuildList lists all Python package builds (name_normalized + version pairs), optionally
-// filtered by name_normalized and version, from the latest version of a repository.
-func (t *tangyImpl) PythonBuildList(ctx context.Context, repositoryHref, nameNormalized, version string, pageOpts PageOptions) (PythonBuildListResponse, error) {
+// filtered by name_normalized and version, from a repository version, or the latest version of a repository.
+func (t *tangyImpl) PythonBuildList(ctx context.Context, repositoryHref, nameNormalized, version string, pageOpts PageOptions) (_ PythonBuildListResponse, err error) {
+	ctx, done := t.call(ctx, "PythonBuildList", arg("name_normalized", nameNormalized), arg("version", version), pageOpts)
+	defer done(&err)
+
 	if repositoryHref == "" {
 		return PythonBuildListResponse{}, nil
 	}
+	return t.PythonRepositoryVersionBuildList(ctx, []string{repositoryHref}, nameNormalized, version, pageOpts)
+}
 
-	conn, err := t.pool.Acquire(ctx)
+// PythonRepositoryVersionBuildList lists all Python package builds (name_normalized + version pairs) merged across
+// several repository versions, or the latest versions of several repositories, optionally filtered by name_normalized
+// and version. Each build reports the repositories that contain it.
+func (t *tangyImpl) PythonRepositoryVersionBuildList(ctx context.Context, hrefs []string, nameNormalized, version string, pageOpts PageOptions) (_ PythonBuildListResponse, err error) {
+	ctx, done := t.call(ctx, "PythonRepositoryVersionBuildList", arg("name_normalized", nameNormalized), arg("version", version), pageOpts)
+	defer done(&err)
+
+	if len(hrefs) == 0 {
+		return PythonBuildListResponse{}, nil
+	}
+
+	tx, end, err := t.begin(ctx)
 	if err != nil {
 		return PythonBuildListResponse{}, err
 	}
-	defer conn.Release()
+	defer end()
 
 	if pageOpts.Limit == 0 {
 		pageOpts.Limit = DefaultLimit
 	}
 
-	repoUUID, err := parsePythonRepositoryHref(repositoryHref)
+	cursor, err := decodeCursor(pageOpts.Cursor, cursorKindPythonBuildList, len(pythonBuildListSort.Columns))
 	if err != nil {
-		return PythonBuildListResponse{}, fmt.Errorf("error parsing repository href: %w", err)
+		return PythonBuildListResponse{}, err
 	}
 
-	latestVersion, err := getLatestPythonRepositoryVersion(ctx, conn, repoUUID)
+	repoVerMap, repositories, err := resolveRepositoryHrefs(ctx, tx, hrefs, cursor)
 	if err != nil {
-		return PythonBuildListResponse{}, fmt.Errorf("error getting latest repository version: %w", err)
-	}
-
-	repoVerMap := []ParsedRepoVersion{{
-		RepositoryUUID: repoUUID,
-		Version:        latestVersion,
-	}}
-
-	args := pgx.NamedArgs{}
-
-	var whereClause string
-	if nameNormalized != "" {
-		args["name_normalized"] = nameNormalized
-		whereClause += "\n\t\tAND rp.name_normalized = @name_normalized"
-	}
-	if version != "" {
-		args["version"] = version
-		whereClause += "\n\t\tAND rp.version = @version"
+		return PythonBuildListResponse{}, err
 	}
 
-	innerUnion, err := contentIdsInVersions(ctx, conn, repoVerMap, &args)
+	m, err := t.contentMembership(ctx, tx, repoVerMap)
 	if err != nil {
 		return PythonBuildListResponse{}, err
 	}
 
-	buildFrom := `
-		FROM python_pythonpackagecontent rp
-		INNER JOIN core_content cc ON rp.content_ptr_id = cc.pulp_id
-	` + innerUnion + whereClause
-
-	countQuery := `
-		SELECT COUNT(*)
-		FROM (
-			SELECT rp.name_normalized, rp.version
-		` + buildFrom + `
-			GROUP BY rp.name_normalized, rp.version
-		) builds`
-
-	var countTotal int
-	err = conn.QueryRow(ctx, countQuery, args).Scan(&countTotal)
+	total, totalMode, err := listTotal(ctx, tx, pageOpts.Count, func(limit int) sqlQuery {
+		return pythonBuildListCountQuery(m, nameNormalized, version, limit)
+	})
 	if err != nil {
 		return PythonBuildListResponse{}, err
 	}
 
-	args["limit"] = pageOpts.Limit
-	args["offset"] = pageOpts.Offset
-
-	query := `
-		SELECT MIN(rp.name) AS name, rp.name_normalized, rp.version, MAX(cc.pulp_created) AS created_at
-	` + buildFrom + `
-		GROUP BY rp.name_normalized, rp.version
-		ORDER BY created_at DESC
-		LIMIT @limit OFFSET @offset`
-
-	rows, err := conn.Query(ctx, query, args)
+	query := pythonBuildListQuery(m, nameNormalized, version, pageOpts, cursor)
+	rows, err := tx.Query(ctx, query.SQL, query.Args)
 	if err != nil {
 		return PythonBuildListResponse{}, err
 	}
 
-	buildRows, err := pgx.CollectRows(rows, pgx.RowToStructByName[pythonBuildRow])
+	buildRows, err := collectRows(ctx, rows, pgx.RowToStructByName[pythonBuildRow])
 	if err != nil {
 		return PythonBuildListResponse{}, err
 	}
@@ -510,140 +582,179 @@ func (t *tangyImpl) PythonBuildList(ctx context.Context, repositoryHref, nameNor
 			NameNormalized: row.NameNormalized,
 			Version:        row.Version,
 			CreatedAt:      row.CreatedAt.Format(time.RFC3339),
+			Repositories:   repositories.hrefs(row.RepositoryIds),
 		}
 	}
 
-	return PythonBuildListResponse{
-		Results: results,
-		Total:   countTotal,
-		Limit:   pageOpts.Limit,
-		Offset:  pageOpts.Offset,
-	}, nil
+	response := PythonBuildListResponse{
+		Results:   results,
+		Total:     total,
+		TotalMode: totalMode,
+		Limit:     pageOpts.Limit,
+		Offset:    pageOpts.Offset,
+	}
+	if len(buildRows) > 0 {
+		last := buildRows[len(buildRows)-1]
+		response.NextCursor = nextCursor(cursorKindPythonBuildList, len(buildRows), pageOpts.Limit, repoVerMap, formatCursorTime(last.CreatedAt), last.NameNormalized, last.Version)
+	}
+	return response, nil
+}
+
+func pythonBuildListCountQuery(m membership, nameNormalized, version string, countLimit int) sqlQuery {
+	b := newQueryBuilder()
+	return b.query(b.count(`
+		SELECT rp.name_normalized, rp.version
+		FROM python_pythonpackagecontent rp`+b.membershipJoin(m)+pythonBuildListFilters(b, nameNormalized, version).and()+`
+		GROUP BY rp.name_normalized, rp.version`, countLimit))
+}
+
+func pythonBuildListQuery(m membership, nameNormalized, version string, pageOpts PageOptions, cursor pageCursor) sqlQuery {
+	b := newQueryBuilder()
+	join := b.membershipJoin(m)
+	where := pythonBuildListFilters(b, nameNormalized, version)
+	return b.query(`
+		SELECT MIN(rp.name) AS name, rp.name_normalized, rp.version, MAX(cc.pulp_created) AS created_at,
+		       ARRAY_AGG(DISTINCT crv.repository_id) AS repository_ids
+		FROM python_pythonpackagecontent rp
+		INNER JOIN core_content cc ON rp.content_ptr_id = cc.pulp_id` + join + where.and() + `
+		GROUP BY rp.name_normalized, rp.version` + b.pageAfter(pythonBuildListSort, cursor).clause("HAVING") + `
+		` + orderBy(pythonBuildListSort) + `
+		` + b.page(pageOpts.Limit, pageOpts.Offset, cursor))
+}
+
+func pythonBuildListFilters(b *queryBuilder, nameNormalized, version string) conditions {
+	var where conditions
+	if nameNormalized != "" {
+		where = append(where, "rp.name_normalized = "+b.bind("name_normalized", nameNormalized))
+	}
+	if version != "" {
+		where = append(where, "rp.version = "+b.bind("version", version))
+	}
+	return where
 }
 
-// PythonRepositoryMetrics returns package, build, and version counts for the latest version of a repository.
+// PythonRepositoryMetrics returns package, build, and version counts for a repository version, or the latest version of a repository.
 // Build count equals version count (distinct name_normalized + version pairs).
-func (t *tangyImpl) PythonRepositoryMetrics(ctx context.Context, repositoryHref string) (PythonRepositoryMetrics, error) {
+func (t *tangyImpl) PythonRepositoryMetrics(ctx context.Context, repositoryHref string) (_ PythonRepositoryMetrics, err error) {
+	ctx, done := t.call(ctx, "PythonRepositoryMetrics")
+	defer done(&err)
+
 	if repositoryHref == "" {
 		return PythonRepositoryMetrics{}, nil
 	}
+	return t.PythonRepositoryVersionMetrics(ctx, []string{repositoryHref})
+}
 
-	conn, err := t.pool.Acquire(ctx)
+// PythonRepositoryVersionMetrics returns package, build, and version counts for the union of several repository
+// versions, or the latest versions of several repositories. Packages and builds found in several repositories are counted once.
+func (t *tangyImpl) PythonRepositoryVersionMetrics(ctx context.Context, hrefs []string) (_ PythonRepositoryMetrics, err error) {
+	ctx, done := t.call(ctx, "PythonRepositoryVersionMetrics")
+	defer done(&err)
+
+	if len(hrefs) == 0 {
+		return PythonRepositoryMetrics{}, nil
+	}
+
+	tx, end, err := t.begin(ctx)
 	if err != nil {
 		return PythonRepositoryMetrics{}, err
 	}
-	defer conn.Release()
+	defer end()
 
-	repoUUID, err := parsePythonRepositoryHref(repositoryHref)
+	repoVerMap, _, err := resolveRepositoryHrefs(ctx, tx, hrefs, pageCursor{})
 	if err != nil {
-		return PythonRepositoryMetrics{}, fmt.Errorf("error parsing repository href: %w", err)
+		return PythonRepositoryMetrics{}, err
 	}
 
-	latestVersion, err := getLatestPythonRepositoryVersion(ctx, conn, repoUUID)
+	m, err := t.contentMembership(ctx, tx, repoVerMap)
 	if err != nil {
-		return PythonRepositoryMetrics{}, fmt.Errorf("error getting latest repository version: %w", err)
+		return PythonRepositoryMetrics{}, err
 	}
 
-	repoVerMap := []ParsedRepoVersion{{
-		RepositoryUUID: repoUUID,
-		Version:        latestVersion,
-	}}
-
-	args := pgx.NamedArgs{}
-	innerUnion, err := contentIdsInVersions(ctx, conn, repoVerMap, &args)
+	metricsQuery := pythonRepositoryMetricsQuery(m)
+	var metrics PythonRepositoryMetrics
+	err = tx.QueryRow(ctx, metricsQuery.SQL, metricsQuery.Args).Scan(&metrics.PackageCount, &metrics.VersionCount)
 	if err != nil {
 		return PythonRepositoryMetrics{}, err
 	}
 
-	contentFrom := `
-		FROM python_pythonpackagecontent rp
-	` + innerUnion
+	metrics.BuildCount = metrics.VersionCount
 
-	metricsQuery := `
+	return metrics, nil
+}
+
+// pythonRepositoryMetricsQuery counts packages and versions. Each subquery joins the membership on its own,
+// and numbers its membership arguments accordingly.
+func pythonRepositoryMetricsQuery(m membership) sqlQuery {
+	b := newQueryBuilder()
+	return b.query(`
 		SELECT
 			(SELECT COUNT(DISTINCT rp.name_normalized)
-			` + contentFrom + `) AS package_count,
+			FROM python_pythonpackagecontent rp` + b.membershipJoin(m) + `) AS package_count,
 			(SELECT COUNT(*)
 			 FROM (
 				SELECT 1
-				` + contentFrom + `
+				FROM python_pythonpackagecontent rp` + b.membershipJoin(m) + `
 				GROUP BY rp.name_normalized, rp.version
-			 ) versions) AS version_count`
-
-	var metrics PythonRepositoryMetrics
-	err = conn.QueryRow(ctx, metricsQuery, args).Scan(&metrics.PackageCount, &metrics.VersionCount)
-	if err != nil {
-		return PythonRepositoryMetrics{}, err
-	}
-
-	metrics.BuildCount = metrics.VersionCount
-
-	return metrics, nil
+			 ) versions) AS version_count`)
 }
 
-func (t *tangyImpl) preparePythonPackageQuery(ctx context.Context, repositoryHref, nameNormalized string) (*pgxpool.Conn, string, pgx.NamedArgs, error) {
-	conn, err := t.pool.Acquire(ctx)
+func (t *tangyImpl) preparePythonPackageQuery(ctx context.Context, hrefs []string) (pgx.Tx, func(), membership, repositoryHrefMap, error) {
+	tx, end, err := t.begin(ctx)
 	if err != nil {
-		return nil, "", nil, err
+		return nil, nil, membership{}, nil, err
 	}
 
-	repoUUID, err := parsePythonRepositoryHref(repositoryHref)
+	repoVerMap, repositories, err := resolveRepositoryHrefs(ctx, tx, hrefs, pageCursor{})
 	if err != nil {
-		conn.Release()
-		return nil, "", nil, fmt.Errorf("error parsing repository href: %w", err)
+		end()
+		return nil, nil, membership{}, nil, err
 	}
 
-	latestVersion, err := getLatestPythonRepositoryVersion(ctx, conn, repoUUID)
+	m, err := t.contentMembership(ctx, tx, repoVerMap)
 	if err != nil {
-		conn.Release()
-		return nil, "", nil, fmt.Errorf("error getting latest repository version: %w", err)
+		end()
+		return nil, nil, membership{}, nil, err
 	}
 
-	repoVerMap := []ParsedRepoVersion{{
-		RepositoryUUID: repoUUID,
-		Version:        latestVersion,
-	}}
-
-	args := pgx.NamedArgs{}
-	if nameNormalized != "" {
-		args["name_normalized"] = nameNormalized
-	}
+	return tx, end, m, repositories, nil
+}
 
-	innerUnion, err := contentIdsInVersions(ctx, conn, repoVerMap, &args)
+func fetchPythonPackageDetailRows(ctx context.Context, tx pgx.Tx, query sqlQuery) ([]pythonPackageDetailRow, error) {
+	rows, err := tx.Query(ctx, query.SQL, query.Args)
 	if err != nil {
-		conn.Release()
-		return nil, "", nil, err
+		return nil, err
 	}
 
-	return conn, innerUnion, args, nil
+	return collectRows(ctx, rows, pgx.RowToStructByName[pythonPackageDetailRow])
 }
 
-func fetchPythonPackageDetailRows(ctx context.Context, conn *pgxpool.Conn, innerUnion string, args pgx.NamedArgs, version string) ([]pythonPackageDetailRow, error) {
-	detailFilter := ""
-	orderBy := "ORDER BY d.version"
-	if version != "" {
-		args["version"] = version
-		detailFilter = "WHERE f.version = @version"
-		orderBy = ""
-	}
+// pythonPackageDetailQuery selects one representative row per version of a package, or only of the given version
+func pythonPackageDetailQuery(m membership, nameNormalized, version string) sqlQuery {
+	b := newQueryBuilder()
+	join := b.membershipJoin(m)
 
-	// Build WHERE clause conditionally based on provided parameters
-	var whereClause string
-	if _, hasNameNormalized := args["name_normalized"]; hasNameNormalized {
-		whereClause = "\n\t\t\tAND rp.name_normalized = @name_normalized"
+	var where conditions
+	if nameNormalized != "" {
+		where = append(where, "rp.name_normalized = "+b.bind("name_normalized", nameNormalized))
+	}
+	var detailFilter conditions
+	order := "ORDER BY d.version"
+	if version != "" {
+		detailFilter = append(detailFilter, "f.version = "+b.bind("version", version))
+		order = ""
 	}
 
-	query := `
+	return b.query(`
 		WITH filtered AS (
 			SELECT rp.name, rp.name_normalized, rp.version, rp.summary, rp.description,
 			       rp.description_content_type, rp.author, rp.author_email,
 			       rp.maintainer, rp.maintainer_email, rp.license, rp.license_expression,
 			       rp.home_page, rp.project_url, rp.project_urls, rp.keywords,
 			       rp.requires_python, rp.classifiers, rp.requires_dist,
-			       rp.packagetype, cc.pulp_created
+			       rp.packagetype, cc.pulp_created, crv.repository_id
 			FROM python_pythonpackagecontent rp
-			INNER JOIN core_content cc ON rp.content_ptr_id = cc.pulp_id
-	` + innerUnion + whereClause + `
+			INNER JOIN core_content cc ON rp.content_ptr_id = cc.pulp_id` + join + where.and() + `
 		),
 		version_agg AS (
 			SELECT
@@ -661,6 +772,11 @@ func fetchPythonPackageDetailRows(ctx context.Context, conn *pgxpool.Conn, inner
 				GROUP BY version
 			) v
 		),
+		version_repositories AS (
+			SELECT version, ARRAY_AGG(DISTINCT repository_id) AS repository_ids
+			FROM filtered
+			GROUP BY version
+		),
 		detail AS (
 			SELECT f.*,
 			       MAX(f.pulp_created) OVER (PARTITION BY f.version) AS last_updated,
@@ -669,29 +785,22 @@ func fetchPythonPackageDetailRows(ctx context.Context, conn *pgxpool.Conn, inner
 			           ORDER BY CASE WHEN f.packagetype = 'sdist' THEN 0 ELSE 1 END,
 			                    f.pulp_created DESC
 			       ) AS rn
-			FROM filtered f
-			` + detailFilter + `
+			FROM filtered f` + detailFilter.clause("WHERE") + `
 		)
 		SELECT d.name, d.name_normalized, d.version, d.summary, d.description,
 		       d.description_content_type, d.author, d.author_email,
 		       d.maintainer, d.maintainer_email, d.license, d.license_expression,
 		       d.home_page, d.project_url, d.project_urls, d.keywords,
 		       d.requires_python, d.classifiers, d.requires_dist,
-		       d.last_updated, va.versions, va.latest_versions_json
+		       d.last_updated, va.versions, va.latest_versions_json, vr.repository_ids
 		FROM detail d
 		CROSS JOIN version_agg va
+		INNER JOIN version_repositories vr ON vr.version = d.version
 		WHERE d.rn = 1
-		` + orderBy
-
-	rows, err := conn.Query(ctx, query, args)
-	if err != nil {
-		return nil, err
-	}
-
-	return pgx.CollectRows(rows, pgx.RowToStructByName[pythonPackageDetailRow])
+		` + order)
 }
 
-func pythonPackageDetailFromRow(row pythonPackageDetailRow, latestVersions []PythonVersionInfo, distributions []PythonDistributionListItem) PythonPackageDetail {
+func pythonPackageDetailFromRow(row pythonPackageDetailRow, latestVersions []PythonVersionInfo, distributions []PythonDistributionListItem, repositories repositoryHrefMap) PythonPackageDetail {
 	return PythonPackageDetail{
 		Name:                   row.Name,
 		NameNormalized:         row.NameNormalized,
@@ -716,36 +825,74 @@ func pythonPackageDetailFromRow(row pythonPackageDetailRow, latestVersions []Pyt
 		Versions:               row.Versions,
 		LatestVersions:         latestVersions,
 		Distributions:          distributions,
+		Repositories:           repositories.hrefs(row.RepositoryIds),
 	}
 }
 
-func fetchPythonDistributionRows(ctx context.Context, conn *pgxpool.Conn, innerUnion string, args pgx.NamedArgs, limit, offset int) ([]pythonDistributionRow, error) {
-	query := `
-		SELECT rp.name, rp.name_normalized, rp.version, rp.filename, rp.packagetype,
-		       rp.python_version, rp.sha256, rp.size, cc.pulp_created AS created_at
-		FROM python_pythonpackagecontent rp
-		INNER JOIN core_content cc ON rp.content_ptr_id = cc.pulp_id
-	` + innerUnion + `
-		AND rp.name_normalized = @name_normalized
-		AND rp.version = @version
-		ORDER BY cc.pulp_created DESC`
+func fetchPythonDistributionRows(ctx context.Context, tx pgx.Tx, query sqlQuery) ([]pythonDistributionRow, error) {
+	rows, err := tx.Query(ctx, query.SQL, query.Args)
+	if err != nil {
+		return nil, err
+	}
 
-	if limit > 0 {
-		args["limit"] = limit
-		args["offset"] = offset
-		query += `
-		LIMIT @limit OFFSET @offset`
+	return collectRows(ctx, rows, pgx.RowToStructByName[pythonDistributionRow])
+}
+
+func pythonDistributionListCountQuery(m membership, nameNormalized, version string, countLimit int) sqlQuery {
+	b := newQueryBuilder()
+	return b.query(b.count(`
+		SELECT DISTINCT rp.content_ptr_id
+		FROM python_pythonpackagecontent rp`+b.membershipJoin(m)+pythonDistributionFilters(b, nameNormalized, version).and(), countLimit))
+}
+
+// pythonDistributionQuery selects the distribution files of a package version. All of them are selected when limit is 0.
+func pythonDistributionQuery(m membership, nameNormalized, version string, limit, offset int, cursor pageCursor) sqlQuery {
+	b := newQueryBuilder()
+	return b.pythonDistributions(m, pythonDistributionFilters(b, nameNormalized, version), limit, offset, cursor)
+}
+
+// pythonDistributionAllQuery selects the distribution files of every package, or of a package name and version when set
+func pythonDistributionAllQuery(m membership, nameNormalized, version string) sqlQuery {
+	b := newQueryBuilder()
+	var where conditions
+	if nameNormalized != "" {
+		where = append(where, "rp.name_normalized = "+b.bind("name_normalized", nameNormalized))
+	}
+	if version != "" {
+		where = append(where, "rp.version = "+b.bind("version", version))
 	}
+	return b.pythonDistributions(m, where, 0, 0, pageCursor{})
+}
 
-	rows, err := conn.Query(ctx, query, args)
-	if err != nil {
-		return nil, err
+// pythonDistributions selects the distribution files matching where
+func (b *queryBuilder) pythonDistributions(m membership, where conditions, limit, offset int, cursor pageCursor) sqlQuery {
+	join := b.membershipJoin(m)
+	where = append(where, b.pageAfter(pythonDistributionListSort, cursor)...)
+
+	page := ""
+	if limit > 0 {
+		page = `
+		` + b.page(limit, offset, cursor)
 	}
 
-	return pgx.CollectRows(rows, pgx.RowToStructByName[pythonDistributionRow])
+	return b.query(`
+		SELECT rp.content_ptr_id, rp.name, rp.name_normalized, rp.version, rp.filename, rp.packagetype,
+		       rp.python_version, rp.sha256, rp.size, cc.pulp_created AS created_at,
+		       ARRAY_AGG(DISTINCT crv.repository_id) AS repository_ids
+		FROM python_pythonpackagecontent rp
+		INNER JOIN core_content cc ON rp.content_ptr_id = cc.pulp_id` + join + where.and() + `
+		GROUP BY rp.content_ptr_id, cc.pulp_created
+		` + orderBy(pythonDistributionListSort) + page)
+}
+
+func pythonDistributionFilters(b *queryBuilder, nameNormalized, version string) conditions {
+	return conditions{
+		"rp.name_normalized = " + b.bind("name_normalized", nameNormalized),
+		"rp.version = " + b.bind("version", version),
+	}
 }
 
-func pythonDistributionRowToItem(dist pythonDistributionRow) PythonDistributionListItem {
+func pythonDistributionRowToItem(dist pythonDistributionRow, repositories repositoryHrefMap) PythonDistributionListItem {
 	return PythonDistributionListItem{
 		Name:           dist.Name,
 		NameNormalized: dist.NameNormalized,
@@ -756,38 +903,36 @@ func pythonDistributionRowToItem(dist pythonDistributionRow) PythonDistributionL
 		Sha256:         dist.Sha256,
 		Size:           dist.Size,
 		CreatedAt:      dist.CreatedAt.Format(time.RFC3339),
+		Repositories:   repositories.hrefs(dist.RepositoryIds),
 	}
 }
 
-func pythonDistributionRowsToItems(distributions []pythonDistributionRow) []PythonDistributionListItem {
+func pythonDistributionRowsToItems(distributions []pythonDistributionRow, repositories repositoryHrefMap) []PythonDistributionListItem {
 	results := make([]PythonDistributionListItem, len(distributions))
 	for i, dist := range distributions {
-		results[i] = pythonDistributionRowToItem(dist)
+		results[i] = pythonDistributionRowToItem(dist, repositories)
 	}
 	return results
 }
 
-func fetchAllPythonDistributionRowsForPackage(ctx context.Context, conn *pgxpool.Conn, innerUnion string, args pgx.NamedArgs) ([]pythonDistributionRow, error) {
-	// Build WHERE clause conditionally based on provided parameters
-	var whereClause string
-	if _, hasNameNormalized := args["name_normalized"]; hasNameNormalized {
-		whereClause = "\n\t\tAND rp.name_normalized = @name_normalized"
-	}
-
-	query := `
-		SELECT rp.name, rp.name_normalized, rp.version, rp.filename, rp.packagetype,
-		       rp.python_version, rp.sha256, rp.size, cc.pulp_created AS created_at
-		FROM python_pythonpackagecontent rp
-		INNER JOIN core_content cc ON rp.content_ptr_id = cc.pulp_id
-	` + innerUnion + whereClause + `
-		ORDER BY rp.version, cc.pulp_created DESC`
+// pythonPackageDistributionsQuery selects the distribution files of every version of a package
+func pythonPackageDistributionsQuery(m membership, nameNormalized string) sqlQuery {
+	b := newQueryBuilder()
+	join := b.membershipJoin(m)
 
-	rows, err := conn.Query(ctx, query, args)
-	if err != nil {
-		return nil, err
+	var where conditions
+	if nameNormalized != "" {
+		where = append(where, "rp.name_normalized = "+b.bind("name_normalized", nameNormalized))
 	}
 
-	return pgx.CollectRows(rows, pgx.RowToStructByName[pythonDistributionRow])
+	return b.query(`
+		SELECT rp.content_ptr_id, rp.name, rp.name_normalized, rp.version, rp.filename, rp.packagetype,
+		       rp.python_version, rp.sha256, rp.size, cc.pulp_created AS created_at,
+		       ARRAY_AGG(DISTINCT crv.repository_id) AS repository_ids
+		FROM python_pythonpackagecontent rp
+		INNER JOIN core_content cc ON rp.content_ptr_id = cc.pulp_id` + join + where.and() + `
+		GROUP BY rp.content_ptr_id, cc.pulp_created
+		ORDER BY rp.version, cc.pulp_created DESC`)
 }
 
 func parsePythonLatestVersionsJSON(data []byte) ([]PythonVersionInfo, error) {
@@ -855,19 +1000,21 @@ func parsePythonJSONStringMap(data []byte) map[string]string {
 	return values
 }
 
-func assemblePythonPackageListFromRows(rows []pythonPackageVersionRow) []PythonPackageListItem {
+func assemblePythonPackageListFromRows(rows []pythonPackageVersionRow, repositories repositoryHrefMap) []PythonPackageListItem {
 	if len(rows) == 0 {
 		return nil
 	}
 
 	results := make([]PythonPackageListItem, 0)
 	var current PythonPackageListItem
+	var currentRepositoryIds []string
 
 	for i, row := range rows {
 		if i == 0 || row.NameNormalized != current.NameNormalized {
 			if i > 0 {
 				results = append(results, current)
 			}
+			currentRepositoryIds = slices.Clone(row.RepositoryIds)
 			current = PythonPackageListItem{
 				Name:           row.Name,
 				NameNormalized: row.NameNormalized,
@@ -876,6 +1023,7 @@ func assemblePythonPackageListFromRows(rows []pythonPackageVersionRow) []PythonP
 					Version:   row.Version,
 					CreatedAt: row.CreatedAt.Format(time.RFC3339),
 				}},
+				Repositories: repositories.hrefs(row.RepositoryIds),
 			}
 			continue
 		}
@@ -885,41 +1033,9 @@ func assemblePythonPackageListFromRows(rows []pythonPackageVersionRow) []PythonP
 			Version:   row.Version,
 			CreatedAt: row.CreatedAt.Format(time.RFC3339),
 		})
+		currentRepositoryIds = append(currentRepositoryIds, row.RepositoryIds...)
+		current.Repositories = repositories.hrefs(currentRepositoryIds)
 	}
 
 	return append(results, current)
 }
-
-// parsePythonRepositoryHref extracts the repository UUID from a Python repository href.
-// Example: /api/pulp/default/api/v3/repositories/python/python/018c1c95-4281-76eb-b277-842cbad524f4/
-func parsePythonRepositoryHref(href string) (string, error) {
-	parts := strings.Split(href, "/")
-	var nonEmptyParts []string
-	for _, part := range parts {
-		if part != "" {
-			nonEmptyParts = append(nonEmptyParts, part)
-		}
-	}
-
-	if len(nonEmptyParts) < 8 {
-		return "", fmt.Errorf("invalid repository href format: %s", href)
-	}
-
-	return nonEmptyParts[len(nonEmptyParts)-1], nil
-}
-
-func getLatestPythonRepositoryVersion(ctx context.Context, conn *pgxpool.Conn, repoUUID string) (int, error) {
-	query := `
-		SELECT MAX(number)
-		FROM core_repositoryversion
-		WHERE repository_id = $1 AND complete = true
-	`
-
-	var latestVersion int
-	err := conn.QueryRow(ctx, query, repoUUID).Scan(&latestVersion)
-	if err != nil {
-		return 0, fmt.Errorf("failed to get latest version for repository %s: %w", repoUUID, err)
-	}
-
-	return latestVersion, nil
-}
@@ -9,48 +9,6 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-func TestParsePythonRepositoryHref(t *testing.T) {
-	t.Parallel()
-
-	tests := []struct {
-		name    string
-		href    string
-		want    string
-		wantErr bool
-	}{
-		{
-			name: "valid python repository href",
-			href: "/api/pulp/default/api/v3/repositories/python/python/018c1c95-4281-76eb-b277-842cbad524f4/",
-			want: "018c1c95-4281-76eb-b277-842cbad524f4",
-		},
-		{
-			name: "valid href without trailing slash",
-			href: "/api/pulp/default/api/v3/repositories/python/python/018c1c95-4281-76eb-b277-842cbad524f4",
-			want: "018c1c95-4281-76eb-b277-842cbad524f4",
-		},
-		{
-			name:    "invalid href",
-			href:    "/api/pulp/default/api/v3/repositories/python/",
-			wantErr: true,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			t.Parallel()
-
-			got, err := parsePythonRepositoryHref(tt.href)
-			if tt.wantErr {
-				require.Error(t, err)
-				return
-			}
-
-			require.NoError(t, err)
-			assert.Equal(t, tt.want, got)
-		})
-	}
-}
-
 func TestAssemblePythonPackageListFromRows(t *testing.T) {
 	t.Parallel()
 
@@ -133,7 +91,7 @@ func TestAssemblePythonPackageListFromRows(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			got := assemblePythonPackageListFromRows(tt.rows)
+			got := assemblePythonPackageListFromRows(tt.rows, nil)
 			assert.Equal(t, tt.want, got)
 		})
 	}
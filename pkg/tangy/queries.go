@@ -3,138 +3,244 @@ package tangy
 import (
 	"context"
 	"fmt"
-	"math/rand/v2"
+	"slices"
 	"strings"
 
 	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgxpool"
 )
 
-// contentIdsInVersionNew forms a single query to fetch a list of content ids in a repository version
-// using the new content_ids array field (only works for versions created after August 1st, 2025)
+// repositoryVersionArrays returns the repository uuids and version numbers of the given map as two arrays of the
+// same length, without duplicate repository versions. The arrays are passed as query parameters and unnested
+// together, so a query has the same shape and number of parameters no matter how many versions are requested.
+func repositoryVersionArrays(repoVerMap []ParsedRepoVersion) ([]string, []int) {
+	repoIds := make([]string, 0, len(repoVerMap))
+	versionNums := make([]int, 0, len(repoVerMap))
+	for i, parsed := range repoVerMap {
+		if slices.Contains(repoVerMap[:i], parsed) {
+			continue
+		}
+		repoIds = append(repoIds, parsed.RepositoryUUID)
+		versionNums = append(versionNums, parsed.Version)
+	}
+	return repoIds, versionNums
+}
+
+// requestedVersions adds the repository uuids and version numbers of the given map as arguments and returns
+// a FROM item unnesting them as requested(repository_id, number)
 //
-//	It uses randomized query parameter names and modifies the passed in namedArgs to include the key/values for these named query parameters.
-//	By using randomized query parameter names, this query can be included multiple times with different repository
-//	versions as multiple subqueries.
-func contentIdsInVersionNew(repoId string, versionNum int, namedArgs *pgx.NamedArgs) string {
-	ran := rand.Int()
-	repoIdName := fmt.Sprintf("%v%v", "repoName", ran)
-	versionNumName := fmt.Sprintf("%v%v", "versionNum", ran)
-	query := `
-                (crv.repository_id = @%v AND crv.number = @%v AND crv.content_ids IS NOT NULL)
-	`
-	(*namedArgs)[repoIdName] = repoId
-	(*namedArgs)[versionNumName] = versionNum
-	return fmt.Sprintf(query, repoIdName, versionNumName)
+//	Its arguments are numbered (repoIds0, versionNums0, repoIds1, ...), so that this FROM item can be included
+//	multiple times with different repository versions as multiple subqueries.
+func (b *queryBuilder) requestedVersions(repoVerMap []ParsedRepoVersion) string {
+	repoIds, versionNums := repositoryVersionArrays(repoVerMap)
+	return fmt.Sprintf("UNNEST(%v::uuid[], %v::integer[]) AS requested(repository_id, number)",
+		b.bindNext("repoIds", repoIds), b.bindNext("versionNums", versionNums))
+}
+
+// contentIdsInVersionNew forms a subquery selecting the repository id and content id of every content unit
+// in a set of repository versions, using the new content_ids array field (only works for versions created after August 1st, 2025)
+func (b *queryBuilder) contentIdsInVersionNew(repoVerMap []ParsedRepoVersion) string {
+	return fmt.Sprintf(`
+                        SELECT crv.repository_id, UNNEST(crv.content_ids) AS content_id
+                        FROM %v
+                        INNER JOIN core_repositoryversion crv ON (crv.repository_id = requested.repository_id AND crv.number = requested.number)
+                        WHERE crv.content_ids IS NOT NULL
+	`, b.requestedVersions(repoVerMap))
 }
 
-// contentIdsInVersionOld forms a single query to fetch a list of content ids in a repository version
-// using the old method with core_repositorycontent table (works for all versions)
+// contentIdsInVersionOld forms a subquery selecting the repository id and content id of every content unit
+// in a set of repository versions, using the old method with core_repositorycontent table (works for all versions)
 //
 //	TODO: DELETE THIS FUNCTION after August 1st, 2026 when all repository versions use content_ids
-//	It uses randomized query parameter names and modifies the passed in namedArgs to include the key/values for these named query parameters.
-//	By using randomized query parameter names, this query can be included multiple times with different repository
-//	versions as multiple subqueries.
-func contentIdsInVersionOld(repoId string, versionNum int, namedArgs *pgx.NamedArgs) string {
-	ran := rand.Int()
-	repoIdName := fmt.Sprintf("%v%v", "repoName", ran)
-	versionNumName := fmt.Sprintf("%v%v", "versionNum", ran)
-	query := `
-                (crv.repository_id = @%v AND crv.number <= @%v AND NOT (crv2.number <= @%v AND crv2.number IS NOT NULL))
-	`
-	(*namedArgs)[repoIdName] = repoId
-	(*namedArgs)[versionNumName] = versionNum
-	return fmt.Sprintf(query, repoIdName, versionNumName, versionNumName)
+func (b *queryBuilder) contentIdsInVersionOld(repoVerMap []ParsedRepoVersion) string {
+	return fmt.Sprintf(`
+                        SELECT crv.repository_id, crc.content_id
+                        FROM %v
+                        INNER JOIN core_repositoryversion crv ON (crv.repository_id = requested.repository_id AND crv.number <= requested.number)
+                        INNER JOIN core_repositorycontent crc ON (crc.version_added_id = crv.pulp_id)
+                        LEFT OUTER JOIN core_repositoryversion crv2 ON (crc.version_removed_id = crv2.pulp_id)
+                        WHERE NOT (crv2.number <= requested.number AND crv2.number IS NOT NULL)
+	`, b.requestedVersions(repoVerMap))
 }
 
-// contentIdsInVersionsNew returns part of a query that joins a table to the needed tables to select content units
-// in a given set of versions using the new content_ids array field
+// contentIdsInVersionsJoin returns part of a query that joins a table to the deduplicated set of
+// (repository id, content id) pairs selected by the given subqueries.
 //
-//	TODO: DELETE THIS FUNCTION after August 1st, 2026 when all repository versions use content_ids
-//	 The return of this functions should be added to a query such as "select ** from TABLE rp" query,
-//	 Where rp has a column 'content_ptr_id', such as rpm_updaterecord, rpm_package, etc.
-//		Takes in a pointer to Named args in order to add required named arguments for the query.
-func contentIdsInVersionsNew(repoVerMap []ParsedRepoVersion, namedArgs *pgx.NamedArgs) string {
-	mainQuery := ` 				
-                INNER JOIN core_repositoryversion crv ON (rp.content_ptr_id = ANY(crv.content_ids))
+//	The content ids are resolved once for all repository versions, instead of matching every row of the table
+//	against the content of each repository version. The set is exposed as crv, and only crv.repository_id
+//	is available to the rest of the query.
+func contentIdsInVersionsJoin(subqueries ...string) string {
+	return fmt.Sprintf(`
+                INNER JOIN (
+                    SELECT DISTINCT membership.repository_id, membership.content_id
+                    FROM (%v) membership
+                ) crv ON (rp.content_ptr_id = crv.content_id)
                 WHERE
-                    `
-	queries := []string{}
-	for _, parsed := range repoVerMap {
-		queries = append(queries, contentIdsInVersionNew(parsed.RepositoryUUID, parsed.Version, namedArgs))
-	}
-	return fmt.Sprintf("%v (%v)", mainQuery, strings.Join(queries, " OR "))
+                    (TRUE)
+	`, strings.Join(subqueries, " UNION ALL "))
 }
 
-// contentIdsInVersionsOld returns part of a query that joins a table to the needed tables to select content units
-// in a given set of versions using the old method with core_repositorycontent table
+// membership is the set of repository versions a query selects content units from.
+// OldVersions are the versions among Versions that lack content_ids, and are read through core_repositorycontent.
+type membership struct {
+	Versions    []ParsedRepoVersion
+	OldVersions []ParsedRepoVersion
+}
+
+// newVersions returns the repository versions of the membership that are read through content_ids
+func (m membership) newVersions() []ParsedRepoVersion {
+	return slices.DeleteFunc(slices.Clone(m.Versions), func(repoVersion ParsedRepoVersion) bool {
+		return slices.Contains(m.OldVersions, repoVersion)
+	})
+}
+
+// membershipJoin returns part of a query that joins a table to the needed tables to select content units
+// in the repository versions of the membership, using content_ids for the versions that have them,
+// and core_repositorycontent for the others.
 //
-//	TODO: DELETE THIS FUNCTION after August 1st, 2025 when all repository versions use content_ids
 //	 The return of this functions should be added to a query such as "select ** from TABLE rp" query,
 //	 Where rp has a column 'content_ptr_id', such as rpm_updaterecord, rpm_package, etc.
-//		Takes in a pointer to Named args in order to add required named arguments for the query.
-func contentIdsInVersionsOld(repoVerMap []ParsedRepoVersion, namedArgs *pgx.NamedArgs) string {
-	mainQuery := ` 				
-                INNER JOIN core_repositorycontent crc on rp.content_ptr_id = crc.content_id
-                INNER JOIN core_repositoryversion crv ON (crc.version_added_id = crv.pulp_id)
-                LEFT OUTER JOIN core_repositoryversion crv2 ON (crc.version_removed_id = crv2.pulp_id)
-                WHERE
-                    `
-	queries := []string{}
-	for _, parsed := range repoVerMap {
-		queries = append(queries, contentIdsInVersionOld(parsed.RepositoryUUID, parsed.Version, namedArgs))
+//		It ends with an open WHERE clause, that further conditions continue with AND.
+func (b *queryBuilder) membershipJoin(m membership) string {
+	newVersions := m.newVersions()
+	var subqueries []string
+	if len(newVersions) > 0 || len(m.OldVersions) == 0 {
+		subqueries = append(subqueries, b.contentIdsInVersionNew(newVersions))
+	}
+	// TODO: DELETE after August 1st, 2026 when all repository versions use content_ids
+	if len(m.OldVersions) > 0 {
+		subqueries = append(subqueries, b.contentIdsInVersionOld(m.OldVersions))
 	}
-	return fmt.Sprintf("%v (%v)", mainQuery, strings.Join(queries, " OR "))
+	return contentIdsInVersionsJoin(subqueries...)
 }
 
-// checkAllVersionsWithContentIds checks if all repository versions in the given map were created after the specified date
-func checkAllVersionsWithContentIds(ctx context.Context, conn *pgxpool.Conn, repoVerMap []ParsedRepoVersion) (bool, error) {
+// repositoryVersionsWithoutContentIds returns the repository versions in the given map that were created before
+// the content_ids field was populated, and must be queried through core_repositorycontent.
+// Returns ErrRepositoryVersionNotFound if any of the repository versions does not exist.
+func repositoryVersionsWithoutContentIds(ctx context.Context, tx pgx.Tx, repoVerMap []ParsedRepoVersion) ([]ParsedRepoVersion, error) {
 	if len(repoVerMap) == 0 {
-		return true, nil
+		return nil, nil
 	}
 
-	// Build query to check content_ids dates of all repository versions
-	queryParts := []string{}
-	args := pgx.NamedArgs{}
-	for i, parsed := range repoVerMap {
-		repoIdParam := fmt.Sprintf("repoId%d", i)
-		versionNumParam := fmt.Sprintf("versionNum%d", i)
-		queryParts = append(queryParts, fmt.Sprintf("(crv.repository_id = @%s AND crv.number = @%s)", repoIdParam, versionNumParam))
-		args[repoIdParam] = parsed.RepositoryUUID
-		args[versionNumParam] = parsed.Version
+	// Check content_ids of all repository versions at once
+	b := newQueryBuilder()
+	query := b.query(fmt.Sprintf(`
+		SELECT crv.repository_id, crv.number, crv.content_ids IS NULL
+		FROM %v
+		INNER JOIN core_repositoryversion crv ON (crv.repository_id = requested.repository_id AND crv.number = requested.number)
+	`, b.requestedVersions(repoVerMap)))
+
+	rows, err := tx.Query(ctx, query.SQL, query.Args)
+	if err != nil {
+		return nil, err
 	}
+	defer rows.Close()
 
-	query := fmt.Sprintf(`
-		SELECT COUNT(*) 
-		FROM core_repositoryversion crv 
-		WHERE (%s) AND crv.content_ids is null
-	`, strings.Join(queryParts, " OR "))
+	found := []ParsedRepoVersion{}
+	withoutContentIds := []ParsedRepoVersion{}
+	for rows.Next() {
+		var version ParsedRepoVersion
+		var contentIdsNull bool
+		if err := rows.Scan(&version.RepositoryUUID, &version.Version, &contentIdsNull); err != nil {
+			return nil, err
+		}
+		found = append(found, version)
+		if contentIdsNull {
+			withoutContentIds = append(withoutContentIds, version)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
 
-	var count int
-	err := conn.QueryRow(ctx, query, args).Scan(&count)
-	if err != nil {
-		return false, err
+	if missing := missingRepositoryVersions(repoVerMap, found); len(missing) > 0 {
+		return nil, fmt.Errorf("%w: %s version %d", ErrRepositoryVersionNotFound, missing[0].RepositoryUUID, missing[0].Version)
+	}
+
+	// Return the repository versions as requested, postgres returns repository uuids in lower case
+	return slices.DeleteFunc(slices.Clone(repoVerMap), func(repoVersion ParsedRepoVersion) bool {
+		return !containsRepositoryVersion(withoutContentIds, repoVersion)
+	}), nil
+}
+
+// checkCompleteRepositoryVersions records whether the given repository versions all exist and are complete,
+// for CacheInterceptor not to cache results read from a version whose content is still being written.
+// Nothing is checked for calls that are not cached.
+func checkCompleteRepositoryVersions(ctx context.Context, tx pgx.Tx, repoVerMap []ParsedRepoVersion) error {
+	if !recordsResolvedVersions(ctx) || len(repoVerMap) == 0 {
+		return nil
+	}
+
+	b := newQueryBuilder()
+	query := b.query(fmt.Sprintf(`
+		SELECT COUNT(*)
+		FROM %v
+		INNER JOIN core_repositoryversion crv ON (crv.repository_id = requested.repository_id AND crv.number = requested.number)
+		WHERE crv.complete
+	`, b.requestedVersions(repoVerMap)))
+
+	var complete int
+	if err := tx.QueryRow(ctx, query.SQL, query.Args).Scan(&complete); err != nil {
+		return err
+	}
+	recordCompleteVersions(ctx, complete == len(repoVerMap))
+	return nil
+}
+
+// missingRepositoryVersions returns the repository versions of repoVerMap that are not in found
+func missingRepositoryVersions(repoVerMap, found []ParsedRepoVersion) []ParsedRepoVersion {
+	var missing []ParsedRepoVersion
+	for _, repoVersion := range repoVerMap {
+		if !containsRepositoryVersion(found, repoVersion) && !containsRepositoryVersion(missing, repoVersion) {
+			missing = append(missing, repoVersion)
+		}
 	}
+	return missing
+}
 
-	// If count is 0, all versions are after the cutoff date
-	return count == 0, nil
+// containsRepositoryVersion returns true if versions contains repoVersion.
+// Repository uuids are compared case-insensitively, as postgres returns them in lower case.
+func containsRepositoryVersion(versions []ParsedRepoVersion, repoVersion ParsedRepoVersion) bool {
+	return slices.ContainsFunc(versions, func(other ParsedRepoVersion) bool {
+		return other.Version == repoVersion.Version && strings.EqualFold(other.RepositoryUUID, repoVersion.RepositoryUUID)
+	})
 }
 
-// returns part of a query that joins a table to the needed tables to select content units in a given set of versions
+// contentMembership returns the membership of the given repository versions, to be joined with membershipJoin.
 //
-//	 The return of this functions should be added to a query such as "select ** from TABLE rp" query,
-//	 Where rp has a column 'content_ptr_id', such as rpm_updaterecord, rpm_package, etc.
-//		Takes in a pointer to Named args in order to add required named arguments for the query.
-//		This function automatically chooses between the old and new query methods based on repository version creation dates.
-func contentIdsInVersions(ctx context.Context, conn *pgxpool.Conn, repoVerMap []ParsedRepoVersion, namedArgs *pgx.NamedArgs) (string, error) {
-	// Check if all versions are after the cutoff date, not needed after August 1st, 2026
-	useNewMethod, err := checkAllVersionsWithContentIds(ctx, conn, repoVerMap)
+//	This function automatically chooses between the old and new query methods for each repository version,
+//	unless the Database was configured with SkipContentIdsCheck.
+func (t *tangyImpl) contentMembership(ctx context.Context, tx pgx.Tx, repoVerMap []ParsedRepoVersion) (membership, error) {
+	if err := checkCompleteRepositoryVersions(ctx, tx, repoVerMap); err != nil {
+		return membership{}, fmt.Errorf("error checking repository versions: %w", err)
+	}
+	if t.skipContentIdsCheck {
+		m := membership{Versions: repoVerMap}
+		m.record(ctx)
+		return m, nil
+	}
+
+	// Check which versions lack content_ids, not needed after August 1st, 2026
+	oldVersions, err := repositoryVersionsWithoutContentIds(ctx, tx, repoVerMap)
 	if err != nil {
-		return "", fmt.Errorf("error checking repository version dates: %w", err)
+		return membership{}, fmt.Errorf("error checking repository versions: %w", err)
 	}
+	m := membership{Versions: repoVerMap, OldVersions: oldVersions}
+	m.record(ctx)
+	return m, nil
+}
 
-	if useNewMethod {
-		return contentIdsInVersionsNew(repoVerMap, namedArgs), nil
+// record records the repository versions read by the call ctx belongs to, and the membership paths used,
+// so that the statistics show whether core_repositorycontent is still read
+func (m membership) record(ctx context.Context) {
+	state := callOf(ctx)
+	if state == nil {
+		return
+	}
+	recordRepositoryVersions(ctx, m.Versions)
+	if len(m.newVersions()) > 0 {
+		state.contentIds.Store(true)
+	}
+	if len(m.OldVersions) > 0 {
+		state.legacy.Store(true)
 	}
-	return contentIdsInVersionsOld(repoVerMap, namedArgs), nil
 }
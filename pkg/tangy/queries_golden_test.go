@@ -0,0 +1,319 @@
+package tangy
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The golden tests in this file render the SQL statements run by every public method, and compare them
+// with the files in testdata/golden. After an intended change to a query, regenerate the files with:
+//
+//	go test ./pkg/tangy/ -run TestGoldenQueries -update
+
+var updateGolden = flag.Bool("update", false, "update the golden SQL files in testdata/golden")
+
+const goldenSecondUUID = "019f3808-fcc2-716e-a7d3-e5a7ef1522a0"
+
+var (
+	// goldenSingle is the membership of a single repository version with content_ids
+	goldenSingle = membership{Versions: []ParsedRepoVersion{{RepositoryUUID: testRepoVersionUUID, Version: 3}}}
+	// goldenHybrid is the membership of two repository versions, the second one without content_ids
+	goldenHybrid = membership{
+		Versions: []ParsedRepoVersion{
+			{RepositoryUUID: testRepoVersionUUID, Version: 3},
+			{RepositoryUUID: goldenSecondUUID, Version: 1},
+		},
+		OldVersions: []ParsedRepoVersion{{RepositoryUUID: goldenSecondUUID, Version: 1}},
+	}
+	goldenPage = PageOptions{Limit: 10, Offset: 20}
+)
+
+// goldenCursor returns a cursor with the given key, as decoded from PageOptions.Cursor
+func goldenCursor(key ...string) pageCursor {
+	return pageCursor{Kind: "golden", Versions: goldenHybrid.Versions, Key: key}
+}
+
+var goldenQueries = []struct {
+	method  string
+	queries func() []sqlQuery
+}{
+	{"RpmRepositoryVersionPackageSearch", func() []sqlQuery {
+		return []sqlQuery{rpmPackageSearchQuery(goldenHybrid, "kernel", 10)}
+	}},
+	{"RpmRepositoryVersionPackageGroupSearch", func() []sqlQuery {
+		return []sqlQuery{rpmPackageGroupSearchQuery(goldenSingle, "base")}
+	}},
+	{"RpmRepositoryVersionEnvironmentSearch", func() []sqlQuery {
+		return []sqlQuery{rpmEnvironmentSearchQuery(goldenHybrid, "server", 10)}
+	}},
+	{"RpmRepositoryVersionPackageList", func() []sqlQuery {
+		filters := RpmListFilters{Name: "bear"}
+		return []sqlQuery{
+			rpmPackageListCountQuery(goldenHybrid, filters, 0),
+			rpmPackageListQuery(goldenHybrid, filters, goldenPage, goldenCursor("bear", "4.1", "1", "noarch", testRepoVersionUUID)),
+		}
+	}},
+	{"RpmRepositoryVersionModuleStreamsList", func() []sqlQuery {
+		filters := ModuleStreamListFilters{RpmNames: []string{"nodejs"}, Search: "node"}
+		return []sqlQuery{rpmModuleStreamsListQuery(goldenHybrid, filters, "name:desc")}
+	}},
+	{"RpmRepositoryVersionErrataList", func() []sqlQuery {
+		filters := ErrataListFilters{Search: "RHSA", Type: []string{"security,other"}, Severity: []string{"Unknown"}}
+		_, order := errataListSort("updated_date:asc")
+		return []sqlQuery{
+			rpmErrataListCountQuery(goldenHybrid, filters, EstimatedCountLimit),
+			rpmErrataListQuery(goldenHybrid, filters, order, goldenPage, goldenCursor("2024-01-01", testRepoVersionUUID)),
+		}
+	}},
+	{"PythonPackageList", func() []sqlQuery {
+		filters := PythonPackageListFilters{Search: "shelf"}
+		return []sqlQuery{
+			pythonPackageListCountQuery(goldenSingle, filters, 0),
+			pythonPackageListQuery(goldenSingle, filters, goldenPage, pageCursor{}),
+		}
+	}},
+	{"PythonRepositoryVersionPackageList", func() []sqlQuery {
+		return []sqlQuery{
+			pythonPackageListCountQuery(goldenHybrid, PythonPackageListFilters{}, EstimatedCountLimit),
+			pythonPackageListQuery(goldenHybrid, PythonPackageListFilters{}, goldenPage, goldenCursor("shelf-reader")),
+		}
+	}},
+	{"PythonDistributionList", func() []sqlQuery {
+		return []sqlQuery{
+			pythonDistributionListCountQuery(goldenSingle, "shelf-reader", "0.1", 0),
+			pythonDistributionQuery(goldenSingle, "shelf-reader", "0.1", 10, 20, pageCursor{}),
+		}
+	}},
+	{"PythonRepositoryVersionDistributionList", func() []sqlQuery {
+		return []sqlQuery{
+			pythonDistributionListCountQuery(goldenHybrid, "shelf-reader", "0.1", EstimatedCountLimit),
+			pythonDistributionQuery(goldenHybrid, "shelf-reader", "0.1", 10, 20, goldenCursor("2024-01-01T00:00:00Z", testRepoVersionUUID)),
+		}
+	}},
+	{"PythonPackageGet", func() []sqlQuery {
+		return []sqlQuery{
+			pythonPackageDetailQuery(goldenSingle, "shelf-reader", "0.1"),
+			pythonDistributionQuery(goldenSingle, "shelf-reader", "0.1", 0, 0, pageCursor{}),
+		}
+	}},
+	{"PythonRepositoryVersionPackageGet", func() []sqlQuery {
+		return []sqlQuery{
+			pythonPackageDetailQuery(goldenHybrid, "shelf-reader", "0.1"),
+			pythonDistributionQuery(goldenHybrid, "shelf-reader", "0.1", 0, 0, pageCursor{}),
+		}
+	}},
+	{"PythonPackageVersionsGet", func() []sqlQuery {
+		return []sqlQuery{
+			pythonPackageDetailQuery(goldenSingle, "shelf-reader", ""),
+			pythonPackageDistributionsQuery(goldenSingle, "shelf-reader"),
+		}
+	}},
+	{"PythonRepositoryVersionPackageVersionsGet", func() []sqlQuery {
+		return []sqlQuery{
+			pythonPackageDetailQuery(goldenHybrid, "shelf-reader", ""),
+			pythonPackageDistributionsQuery(goldenHybrid, "shelf-reader"),
+		}
+	}},
+	{"PythonBuildList", func() []sqlQuery {
+		return []sqlQuery{
+			pythonBuildListCountQuery(goldenSingle, "shelf-reader", "0.1", 0),
+			pythonBuildListQuery(goldenSingle, "shelf-reader", "0.1", goldenPage, pageCursor{}),
+		}
+	}},
+	{"PythonRepositoryVersionBuildList", func() []sqlQuery {
+		return []sqlQuery{
+			pythonBuildListCountQuery(goldenHybrid, "", "", EstimatedCountLimit),
+			pythonBuildListQuery(goldenHybrid, "", "", goldenPage, goldenCursor("2024-01-01T00:00:00Z", "shelf-reader", "0.1")),
+		}
+	}},
+	{"PythonRepositoryMetrics", func() []sqlQuery {
+		return []sqlQuery{pythonRepositoryMetricsQuery(goldenSingle)}
+	}},
+	{"PythonRepositoryVersionMetrics", func() []sqlQuery {
+		return []sqlQuery{pythonRepositoryMetricsQuery(goldenHybrid)}
+	}},
+	{"MavenPackageList", func() []sqlQuery {
+		filters := MavenPackageListFilters{Search: "io.vertx"}
+		return []sqlQuery{
+			mavenPackageListCountQuery(goldenSingle, filters, 0),
+			mavenPackageListQuery(goldenSingle, filters, goldenPage, pageCursor{}),
+		}
+	}},
+	{"MavenRepositoryVersionPackageList", func() []sqlQuery {
+		return []sqlQuery{
+			mavenPackageListCountQuery(goldenHybrid, MavenPackageListFilters{}, EstimatedCountLimit),
+			mavenPackageListQuery(goldenHybrid, MavenPackageListFilters{}, goldenPage, goldenCursor("io.vertx", "vertx-core")),
+		}
+	}},
+	{"MavenVersionsList", func() []sqlQuery {
+		return []sqlQuery{
+			mavenVersionsListCountQuery(goldenSingle, "io.vertx", "vertx-core", "4.5.10", 0),
+			mavenVersionsListQuery(goldenSingle, "io.vertx", "vertx-core", "4.5.10", goldenPage, pageCursor{}),
+		}
+	}},
+	{"MavenRepositoryVersionVersionsList", func() []sqlQuery {
+		return []sqlQuery{
+			mavenVersionsListCountQuery(goldenHybrid, "", "", "", EstimatedCountLimit),
+			mavenVersionsListQuery(goldenHybrid, "", "", "", goldenPage, goldenCursor("2024-01-01T00:00:00Z", "io.vertx", "vertx-core", "4.5.10")),
+		}
+	}},
+	{"MavenRepositoryMetrics", func() []sqlQuery {
+		return []sqlQuery{mavenRepositoryMetricsQuery(goldenSingle)}
+	}},
+	{"MavenRepositoryVersionMetrics", func() []sqlQuery {
+		return []sqlQuery{mavenRepositoryMetricsQuery(goldenHybrid)}
+	}},
+	{"NpmPackageList", func() []sqlQuery {
+		filters := NpmPackageListFilters{Search: "is-"}
+		return []sqlQuery{
+			npmPackageListCountQuery(goldenSingle, filters, 0),
+			npmPackageListQuery(goldenSingle, filters, goldenPage, pageCursor{}),
+		}
+	}},
+	{"NpmRepositoryVersionPackageList", func() []sqlQuery {
+		return []sqlQuery{
+			npmPackageListCountQuery(goldenHybrid, NpmPackageListFilters{}, EstimatedCountLimit),
+			npmPackageListQuery(goldenHybrid, NpmPackageListFilters{}, goldenPage, goldenCursor("is-odd")),
+		}
+	}},
+	{"NpmPackageGet", func() []sqlQuery {
+		return []sqlQuery{npmPackageDetailQuery(goldenSingle, "is-odd", "3.0.1")}
+	}},
+	{"NpmRepositoryVersionPackageGet", func() []sqlQuery {
+		return []sqlQuery{npmPackageDetailQuery(goldenHybrid, "is-odd", "3.0.1")}
+	}},
+	{"NpmPackageVersionsGet", func() []sqlQuery {
+		return []sqlQuery{npmPackageDetailQuery(goldenSingle, "is-odd", "")}
+	}},
+	{"NpmRepositoryVersionPackageVersionsGet", func() []sqlQuery {
+		return []sqlQuery{npmPackageDetailQuery(goldenHybrid, "is-odd", "")}
+	}},
+	{"NpmBuildList", func() []sqlQuery {
+		return []sqlQuery{
+			npmBuildListCountQuery(goldenSingle, "is-odd", "3.0.1", 0),
+			npmBuildListQuery(goldenSingle, "is-odd", "3.0.1", goldenPage, pageCursor{}),
+		}
+	}},
+	{"NpmRepositoryVersionBuildList", func() []sqlQuery {
+		return []sqlQuery{
+			npmBuildListCountQuery(goldenHybrid, "", "", EstimatedCountLimit),
+			npmBuildListQuery(goldenHybrid, "", "", goldenPage, goldenCursor("2024-01-01T00:00:00Z", "is-odd", "3.0.1")),
+		}
+	}},
+	{"RpmRepositoryVersionBatch", func() []sqlQuery {
+		batch := &Batch{}
+		batch.RpmRepositoryVersionPackageList(RpmListFilters{Name: "bear"}, PageOptions{Limit: 10, Count: CountEstimated})
+		batch.RpmRepositoryVersionErrataList(ErrataListFilters{}, PageOptions{Limit: 10, Count: CountNone})
+		batch.RpmRepositoryVersionEnvironmentSearch("server", 10)
+		return batchQueries(batch, goldenHybrid)
+	}},
+	{"RpmRepositoryVersionPackageAll", func() []sqlQuery {
+		return []sqlQuery{declareIteratorCursor(rpmPackageListQuery(goldenHybrid, RpmListFilters{Name: "bear"}, PageOptions{}, pageCursor{}))}
+	}},
+	{"RpmRepositoryVersionErrataAll", func() []sqlQuery {
+		_, order := errataListSort("")
+		return []sqlQuery{declareIteratorCursor(rpmErrataListQuery(goldenHybrid, ErrataListFilters{Type: []string{"security"}}, order, PageOptions{}, pageCursor{}))}
+	}},
+	{"PythonRepositoryVersionDistributionAll", func() []sqlQuery {
+		return []sqlQuery{
+			declareIteratorCursor(pythonDistributionAllQuery(goldenHybrid, "", "")),
+			declareIteratorCursor(pythonDistributionAllQuery(goldenSingle, "shelf-reader", "0.1")),
+		}
+	}},
+	{"MavenRepositoryVersionVersionsAll", func() []sqlQuery {
+		return []sqlQuery{declareIteratorCursor(mavenVersionsListQuery(goldenHybrid, "io.vertx", "", "", PageOptions{}, pageCursor{}))}
+	}},
+	{"NpmRepositoryVersionPackageAll", func() []sqlQuery {
+		return []sqlQuery{declareIteratorCursor(npmPackageListQuery(goldenHybrid, NpmPackageListFilters{}, PageOptions{}, pageCursor{}))}
+	}},
+}
+
+func TestGoldenQueries(t *testing.T) {
+	for _, tt := range goldenQueries {
+		t.Run(tt.method, func(t *testing.T) {
+			queries := tt.queries()
+			for _, query := range queries {
+				for _, match := range namedArgPlaceholder.FindAllStringSubmatch(query.SQL, -1) {
+					assert.Contains(t, query.Args, match[1], "query references an argument that is not bound")
+				}
+			}
+
+			rendered := renderGoldenQueries(queries)
+			assert.Equal(t, rendered, renderGoldenQueries(tt.queries()), "queries are not deterministic")
+
+			path := filepath.Join("testdata", "golden", tt.method+".sql")
+			if *updateGolden {
+				require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+				require.NoError(t, os.WriteFile(path, []byte(rendered), 0o644))
+			}
+			golden, err := os.ReadFile(path)
+			require.NoError(t, err, "run with -update to create the golden file")
+			assert.Equal(t, string(golden), rendered)
+		})
+	}
+}
+
+// TestGoldenQueriesCoverTangy checks that every method of the Tangy interface running queries has a golden file
+func TestGoldenQueriesCoverTangy(t *testing.T) {
+	t.Parallel()
+
+	methods := make([]string, len(goldenQueries))
+	for i, tt := range goldenQueries {
+		methods[i] = tt.method
+	}
+
+	tangyType := reflect.TypeOf((*Tangy)(nil)).Elem()
+	for i := range tangyType.NumMethod() {
+		method := tangyType.Method(i).Name
+		if slices.Contains(nonQueryMethods, method) {
+			continue
+		}
+		assert.True(t, slices.Contains(methods, method), "no golden queries for %s", method)
+	}
+}
+
+// renderGoldenQueries renders statements with their arguments, sorted by name, as SQL comments
+func renderGoldenQueries(queries []sqlQuery) string {
+	var out strings.Builder
+	for i, query := range queries {
+		fmt.Fprintf(&out, "-- query %d\n%s\n", i+1, strings.TrimSpace(query.SQL))
+
+		names := make([]string, 0, len(query.Args))
+		for name := range query.Args {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+		for _, name := range names {
+			value, _ := json.Marshal(query.Args[name])
+			fmt.Fprintf(&out, "-- @%s = %s\n", name, value)
+		}
+		out.WriteString("\n")
+	}
+	return out.String()
+}
+
+// batchQueries returns the statements queued by the calls of a batch
+func batchQueries(batch *Batch, m membership) []sqlQuery {
+	queued := &pgx.Batch{}
+	for _, request := range batch.requests {
+		request.queue(context.Background(), queued, m)
+	}
+	queries := make([]sqlQuery, len(queued.QueuedQueries))
+	for i, query := range queued.QueuedQueries {
+		args, _ := query.Arguments[0].(pgx.NamedArgs)
+		queries[i] = sqlQuery{SQL: query.SQL, Args: args}
+	}
+	return queries
+}
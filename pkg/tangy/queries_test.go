@@ -1,6 +1,8 @@
 package tangy
 
 import (
+	"context"
+	"strings"
 	"testing"
 
 	"github.com/jackc/pgx/v5"
@@ -8,77 +10,154 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+func TestRepositoryVersionArrays(t *testing.T) {
+	t.Parallel()
+
+	secondUUID := "019f3808-fcc2-716e-a7d3-e5a7ef1522a0"
+	repoIds, versionNums := repositoryVersionArrays([]ParsedRepoVersion{
+		{RepositoryUUID: testRepoVersionUUID, Version: 1},
+		{RepositoryUUID: secondUUID, Version: 2},
+		{RepositoryUUID: testRepoVersionUUID, Version: 1},
+		{RepositoryUUID: testRepoVersionUUID, Version: 2},
+	})
+	assert.Equal(t, []string{testRepoVersionUUID, secondUUID, testRepoVersionUUID}, repoIds)
+	assert.Equal(t, []int{1, 2, 2}, versionNums)
+}
+
 func TestContentIdsInVersionNew(t *testing.T) {
 	t.Parallel()
 
-	args := pgx.NamedArgs{}
-	query := contentIdsInVersionNew(testRepoVersionUUID, 3, &args)
+	b := newQueryBuilder()
+	query := b.contentIdsInVersionNew([]ParsedRepoVersion{{RepositoryUUID: testRepoVersionUUID, Version: 3}})
 
-	assert.Contains(t, query, "crv.repository_id = @repoName")
-	assert.Contains(t, query, "crv.number = @versionNum")
+	assert.Contains(t, query, "UNNEST(@repoIds0::uuid[], @versionNums0::integer[]) AS requested(repository_id, number)")
+	assert.Contains(t, query, "crv.number = requested.number")
 	assert.Contains(t, query, "crv.content_ids IS NOT NULL")
-	require.Len(t, args, 2)
-	assert.Contains(t, namedArgValues(args), testRepoVersionUUID)
-	assert.Contains(t, namedArgValues(args), 3)
+	assert.Equal(t, pgx.NamedArgs{"repoIds0": []string{testRepoVersionUUID}, "versionNums0": []int{3}}, b.args)
 }
 
 func TestContentIdsInVersionOld(t *testing.T) {
 	t.Parallel()
 
-	args := pgx.NamedArgs{}
-	query := contentIdsInVersionOld(testRepoVersionUUID, 3, &args)
+	b := newQueryBuilder()
+	query := b.contentIdsInVersionOld([]ParsedRepoVersion{{RepositoryUUID: testRepoVersionUUID, Version: 3}})
 
-	assert.Contains(t, query, "crv.repository_id = @repoName")
-	assert.Contains(t, query, "crv.number <= @versionNum")
+	assert.Contains(t, query, "UNNEST(@repoIds0::uuid[], @versionNums0::integer[])")
+	assert.Contains(t, query, "crv.number <= requested.number")
 	assert.Contains(t, query, "crv2.number IS NOT NULL")
-	require.Len(t, args, 2)
-	assert.Contains(t, namedArgValues(args), testRepoVersionUUID)
-	assert.Contains(t, namedArgValues(args), 3)
+	assert.Equal(t, pgx.NamedArgs{"repoIds0": []string{testRepoVersionUUID}, "versionNums0": []int{3}}, b.args)
 }
 
-func TestContentIdsInVersionsNew(t *testing.T) {
+func TestMembershipJoinNew(t *testing.T) {
 	t.Parallel()
 
-	args := pgx.NamedArgs{}
+	b := newQueryBuilder()
 	secondUUID := "019f3808-fcc2-716e-a7d3-e5a7ef1522a0"
 	repoVerMap := []ParsedRepoVersion{
 		{RepositoryUUID: testRepoVersionUUID, Version: 1},
 		{RepositoryUUID: secondUUID, Version: 2},
 	}
 
-	query := contentIdsInVersionsNew(repoVerMap, &args)
+	query := b.membershipJoin(membership{Versions: repoVerMap})
 
-	assert.Contains(t, query, "INNER JOIN core_repositoryversion crv ON (rp.content_ptr_id = ANY(crv.content_ids))")
-	assert.Contains(t, query, " OR ")
-	require.Len(t, args, 4)
-	values := namedArgValues(args)
-	assert.Contains(t, values, testRepoVersionUUID)
-	assert.Contains(t, values, secondUUID)
-	assert.Contains(t, values, 1)
-	assert.Contains(t, values, 2)
+	assert.Contains(t, query, "SELECT DISTINCT membership.repository_id, membership.content_id")
+	assert.Contains(t, query, ") crv ON (rp.content_ptr_id = crv.content_id)")
+	assert.NotContains(t, query, " OR ")
+	assert.NotContains(t, query, "core_repositorycontent")
+	assert.Equal(t, pgx.NamedArgs{
+		"repoIds0":     []string{testRepoVersionUUID, secondUUID},
+		"versionNums0": []int{1, 2},
+	}, b.args)
 }
 
-func TestContentIdsInVersionsOld(t *testing.T) {
+func TestMembershipJoinOld(t *testing.T) {
 	t.Parallel()
 
-	args := pgx.NamedArgs{}
+	b := newQueryBuilder()
 	repoVerMap := []ParsedRepoVersion{
 		{RepositoryUUID: testRepoVersionUUID, Version: 1},
 	}
 
-	query := contentIdsInVersionsOld(repoVerMap, &args)
+	query := b.membershipJoin(membership{Versions: repoVerMap, OldVersions: repoVerMap})
 
-	assert.Contains(t, query, "INNER JOIN core_repositorycontent crc on rp.content_ptr_id = crc.content_id")
+	assert.Contains(t, query, "INNER JOIN core_repositorycontent crc ON (crc.version_added_id = crv.pulp_id)")
 	assert.Contains(t, query, "LEFT OUTER JOIN core_repositoryversion crv2")
-	require.Len(t, args, 2)
-	assert.Contains(t, namedArgValues(args), testRepoVersionUUID)
-	assert.Contains(t, namedArgValues(args), 1)
+	assert.Contains(t, query, ") crv ON (rp.content_ptr_id = crv.content_id)")
+	assert.NotContains(t, query, "UNNEST(crv.content_ids)")
+	assert.Equal(t, pgx.NamedArgs{"repoIds0": []string{testRepoVersionUUID}, "versionNums0": []int{1}}, b.args)
 }
 
-func namedArgValues(args pgx.NamedArgs) []any {
-	values := make([]any, 0, len(args))
-	for _, value := range args {
-		values = append(values, value)
+func TestMembershipJoinHybrid(t *testing.T) {
+	t.Parallel()
+
+	b := newQueryBuilder()
+	secondUUID := "019f3808-fcc2-716e-a7d3-e5a7ef1522a0"
+	query := b.membershipJoin(membership{
+		Versions: []ParsedRepoVersion{
+			{RepositoryUUID: testRepoVersionUUID, Version: 2},
+			{RepositoryUUID: secondUUID, Version: 1},
+		},
+		OldVersions: []ParsedRepoVersion{{RepositoryUUID: secondUUID, Version: 1}},
+	})
+
+	assert.Contains(t, query, "UNNEST(crv.content_ids) AS content_id")
+	assert.Contains(t, query, "crv.content_ids IS NOT NULL")
+	assert.Contains(t, query, " UNION ALL ")
+	assert.Contains(t, query, "INNER JOIN core_repositorycontent crc ON (crc.version_added_id = crv.pulp_id)")
+	assert.Contains(t, query, "crv2.number IS NOT NULL")
+	assert.Contains(t, query, ") crv ON (rp.content_ptr_id = crv.content_id)")
+	assert.Equal(t, pgx.NamedArgs{
+		"repoIds0":     []string{testRepoVersionUUID},
+		"versionNums0": []int{2},
+		"repoIds1":     []string{secondUUID},
+		"versionNums1": []int{1},
+	}, b.args)
+}
+
+func TestMembershipJoinDeterministic(t *testing.T) {
+	t.Parallel()
+
+	m := membership{Versions: []ParsedRepoVersion{{RepositoryUUID: testRepoVersionUUID, Version: 1}}}
+	assert.Equal(t, newQueryBuilder().membershipJoin(m), newQueryBuilder().membershipJoin(m))
+}
+
+func TestContentMembershipSkipCheck(t *testing.T) {
+	t.Parallel()
+
+	impl := &tangyImpl{skipContentIdsCheck: true}
+	repoVerMap := []ParsedRepoVersion{{RepositoryUUID: testRepoVersionUUID, Version: 1}}
+
+	// no connection is needed when the check is skipped
+	m, err := impl.contentMembership(context.Background(), nil, repoVerMap)
+	require.NoError(t, err)
+	assert.Equal(t, membership{Versions: repoVerMap}, m)
+	assert.NotContains(t, newQueryBuilder().membershipJoin(m), "core_repositorycontent")
+}
+
+func TestContainsRepositoryVersion(t *testing.T) {
+	t.Parallel()
+
+	versions := []ParsedRepoVersion{{RepositoryUUID: testRepoVersionUUID, Version: 1}}
+	assert.True(t, containsRepositoryVersion(versions, ParsedRepoVersion{RepositoryUUID: strings.ToUpper(testRepoVersionUUID), Version: 1}))
+	assert.False(t, containsRepositoryVersion(versions, ParsedRepoVersion{RepositoryUUID: testRepoVersionUUID, Version: 2}))
+	assert.False(t, containsRepositoryVersion(nil, versions[0]))
+}
+
+func TestMissingRepositoryVersions(t *testing.T) {
+	t.Parallel()
+
+	secondUUID := "019f3808-fcc2-716e-a7d3-e5a7ef1522a0"
+	repoVerMap := []ParsedRepoVersion{
+		{RepositoryUUID: strings.ToUpper(testRepoVersionUUID), Version: 1},
+		{RepositoryUUID: testRepoVersionUUID, Version: 2},
+		{RepositoryUUID: secondUUID, Version: 1},
+		{RepositoryUUID: secondUUID, Version: 1},
 	}
-	return values
+	found := []ParsedRepoVersion{{RepositoryUUID: testRepoVersionUUID, Version: 1}}
+
+	assert.Equal(t, []ParsedRepoVersion{
+		{RepositoryUUID: testRepoVersionUUID, Version: 2},
+		{RepositoryUUID: secondUUID, Version: 1},
+	}, missingRepositoryVersions(repoVerMap, found))
+	assert.Empty(t, missingRepositoryVersions(found, found))
 }
@@ -0,0 +1,196 @@
+package tangy
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rs/zerolog/log"
+)
+
+// DefaultReplicaCheckInterval is how often replicas are checked when Database.ReplicaCheckInterval is not set
+const DefaultReplicaCheckInterval = 10 * time.Second
+
+type primaryContextKey struct{}
+
+// WithPrimary returns a context sending the queries of the Tangy calls made with it to the primary database
+// instead of a replica, for example to read a repository version right after it was synced
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryContextKey{}, true)
+}
+
+// usePrimary returns true if ctx was returned by WithPrimary
+func usePrimary(ctx context.Context) bool {
+	primary, _ := ctx.Value(primaryContextKey{}).(bool)
+	return primary
+}
+
+// readPool returns the pool the queries of a call are sent to: the next healthy replica, or the primary
+func (t *tangyImpl) readPool(ctx context.Context) *pgxpool.Pool {
+	if t.replicas != nil && !usePrimary(ctx) {
+		if pool := t.replicas.pick(); pool != nil {
+			return pool
+		}
+	}
+	return t.pool
+}
+
+// replicaLagQuery returns the replication lag of a replica in seconds. A replica that replayed all the WAL it
+// received is not lagging, even if the primary has not written anything since the last replayed transaction.
+const replicaLagQuery = `
+	SELECT CASE
+		WHEN pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+		ELSE COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0)
+	END::float8`
+
+type replica struct {
+	name    string
+	pool    *pgxpool.Pool
+	healthy atomic.Bool
+}
+
+// replicaSet routes reads to replicas in turn, skipping the replicas that are down or lagging.
+// Replicas are unhealthy until they are first checked, so reads go to the primary meanwhile.
+type replicaSet struct {
+	replicas []*replica
+	next     atomic.Uint64
+	maxLag   time.Duration
+	stop     chan struct{}
+	wg       sync.WaitGroup
+}
+
+// newReplicaSet creates the pools of the replicas of primary, and starts checking them in the background
+func newReplicaSet(primary Database, logConfig Logger) (*replicaSet, error) {
+	r := &replicaSet{maxLag: primary.MaxReplicaLag, stop: make(chan struct{})}
+	for i, replicaConfig := range primary.Replicas {
+		pool, err := newPool(replicaConfig.replicaOf(primary), logConfig)
+		if err != nil {
+			r.closePools()
+			return nil, fmt.Errorf("error configuring replica %d: %w", i, err)
+		}
+		r.replicas = append(r.replicas, &replica{name: r.replicaName(i, pool), pool: pool})
+	}
+
+	interval := primary.ReplicaCheckInterval
+	if interval <= 0 {
+		interval = DefaultReplicaCheckInterval
+	}
+	r.wg.Add(1)
+	go r.run(interval)
+	return r, nil
+}
+
+// replicaName returns the name of the replica i, the host and port its pool connects to, as parsed from a DSN
+// or from the connection fields. Replicas connecting to the host and port of a previous replica are told apart by their index.
+func (r *replicaSet) replicaName(i int, pool *pgxpool.Pool) string {
+	connConfig := pool.Config().ConnConfig
+	name := fmt.Sprintf("%s:%d", connConfig.Host, connConfig.Port)
+	if slices.ContainsFunc(r.replicas, func(replica *replica) bool { return replica.name == name }) {
+		name = fmt.Sprintf("%s#%d", name, i)
+	}
+	return name
+}
+
+// replicaOf returns the replica configuration with the fields it leaves empty taken from primary.
+// Connection fields are only taken from a primary configured without DSN, by a replica configured without DSN.
+func (d Database) replicaOf(primary Database) Database {
+	if d.DSN == "" && primary.DSN == "" {
+		inherit(&d.Name, primary.Name)
+		inherit(&d.Host, primary.Host)
+		inherit(&d.Port, primary.Port)
+		inherit(&d.User, primary.User)
+		inherit(&d.Password, primary.Password)
+		inherit(&d.PassFile, primary.PassFile)
+		inherit(&d.SSLMode, primary.SSLMode)
+		inherit(&d.CACertPath, primary.CACertPath)
+		inherit(&d.ClientCertPath, primary.ClientCertPath)
+		inherit(&d.ClientKeyPath, primary.ClientKeyPath)
+	}
+	inherit(&d.ApplicationName, primary.ApplicationName)
+	inherit(&d.ConnectTimeout, primary.ConnectTimeout)
+	inherit(&d.MaxConnectBackoff, primary.MaxConnectBackoff)
+	inherit(&d.PoolLimit, primary.PoolLimit)
+	inherit(&d.QueryExecMode, primary.QueryExecMode)
+	inherit(&d.SQLComments, primary.SQLComments)
+	if d.BeforeConnect == nil {
+		d.BeforeConnect = primary.BeforeConnect
+	}
+	d.Replicas = nil
+	return d
+}
+
+// inherit sets field to value if it is empty
+func inherit[T comparable](field *T, value T) {
+	var zero T
+	if *field == zero {
+		*field = value
+	}
+}
+
+// pick returns the pool of the next healthy replica, or nil if no replica is healthy
+func (r *replicaSet) pick() *pgxpool.Pool {
+	healthy := make([]*pgxpool.Pool, 0, len(r.replicas))
+	for _, replica := range r.replicas {
+		if replica.healthy.Load() {
+			healthy = append(healthy, replica.pool)
+		}
+	}
+	if len(healthy) == 0 {
+		return nil
+	}
+	return healthy[r.next.Add(1)%uint64(len(healthy))]
+}
+
+// run checks the replicas every interval until the set is closed
+func (r *replicaSet) run(interval time.Duration) {
+	defer r.wg.Done()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), interval)
+		r.check(ctx)
+		cancel()
+		select {
+		case <-r.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// check marks the replicas that answer and lag at most maxLag as healthy, and the others as unhealthy
+func (r *replicaSet) check(ctx context.Context) {
+	for _, replica := range r.replicas {
+		var lagSeconds float64
+		err := replica.pool.QueryRow(ctx, replicaLagQuery).Scan(&lagSeconds)
+		lag := time.Duration(lagSeconds * float64(time.Second))
+
+		healthy := err == nil && (r.maxLag <= 0 || lag <= r.maxLag)
+		if replica.healthy.Swap(healthy) != healthy {
+			if healthy {
+				log.Info().Str("replica", replica.name).Dur("lag", lag).Msg("Tangy replica is healthy")
+			} else if err != nil {
+				log.Warn().Err(err).Str("replica", replica.name).Msg("Tangy replica is unavailable, reading from the primary")
+			} else {
+				log.Warn().Str("replica", replica.name).Dur("lag", lag).Msg("Tangy replica is lagging, reading from the primary")
+			}
+		}
+	}
+}
+
+// close stops checking the replicas and closes their pools
+func (r *replicaSet) close() {
+	close(r.stop)
+	r.wg.Wait()
+	r.closePools()
+}
+
+func (r *replicaSet) closePools() {
+	for _, replica := range r.replicas {
+		replica.pool.Close()
+	}
+}
@@ -0,0 +1,102 @@
+package tangy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// unreachablePool returns a pool that is never connected to, as connections are only opened when used
+func unreachablePool(t *testing.T) *pgxpool.Pool {
+	pool, err := newPool(Database{Host: "127.0.0.1", Port: 1, Name: "pulp", User: "pulp"}, Logger{})
+	require.NoError(t, err)
+	t.Cleanup(pool.Close)
+	return pool
+}
+
+func TestReplicaOf(t *testing.T) {
+	t.Parallel()
+
+	primary := Database{Name: "pulp", Host: "primary", Port: 5432, User: "pulp", Password: "secret", PoolLimit: 5,
+		Replicas: []Database{{Host: "replica"}}}
+	assert.Equal(t, Database{Name: "pulp", Host: "replica", Port: 5432, User: "pulp", Password: "secret", PoolLimit: 5},
+		primary.Replicas[0].replicaOf(primary))
+
+	replica := Database{Host: "replica", Port: 5433, User: "reader", PoolLimit: 50}
+	assert.Equal(t, Database{Name: "pulp", Host: "replica", Port: 5433, User: "reader", Password: "secret", PoolLimit: 50},
+		replica.replicaOf(primary))
+
+	// Connection fields are not combined with a DSN
+	replica = Database{DSN: "postgres://reader@replica/pulp"}
+	assert.Equal(t, Database{DSN: "postgres://reader@replica/pulp", PoolLimit: 5}, replica.replicaOf(primary))
+}
+
+func TestReplicaSetPick(t *testing.T) {
+	t.Parallel()
+
+	first, second, third := &replica{pool: unreachablePool(t)}, &replica{pool: unreachablePool(t)}, &replica{pool: unreachablePool(t)}
+	r := &replicaSet{replicas: []*replica{first, second, third}}
+	assert.Nil(t, r.pick())
+
+	first.healthy.Store(true)
+	third.healthy.Store(true)
+	picked := map[*pgxpool.Pool]int{}
+	for range 10 {
+		picked[r.pick()]++
+	}
+	assert.Equal(t, map[*pgxpool.Pool]int{first.pool: 5, third.pool: 5}, picked)
+}
+
+func TestReadPool(t *testing.T) {
+	t.Parallel()
+
+	primary := unreachablePool(t)
+	healthy := &replica{pool: unreachablePool(t)}
+	healthy.healthy.Store(true)
+	tangy := &tangyImpl{pool: primary, replicas: &replicaSet{replicas: []*replica{healthy}}}
+
+	assert.Same(t, healthy.pool, tangy.readPool(context.Background()))
+	assert.Same(t, primary, tangy.readPool(WithPrimary(context.Background())))
+
+	// Without any healthy replica, reads go to the primary
+	healthy.healthy.Store(false)
+	assert.Same(t, primary, tangy.readPool(context.Background()))
+	assert.Same(t, primary, (&tangyImpl{pool: primary}).readPool(context.Background()))
+}
+
+func TestReplicaSetCheckUnavailable(t *testing.T) {
+	t.Parallel()
+
+	unavailable := &replica{name: "127.0.0.1:1", pool: unreachablePool(t)}
+	unavailable.healthy.Store(true)
+	r := &replicaSet{replicas: []*replica{unavailable}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	r.check(ctx)
+	assert.False(t, unavailable.healthy.Load())
+}
+
+func TestNewReplicaSetNames(t *testing.T) {
+	t.Parallel()
+
+	// Replicas configured with a DSN are named after the host and port of the DSN, and by their index when they repeat
+	primary := Database{DSN: "postgres://pulp@127.0.0.1:1/pulp", Replicas: []Database{
+		{DSN: "postgres://reader@127.0.0.1:2/pulp"},
+		{DSN: "host=127.0.0.1 port=3 user=reader dbname=pulp"},
+		{DSN: "postgres://other@127.0.0.1:2/pulp"},
+	}}
+	r, err := newReplicaSet(primary, Logger{})
+	require.NoError(t, err)
+	t.Cleanup(r.close)
+
+	var names []string
+	for _, replica := range r.replicas {
+		names = append(names, replica.name)
+	}
+	assert.Equal(t, []string{"127.0.0.1:2", "127.0.0.1:3", "127.0.0.1:2#2"}, names)
+}
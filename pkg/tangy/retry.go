@@ -0,0 +1,149 @@
+package tangy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/rs/zerolog"
+)
+
+// Defaults of the backoff of RetryPolicy
+const (
+	DefaultRetryInitialBackoff = 100 * time.Millisecond
+	DefaultRetryMaxBackoff     = 2 * time.Second
+)
+
+// RetryPolicy configures how calls failing with a transient database error are retried.
+// As every Tangy call is read-only, a call can always be run again from the start.
+type RetryPolicy struct {
+	// MaxAttempts is the number of times a call is attempted, including the first one. Calls are not retried when at most 1.
+	MaxAttempts int `mapstructure:"max_attempts"`
+	// InitialBackoff is the wait before the first retry, DefaultRetryInitialBackoff when zero.
+	// The wait doubles before each following retry, up to MaxBackoff.
+	InitialBackoff time.Duration `mapstructure:"initial_backoff"`
+	// MaxBackoff is the longest wait between two attempts, DefaultRetryMaxBackoff when zero
+	MaxBackoff time.Duration `mapstructure:"max_backoff"`
+	// Jitter is the fraction of each wait that is random, between 0 (no jitter) and 1
+	Jitter float64 `mapstructure:"jitter"`
+	// RetryableCodes are SQLSTATE codes or classes (two characters) retried in addition to retryableCodes
+	RetryableCodes []string `mapstructure:"retryable_codes"`
+}
+
+// retryableCodes are the SQLSTATE codes and classes of errors that may not happen again on a new attempt
+var retryableCodes = []string{
+	"08",    // connection_exception
+	"40001", // serialization_failure, also raised by recovery conflicts on replicas
+	"40P01", // deadlock_detected
+	"53300", // too_many_connections
+	"57P01", // admin_shutdown
+	"57P02", // crash_shutdown
+	"57P03", // cannot_connect_now
+}
+
+// validate returns every problem of the policy at once
+func (p RetryPolicy) validate() error {
+	var errs []error
+	if p.MaxAttempts < 0 {
+		errs = append(errs, fmt.Errorf("retry max_attempts is invalid: %d (must not be negative)", p.MaxAttempts))
+	}
+	if p.InitialBackoff < 0 || p.MaxBackoff < 0 {
+		errs = append(errs, fmt.Errorf("retry backoff is invalid: %v to %v (must not be negative)", p.InitialBackoff, p.MaxBackoff))
+	}
+	if p.Jitter < 0 || p.Jitter > 1 {
+		errs = append(errs, fmt.Errorf("retry jitter is invalid: %v (must be between 0 and 1)", p.Jitter))
+	}
+	for _, code := range p.RetryableCodes {
+		if len(code) != 2 && len(code) != 5 {
+			errs = append(errs, fmt.Errorf("retry retryable_codes is invalid: %q (must be a SQLSTATE code or class)", code))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// retryable returns true if err is a transient database or network error, worth running the call again
+func (p RetryPolicy) retryable(err error) bool {
+	if errors.Is(err, ErrQueryTimeout) || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return slices.ContainsFunc(slices.Concat(retryableCodes, p.RetryableCodes), func(code string) bool {
+			return strings.HasPrefix(pgErr.Code, code)
+		})
+	}
+
+	var connectErr *pgconn.ConnectError
+	var netErr net.Error
+	return errors.As(err, &connectErr) || errors.As(err, &netErr) ||
+		errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) || pgconn.SafeToRetry(err)
+}
+
+// backoff returns the wait before the given retry, counting from 1
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	initial, maxBackoff := p.InitialBackoff, p.MaxBackoff
+	if initial <= 0 {
+		initial = DefaultRetryInitialBackoff
+	}
+	if maxBackoff <= 0 {
+		maxBackoff = DefaultRetryMaxBackoff
+	}
+
+	wait := maxBackoff
+	if retry < 32 && initial<<(retry-1) < maxBackoff {
+		wait = initial << (retry - 1)
+	}
+	if p.Jitter > 0 {
+		wait -= time.Duration(rand.Float64() * p.Jitter * float64(wait))
+	}
+	return wait
+}
+
+type retryContextKey struct{}
+
+// isRetry returns true if ctx is the context of an attempt of a call after the first one,
+// for the attempt to be counted in the Retries of its method
+func isRetry(ctx context.Context) bool {
+	attempt, _ := ctx.Value(retryContextKey{}).(int)
+	return attempt > 1
+}
+
+// Interceptor returns an interceptor running a call, and running it again while it fails with a retryable error,
+// up to MaxAttempts times. Calls are never retried once ctx is done. Each retry is logged as a warning with logger.
+func (p RetryPolicy) Interceptor(logger zerolog.Logger) Interceptor {
+	return func(ctx context.Context, call *Call, invoke func(ctx context.Context) error) error {
+		return p.intercept(ctx, logger, call, invoke)
+	}
+}
+
+func (p RetryPolicy) intercept(ctx context.Context, logger zerolog.Logger, call *Call, invoke func(ctx context.Context) error) error {
+	for attempt := 1; ; attempt++ {
+		attemptCtx := ctx
+		if attempt > 1 {
+			attemptCtx = context.WithValue(ctx, retryContextKey{}, attempt)
+		}
+		err := invoke(attemptCtx)
+		if err == nil || attempt >= p.MaxAttempts || ctx.Err() != nil || !p.retryable(err) {
+			return err
+		}
+
+		wait := p.backoff(attempt)
+		logger.Warn().Err(err).Str("method", call.Method).Int("attempt", attempt).Dur("backoff", wait).Msg("Retrying Tangy call")
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
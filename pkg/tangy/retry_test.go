@@ -0,0 +1,125 @@
+package tangy
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryPolicyRetryable(t *testing.T) {
+	t.Parallel()
+
+	policy := RetryPolicy{RetryableCodes: []string{"55P03"}}
+	for _, tt := range []struct {
+		err       error
+		retryable bool
+	}{
+		{&pgconn.PgError{Code: "57P01"}, true},
+		{&pgconn.PgError{Code: "08006"}, true},
+		{fmt.Errorf("error checking repository versions: %w", &pgconn.PgError{Code: "40001"}), true},
+		{&pgconn.PgError{Code: "55P03"}, true},
+		{&pgconn.PgError{Code: "42P01"}, false},
+		{&net.OpError{Op: "dial", Err: errors.New("connection refused")}, true},
+		{io.ErrUnexpectedEOF, true},
+		{ErrRepositoryNotFound, false},
+		{fmt.Errorf("%w: %w", ErrQueryTimeout, &pgconn.PgError{Code: queryCanceledCode}), false},
+		{context.Canceled, false},
+		{context.DeadlineExceeded, false},
+	} {
+		assert.Equal(t, tt.retryable, policy.retryable(tt.err), tt.err.Error())
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	t.Parallel()
+
+	policy := RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+	assert.Equal(t, 100*time.Millisecond, policy.backoff(1))
+	assert.Equal(t, 200*time.Millisecond, policy.backoff(2))
+	assert.Equal(t, 800*time.Millisecond, policy.backoff(4))
+	assert.Equal(t, time.Second, policy.backoff(5))
+	assert.Equal(t, time.Second, policy.backoff(100))
+
+	assert.Equal(t, DefaultRetryInitialBackoff, RetryPolicy{}.backoff(1))
+	assert.Equal(t, DefaultRetryMaxBackoff, RetryPolicy{}.backoff(100))
+
+	policy.Jitter = 0.5
+	for range 100 {
+		wait := policy.backoff(2)
+		assert.GreaterOrEqual(t, wait, 100*time.Millisecond)
+		assert.LessOrEqual(t, wait, 200*time.Millisecond)
+	}
+}
+
+func TestRetryPolicyIntercept(t *testing.T) {
+	t.Parallel()
+
+	policy := RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}
+	transient := &pgconn.PgError{Code: "57P01"}
+
+	// Retried until the call succeeds, logging each retry, and marking the context of the attempts after the first one
+	attempts := 0
+	var logs bytes.Buffer
+	err := policy.intercept(context.Background(), zerolog.New(&logs), &Call{Method: "RpmRepositoryVersionPackageList"}, func(ctx context.Context) error {
+		attempts++
+		assert.Equal(t, attempts > 1, isRetry(ctx))
+		if attempts < 3 {
+			return transient
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, attempts)
+	assert.Equal(t, 2, strings.Count(logs.String(), "Retrying Tangy call"))
+
+	// Up to MaxAttempts
+	attempts = 0
+	err = policy.intercept(context.Background(), zerolog.Nop(), &Call{Method: "RpmRepositoryVersionPackageList"}, func(ctx context.Context) error {
+		attempts++
+		return transient
+	})
+	assert.ErrorIs(t, err, transient)
+	assert.Equal(t, 3, attempts)
+
+	// Errors that are not transient are not retried
+	attempts = 0
+	err = policy.intercept(context.Background(), zerolog.Nop(), &Call{Method: "RpmRepositoryVersionPackageList"}, func(ctx context.Context) error {
+		attempts++
+		return ErrRepositoryNotFound
+	})
+	assert.ErrorIs(t, err, ErrRepositoryNotFound)
+	assert.Equal(t, 1, attempts)
+
+	// Nor calls whose context is done
+	ctx, cancel := context.WithCancel(context.Background())
+	attempts = 0
+	err = policy.intercept(ctx, zerolog.Nop(), &Call{Method: "RpmRepositoryVersionPackageList"}, func(ctx context.Context) error {
+		attempts++
+		cancel()
+		return transient
+	})
+	assert.ErrorIs(t, err, transient)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestRetryPolicyValidate(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, RetryPolicy{MaxAttempts: 3, Jitter: 0.2, RetryableCodes: []string{"55", "55P03"}}.validate())
+
+	err := RetryPolicy{MaxAttempts: -1, InitialBackoff: -time.Second, Jitter: 2, RetryableCodes: []string{"5"}}.validate()
+	assert.ErrorContains(t, err, "max_attempts is invalid")
+	assert.ErrorContains(t, err, "backoff is invalid")
+	assert.ErrorContains(t, err, "jitter is invalid")
+	assert.ErrorContains(t, err, `retryable_codes is invalid: "5"`)
+}
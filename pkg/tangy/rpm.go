@@ -70,10 +70,35 @@ type ErrataListItem struct {
 	RebootSuggested bool
 	CVEs            []string
 }
+
+type RpmListResponse struct {
+	Results    []RpmListItem `json:"results"`
+	Total      int           `json:"total"`
+	TotalMode  CountMode     `json:"total_mode"`
+	Limit      int           `json:"limit"`
+	Offset     int           `json:"offset"`
+	NextCursor string        `json:"next_cursor,omitempty"`
+}
+
+type ErrataListResponse struct {
+	Results    []ErrataListItem `json:"results"`
+	Total      int              `json:"total"`
+	TotalMode  CountMode        `json:"total_mode"`
+	Limit      int              `json:"limit"`
+	Offset     int              `json:"offset"`
+	NextCursor string           `json:"next_cursor,omitempty"`
+}
+
 type PageOptions struct {
 	Offset int
 	Limit  int
 	SortBy string
+	// Cursor is the NextCursor of a previous response. When set, the page starts right after the
+	// last row of that response and Offset is ignored, so deep pages cost the same as the first one.
+	Cursor string
+	// Count selects how Total is computed, CountExact by default. The response TotalMode reports
+	// the mode that produced Total.
+	Count CountMode
 }
 
 type RpmListFilters struct {
@@ -92,16 +117,19 @@ type ErrataListFilters struct {
 }
 
 // RpmRepositoryVersionPackageSearch search for RPMs, by name, associated to repository hrefs, returning an amount up to limit
-func (t *tangyImpl) RpmRepositoryVersionPackageSearch(ctx context.Context, hrefs []string, search string, limit int) ([]RpmPackageSearch, error) {
+func (t *tangyImpl) RpmRepositoryVersionPackageSearch(ctx context.Context, hrefs []string, search string, limit int) (_ []RpmPackageSearch, err error) {
+	ctx, done := t.call(ctx, "RpmRepositoryVersionPackageSearch", arg("search", search), arg("limit", limit))
+	defer done(&err)
+
 	if len(hrefs) == 0 {
 		return []RpmPackageSearch{}, nil
 	}
 
-	conn, err := t.pool.Acquire(ctx)
+	tx, end, err := t.begin(ctx)
 	if err != nil {
 		return nil, err
 	}
-	defer conn.Release()
+	defer end()
 
 	if limit == 0 {
 		limit = DefaultLimit
@@ -112,38 +140,48 @@ func (t *tangyImpl) RpmRepositoryVersionPackageSearch(ctx context.Context, hrefs
 		return []RpmPackageSearch{}, fmt.Errorf("error parsing repository version hrefs: %w", err)
 	}
 
-	args := pgx.NamedArgs{"nameFilter": search + "%", "limit": limit}
-	innerUnion, err := contentIdsInVersions(ctx, conn, repoVerMap, &args)
+	m, err := t.contentMembership(ctx, tx, repoVerMap)
 	if err != nil {
 		return nil, err
 	}
 
-	query := `SELECT DISTINCT ON (rp.name) rp.name, rp.summary
-              FROM rpm_package rp `
-
-	rows, err := conn.Query(context.Background(), query+innerUnion+" AND rp.name ILIKE CONCAT( @nameFilter::text, '%') ORDER BY rp.name  LIMIT @limit", args)
+	query := rpmPackageSearchQuery(m, search, limit)
+	rows, err := tx.Query(ctx, query.SQL, query.Args)
 	if err != nil {
 		return nil, err
 	}
 
-	rpms, err := pgx.CollectRows(rows, pgx.RowToStructByName[RpmPackageSearch])
+	rpms, err := collectRows(ctx, rows, pgx.RowToStructByName[RpmPackageSearch])
 	if err != nil {
 		return nil, err
 	}
 	return rpms, nil
 }
 
+func rpmPackageSearchQuery(m membership, search string, limit int) sqlQuery {
+	b := newQueryBuilder()
+	where := conditions{"rp.name ILIKE CONCAT(" + b.bind("nameFilter", search+"%") + "::text, '%')"}
+	return b.query(`
+		SELECT DISTINCT ON (rp.name) rp.name, rp.summary
+		FROM rpm_package rp` + b.membershipJoin(m) + where.and() + `
+		ORDER BY rp.name
+		LIMIT ` + b.bind("limit", limit))
+}
+
 // RpmRepositoryVersionPackageGroupSearch search for RPM Package Groups, by name, associated to repository hrefs, returning an amount up to limit
-func (t *tangyImpl) RpmRepositoryVersionPackageGroupSearch(ctx context.Context, hrefs []string, search string, limit int) ([]RpmPackageGroupSearch, error) {
+func (t *tangyImpl) RpmRepositoryVersionPackageGroupSearch(ctx context.Context, hrefs []string, search string, limit int) (_ []RpmPackageGroupSearch, err error) {
+	ctx, done := t.call(ctx, "RpmRepositoryVersionPackageGroupSearch", arg("search", search), arg("limit", limit))
+	defer done(&err)
+
 	if len(hrefs) == 0 {
 		return []RpmPackageGroupSearch{}, nil
 	}
 
-	conn, err := t.pool.Acquire(ctx)
+	tx, end, err := t.begin(ctx)
 	if err != nil {
 		return nil, err
 	}
-	defer conn.Release()
+	defer end()
 
 	if limit == 0 {
 		limit = DefaultLimit
@@ -154,25 +192,27 @@ func (t *tangyImpl) RpmRepositoryVersionPackageGroupSearch(ctx context.Context,
 		return []RpmPackageGroupSearch{}, fmt.Errorf("error parsing repository version hrefs: %w", err)
 	}
 
-	args := pgx.NamedArgs{"nameFilter": "%" + search + "%"}
-	innerUnion, err := contentIdsInVersions(ctx, conn, repoVerMap, &args)
+	m, err := t.contentMembership(ctx, tx, repoVerMap)
 	if err != nil {
 		return nil, err
 	}
 
-	query := `SELECT DISTINCT ON (rp.name, rp.id, rp.packages) rp.name, rp.id, rp.description, rp.packages
-              FROM rpm_packagegroup rp 
-			`
-
-	rows, err := conn.Query(ctx, query+innerUnion+"AND rp.name ILIKE CONCAT( '%', @nameFilter::text, '%') ORDER BY rp.name", args)
+	query := rpmPackageGroupSearchQuery(m, search)
+	rows, err := tx.Query(ctx, query.SQL, query.Args)
 	if err != nil {
 		return nil, err
 	}
-	rpms, err := pgx.CollectRows(rows, pgx.RowToStructByName[rpmPackageGroupSearchQueryReturn])
+	rpms, err := collectRows(ctx, rows, pgx.RowToStructByName[rpmPackageGroupSearchQueryReturn])
 	if err != nil {
 		return nil, err
 	}
 
+	return rpmPackageGroupSearchResults(rpms, limit)
+}
+
+// rpmPackageGroupSearchResults merges the packages of the groups found with the same name and id,
+// and returns up to limit groups in the order they were found
+func rpmPackageGroupSearchResults(rpms []rpmPackageGroupSearchQueryReturn, limit int) ([]RpmPackageGroupSearch, error) {
 	var pkgGroupMap = make(map[string]RpmPackageGroupSearch, 0)
 	for _, rpm := range rpms {
 		nameId := rpm.Name + rpm.ID
@@ -187,10 +227,11 @@ func (t *tangyImpl) RpmRepositoryVersionPackageGroupSearch(ctx context.Context,
 			pkgGroup.ID = rpm.ID
 			pkgGroup.Name = rpm.Name
 			pkgGroup.Description = rpm.Description
-			pkgGroup.Packages, err = parsePackages(rpm.Packages)
+			packages, err := parsePackages(rpm.Packages)
 			if err != nil {
 				return nil, err
 			}
+			pkgGroup.Packages = packages
 		}
 		pkgGroupMap[nameId] = RpmPackageGroupSearch{
 			ID:          pkgGroup.ID,
@@ -217,17 +258,29 @@ func (t *tangyImpl) RpmRepositoryVersionPackageGroupSearch(ctx context.Context,
 	}
 }
 
+func rpmPackageGroupSearchQuery(m membership, search string) sqlQuery {
+	b := newQueryBuilder()
+	where := conditions{"rp.name ILIKE CONCAT('%', " + b.bind("nameFilter", "%"+search+"%") + "::text, '%')"}
+	return b.query(`
+		SELECT DISTINCT ON (rp.name, rp.id, rp.packages) rp.name, rp.id, rp.description, rp.packages
+		FROM rpm_packagegroup rp` + b.membershipJoin(m) + where.and() + `
+		ORDER BY rp.name`)
+}
+
 // RpmRepositoryVersionEnvironmentSearch search for RPM Environments, by name, associated to repository hrefs, returning an amount up to limit
-func (t *tangyImpl) RpmRepositoryVersionEnvironmentSearch(ctx context.Context, hrefs []string, search string, limit int) ([]RpmEnvironmentSearch, error) {
+func (t *tangyImpl) RpmRepositoryVersionEnvironmentSearch(ctx context.Context, hrefs []string, search string, limit int) (_ []RpmEnvironmentSearch, err error) {
+	ctx, done := t.call(ctx, "RpmRepositoryVersionEnvironmentSearch", arg("search", search), arg("limit", limit))
+	defer done(&err)
+
 	if len(hrefs) == 0 {
 		return []RpmEnvironmentSearch{}, nil
 	}
 
-	conn, err := t.pool.Acquire(ctx)
+	tx, end, err := t.begin(ctx)
 	if err != nil {
 		return nil, err
 	}
-	defer conn.Release()
+	defer end()
 
 	if limit == 0 {
 		limit = DefaultLimit
@@ -238,21 +291,17 @@ func (t *tangyImpl) RpmRepositoryVersionEnvironmentSearch(ctx context.Context, h
 		return []RpmEnvironmentSearch{}, fmt.Errorf("error parsing repository version hrefs: %w", err)
 	}
 
-	args := pgx.NamedArgs{"nameFilter": "%" + search + "%", "limit": limit}
-	innerUnion, err := contentIdsInVersions(ctx, conn, repoVerMap, &args)
+	m, err := t.contentMembership(ctx, tx, repoVerMap)
 	if err != nil {
 		return nil, err
 	}
 
-	query := `SELECT DISTINCT ON (rp.name, rp.id) rp.name, rp.id, rp.description
-              FROM rpm_packageenvironment rp 
-			`
-
-	rows, err := conn.Query(ctx, query+innerUnion+" AND rp.name ILIKE CONCAT( '%', @nameFilter::text, '%') ORDER BY rp.name  LIMIT @limit", args)
+	query := rpmEnvironmentSearchQuery(m, search, limit)
+	rows, err := tx.Query(ctx, query.SQL, query.Args)
 	if err != nil {
 		return nil, err
 	}
-	rpms, err := pgx.CollectRows(rows, pgx.RowToStructByName[RpmEnvironmentSearch])
+	rpms, err := collectRows(ctx, rows, pgx.RowToStructByName[RpmEnvironmentSearch])
 	if err != nil {
 		return nil, err
 	}
@@ -260,17 +309,30 @@ func (t *tangyImpl) RpmRepositoryVersionEnvironmentSearch(ctx context.Context, h
 	return rpms, nil
 }
 
+func rpmEnvironmentSearchQuery(m membership, search string, limit int) sqlQuery {
+	b := newQueryBuilder()
+	where := conditions{"rp.name ILIKE CONCAT('%', " + b.bind("nameFilter", "%"+search+"%") + "::text, '%')"}
+	return b.query(`
+		SELECT DISTINCT ON (rp.name, rp.id) rp.name, rp.id, rp.description
+		FROM rpm_packageenvironment rp` + b.membershipJoin(m) + where.and() + `
+		ORDER BY rp.name
+		LIMIT ` + b.bind("limit", limit))
+}
+
 // RpmRepositoryVersionErrataList List Errata within a repository version, with pagination, and optional filters
-func (t *tangyImpl) RpmRepositoryVersionErrataList(ctx context.Context, hrefs []string, filterOpts ErrataListFilters, pageOpts PageOptions) ([]ErrataListItem, int, error) {
+func (t *tangyImpl) RpmRepositoryVersionErrataList(ctx context.Context, hrefs []string, filterOpts ErrataListFilters, pageOpts PageOptions) (_ ErrataListResponse, err error) {
+	ctx, done := t.call(ctx, "RpmRepositoryVersionErrataList", filterOpts, pageOpts)
+	defer done(&err)
+
 	if len(hrefs) == 0 {
-		return []ErrataListItem{}, 0, nil
+		return ErrataListResponse{Results: []ErrataListItem{}}, nil
 	}
 
-	conn, err := t.pool.Acquire(ctx)
+	tx, end, err := t.begin(ctx)
 	if err != nil {
-		return nil, 0, err
+		return ErrataListResponse{}, err
 	}
-	defer conn.Release()
+	defer end()
 
 	if pageOpts.Limit == 0 {
 		pageOpts.Limit = DefaultLimit
@@ -279,162 +341,185 @@ func (t *tangyImpl) RpmRepositoryVersionErrataList(ctx context.Context, hrefs []
 	repoVerMap, err := parseRepositoryVersionHrefsMap(hrefs)
 
 	if err != nil {
-		return nil, 0, fmt.Errorf("error parsing repository version hrefs: %w", err)
+		return ErrataListResponse{}, fmt.Errorf("error parsing repository version hrefs: %w", err)
 	}
 
-	countQueryOpen := "select count(distinct rp.content_ptr_id) as total FROM rpm_updaterecord rp "
-
-	args := pgx.NamedArgs{
-		"searchFilter":   filterOpts.Search,
-		"typeFilter":     filterOpts.Type,
-		"severityFilter": filterOpts.Severity,
-		"typeList":       []string{"security", "bugfix", "enhancement"},
-		"severityList":   []string{"Important", "Critical", "Moderate", "Low"},
+	sortField, order := errataListSort(pageOpts.SortBy)
+	cursorKind := cursorKindRpmErrataList + ":" + sortField
+	if order.Desc {
+		cursorKind += ":desc"
 	}
-
-	var concatFilter strings.Builder
-	if filterOpts.Search != "" {
-		concatFilter.WriteString(" AND (rp.id ILIKE CONCAT( '%', @searchFilter::text, '%') OR rp.summary ILIKE CONCAT( '%', @searchFilter::text, '%'))")
+	cursor, err := decodeCursor(pageOpts.Cursor, cursorKind, len(order.Columns))
+	if err != nil {
+		return ErrataListResponse{}, err
 	}
-	if filterOpts.Type != nil {
-		if strings.Contains(filterOpts.Type[0], ",") {
-			filterOpts.Type = strings.Split(filterOpts.Type[0], ",")
-		}
-		args["typeFilter"] = filterOpts.Type
-		concatFilter.WriteString(" AND (rp.type = ANY(@typeFilter)")
-		if containsString(filterOpts.Type, "other") {
-			concatFilter.WriteString(" OR NOT (rp.type = ANY(@typeList))")
-		}
-		concatFilter.WriteString(")")
+
+	m, err := t.contentMembership(ctx, tx, repoVerMap)
+	if err != nil {
+		return ErrataListResponse{}, err
 	}
-	if filterOpts.Severity != nil {
-		if strings.Contains(filterOpts.Severity[0], ",") {
-			filterOpts.Severity = strings.Split(filterOpts.Severity[0], ",")
-		}
-		args["severityFilter"] = filterOpts.Severity
-		concatFilter.WriteString(" AND (rp.severity = ANY(@severityFilter)")
-		if containsString(filterOpts.Severity, "Unknown") {
-			concatFilter.WriteString(" OR NOT (rp.severity = ANY(@severityList))")
-		}
-		concatFilter.WriteString(")")
+
+	total, totalMode, err := listTotal(ctx, tx, pageOpts.Count, func(limit int) sqlQuery {
+		return rpmErrataListCountQuery(m, filterOpts, limit)
+	})
+	if err != nil {
+		return ErrataListResponse{}, err
 	}
-	filterQuery := concatFilter.String()
 
-	innerUnion, err := contentIdsInVersions(ctx, conn, repoVerMap, &args)
+	query := rpmErrataListQuery(m, filterOpts, order, pageOpts, cursor)
+	rows, err := tx.Query(ctx, query.SQL, query.Args)
 	if err != nil {
-		return nil, 0, err
+		return ErrataListResponse{}, err
 	}
 
-	var countTotal int
-	err = conn.QueryRow(ctx, countQueryOpen+innerUnion+filterQuery,
-		args).Scan(&countTotal)
+	errata, err := collectRows(ctx, rows, pgx.RowToStructByName[ErrataListItem])
 
 	if err != nil {
-		return nil, 0, err
+		return ErrataListResponse{}, err
+	}
+
+	response := ErrataListResponse{
+		Results:   errata,
+		Total:     total,
+		TotalMode: totalMode,
+		Limit:     pageOpts.Limit,
+		Offset:    pageOpts.Offset,
+	}
+	if len(errata) > 0 {
+		last := errata[len(errata)-1]
+		response.NextCursor = nextCursor(cursorKind, len(errata), pageOpts.Limit, nil, errataSortValue(last, sortField), last.Id)
 	}
+	return response, nil
+}
 
-	queryOpen := `SELECT distinct rp.content_ptr_id as id, rp.id as ErrataId, rp.title, rp.summary, rp.description, rp.issued_date as IssuedDate, rp.updated_date as UpdatedDate, rp.type, rp.severity, rp.reboot_suggested as RebootSuggested, 
-              (SELECT ARRAY_AGG(ru.ref_id)
-                FROM rpm_updatereference ru 
-                WHERE ru.update_record_id = rp.content_ptr_id
-                AND ru.ref_type = 'cve') AS CVEs
-              FROM rpm_updaterecord rp `
+func rpmErrataListCountQuery(m membership, filterOpts ErrataListFilters, countLimit int) sqlQuery {
+	b := newQueryBuilder()
+	return b.query(b.count(`
+		SELECT DISTINCT rp.content_ptr_id
+		FROM rpm_updaterecord rp`+b.membershipJoin(m)+errataListFilters(b, filterOpts).and(), countLimit))
+}
 
-	args["limit"] = pageOpts.Limit
-	args["offset"] = pageOpts.Offset
-	args["sort_by"] = pageOpts.SortBy
+func rpmErrataListQuery(m membership, filterOpts ErrataListFilters, order sortOrder, pageOpts PageOptions, cursor pageCursor) sqlQuery {
+	b := newQueryBuilder()
+	join := b.membershipJoin(m)
+	where := append(errataListFilters(b, filterOpts), b.pageAfter(order, cursor)...)
+	return b.query(`
+		SELECT DISTINCT rp.content_ptr_id AS id, rp.id AS ErrataId, rp.title, rp.summary, rp.description,
+		       rp.issued_date AS IssuedDate, rp.updated_date AS UpdatedDate, rp.type, rp.severity,
+		       rp.reboot_suggested AS RebootSuggested,
+		       (SELECT ARRAY_AGG(ru.ref_id)
+		        FROM rpm_updatereference ru
+		        WHERE ru.update_record_id = rp.content_ptr_id
+		        AND ru.ref_type = 'cve') AS CVEs
+		FROM rpm_updaterecord rp` + join + where.and() + `
+		` + orderBy(order) + `
+		` + b.page(pageOpts.Limit, pageOpts.Offset, cursor))
+}
 
-	var orderBy string
-	sortField := strings.Split(pageOpts.SortBy, ":")[0]
+// errataListFilters returns the conditions of the errata list filters. Type and severity filters
+// may be passed as a single comma separated value.
+func errataListFilters(b *queryBuilder, filterOpts ErrataListFilters) conditions {
+	var where conditions
+	if filterOpts.Search != "" {
+		search := b.bind("searchFilter", filterOpts.Search)
+		where = append(where, "(rp.id ILIKE CONCAT('%', "+search+"::text, '%') OR rp.summary ILIKE CONCAT('%', "+search+"::text, '%'))")
+	}
+	if filterOpts.Type != nil {
+		types := filterOpts.Type
+		if strings.Contains(types[0], ",") {
+			types = strings.Split(types[0], ",")
+		}
+		condition := "rp.type = ANY(" + b.bind("typeFilter", types) + ")"
+		if containsString(types, "other") {
+			condition += " OR NOT (rp.type = ANY(" + b.bind("typeList", []string{"security", "bugfix", "enhancement"}) + "))"
+		}
+		where = append(where, "("+condition+")")
+	}
+	if filterOpts.Severity != nil {
+		severities := filterOpts.Severity
+		if strings.Contains(severities[0], ",") {
+			severities = strings.Split(severities[0], ",")
+		}
+		condition := "rp.severity = ANY(" + b.bind("severityFilter", severities) + ")"
+		if containsString(severities, "Unknown") {
+			condition += " OR NOT (rp.severity = ANY(" + b.bind("severityList", []string{"Important", "Critical", "Moderate", "Low"}) + "))"
+		}
+		where = append(where, "("+condition+")")
+	}
+	return where
+}
+
+// errataListSort returns the sort field and keyset sort order for an errata list sort_by option.
+// The content id is always added as the last column, so that errata with equal sort values have a stable order.
+func errataListSort(sortBy string) (string, sortOrder) {
+	sortField := strings.Split(sortBy, ":")[0]
+	var column keysetColumn
 	switch sortField {
-	case "issued_date":
-		orderBy = "rp.issued_date"
 	case "updated_date":
-		orderBy = "rp.updated_date"
+		column = keysetColumn{Expr: "rp.updated_date", Type: "text", Nullable: true}
 	case "type":
-		orderBy = "rp.type"
+		column = keysetColumn{Expr: "rp.type", Type: "text"}
 	case "severity":
-		orderBy = "rp.severity"
+		column = keysetColumn{Expr: "rp.severity", Type: "text"}
 	default:
-		orderBy = "rp.issued_date"
-	}
-
-	if strings.Contains(pageOpts.SortBy, "asc") {
-		orderBy += " ASC"
-	} else {
-		orderBy += " DESC"
+		sortField = "issued_date"
+		column = keysetColumn{Expr: "rp.issued_date", Type: "text"}
 	}
 
-	rows, err := conn.Query(ctx, queryOpen+innerUnion+filterQuery+
-		" ORDER BY "+orderBy+" LIMIT @limit OFFSET @offset",
-		args)
-	if err != nil {
-		return nil, 0, err
-	}
-
-	errata, err := pgx.CollectRows(rows, pgx.RowToStructByName[ErrataListItem])
+	desc := !strings.Contains(sortBy, "asc")
+	return sortField, sortOrder{Columns: []keysetColumn{column, {Expr: "rp.content_ptr_id", Type: "uuid"}}, Desc: desc}
+}
 
-	if err != nil {
-		return nil, 0, err
+// errataSortValue returns the value of the errata sort field, as compared by errataListSort columns
+func errataSortValue(erratum ErrataListItem, sortField string) string {
+	switch sortField {
+	case "updated_date":
+		if erratum.UpdatedDate == nil {
+			return cursorNull
+		}
+		return *erratum.UpdatedDate
+	case "type":
+		return erratum.Type
+	case "severity":
+		return erratum.Severity
+	default:
+		return erratum.IssuedDate
 	}
-	return errata, countTotal, nil
 }
 
 // RpmRepositoryVersionModuleStreamsList List Modules streams within a repository version, with pagination, search and an optional name filter
-func (t *tangyImpl) RpmRepositoryVersionModuleStreamsList(ctx context.Context, hrefs []string, filterOpts ModuleStreamListFilters, sortBy string) ([]ModuleStreams, error) {
+func (t *tangyImpl) RpmRepositoryVersionModuleStreamsList(ctx context.Context, hrefs []string, filterOpts ModuleStreamListFilters, sortBy string) (_ []ModuleStreams, err error) {
+	ctx, done := t.call(ctx, "RpmRepositoryVersionModuleStreamsList", filterOpts, arg("sort_by", sortBy))
+	defer done(&err)
+
 	if len(hrefs) == 0 {
 		return []ModuleStreams{}, nil
 	}
 
-	conn, err := t.pool.Acquire(ctx)
+	tx, end, err := t.begin(ctx)
 	if err != nil {
 		return nil, err
 	}
-	defer conn.Release()
+	defer end()
 
 	repoVerMap, err := parseRepositoryVersionHrefsMap(hrefs)
 	if err != nil {
 		return nil, fmt.Errorf("error parsing repository version hrefs: %w", err)
 	}
 
-	orderBy := "rp.name"
-
-	if strings.Contains(strings.ToLower(sortBy), "desc") {
-		orderBy += " DESC"
-	} else {
-		orderBy += " ASC"
-	}
-
-	orderBy += ", rp.stream, rp.version"
-
-	args := pgx.NamedArgs{
-		"nameFilter": "%" + filterOpts.Search + "%",
-		"rpm_names":  filterOpts.RpmNames,
-	}
-	query := `Select distinct on (rp.name, rp.stream) rp.name, rp.stream, rp.version, rp.profiles, rp.context, rp.arch, rp.description FROM rpm_modulemd rp 
-	INNER JOIN rpm_modulemd_packages rmp on rmp.modulemd_id = rp.content_ptr_id
-	INNER JOIN rpm_package pack on pack.content_ptr_id = rmp.package_id `
-
-	innerUnion, err := contentIdsInVersions(ctx, conn, repoVerMap, &args)
+	m, err := t.contentMembership(ctx, tx, repoVerMap)
 	if err != nil {
 		return nil, err
 	}
 
-	rpmNameFilter := ""
-
-	if len(filterOpts.RpmNames) > 0 {
-		rpmNameFilter = " AND pack.name = ANY(@rpm_names)"
-	}
-
-	filter := rpmNameFilter + " AND rp.name ILIKE CONCAT( '%', @nameFilter::text, '%') "
-
-	rows, err := conn.Query(ctx, query+innerUnion+filter+" ORDER BY "+orderBy+" LIMIT 5000", args)
+	query := rpmModuleStreamsListQuery(m, filterOpts, sortBy)
+	rows, err := tx.Query(ctx, query.SQL, query.Args)
 
 	if err != nil {
 		return nil, err
 	}
 
-	moduleStreams, err := pgx.CollectRows(rows, pgx.RowToStructByName[ModuleStreams])
+	moduleStreams, err := collectRows(ctx, rows, pgx.RowToStructByName[ModuleStreams])
 
 	if err != nil {
 		return nil, err
@@ -443,17 +528,43 @@ func (t *tangyImpl) RpmRepositoryVersionModuleStreamsList(ctx context.Context, h
 	return moduleStreams, nil
 }
 
+func rpmModuleStreamsListQuery(m membership, filterOpts ModuleStreamListFilters, sortBy string) sqlQuery {
+	b := newQueryBuilder()
+	join := b.membershipJoin(m)
+
+	var where conditions
+	if len(filterOpts.RpmNames) > 0 {
+		where = append(where, "pack.name = ANY("+b.bind("rpm_names", filterOpts.RpmNames)+")")
+	}
+	where = append(where, "rp.name ILIKE CONCAT('%', "+b.bind("nameFilter", "%"+filterOpts.Search+"%")+"::text, '%')")
+
+	// Only the module name is sorted in the requested direction, streams of a module are always sorted ascending
+	nameOrder := sortOrder{Columns: []keysetColumn{{Expr: "rp.name"}}, Desc: strings.Contains(strings.ToLower(sortBy), "desc")}
+	streamOrder := sortOrder{Columns: []keysetColumn{{Expr: "rp.stream"}, {Expr: "rp.version"}}}
+
+	return b.query(`
+		SELECT DISTINCT ON (rp.name, rp.stream) rp.name, rp.stream, rp.version, rp.profiles, rp.context, rp.arch, rp.description
+		FROM rpm_modulemd rp
+		INNER JOIN rpm_modulemd_packages rmp ON rmp.modulemd_id = rp.content_ptr_id
+		INNER JOIN rpm_package pack ON pack.content_ptr_id = rmp.package_id` + join + where.and() + `
+		` + orderBy(nameOrder, streamOrder) + `
+		LIMIT 5000`)
+}
+
 // RpmRepositoryVersionPackageList List RPMs within a repository version, with pagination, and an optional name filter
-func (t *tangyImpl) RpmRepositoryVersionPackageList(ctx context.Context, hrefs []string, filterOpts RpmListFilters, pageOpts PageOptions) ([]RpmListItem, int, error) {
+func (t *tangyImpl) RpmRepositoryVersionPackageList(ctx context.Context, hrefs []string, filterOpts RpmListFilters, pageOpts PageOptions) (_ RpmListResponse, err error) {
+	ctx, done := t.call(ctx, "RpmRepositoryVersionPackageList", filterOpts, pageOpts)
+	defer done(&err)
+
 	if len(hrefs) == 0 {
-		return []RpmListItem{}, 0, nil
+		return RpmListResponse{Results: []RpmListItem{}}, nil
 	}
 
-	conn, err := t.pool.Acquire(ctx)
+	tx, end, err := t.begin(ctx)
 	if err != nil {
-		return nil, 0, err
+		return RpmListResponse{}, err
 	}
-	defer conn.Release()
+	defer end()
 
 	if pageOpts.Limit == 0 {
 		pageOpts.Limit = DefaultLimit
@@ -461,40 +572,80 @@ func (t *tangyImpl) RpmRepositoryVersionPackageList(ctx context.Context, hrefs [
 
 	repoVerMap, err := parseRepositoryVersionHrefsMap(hrefs)
 	if err != nil {
-		return nil, 0, fmt.Errorf("error parsing repository version hrefs: %w", err)
+		return RpmListResponse{}, fmt.Errorf("error parsing repository version hrefs: %w", err)
 	}
 
-	countQueryOpen := "select count(distinct(rp.content_ptr_id)) as total FROM rpm_package rp "
-	args := pgx.NamedArgs{"nameFilter": filterOpts.Name + "%"}
-	innerUnion, err := contentIdsInVersions(ctx, conn, repoVerMap, &args)
+	cursor, err := decodeCursor(pageOpts.Cursor, cursorKindRpmPackageList, len(rpmPackageListSort.Columns))
 	if err != nil {
-		return nil, 0, err
+		return RpmListResponse{}, err
 	}
 
-	var countTotal int
-	err = conn.QueryRow(ctx, countQueryOpen+innerUnion+" AND rp.name ILIKE CONCAT( @nameFilter::text, '%')", args).Scan(&countTotal)
+	m, err := t.contentMembership(ctx, tx, repoVerMap)
 	if err != nil {
-		return nil, 0, err
+		return RpmListResponse{}, err
 	}
 
-	queryOpen := `SELECT distinct rp.content_ptr_id as id, rp.name, rp.version, rp.arch, rp.release, rp.epoch, rp.summary
-              FROM rpm_package rp `
+	total, totalMode, err := listTotal(ctx, tx, pageOpts.Count, func(limit int) sqlQuery {
+		return rpmPackageListCountQuery(m, filterOpts, limit)
+	})
+	if err != nil {
+		return RpmListResponse{}, err
+	}
 
-	args["limit"] = pageOpts.Limit
-	args["offset"] = pageOpts.Offset
-	rows, err := conn.Query(ctx, queryOpen+innerUnion+
-		" AND rp.name ILIKE CONCAT( @nameFilter::text, '%') ORDER BY rp.name ASC, rp.version ASC, rp.release ASC, rp.arch ASC LIMIT @limit OFFSET @offset",
-		args)
+	query := rpmPackageListQuery(m, filterOpts, pageOpts, cursor)
+	rows, err := tx.Query(ctx, query.SQL, query.Args)
 	if err != nil {
-		return nil, 0, err
+		return RpmListResponse{}, err
 	}
-	rpms, err := pgx.CollectRows(rows, pgx.RowToStructByName[RpmListItem])
+	rpms, err := collectRows(ctx, rows, pgx.RowToStructByName[RpmListItem])
 	if err != nil {
-		return nil, 0, err
+		return RpmListResponse{}, err
+	}
+
+	response := RpmListResponse{
+		Results:   rpms,
+		Total:     total,
+		TotalMode: totalMode,
+		Limit:     pageOpts.Limit,
+		Offset:    pageOpts.Offset,
 	}
-	return rpms, countTotal, nil
+	if len(rpms) > 0 {
+		last := rpms[len(rpms)-1]
+		response.NextCursor = nextCursor(cursorKindRpmPackageList, len(rpms), pageOpts.Limit, nil, last.Name, last.Version, last.Release, last.Arch, last.Id)
+	}
+	return response, nil
+}
+
+func rpmPackageListCountQuery(m membership, filterOpts RpmListFilters, countLimit int) sqlQuery {
+	b := newQueryBuilder()
+	join := b.membershipJoin(m)
+	where := conditions{"rp.name ILIKE CONCAT(" + b.bind("nameFilter", filterOpts.Name+"%") + "::text, '%')"}
+	return b.query(b.count(`
+		SELECT DISTINCT rp.content_ptr_id
+		FROM rpm_package rp`+join+where.and(), countLimit))
 }
 
+func rpmPackageListQuery(m membership, filterOpts RpmListFilters, pageOpts PageOptions, cursor pageCursor) sqlQuery {
+	b := newQueryBuilder()
+	join := b.membershipJoin(m)
+	where := conditions{"rp.name ILIKE CONCAT(" + b.bind("nameFilter", filterOpts.Name+"%") + "::text, '%')"}
+	where = append(where, b.pageAfter(rpmPackageListSort, cursor)...)
+	return b.query(`
+		SELECT DISTINCT rp.content_ptr_id AS id, rp.name, rp.version, rp.arch, rp.release, rp.epoch, rp.summary
+		FROM rpm_package rp` + join + where.and() + `
+		` + orderBy(rpmPackageListSort) + `
+		` + b.page(pageOpts.Limit, pageOpts.Offset, cursor))
+}
+
+// rpmPackageListSort is the sort key of RpmRepositoryVersionPackageList, ending with the content id to make it unique
+var rpmPackageListSort = sortOrder{Columns: []keysetColumn{
+	{Expr: "rp.name", Type: "text"},
+	{Expr: "rp.version", Type: "text"},
+	{Expr: "rp.release", Type: "text"},
+	{Expr: "rp.arch", Type: "text"},
+	{Expr: "rp.content_ptr_id", Type: "uuid"},
+}}
+
 type ParsedRepoVersion struct {
 	RepositoryUUID string
 	Version        int
@@ -506,19 +657,19 @@ func parseRepositoryVersionHrefsMap(hrefs []string) (mapping []ParsedRepoVersion
 	for _, href := range hrefs {
 		splitHref := strings.Split(href, "/")
 		if len(splitHref) < 12 {
-			return mapping, fmt.Errorf("%v is not a valid href", splitHref)
+			return mapping, fmt.Errorf("%w: %v is not a valid href", ErrInvalidHref, href)
 		}
 		id := splitHref[9]
 		num := splitHref[11]
 
 		_, err = uuid.Parse(id)
 		if err != nil {
-			return mapping, fmt.Errorf("%v is not a valid uuid", id)
+			return mapping, fmt.Errorf("%w: %v is not a valid uuid", ErrInvalidHref, id)
 		}
 
 		ver, err := strconv.Atoi(num)
 		if err != nil {
-			return mapping, fmt.Errorf("%v is not a valid integer", num)
+			return mapping, fmt.Errorf("%w: %v is not a valid integer", ErrInvalidHref, num)
 		}
 
 		mapping = append(mapping, ParsedRepoVersion{
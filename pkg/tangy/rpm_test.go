@@ -63,7 +63,7 @@ func TestParseRepositoryVersionHrefsMap(t *testing.T) {
 
 			got, err := parseRepositoryVersionHrefsMap(tt.hrefs)
 			if tt.wantErr {
-				require.Error(t, err)
+				require.ErrorIs(t, err, ErrInvalidHref)
 				return
 			}
 
@@ -114,24 +114,27 @@ func TestMockTangyRpmRepositoryVersionPackageList(t *testing.T) {
 	filterOpts := RpmListFilters{Name: "kernel"}
 	pageOpts := PageOptions{Offset: 0, Limit: 20}
 
-	expected := []RpmListItem{
-		{
-			Id:      "pkg-1",
-			Name:    "kernel",
-			Arch:    "x86_64",
-			Version: "6.1.0",
-			Release: "1",
-			Epoch:   "0",
-			Summary: "The Linux kernel",
+	expected := RpmListResponse{
+		Results: []RpmListItem{
+			{
+				Id:      "pkg-1",
+				Name:    "kernel",
+				Arch:    "x86_64",
+				Version: "6.1.0",
+				Release: "1",
+				Epoch:   "0",
+				Summary: "The Linux kernel",
+			},
 		},
+		Total: 1,
+		Limit: 20,
 	}
 
-	mockTangy.On("RpmRepositoryVersionPackageList", ctx, []string{href}, filterOpts, pageOpts).Return(expected, 1, nil)
+	mockTangy.On("RpmRepositoryVersionPackageList", ctx, []string{href}, filterOpts, pageOpts).Return(expected, nil)
 
-	got, total, err := mockTangy.RpmRepositoryVersionPackageList(ctx, []string{href}, filterOpts, pageOpts)
+	got, err := mockTangy.RpmRepositoryVersionPackageList(ctx, []string{href}, filterOpts, pageOpts)
 	require.NoError(t, err)
 	assert.Equal(t, expected, got)
-	assert.Equal(t, 1, total)
 }
 
 func TestMockTangyRpmRepositoryVersionPackageSearch(t *testing.T) {
@@ -0,0 +1,104 @@
+package tangy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// ErrSnapshotClosed is returned by the methods of the Tangy passed to ReadSnapshot when they are called
+// after the function given to ReadSnapshot returned
+var ErrSnapshotClosed = errors.New("read snapshot is closed")
+
+// snapshotTxOptions are the options of the transactions queries run in. A repeatable read transaction sees
+// a single snapshot of the database, so the count and page queries of a list method agree even if a
+// repository version is created in between.
+var snapshotTxOptions = pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly}
+
+// snapshot is the transaction shared by the calls made through the Tangy passed to ReadSnapshot.
+// Calls are serialized, as a transaction can only run one statement at a time.
+type snapshot struct {
+	mu     sync.Mutex
+	tx     pgx.Tx
+	closed bool
+}
+
+// begin starts the transaction the queries of a method run in, and returns a function ending it.
+//
+//	Outside ReadSnapshot, every method runs in its own read-only repeatable read transaction.
+//	Inside ReadSnapshot, the method runs in a savepoint of the shared transaction, so an error in one call
+//	does not abort the transaction for the following calls.
+//	The statement timeout of the call is set locally to the transaction or savepoint.
+//	The statements run in the returned transaction are commented when Database.SQLComments is set.
+func (t *tangyImpl) begin(ctx context.Context) (pgx.Tx, func(), error) {
+	if t.snapshot == nil {
+		tx, err := t.readPool(ctx).BeginTx(ctx, snapshotTxOptions)
+		if err != nil {
+			return nil, nil, err
+		}
+		end := rollback(ctx, tx)
+		tx = t.commented(tx)
+		if err := t.setStatementTimeout(ctx, tx); err != nil {
+			end()
+			return nil, nil, err
+		}
+		return tx, end, nil
+	}
+
+	t.snapshot.mu.Lock()
+	if t.snapshot.closed {
+		t.snapshot.mu.Unlock()
+		return nil, nil, ErrSnapshotClosed
+	}
+	tx, err := t.snapshot.tx.Begin(ctx)
+	if err != nil {
+		t.snapshot.mu.Unlock()
+		return nil, nil, err
+	}
+	end := func() {
+		rollback(ctx, tx)()
+		t.snapshot.mu.Unlock()
+	}
+	tx = t.commented(tx)
+	if err := t.setStatementTimeout(ctx, tx); err != nil {
+		end()
+		return nil, nil, err
+	}
+	return tx, end, nil
+}
+
+// rollback returns a function rolling back a read-only transaction, even if ctx was canceled
+func rollback(ctx context.Context, tx pgx.Tx) func() {
+	return func() {
+		_ = tx.Rollback(context.WithoutCancel(ctx))
+	}
+}
+
+// ReadSnapshot calls fn with a Tangy whose methods all read the same snapshot of the database,
+// in a single read-only repeatable read transaction. The transaction is ended when fn returns,
+// and the error returned by fn is returned.
+func (t *tangyImpl) ReadSnapshot(ctx context.Context, fn func(snapshot Tangy) error) error {
+	// Nested snapshots share the transaction of the outer snapshot
+	if t.snapshot != nil {
+		return fn(t)
+	}
+
+	tx, err := t.readPool(ctx).BeginTx(ctx, snapshotTxOptions)
+	if err != nil {
+		return fmt.Errorf("error beginning read snapshot: %w", err)
+	}
+	s := &snapshot{tx: tx}
+	defer func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.closed = true
+		rollback(ctx, tx)()
+	}()
+
+	snapshotTangy := *t
+	snapshotTangy.snapshot = s
+	return fn(&snapshotTangy)
+}
@@ -0,0 +1,40 @@
+package tangy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshotTxOptions(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, pgx.RepeatableRead, snapshotTxOptions.IsoLevel)
+	assert.Equal(t, pgx.ReadOnly, snapshotTxOptions.AccessMode)
+}
+
+func TestBeginClosedSnapshot(t *testing.T) {
+	t.Parallel()
+
+	tangy := &tangyImpl{snapshot: &snapshot{closed: true}}
+	_, _, err := tangy.begin(context.Background())
+	assert.ErrorIs(t, err, ErrSnapshotClosed)
+
+	// The snapshot is unlocked after the error
+	assert.True(t, tangy.snapshot.mu.TryLock())
+}
+
+func TestReadSnapshotNested(t *testing.T) {
+	t.Parallel()
+
+	outer := &tangyImpl{snapshot: &snapshot{}}
+	err := outer.ReadSnapshot(context.Background(), func(inner Tangy) error {
+		assert.Same(t, outer, inner)
+		// Closing the snapshot Tangy does not close the pool
+		inner.Close()
+		return nil
+	})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,115 @@
+package tangy
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Keys of the SQL comments set by Tangy. Callers may set any other key with WithSQLComment.
+const (
+	SQLCommentMethod      = "method"
+	SQLCommentRequestID   = "request_id"
+	SQLCommentTraceparent = "traceparent"
+)
+
+type sqlCommentContextKey struct{}
+
+// WithSQLComment returns a context adding key and value to the SQL comment of the statements of the Tangy calls made
+// with it, when Database.SQLComments is set. Values set for the same key by an outer context are replaced.
+func WithSQLComment(ctx context.Context, key, value string) context.Context {
+	tags := maps.Clone(sqlCommentTags(ctx))
+	if tags == nil {
+		tags = make(map[string]string)
+	}
+	tags[key] = value
+	return context.WithValue(ctx, sqlCommentContextKey{}, tags)
+}
+
+// WithRequestID returns a context adding the ID of the request a Tangy call is made for to the SQL comment
+// of its statements, so that the statements seen in pg_stat_activity can be traced back to the request
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return WithSQLComment(ctx, SQLCommentRequestID, requestID)
+}
+
+func sqlCommentTags(ctx context.Context) map[string]string {
+	tags, _ := ctx.Value(sqlCommentContextKey{}).(map[string]string)
+	return tags
+}
+
+// sqlComment returns sql followed by a sqlcommenter comment with the method of the call ctx belongs to,
+// the traceparent of the span of ctx and the tags set with WithSQLComment, such as:
+//
+//	SELECT ... /*method='RpmRepositoryVersionErrataList',request_id='3f2a',traceparent='00-...-01'*/
+//
+// Keys and values are URL encoded and sorted by key, as defined by https://google.github.io/sqlcommenter/spec/
+func sqlComment(ctx context.Context, sql string) string {
+	tags := maps.Clone(sqlCommentTags(ctx))
+	if tags == nil {
+		tags = make(map[string]string)
+	}
+	if method := callMethod(ctx); method != "" {
+		tags[SQLCommentMethod] = method
+	}
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+		tags[SQLCommentTraceparent] = fmt.Sprintf("00-%s-%s-%s", spanContext.TraceID(), spanContext.SpanID(), spanContext.TraceFlags())
+	}
+	if len(tags) == 0 {
+		return sql
+	}
+
+	pairs := make([]string, 0, len(tags))
+	for _, key := range slices.Sorted(maps.Keys(tags)) {
+		pairs = append(pairs, sqlCommentEscape(key)+"='"+sqlCommentEscape(tags[key])+"'")
+	}
+	return sql + " /*" + strings.Join(pairs, ",") + "*/"
+}
+
+// sqlCommentEscape URL encodes a key or value of a SQL comment. Quotes and the characters ending a comment
+// are encoded, so that a value cannot end the comment.
+func sqlCommentEscape(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+}
+
+// commented returns tx, adding a SQL comment to its statements when Database.SQLComments is set
+func (t *tangyImpl) commented(tx pgx.Tx) pgx.Tx {
+	if !t.sqlComments {
+		return tx
+	}
+	return commentedTx{Tx: tx}
+}
+
+// commentedTx adds a SQL comment to every statement run in a transaction
+type commentedTx struct {
+	pgx.Tx
+}
+
+func (c commentedTx) Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error) {
+	return c.Tx.Exec(ctx, sqlComment(ctx, sql), arguments...)
+}
+
+func (c commentedTx) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	return c.Tx.Query(ctx, sqlComment(ctx, sql), args...)
+}
+
+func (c commentedTx) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return c.Tx.QueryRow(ctx, sqlComment(ctx, sql), args...)
+}
+
+// SendBatch sends a copy of b with commented statements, leaving b as is, so that a batch sent again is not commented twice
+func (c commentedTx) SendBatch(ctx context.Context, b *pgx.Batch) pgx.BatchResults {
+	commented := &pgx.Batch{QueuedQueries: make([]*pgx.QueuedQuery, len(b.QueuedQueries))}
+	for i, query := range b.QueuedQueries {
+		copied := *query
+		copied.SQL = sqlComment(ctx, query.SQL)
+		commented.QueuedQueries[i] = &copied
+	}
+	return c.Tx.SendBatch(ctx, commented)
+}
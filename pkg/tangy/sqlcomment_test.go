@@ -0,0 +1,77 @@
+package tangy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestSQLComment(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "SELECT 1", sqlComment(context.Background(), "SELECT 1"))
+
+	ctx := WithRequestID(context.Background(), "first")
+	ctx = WithSQLComment(ctx, "user", "o'brien */ DROP TABLE core_repository; /*")
+	inner := WithRequestID(ctx, "3f2a 9b")
+	ctx, _ = (&tangyImpl{}).call(inner, "RpmRepositoryVersionErrataList")
+	assert.Equal(t, "SELECT 1 /*method='RpmRepositoryVersionErrataList',request_id='3f2a%209b',"+
+		"user='o%27brien%20%2A%2F%20DROP%20TABLE%20core_repository%3B%20%2F%2A'*/", sqlComment(ctx, "SELECT 1"))
+
+	// The tags of the outer context are not changed by an inner context
+	assert.Equal(t, "first", sqlCommentTags(WithSQLComment(WithRequestID(context.Background(), "first"), "user", "pulp"))[SQLCommentRequestID])
+
+	spanContext := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+		SpanID:     trace.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx = trace.ContextWithSpanContext(context.Background(), spanContext)
+	assert.Equal(t, "SELECT 1 /*traceparent='00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01'*/", sqlComment(ctx, "SELECT 1"))
+}
+
+func TestSQLCommentsConfig(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, Database{Name: "pulp", Host: "localhost", User: "pulp", SQLComments: true}.Validate())
+	err := Database{Name: "pulp", Host: "localhost", User: "pulp", SQLComments: true, QueryExecMode: QueryExecModeCacheStatement}.Validate()
+	assert.ErrorContains(t, err, "sql_comments cannot be used with query_exec_mode cache_statement")
+
+	pool, err := newPool(Database{Host: "127.0.0.1", Port: 1, Name: "pulp", User: "pulp", SQLComments: true}, Logger{})
+	assert.NoError(t, err)
+	defer pool.Close()
+	assert.Zero(t, pool.Config().ConnConfig.StatementCacheCapacity)
+}
+
+// batchTx records the statements of the batches sent in a transaction
+type batchTx struct {
+	pgx.Tx
+	sent [][]string
+}
+
+func (b *batchTx) SendBatch(_ context.Context, batch *pgx.Batch) pgx.BatchResults {
+	var statements []string
+	for _, query := range batch.QueuedQueries {
+		statements = append(statements, query.SQL)
+	}
+	b.sent = append(b.sent, statements)
+	return nil
+}
+
+func TestCommentedTxSendBatch(t *testing.T) {
+	t.Parallel()
+
+	// A batch sent again, such as by a retried call, is commented once
+	tx := &batchTx{}
+	ctx := WithRequestID(context.Background(), "retried")
+	batch := &pgx.Batch{}
+	batch.Queue("SELECT 1")
+	for range 2 {
+		commentedTx{Tx: tx}.SendBatch(ctx, batch)
+	}
+	assert.Equal(t, [][]string{{"SELECT 1 /*request_id='retried'*/"}, {"SELECT 1 /*request_id='retried'*/"}}, tx.sent)
+	assert.Equal(t, "SELECT 1", batch.QueuedQueries[0].SQL)
+}
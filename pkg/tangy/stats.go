@@ -0,0 +1,281 @@
+package tangy
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ErrorClass is the kind of error a call failed with, as counted in MethodStats
+type ErrorClass string
+
+// Classes of the errors returned by Tangy methods
+const (
+	// ErrorClassInvalid is an invalid argument, such as an href, cursor or count mode that cannot be parsed,
+	// or a call that is not valid at this point, such as a call within a closed snapshot
+	ErrorClassInvalid ErrorClass = "invalid"
+	// ErrorClassNotFound is a repository, repository version or package that does not exist
+	ErrorClassNotFound ErrorClass = "not_found"
+	// ErrorClassTimeout is a query stopped by the statement timeout or the deadline of the context
+	ErrorClassTimeout ErrorClass = "timeout"
+	// ErrorClassCanceled is a call canceled by its caller
+	ErrorClassCanceled ErrorClass = "canceled"
+	// ErrorClassConnection is a database that cannot be reached, or a connection that was lost
+	ErrorClassConnection ErrorClass = "connection"
+	// ErrorClassDatabase is any other error reported by Postgres
+	ErrorClassDatabase ErrorClass = "database"
+	// ErrorClassOther is any other error
+	ErrorClassOther ErrorClass = "other"
+)
+
+// errorClasses are the classes of errors, in the order they are counted in
+var errorClasses = []ErrorClass{
+	ErrorClassInvalid, ErrorClassNotFound, ErrorClassTimeout, ErrorClassCanceled,
+	ErrorClassConnection, ErrorClassDatabase, ErrorClassOther,
+}
+
+// ClassifyError returns the class of an error returned by a Tangy method
+func ClassifyError(err error) ErrorClass {
+	var pgErr *pgconn.PgError
+	var connectErr *pgconn.ConnectError
+	var netErr net.Error
+	switch {
+	case errors.Is(err, ErrInvalidHref), errors.Is(err, ErrInvalidCursor), errors.Is(err, ErrInvalidCountMode),
+		errors.Is(err, ErrPythonNameNormalizedRequired), errors.Is(err, ErrIteratorInSnapshot), errors.Is(err, ErrSnapshotClosed),
+		errors.Is(err, ErrBatchNotRun):
+		return ErrorClassInvalid
+	case errors.Is(err, ErrRepositoryNotFound), errors.Is(err, ErrNoCompleteVersion), errors.Is(err, ErrRepositoryVersionNotFound),
+		errors.Is(err, ErrPythonPackageNotFound), errors.Is(err, ErrNpmPackageNotFound):
+		return ErrorClassNotFound
+	case errors.Is(err, ErrQueryTimeout):
+		return ErrorClassTimeout
+	case errors.Is(err, context.Canceled):
+		return ErrorClassCanceled
+	case errors.As(err, &pgErr):
+		if strings.HasPrefix(pgErr.Code, "08") {
+			return ErrorClassConnection
+		}
+		return ErrorClassDatabase
+	case errors.As(err, &connectErr), errors.As(err, &netErr), errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		return ErrorClassConnection
+	default:
+		return ErrorClassOther
+	}
+}
+
+// latencyBuckets are the upper bounds of the buckets of the latency histograms of the methods
+var latencyBuckets = []time.Duration{
+	time.Millisecond, 5 * time.Millisecond, 10 * time.Millisecond, 25 * time.Millisecond, 50 * time.Millisecond,
+	100 * time.Millisecond, 250 * time.Millisecond, 500 * time.Millisecond,
+	time.Second, 2500 * time.Millisecond, 5 * time.Second, 10 * time.Second,
+}
+
+// Stats are the statistics of a Tangy, counted since it was created
+type Stats struct {
+	// Pool are the statistics of the connection pool of the primary database
+	Pool PoolStats `json:"pool"`
+	// Replicas are the statistics of the read replicas, in the order they are configured in
+	Replicas []ReplicaStats `json:"replicas,omitempty"`
+	// Methods are the statistics of the calls of each method running queries, keyed by method name
+	Methods map[string]MethodStats `json:"methods"`
+}
+
+// PoolStats are the statistics of a connection pool
+type PoolStats struct {
+	MaxConns      int32 `json:"max_conns"`
+	TotalConns    int32 `json:"total_conns"`
+	AcquiredConns int32 `json:"acquired_conns"`
+	IdleConns     int32 `json:"idle_conns"`
+	// ConstructingConns are the connections being established
+	ConstructingConns int32 `json:"constructing_conns"`
+	// AcquireCount is the number of connections acquired, and AcquireDuration the total time spent acquiring them
+	AcquireCount    int64         `json:"acquire_count"`
+	AcquireDuration time.Duration `json:"acquire_duration"`
+	// CanceledAcquireCount is the number of acquires canceled by their context
+	CanceledAcquireCount int64 `json:"canceled_acquire_count"`
+	// WaitCount is the number of acquires that waited for a connection, as none was idle, and WaitDuration the
+	// total time they waited. Waits growing with the calls show that the pool limit is too low.
+	WaitCount    int64         `json:"wait_count"`
+	WaitDuration time.Duration `json:"wait_duration"`
+	// NewConnsCount is the number of connections established
+	NewConnsCount int64 `json:"new_conns_count"`
+}
+
+// ReplicaStats are the statistics of a read replica
+type ReplicaStats struct {
+	// Name is the host and port of the replica, followed by its index, such as "replica:5432#1",
+	// when a previous replica has the same host and port
+	Name    string    `json:"name"`
+	Healthy bool      `json:"healthy"`
+	Pool    PoolStats `json:"pool"`
+}
+
+// MethodStats are the statistics of the calls of a method. A call retried by the retry policy counts once per attempt.
+type MethodStats struct {
+	Calls  uint64 `json:"calls"`
+	Errors uint64 `json:"errors"`
+	// Retries counts the attempts of the retry policy after the first one, which are also counted in Calls
+	Retries uint64 `json:"retries"`
+	// ErrorsByClass counts the calls that failed by the class of their error, omitting the classes without errors
+	ErrorsByClass map[ErrorClass]uint64 `json:"errors_by_class"`
+	// Rows is the number of result rows read by the calls, before they are grouped into the results of the method
+	Rows uint64 `json:"rows"`
+	// ContentIdsCalls and LegacyCalls count the calls that read content of repository versions through content_ids,
+	// and through core_repositorycontent for versions created before content_ids was populated.
+	// Once LegacyCalls stays at zero, the core_repositorycontent path is no longer used.
+	ContentIdsCalls uint64           `json:"content_ids_calls"`
+	LegacyCalls     uint64           `json:"legacy_calls"`
+	Latency         LatencyHistogram `json:"latency"`
+}
+
+// LatencyHistogram counts calls by duration
+type LatencyHistogram struct {
+	// Buckets count the calls by the smallest upper bound their duration is within. The last bucket has no upper bound.
+	Buckets []LatencyBucket `json:"buckets"`
+	// Sum is the total duration of the calls
+	Sum time.Duration `json:"sum"`
+}
+
+// LatencyBucket counts the calls that lasted at most UpperBound, and more than the upper bound of the previous bucket.
+// UpperBound is zero in the last bucket, counting the calls longer than every other bound.
+type LatencyBucket struct {
+	UpperBound time.Duration `json:"upper_bound"`
+	Count      uint64        `json:"count"`
+}
+
+// methodStats counts the calls of a method as they end
+type methodStats struct {
+	calls      atomic.Uint64
+	retries    atomic.Uint64
+	errors     []atomic.Uint64
+	rows       atomic.Uint64
+	contentIds atomic.Uint64
+	legacy     atomic.Uint64
+	buckets    []atomic.Uint64
+	sum        atomic.Int64
+}
+
+// callStats are the statistics of the calls of every method running queries. The map is filled when it is
+// created and never updated afterwards, so it is read without locking.
+type callStats struct {
+	methods map[string]*methodStats
+}
+
+func newCallStats() *callStats {
+	s := &callStats{methods: make(map[string]*methodStats)}
+	for _, method := range queryMethods() {
+		s.methods[method] = &methodStats{
+			errors:  make([]atomic.Uint64, len(errorClasses)),
+			buckets: make([]atomic.Uint64, len(latencyBuckets)+1),
+		}
+	}
+	return s
+}
+
+// record counts a call that lasted duration, and returned err
+func (s *callStats) record(state *callState, duration time.Duration, err error) {
+	if s == nil {
+		return
+	}
+	m, ok := s.methods[state.method]
+	if !ok {
+		return
+	}
+	m.calls.Add(1)
+	if state.retry {
+		m.retries.Add(1)
+	}
+	if err != nil {
+		m.errors[slices.Index(errorClasses, ClassifyError(err))].Add(1)
+	}
+	m.rows.Add(state.rows.Load())
+	if state.contentIds.Load() {
+		m.contentIds.Add(1)
+	}
+	if state.legacy.Load() {
+		m.legacy.Add(1)
+	}
+	bucket := len(latencyBuckets)
+	for i, bound := range latencyBuckets {
+		if duration <= bound {
+			bucket = i
+			break
+		}
+	}
+	m.buckets[bucket].Add(1)
+	m.sum.Add(int64(duration))
+}
+
+// snapshot returns the statistics of every method
+func (s *callStats) snapshot() map[string]MethodStats {
+	methods := make(map[string]MethodStats)
+	if s == nil {
+		return methods
+	}
+	for method, m := range s.methods {
+		stats := MethodStats{
+			Calls:           m.calls.Load(),
+			Retries:         m.retries.Load(),
+			ErrorsByClass:   make(map[ErrorClass]uint64),
+			Rows:            m.rows.Load(),
+			ContentIdsCalls: m.contentIds.Load(),
+			LegacyCalls:     m.legacy.Load(),
+			Latency:         LatencyHistogram{Buckets: make([]LatencyBucket, len(m.buckets)), Sum: time.Duration(m.sum.Load())},
+		}
+		for i, class := range errorClasses {
+			if count := m.errors[i].Load(); count > 0 {
+				stats.ErrorsByClass[class] = count
+				stats.Errors += count
+			}
+		}
+		for i := range m.buckets {
+			stats.Latency.Buckets[i].Count = m.buckets[i].Load()
+			if i < len(latencyBuckets) {
+				stats.Latency.Buckets[i].UpperBound = latencyBuckets[i]
+			}
+		}
+		methods[method] = stats
+	}
+	return methods
+}
+
+// poolStats returns the statistics of pool
+func poolStats(pool *pgxpool.Pool) PoolStats {
+	stat := pool.Stat()
+	return PoolStats{
+		MaxConns:             stat.MaxConns(),
+		TotalConns:           stat.TotalConns(),
+		AcquiredConns:        stat.AcquiredConns(),
+		IdleConns:            stat.IdleConns(),
+		ConstructingConns:    stat.ConstructingConns(),
+		AcquireCount:         stat.AcquireCount(),
+		AcquireDuration:      stat.AcquireDuration(),
+		CanceledAcquireCount: stat.CanceledAcquireCount(),
+		WaitCount:            stat.EmptyAcquireCount(),
+		WaitDuration:         stat.EmptyAcquireWaitTime(),
+		NewConnsCount:        stat.NewConnsCount(),
+	}
+}
+
+// Stats returns the statistics of the connection pools, and of the calls of each method
+func (t *tangyImpl) Stats() Stats {
+	stats := Stats{Pool: poolStats(t.pool), Methods: t.stats.snapshot()}
+	if t.replicas != nil {
+		for _, replica := range t.replicas.replicas {
+			stats.Replicas = append(stats.Replicas, ReplicaStats{
+				Name:    replica.name,
+				Healthy: replica.healthy.Load(),
+				Pool:    poolStats(replica.pool),
+			})
+		}
+	}
+	return stats
+}
@@ -0,0 +1,151 @@
+package tangy
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCallStats(t *testing.T) {
+	t.Parallel()
+
+	s := newCallStats()
+	s.record(&callState{method: "RpmRepositoryVersionPackageList"}, 3*time.Millisecond, nil)
+	failed := &callState{method: "RpmRepositoryVersionPackageList", retry: true}
+	failed.rows.Add(12)
+	failed.contentIds.Store(true)
+	failed.legacy.Store(true)
+	s.record(failed, time.Minute, ErrQueryTimeout)
+	s.record(&callState{method: "NotAMethod"}, time.Second, nil)
+
+	methods := s.snapshot()
+	assert.Len(t, methods, len(queryMethods()))
+	assert.Equal(t, MethodStats{ErrorsByClass: map[ErrorClass]uint64{}, Latency: LatencyHistogram{Buckets: methods["NpmPackageList"].Latency.Buckets}},
+		methods["NpmPackageList"])
+
+	stats := methods["RpmRepositoryVersionPackageList"]
+	assert.Equal(t, uint64(2), stats.Calls)
+	assert.Equal(t, uint64(1), stats.Errors)
+	assert.Equal(t, uint64(1), stats.Retries)
+	assert.Equal(t, map[ErrorClass]uint64{ErrorClassTimeout: 1}, stats.ErrorsByClass)
+	assert.Equal(t, uint64(12), stats.Rows)
+	assert.Equal(t, uint64(1), stats.ContentIdsCalls)
+	assert.Equal(t, uint64(1), stats.LegacyCalls)
+	assert.Equal(t, time.Minute+3*time.Millisecond, stats.Latency.Sum)
+	require.Len(t, stats.Latency.Buckets, len(latencyBuckets)+1)
+	for i, bucket := range stats.Latency.Buckets {
+		switch i {
+		case 1:
+			assert.Equal(t, LatencyBucket{UpperBound: 5 * time.Millisecond, Count: 1}, bucket)
+		case len(latencyBuckets):
+			assert.Equal(t, LatencyBucket{Count: 1}, bucket)
+		default:
+			assert.Zero(t, bucket.Count)
+		}
+	}
+}
+
+func TestCallRecordsStats(t *testing.T) {
+	t.Parallel()
+
+	tangy := &tangyImpl{pool: unreachablePool(t), stats: newCallStats()}
+	ctx, done := tangy.call(context.Background(), "MavenPackageList")
+	_, innerDone := tangy.call(ctx, "MavenRepositoryVersionPackageList")
+	err := error(nil)
+	innerDone(&err)
+	err = assert.AnError
+	done(&err)
+
+	// Nested calls are counted as part of the outer call
+	stats := tangy.Stats()
+	assert.Equal(t, uint64(1), stats.Methods["MavenPackageList"].Calls)
+	assert.Equal(t, map[ErrorClass]uint64{ErrorClassOther: 1}, stats.Methods["MavenPackageList"].ErrorsByClass)
+	assert.Zero(t, stats.Methods["MavenRepositoryVersionPackageList"].Calls)
+	assert.Equal(t, int32(DefaultMaxPoolLimit), stats.Pool.MaxConns)
+	assert.Zero(t, stats.Pool.AcquiredConns)
+	assert.Empty(t, stats.Replicas)
+}
+
+func TestClassifyError(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		err   error
+		class ErrorClass
+	}{
+		{fmt.Errorf("%w: pulp/api/v3", ErrInvalidHref), ErrorClassInvalid},
+		{ErrInvalidCursor, ErrorClassInvalid},
+		{fmt.Errorf("%w: 1234 version 2", ErrRepositoryVersionNotFound), ErrorClassNotFound},
+		{ErrNpmPackageNotFound, ErrorClassNotFound},
+		{fmt.Errorf("%w: %w", ErrQueryTimeout, context.DeadlineExceeded), ErrorClassTimeout},
+		{context.Canceled, ErrorClassCanceled},
+		{&pgconn.PgError{Code: "08006"}, ErrorClassConnection},
+		{&pgconn.PgError{Code: "42P01"}, ErrorClassDatabase},
+		{io.ErrUnexpectedEOF, ErrorClassConnection},
+		{assert.AnError, ErrorClassOther},
+	} {
+		assert.Equal(t, tt.class, ClassifyError(tt.err), tt.err.Error())
+	}
+}
+
+func TestClassifyErrorSentinels(t *testing.T) {
+	t.Parallel()
+
+	sentinels := map[string]struct {
+		err   error
+		class ErrorClass
+	}{
+		"ErrInvalidHref":                  {ErrInvalidHref, ErrorClassInvalid},
+		"ErrInvalidCursor":                {ErrInvalidCursor, ErrorClassInvalid},
+		"ErrInvalidCountMode":             {ErrInvalidCountMode, ErrorClassInvalid},
+		"ErrPythonNameNormalizedRequired": {ErrPythonNameNormalizedRequired, ErrorClassInvalid},
+		"ErrIteratorInSnapshot":           {ErrIteratorInSnapshot, ErrorClassInvalid},
+		"ErrSnapshotClosed":               {ErrSnapshotClosed, ErrorClassInvalid},
+		"ErrBatchNotRun":                  {ErrBatchNotRun, ErrorClassInvalid},
+		"ErrRepositoryNotFound":           {ErrRepositoryNotFound, ErrorClassNotFound},
+		"ErrNoCompleteVersion":            {ErrNoCompleteVersion, ErrorClassNotFound},
+		"ErrRepositoryVersionNotFound":    {ErrRepositoryVersionNotFound, ErrorClassNotFound},
+		"ErrPythonPackageNotFound":        {ErrPythonPackageNotFound, ErrorClassNotFound},
+		"ErrNpmPackageNotFound":           {ErrNpmPackageNotFound, ErrorClassNotFound},
+		"ErrQueryTimeout":                 {ErrQueryTimeout, ErrorClassTimeout},
+	}
+	for name, tt := range sentinels {
+		assert.Equal(t, tt.class, ClassifyError(fmt.Errorf("%w: details", tt.err)), name)
+	}
+
+	// Every exported Err variable of the package has to be classified above
+	filenames, err := filepath.Glob("*.go")
+	require.NoError(t, err)
+	for _, filename := range filenames {
+		if strings.HasSuffix(filename, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(token.NewFileSet(), filename, nil, 0)
+		require.NoError(t, err)
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.VAR {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				value, _ := spec.(*ast.ValueSpec)
+				for _, name := range value.Names {
+					if strings.HasPrefix(name.Name, "Err") {
+						assert.Contains(t, sentinels, name.Name, "%s is not classified by TestClassifyErrorSentinels", name.Name)
+					}
+				}
+			}
+		}
+	}
+}
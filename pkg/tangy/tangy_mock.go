@@ -6,6 +6,7 @@ package tangy
 
 import (
 	"context"
+	"iter"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -70,49 +71,1504 @@ func (_c *MockTangy_Close_Call) RunAndReturn(run func()) *MockTangy_Close_Call {
 	return _c
 }
 
+// MavenPackageList provides a mock function for the type MockTangy
+func (_mock *MockTangy) MavenPackageList(ctx context.Context, repositoryHref string, filterOpts MavenPackageListFilters, pageOpts PageOptions) (MavenPackageListResponse, error) {
+	ret := _mock.Called(ctx, repositoryHref, filterOpts, pageOpts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MavenPackageList")
+	}
+
+	var r0 MavenPackageListResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, MavenPackageListFilters, PageOptions) (MavenPackageListResponse, error)); ok {
+		return returnFunc(ctx, repositoryHref, filterOpts, pageOpts)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, MavenPackageListFilters, PageOptions) MavenPackageListResponse); ok {
+		r0 = returnFunc(ctx, repositoryHref, filterOpts, pageOpts)
+	} else {
+		r0 = ret.Get(0).(MavenPackageListResponse)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, MavenPackageListFilters, PageOptions) error); ok {
+		r1 = returnFunc(ctx, repositoryHref, filterOpts, pageOpts)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTangy_MavenPackageList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MavenPackageList'
+type MockTangy_MavenPackageList_Call struct {
+	*mock.Call
+}
+
+// MavenPackageList is a helper method to define mock.On call
+//   - ctx context.Context
+//   - repositoryHref string
+//   - filterOpts MavenPackageListFilters
+//   - pageOpts PageOptions
+func (_e *MockTangy_Expecter) MavenPackageList(ctx any, repositoryHref any, filterOpts any, pageOpts any) *MockTangy_MavenPackageList_Call {
+	return &MockTangy_MavenPackageList_Call{Call: _e.mock.On("MavenPackageList", ctx, repositoryHref, filterOpts, pageOpts)}
+}
+
+func (_c *MockTangy_MavenPackageList_Call) Run(run func(ctx context.Context, repositoryHref string, filterOpts MavenPackageListFilters, pageOpts PageOptions)) *MockTangy_MavenPackageList_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 MavenPackageListFilters
+		if args[2] != nil {
+			arg2 = args[2].(MavenPackageListFilters)
+		}
+		var arg3 PageOptions
+		if args[3] != nil {
+			arg3 = args[3].(PageOptions)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTangy_MavenPackageList_Call) Return(mavenPackageListResponse MavenPackageListResponse, err error) *MockTangy_MavenPackageList_Call {
+	_c.Call.Return(mavenPackageListResponse, err)
+	return _c
+}
+
+func (_c *MockTangy_MavenPackageList_Call) RunAndReturn(run func(ctx context.Context, repositoryHref string, filterOpts MavenPackageListFilters, pageOpts PageOptions) (MavenPackageListResponse, error)) *MockTangy_MavenPackageList_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MavenRepositoryMetrics provides a mock function for the type MockTangy
+func (_mock *MockTangy) MavenRepositoryMetrics(ctx context.Context, repositoryHref string) (MavenRepositoryMetrics, error) {
+	ret := _mock.Called(ctx, repositoryHref)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MavenRepositoryMetrics")
+	}
+
+	var r0 MavenRepositoryMetrics
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (MavenRepositoryMetrics, error)); ok {
+		return returnFunc(ctx, repositoryHref)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) MavenRepositoryMetrics); ok {
+		r0 = returnFunc(ctx, repositoryHref)
+	} else {
+		r0 = ret.Get(0).(MavenRepositoryMetrics)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, repositoryHref)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTangy_MavenRepositoryMetrics_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MavenRepositoryMetrics'
+type MockTangy_MavenRepositoryMetrics_Call struct {
+	*mock.Call
+}
+
+// MavenRepositoryMetrics is a helper method to define mock.On call
+//   - ctx context.Context
+//   - repositoryHref string
+func (_e *MockTangy_Expecter) MavenRepositoryMetrics(ctx any, repositoryHref any) *MockTangy_MavenRepositoryMetrics_Call {
+	return &MockTangy_MavenRepositoryMetrics_Call{Call: _e.mock.On("MavenRepositoryMetrics", ctx, repositoryHref)}
+}
+
+func (_c *MockTangy_MavenRepositoryMetrics_Call) Run(run func(ctx context.Context, repositoryHref string)) *MockTangy_MavenRepositoryMetrics_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTangy_MavenRepositoryMetrics_Call) Return(mavenRepositoryMetrics MavenRepositoryMetrics, err error) *MockTangy_MavenRepositoryMetrics_Call {
+	_c.Call.Return(mavenRepositoryMetrics, err)
+	return _c
+}
+
+func (_c *MockTangy_MavenRepositoryMetrics_Call) RunAndReturn(run func(ctx context.Context, repositoryHref string) (MavenRepositoryMetrics, error)) *MockTangy_MavenRepositoryMetrics_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MavenRepositoryVersionMetrics provides a mock function for the type MockTangy
+func (_mock *MockTangy) MavenRepositoryVersionMetrics(ctx context.Context, hrefs []string) (MavenRepositoryMetrics, error) {
+	ret := _mock.Called(ctx, hrefs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MavenRepositoryVersionMetrics")
+	}
+
+	var r0 MavenRepositoryMetrics
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) (MavenRepositoryMetrics, error)); ok {
+		return returnFunc(ctx, hrefs)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) MavenRepositoryMetrics); ok {
+		r0 = returnFunc(ctx, hrefs)
+	} else {
+		r0 = ret.Get(0).(MavenRepositoryMetrics)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = returnFunc(ctx, hrefs)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTangy_MavenRepositoryVersionMetrics_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MavenRepositoryVersionMetrics'
+type MockTangy_MavenRepositoryVersionMetrics_Call struct {
+	*mock.Call
+}
+
+// MavenRepositoryVersionMetrics is a helper method to define mock.On call
+//   - ctx context.Context
+//   - hrefs []string
+func (_e *MockTangy_Expecter) MavenRepositoryVersionMetrics(ctx any, hrefs any) *MockTangy_MavenRepositoryVersionMetrics_Call {
+	return &MockTangy_MavenRepositoryVersionMetrics_Call{Call: _e.mock.On("MavenRepositoryVersionMetrics", ctx, hrefs)}
+}
+
+func (_c *MockTangy_MavenRepositoryVersionMetrics_Call) Run(run func(ctx context.Context, hrefs []string)) *MockTangy_MavenRepositoryVersionMetrics_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTangy_MavenRepositoryVersionMetrics_Call) Return(mavenRepositoryMetrics MavenRepositoryMetrics, err error) *MockTangy_MavenRepositoryVersionMetrics_Call {
+	_c.Call.Return(mavenRepositoryMetrics, err)
+	return _c
+}
+
+func (_c *MockTangy_MavenRepositoryVersionMetrics_Call) RunAndReturn(run func(ctx context.Context, hrefs []string) (MavenRepositoryMetrics, error)) *MockTangy_MavenRepositoryVersionMetrics_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MavenRepositoryVersionPackageList provides a mock function for the type MockTangy
+func (_mock *MockTangy) MavenRepositoryVersionPackageList(ctx context.Context, hrefs []string, filterOpts MavenPackageListFilters, pageOpts PageOptions) (MavenPackageListResponse, error) {
+	ret := _mock.Called(ctx, hrefs, filterOpts, pageOpts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MavenRepositoryVersionPackageList")
+	}
+
+	var r0 MavenPackageListResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string, MavenPackageListFilters, PageOptions) (MavenPackageListResponse, error)); ok {
+		return returnFunc(ctx, hrefs, filterOpts, pageOpts)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string, MavenPackageListFilters, PageOptions) MavenPackageListResponse); ok {
+		r0 = returnFunc(ctx, hrefs, filterOpts, pageOpts)
+	} else {
+		r0 = ret.Get(0).(MavenPackageListResponse)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string, MavenPackageListFilters, PageOptions) error); ok {
+		r1 = returnFunc(ctx, hrefs, filterOpts, pageOpts)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTangy_MavenRepositoryVersionPackageList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MavenRepositoryVersionPackageList'
+type MockTangy_MavenRepositoryVersionPackageList_Call struct {
+	*mock.Call
+}
+
+// MavenRepositoryVersionPackageList is a helper method to define mock.On call
+//   - ctx context.Context
+//   - hrefs []string
+//   - filterOpts MavenPackageListFilters
+//   - pageOpts PageOptions
+func (_e *MockTangy_Expecter) MavenRepositoryVersionPackageList(ctx any, hrefs any, filterOpts any, pageOpts any) *MockTangy_MavenRepositoryVersionPackageList_Call {
+	return &MockTangy_MavenRepositoryVersionPackageList_Call{Call: _e.mock.On("MavenRepositoryVersionPackageList", ctx, hrefs, filterOpts, pageOpts)}
+}
+
+func (_c *MockTangy_MavenRepositoryVersionPackageList_Call) Run(run func(ctx context.Context, hrefs []string, filterOpts MavenPackageListFilters, pageOpts PageOptions)) *MockTangy_MavenRepositoryVersionPackageList_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
+		var arg2 MavenPackageListFilters
+		if args[2] != nil {
+			arg2 = args[2].(MavenPackageListFilters)
+		}
+		var arg3 PageOptions
+		if args[3] != nil {
+			arg3 = args[3].(PageOptions)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTangy_MavenRepositoryVersionPackageList_Call) Return(mavenPackageListResponse MavenPackageListResponse, err error) *MockTangy_MavenRepositoryVersionPackageList_Call {
+	_c.Call.Return(mavenPackageListResponse, err)
+	return _c
+}
+
+func (_c *MockTangy_MavenRepositoryVersionPackageList_Call) RunAndReturn(run func(ctx context.Context, hrefs []string, filterOpts MavenPackageListFilters, pageOpts PageOptions) (MavenPackageListResponse, error)) *MockTangy_MavenRepositoryVersionPackageList_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MavenRepositoryVersionVersionsAll provides a mock function for the type MockTangy
+func (_mock *MockTangy) MavenRepositoryVersionVersionsAll(ctx context.Context, hrefs []string, groupID string, artifactID string, version string) iter.Seq2[MavenVersionsItem, error] {
+	ret := _mock.Called(ctx, hrefs, groupID, artifactID, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MavenRepositoryVersionVersionsAll")
+	}
+
+	var r0 iter.Seq2[MavenVersionsItem, error]
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string, string, string, string) iter.Seq2[MavenVersionsItem, error]); ok {
+		r0 = returnFunc(ctx, hrefs, groupID, artifactID, version)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(iter.Seq2[MavenVersionsItem, error])
+		}
+	}
+	return r0
+}
+
+// MockTangy_MavenRepositoryVersionVersionsAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MavenRepositoryVersionVersionsAll'
+type MockTangy_MavenRepositoryVersionVersionsAll_Call struct {
+	*mock.Call
+}
+
+// MavenRepositoryVersionVersionsAll is a helper method to define mock.On call
+//   - ctx context.Context
+//   - hrefs []string
+//   - groupID string
+//   - artifactID string
+//   - version string
+func (_e *MockTangy_Expecter) MavenRepositoryVersionVersionsAll(ctx any, hrefs any, groupID any, artifactID any, version any) *MockTangy_MavenRepositoryVersionVersionsAll_Call {
+	return &MockTangy_MavenRepositoryVersionVersionsAll_Call{Call: _e.mock.On("MavenRepositoryVersionVersionsAll", ctx, hrefs, groupID, artifactID, version)}
+}
+
+func (_c *MockTangy_MavenRepositoryVersionVersionsAll_Call) Run(run func(ctx context.Context, hrefs []string, groupID string, artifactID string, version string)) *MockTangy_MavenRepositoryVersionVersionsAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTangy_MavenRepositoryVersionVersionsAll_Call) Return(seq2 iter.Seq2[MavenVersionsItem, error]) *MockTangy_MavenRepositoryVersionVersionsAll_Call {
+	_c.Call.Return(seq2)
+	return _c
+}
+
+func (_c *MockTangy_MavenRepositoryVersionVersionsAll_Call) RunAndReturn(run func(ctx context.Context, hrefs []string, groupID string, artifactID string, version string) iter.Seq2[MavenVersionsItem, error]) *MockTangy_MavenRepositoryVersionVersionsAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MavenRepositoryVersionVersionsList provides a mock function for the type MockTangy
+func (_mock *MockTangy) MavenRepositoryVersionVersionsList(ctx context.Context, hrefs []string, groupID string, artifactID string, version string, pageOpts PageOptions) (MavenVersionsResponse, error) {
+	ret := _mock.Called(ctx, hrefs, groupID, artifactID, version, pageOpts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MavenRepositoryVersionVersionsList")
+	}
+
+	var r0 MavenVersionsResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string, string, string, string, PageOptions) (MavenVersionsResponse, error)); ok {
+		return returnFunc(ctx, hrefs, groupID, artifactID, version, pageOpts)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string, string, string, string, PageOptions) MavenVersionsResponse); ok {
+		r0 = returnFunc(ctx, hrefs, groupID, artifactID, version, pageOpts)
+	} else {
+		r0 = ret.Get(0).(MavenVersionsResponse)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string, string, string, string, PageOptions) error); ok {
+		r1 = returnFunc(ctx, hrefs, groupID, artifactID, version, pageOpts)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTangy_MavenRepositoryVersionVersionsList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MavenRepositoryVersionVersionsList'
+type MockTangy_MavenRepositoryVersionVersionsList_Call struct {
+	*mock.Call
+}
+
+// MavenRepositoryVersionVersionsList is a helper method to define mock.On call
+//   - ctx context.Context
+//   - hrefs []string
+//   - groupID string
+//   - artifactID string
+//   - version string
+//   - pageOpts PageOptions
+func (_e *MockTangy_Expecter) MavenRepositoryVersionVersionsList(ctx any, hrefs any, groupID any, artifactID any, version any, pageOpts any) *MockTangy_MavenRepositoryVersionVersionsList_Call {
+	return &MockTangy_MavenRepositoryVersionVersionsList_Call{Call: _e.mock.On("MavenRepositoryVersionVersionsList", ctx, hrefs, groupID, artifactID, version, pageOpts)}
+}
+
+func (_c *MockTangy_MavenRepositoryVersionVersionsList_Call) Run(run func(ctx context.Context, hrefs []string, groupID string, artifactID string, version string, pageOpts PageOptions)) *MockTangy_MavenRepositoryVersionVersionsList_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		var arg5 PageOptions
+		if args[5] != nil {
+			arg5 = args[5].(PageOptions)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+			arg5,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTangy_MavenRepositoryVersionVersionsList_Call) Return(mavenVersionsResponse MavenVersionsResponse, err error) *MockTangy_MavenRepositoryVersionVersionsList_Call {
+	_c.Call.Return(mavenVersionsResponse, err)
+	return _c
+}
+
+func (_c *MockTangy_MavenRepositoryVersionVersionsList_Call) RunAndReturn(run func(ctx context.Context, hrefs []string, groupID string, artifactID string, version string, pageOpts PageOptions) (MavenVersionsResponse, error)) *MockTangy_MavenRepositoryVersionVersionsList_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // MavenVersionsList provides a mock function for the type MockTangy
 func (_mock *MockTangy) MavenVersionsList(ctx context.Context, repositoryHref string, groupID string, artifactID string, version string, pageOpts PageOptions) (MavenVersionsResponse, error) {
 	ret := _mock.Called(ctx, repositoryHref, groupID, artifactID, version, pageOpts)
 
 	if len(ret) == 0 {
-		panic("no return value specified for MavenVersionsList")
+		panic("no return value specified for MavenVersionsList")
+	}
+
+	var r0 MavenVersionsResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string, PageOptions) (MavenVersionsResponse, error)); ok {
+		return returnFunc(ctx, repositoryHref, groupID, artifactID, version, pageOpts)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string, PageOptions) MavenVersionsResponse); ok {
+		r0 = returnFunc(ctx, repositoryHref, groupID, artifactID, version, pageOpts)
+	} else {
+		r0 = ret.Get(0).(MavenVersionsResponse)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string, string, PageOptions) error); ok {
+		r1 = returnFunc(ctx, repositoryHref, groupID, artifactID, version, pageOpts)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTangy_MavenVersionsList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MavenVersionsList'
+type MockTangy_MavenVersionsList_Call struct {
+	*mock.Call
+}
+
+// MavenVersionsList is a helper method to define mock.On call
+//   - ctx context.Context
+//   - repositoryHref string
+//   - groupID string
+//   - artifactID string
+//   - version string
+//   - pageOpts PageOptions
+func (_e *MockTangy_Expecter) MavenVersionsList(ctx any, repositoryHref any, groupID any, artifactID any, version any, pageOpts any) *MockTangy_MavenVersionsList_Call {
+	return &MockTangy_MavenVersionsList_Call{Call: _e.mock.On("MavenVersionsList", ctx, repositoryHref, groupID, artifactID, version, pageOpts)}
+}
+
+func (_c *MockTangy_MavenVersionsList_Call) Run(run func(ctx context.Context, repositoryHref string, groupID string, artifactID string, version string, pageOpts PageOptions)) *MockTangy_MavenVersionsList_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		var arg5 PageOptions
+		if args[5] != nil {
+			arg5 = args[5].(PageOptions)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+			arg5,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTangy_MavenVersionsList_Call) Return(mavenVersionsResponse MavenVersionsResponse, err error) *MockTangy_MavenVersionsList_Call {
+	_c.Call.Return(mavenVersionsResponse, err)
+	return _c
+}
+
+func (_c *MockTangy_MavenVersionsList_Call) RunAndReturn(run func(ctx context.Context, repositoryHref string, groupID string, artifactID string, version string, pageOpts PageOptions) (MavenVersionsResponse, error)) *MockTangy_MavenVersionsList_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NpmBuildList provides a mock function for the type MockTangy
+func (_mock *MockTangy) NpmBuildList(ctx context.Context, repositoryHref string, name string, version string, pageOpts PageOptions) (NpmBuildListResponse, error) {
+	ret := _mock.Called(ctx, repositoryHref, name, version, pageOpts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NpmBuildList")
+	}
+
+	var r0 NpmBuildListResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, PageOptions) (NpmBuildListResponse, error)); ok {
+		return returnFunc(ctx, repositoryHref, name, version, pageOpts)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, PageOptions) NpmBuildListResponse); ok {
+		r0 = returnFunc(ctx, repositoryHref, name, version, pageOpts)
+	} else {
+		r0 = ret.Get(0).(NpmBuildListResponse)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string, PageOptions) error); ok {
+		r1 = returnFunc(ctx, repositoryHref, name, version, pageOpts)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTangy_NpmBuildList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NpmBuildList'
+type MockTangy_NpmBuildList_Call struct {
+	*mock.Call
+}
+
+// NpmBuildList is a helper method to define mock.On call
+//   - ctx context.Context
+//   - repositoryHref string
+//   - name string
+//   - version string
+//   - pageOpts PageOptions
+func (_e *MockTangy_Expecter) NpmBuildList(ctx any, repositoryHref any, name any, version any, pageOpts any) *MockTangy_NpmBuildList_Call {
+	return &MockTangy_NpmBuildList_Call{Call: _e.mock.On("NpmBuildList", ctx, repositoryHref, name, version, pageOpts)}
+}
+
+func (_c *MockTangy_NpmBuildList_Call) Run(run func(ctx context.Context, repositoryHref string, name string, version string, pageOpts PageOptions)) *MockTangy_NpmBuildList_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 PageOptions
+		if args[4] != nil {
+			arg4 = args[4].(PageOptions)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTangy_NpmBuildList_Call) Return(npmBuildListResponse NpmBuildListResponse, err error) *MockTangy_NpmBuildList_Call {
+	_c.Call.Return(npmBuildListResponse, err)
+	return _c
+}
+
+func (_c *MockTangy_NpmBuildList_Call) RunAndReturn(run func(ctx context.Context, repositoryHref string, name string, version string, pageOpts PageOptions) (NpmBuildListResponse, error)) *MockTangy_NpmBuildList_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NpmPackageGet provides a mock function for the type MockTangy
+func (_mock *MockTangy) NpmPackageGet(ctx context.Context, repositoryHref string, name string, version string) (NpmPackageDetail, error) {
+	ret := _mock.Called(ctx, repositoryHref, name, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NpmPackageGet")
+	}
+
+	var r0 NpmPackageDetail
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) (NpmPackageDetail, error)); ok {
+		return returnFunc(ctx, repositoryHref, name, version)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) NpmPackageDetail); ok {
+		r0 = returnFunc(ctx, repositoryHref, name, version)
+	} else {
+		r0 = ret.Get(0).(NpmPackageDetail)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = returnFunc(ctx, repositoryHref, name, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTangy_NpmPackageGet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NpmPackageGet'
+type MockTangy_NpmPackageGet_Call struct {
+	*mock.Call
+}
+
+// NpmPackageGet is a helper method to define mock.On call
+//   - ctx context.Context
+//   - repositoryHref string
+//   - name string
+//   - version string
+func (_e *MockTangy_Expecter) NpmPackageGet(ctx any, repositoryHref any, name any, version any) *MockTangy_NpmPackageGet_Call {
+	return &MockTangy_NpmPackageGet_Call{Call: _e.mock.On("NpmPackageGet", ctx, repositoryHref, name, version)}
+}
+
+func (_c *MockTangy_NpmPackageGet_Call) Run(run func(ctx context.Context, repositoryHref string, name string, version string)) *MockTangy_NpmPackageGet_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTangy_NpmPackageGet_Call) Return(npmPackageDetail NpmPackageDetail, err error) *MockTangy_NpmPackageGet_Call {
+	_c.Call.Return(npmPackageDetail, err)
+	return _c
+}
+
+func (_c *MockTangy_NpmPackageGet_Call) RunAndReturn(run func(ctx context.Context, repositoryHref string, name string, version string) (NpmPackageDetail, error)) *MockTangy_NpmPackageGet_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NpmPackageList provides a mock function for the type MockTangy
+func (_mock *MockTangy) NpmPackageList(ctx context.Context, repositoryHref string, filterOpts NpmPackageListFilters, pageOpts PageOptions) (NpmPackageListResponse, error) {
+	ret := _mock.Called(ctx, repositoryHref, filterOpts, pageOpts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NpmPackageList")
+	}
+
+	var r0 NpmPackageListResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, NpmPackageListFilters, PageOptions) (NpmPackageListResponse, error)); ok {
+		return returnFunc(ctx, repositoryHref, filterOpts, pageOpts)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, NpmPackageListFilters, PageOptions) NpmPackageListResponse); ok {
+		r0 = returnFunc(ctx, repositoryHref, filterOpts, pageOpts)
+	} else {
+		r0 = ret.Get(0).(NpmPackageListResponse)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, NpmPackageListFilters, PageOptions) error); ok {
+		r1 = returnFunc(ctx, repositoryHref, filterOpts, pageOpts)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTangy_NpmPackageList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NpmPackageList'
+type MockTangy_NpmPackageList_Call struct {
+	*mock.Call
+}
+
+// NpmPackageList is a helper method to define mock.On call
+//   - ctx context.Context
+//   - repositoryHref string
+//   - filterOpts NpmPackageListFilters
+//   - pageOpts PageOptions
+func (_e *MockTangy_Expecter) NpmPackageList(ctx any, repositoryHref any, filterOpts any, pageOpts any) *MockTangy_NpmPackageList_Call {
+	return &MockTangy_NpmPackageList_Call{Call: _e.mock.On("NpmPackageList", ctx, repositoryHref, filterOpts, pageOpts)}
+}
+
+func (_c *MockTangy_NpmPackageList_Call) Run(run func(ctx context.Context, repositoryHref string, filterOpts NpmPackageListFilters, pageOpts PageOptions)) *MockTangy_NpmPackageList_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 NpmPackageListFilters
+		if args[2] != nil {
+			arg2 = args[2].(NpmPackageListFilters)
+		}
+		var arg3 PageOptions
+		if args[3] != nil {
+			arg3 = args[3].(PageOptions)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTangy_NpmPackageList_Call) Return(npmPackageListResponse NpmPackageListResponse, err error) *MockTangy_NpmPackageList_Call {
+	_c.Call.Return(npmPackageListResponse, err)
+	return _c
+}
+
+func (_c *MockTangy_NpmPackageList_Call) RunAndReturn(run func(ctx context.Context, repositoryHref string, filterOpts NpmPackageListFilters, pageOpts PageOptions) (NpmPackageListResponse, error)) *MockTangy_NpmPackageList_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NpmPackageVersionsGet provides a mock function for the type MockTangy
+func (_mock *MockTangy) NpmPackageVersionsGet(ctx context.Context, repositoryHref string, name string) ([]NpmPackageDetail, error) {
+	ret := _mock.Called(ctx, repositoryHref, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NpmPackageVersionsGet")
+	}
+
+	var r0 []NpmPackageDetail
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) ([]NpmPackageDetail, error)); ok {
+		return returnFunc(ctx, repositoryHref, name)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) []NpmPackageDetail); ok {
+		r0 = returnFunc(ctx, repositoryHref, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]NpmPackageDetail)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, repositoryHref, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTangy_NpmPackageVersionsGet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NpmPackageVersionsGet'
+type MockTangy_NpmPackageVersionsGet_Call struct {
+	*mock.Call
+}
+
+// NpmPackageVersionsGet is a helper method to define mock.On call
+//   - ctx context.Context
+//   - repositoryHref string
+//   - name string
+func (_e *MockTangy_Expecter) NpmPackageVersionsGet(ctx any, repositoryHref any, name any) *MockTangy_NpmPackageVersionsGet_Call {
+	return &MockTangy_NpmPackageVersionsGet_Call{Call: _e.mock.On("NpmPackageVersionsGet", ctx, repositoryHref, name)}
+}
+
+func (_c *MockTangy_NpmPackageVersionsGet_Call) Run(run func(ctx context.Context, repositoryHref string, name string)) *MockTangy_NpmPackageVersionsGet_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTangy_NpmPackageVersionsGet_Call) Return(npmPackageDetails []NpmPackageDetail, err error) *MockTangy_NpmPackageVersionsGet_Call {
+	_c.Call.Return(npmPackageDetails, err)
+	return _c
+}
+
+func (_c *MockTangy_NpmPackageVersionsGet_Call) RunAndReturn(run func(ctx context.Context, repositoryHref string, name string) ([]NpmPackageDetail, error)) *MockTangy_NpmPackageVersionsGet_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NpmRepositoryVersionBuildList provides a mock function for the type MockTangy
+func (_mock *MockTangy) NpmRepositoryVersionBuildList(ctx context.Context, hrefs []string, name string, version string, pageOpts PageOptions) (NpmBuildListResponse, error) {
+	ret := _mock.Called(ctx, hrefs, name, version, pageOpts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NpmRepositoryVersionBuildList")
+	}
+
+	var r0 NpmBuildListResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string, string, string, PageOptions) (NpmBuildListResponse, error)); ok {
+		return returnFunc(ctx, hrefs, name, version, pageOpts)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string, string, string, PageOptions) NpmBuildListResponse); ok {
+		r0 = returnFunc(ctx, hrefs, name, version, pageOpts)
+	} else {
+		r0 = ret.Get(0).(NpmBuildListResponse)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string, string, string, PageOptions) error); ok {
+		r1 = returnFunc(ctx, hrefs, name, version, pageOpts)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTangy_NpmRepositoryVersionBuildList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NpmRepositoryVersionBuildList'
+type MockTangy_NpmRepositoryVersionBuildList_Call struct {
+	*mock.Call
+}
+
+// NpmRepositoryVersionBuildList is a helper method to define mock.On call
+//   - ctx context.Context
+//   - hrefs []string
+//   - name string
+//   - version string
+//   - pageOpts PageOptions
+func (_e *MockTangy_Expecter) NpmRepositoryVersionBuildList(ctx any, hrefs any, name any, version any, pageOpts any) *MockTangy_NpmRepositoryVersionBuildList_Call {
+	return &MockTangy_NpmRepositoryVersionBuildList_Call{Call: _e.mock.On("NpmRepositoryVersionBuildList", ctx, hrefs, name, version, pageOpts)}
+}
+
+func (_c *MockTangy_NpmRepositoryVersionBuildList_Call) Run(run func(ctx context.Context, hrefs []string, name string, version string, pageOpts PageOptions)) *MockTangy_NpmRepositoryVersionBuildList_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 PageOptions
+		if args[4] != nil {
+			arg4 = args[4].(PageOptions)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTangy_NpmRepositoryVersionBuildList_Call) Return(npmBuildListResponse NpmBuildListResponse, err error) *MockTangy_NpmRepositoryVersionBuildList_Call {
+	_c.Call.Return(npmBuildListResponse, err)
+	return _c
+}
+
+func (_c *MockTangy_NpmRepositoryVersionBuildList_Call) RunAndReturn(run func(ctx context.Context, hrefs []string, name string, version string, pageOpts PageOptions) (NpmBuildListResponse, error)) *MockTangy_NpmRepositoryVersionBuildList_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NpmRepositoryVersionPackageAll provides a mock function for the type MockTangy
+func (_mock *MockTangy) NpmRepositoryVersionPackageAll(ctx context.Context, hrefs []string, filterOpts NpmPackageListFilters) iter.Seq2[NpmPackageListItem, error] {
+	ret := _mock.Called(ctx, hrefs, filterOpts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NpmRepositoryVersionPackageAll")
+	}
+
+	var r0 iter.Seq2[NpmPackageListItem, error]
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string, NpmPackageListFilters) iter.Seq2[NpmPackageListItem, error]); ok {
+		r0 = returnFunc(ctx, hrefs, filterOpts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(iter.Seq2[NpmPackageListItem, error])
+		}
+	}
+	return r0
+}
+
+// MockTangy_NpmRepositoryVersionPackageAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NpmRepositoryVersionPackageAll'
+type MockTangy_NpmRepositoryVersionPackageAll_Call struct {
+	*mock.Call
+}
+
+// NpmRepositoryVersionPackageAll is a helper method to define mock.On call
+//   - ctx context.Context
+//   - hrefs []string
+//   - filterOpts NpmPackageListFilters
+func (_e *MockTangy_Expecter) NpmRepositoryVersionPackageAll(ctx any, hrefs any, filterOpts any) *MockTangy_NpmRepositoryVersionPackageAll_Call {
+	return &MockTangy_NpmRepositoryVersionPackageAll_Call{Call: _e.mock.On("NpmRepositoryVersionPackageAll", ctx, hrefs, filterOpts)}
+}
+
+func (_c *MockTangy_NpmRepositoryVersionPackageAll_Call) Run(run func(ctx context.Context, hrefs []string, filterOpts NpmPackageListFilters)) *MockTangy_NpmRepositoryVersionPackageAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
+		var arg2 NpmPackageListFilters
+		if args[2] != nil {
+			arg2 = args[2].(NpmPackageListFilters)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTangy_NpmRepositoryVersionPackageAll_Call) Return(seq2 iter.Seq2[NpmPackageListItem, error]) *MockTangy_NpmRepositoryVersionPackageAll_Call {
+	_c.Call.Return(seq2)
+	return _c
+}
+
+func (_c *MockTangy_NpmRepositoryVersionPackageAll_Call) RunAndReturn(run func(ctx context.Context, hrefs []string, filterOpts NpmPackageListFilters) iter.Seq2[NpmPackageListItem, error]) *MockTangy_NpmRepositoryVersionPackageAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NpmRepositoryVersionPackageGet provides a mock function for the type MockTangy
+func (_mock *MockTangy) NpmRepositoryVersionPackageGet(ctx context.Context, hrefs []string, name string, version string) (NpmPackageDetail, error) {
+	ret := _mock.Called(ctx, hrefs, name, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NpmRepositoryVersionPackageGet")
+	}
+
+	var r0 NpmPackageDetail
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string, string, string) (NpmPackageDetail, error)); ok {
+		return returnFunc(ctx, hrefs, name, version)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string, string, string) NpmPackageDetail); ok {
+		r0 = returnFunc(ctx, hrefs, name, version)
+	} else {
+		r0 = ret.Get(0).(NpmPackageDetail)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string, string, string) error); ok {
+		r1 = returnFunc(ctx, hrefs, name, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTangy_NpmRepositoryVersionPackageGet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NpmRepositoryVersionPackageGet'
+type MockTangy_NpmRepositoryVersionPackageGet_Call struct {
+	*mock.Call
+}
+
+// NpmRepositoryVersionPackageGet is a helper method to define mock.On call
+//   - ctx context.Context
+//   - hrefs []string
+//   - name string
+//   - version string
+func (_e *MockTangy_Expecter) NpmRepositoryVersionPackageGet(ctx any, hrefs any, name any, version any) *MockTangy_NpmRepositoryVersionPackageGet_Call {
+	return &MockTangy_NpmRepositoryVersionPackageGet_Call{Call: _e.mock.On("NpmRepositoryVersionPackageGet", ctx, hrefs, name, version)}
+}
+
+func (_c *MockTangy_NpmRepositoryVersionPackageGet_Call) Run(run func(ctx context.Context, hrefs []string, name string, version string)) *MockTangy_NpmRepositoryVersionPackageGet_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTangy_NpmRepositoryVersionPackageGet_Call) Return(npmPackageDetail NpmPackageDetail, err error) *MockTangy_NpmRepositoryVersionPackageGet_Call {
+	_c.Call.Return(npmPackageDetail, err)
+	return _c
+}
+
+func (_c *MockTangy_NpmRepositoryVersionPackageGet_Call) RunAndReturn(run func(ctx context.Context, hrefs []string, name string, version string) (NpmPackageDetail, error)) *MockTangy_NpmRepositoryVersionPackageGet_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NpmRepositoryVersionPackageList provides a mock function for the type MockTangy
+func (_mock *MockTangy) NpmRepositoryVersionPackageList(ctx context.Context, hrefs []string, filterOpts NpmPackageListFilters, pageOpts PageOptions) (NpmPackageListResponse, error) {
+	ret := _mock.Called(ctx, hrefs, filterOpts, pageOpts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NpmRepositoryVersionPackageList")
+	}
+
+	var r0 NpmPackageListResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string, NpmPackageListFilters, PageOptions) (NpmPackageListResponse, error)); ok {
+		return returnFunc(ctx, hrefs, filterOpts, pageOpts)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string, NpmPackageListFilters, PageOptions) NpmPackageListResponse); ok {
+		r0 = returnFunc(ctx, hrefs, filterOpts, pageOpts)
+	} else {
+		r0 = ret.Get(0).(NpmPackageListResponse)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string, NpmPackageListFilters, PageOptions) error); ok {
+		r1 = returnFunc(ctx, hrefs, filterOpts, pageOpts)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTangy_NpmRepositoryVersionPackageList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NpmRepositoryVersionPackageList'
+type MockTangy_NpmRepositoryVersionPackageList_Call struct {
+	*mock.Call
+}
+
+// NpmRepositoryVersionPackageList is a helper method to define mock.On call
+//   - ctx context.Context
+//   - hrefs []string
+//   - filterOpts NpmPackageListFilters
+//   - pageOpts PageOptions
+func (_e *MockTangy_Expecter) NpmRepositoryVersionPackageList(ctx any, hrefs any, filterOpts any, pageOpts any) *MockTangy_NpmRepositoryVersionPackageList_Call {
+	return &MockTangy_NpmRepositoryVersionPackageList_Call{Call: _e.mock.On("NpmRepositoryVersionPackageList", ctx, hrefs, filterOpts, pageOpts)}
+}
+
+func (_c *MockTangy_NpmRepositoryVersionPackageList_Call) Run(run func(ctx context.Context, hrefs []string, filterOpts NpmPackageListFilters, pageOpts PageOptions)) *MockTangy_NpmRepositoryVersionPackageList_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
+		var arg2 NpmPackageListFilters
+		if args[2] != nil {
+			arg2 = args[2].(NpmPackageListFilters)
+		}
+		var arg3 PageOptions
+		if args[3] != nil {
+			arg3 = args[3].(PageOptions)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTangy_NpmRepositoryVersionPackageList_Call) Return(npmPackageListResponse NpmPackageListResponse, err error) *MockTangy_NpmRepositoryVersionPackageList_Call {
+	_c.Call.Return(npmPackageListResponse, err)
+	return _c
+}
+
+func (_c *MockTangy_NpmRepositoryVersionPackageList_Call) RunAndReturn(run func(ctx context.Context, hrefs []string, filterOpts NpmPackageListFilters, pageOpts PageOptions) (NpmPackageListResponse, error)) *MockTangy_NpmRepositoryVersionPackageList_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NpmRepositoryVersionPackageVersionsGet provides a mock function for the type MockTangy
+func (_mock *MockTangy) NpmRepositoryVersionPackageVersionsGet(ctx context.Context, hrefs []string, name string) ([]NpmPackageDetail, error) {
+	ret := _mock.Called(ctx, hrefs, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NpmRepositoryVersionPackageVersionsGet")
+	}
+
+	var r0 []NpmPackageDetail
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string, string) ([]NpmPackageDetail, error)); ok {
+		return returnFunc(ctx, hrefs, name)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string, string) []NpmPackageDetail); ok {
+		r0 = returnFunc(ctx, hrefs, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]NpmPackageDetail)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string, string) error); ok {
+		r1 = returnFunc(ctx, hrefs, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTangy_NpmRepositoryVersionPackageVersionsGet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NpmRepositoryVersionPackageVersionsGet'
+type MockTangy_NpmRepositoryVersionPackageVersionsGet_Call struct {
+	*mock.Call
+}
+
+// NpmRepositoryVersionPackageVersionsGet is a helper method to define mock.On call
+//   - ctx context.Context
+//   - hrefs []string
+//   - name string
+func (_e *MockTangy_Expecter) NpmRepositoryVersionPackageVersionsGet(ctx any, hrefs any, name any) *MockTangy_NpmRepositoryVersionPackageVersionsGet_Call {
+	return &MockTangy_NpmRepositoryVersionPackageVersionsGet_Call{Call: _e.mock.On("NpmRepositoryVersionPackageVersionsGet", ctx, hrefs, name)}
+}
+
+func (_c *MockTangy_NpmRepositoryVersionPackageVersionsGet_Call) Run(run func(ctx context.Context, hrefs []string, name string)) *MockTangy_NpmRepositoryVersionPackageVersionsGet_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTangy_NpmRepositoryVersionPackageVersionsGet_Call) Return(npmPackageDetails []NpmPackageDetail, err error) *MockTangy_NpmRepositoryVersionPackageVersionsGet_Call {
+	_c.Call.Return(npmPackageDetails, err)
+	return _c
+}
+
+func (_c *MockTangy_NpmRepositoryVersionPackageVersionsGet_Call) RunAndReturn(run func(ctx context.Context, hrefs []string, name string) ([]NpmPackageDetail, error)) *MockTangy_NpmRepositoryVersionPackageVersionsGet_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Ping provides a mock function for the type MockTangy
+func (_mock *MockTangy) Ping(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Ping")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockTangy_Ping_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Ping'
+type MockTangy_Ping_Call struct {
+	*mock.Call
+}
+
+// Ping is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockTangy_Expecter) Ping(ctx any) *MockTangy_Ping_Call {
+	return &MockTangy_Ping_Call{Call: _e.mock.On("Ping", ctx)}
+}
+
+func (_c *MockTangy_Ping_Call) Run(run func(ctx context.Context)) *MockTangy_Ping_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTangy_Ping_Call) Return(err error) *MockTangy_Ping_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockTangy_Ping_Call) RunAndReturn(run func(ctx context.Context) error) *MockTangy_Ping_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PythonBuildList provides a mock function for the type MockTangy
+func (_mock *MockTangy) PythonBuildList(ctx context.Context, repositoryHref string, nameNormalized string, version string, pageOpts PageOptions) (PythonBuildListResponse, error) {
+	ret := _mock.Called(ctx, repositoryHref, nameNormalized, version, pageOpts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PythonBuildList")
+	}
+
+	var r0 PythonBuildListResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, PageOptions) (PythonBuildListResponse, error)); ok {
+		return returnFunc(ctx, repositoryHref, nameNormalized, version, pageOpts)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, PageOptions) PythonBuildListResponse); ok {
+		r0 = returnFunc(ctx, repositoryHref, nameNormalized, version, pageOpts)
+	} else {
+		r0 = ret.Get(0).(PythonBuildListResponse)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string, PageOptions) error); ok {
+		r1 = returnFunc(ctx, repositoryHref, nameNormalized, version, pageOpts)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTangy_PythonBuildList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PythonBuildList'
+type MockTangy_PythonBuildList_Call struct {
+	*mock.Call
+}
+
+// PythonBuildList is a helper method to define mock.On call
+//   - ctx context.Context
+//   - repositoryHref string
+//   - nameNormalized string
+//   - version string
+//   - pageOpts PageOptions
+func (_e *MockTangy_Expecter) PythonBuildList(ctx any, repositoryHref any, nameNormalized any, version any, pageOpts any) *MockTangy_PythonBuildList_Call {
+	return &MockTangy_PythonBuildList_Call{Call: _e.mock.On("PythonBuildList", ctx, repositoryHref, nameNormalized, version, pageOpts)}
+}
+
+func (_c *MockTangy_PythonBuildList_Call) Run(run func(ctx context.Context, repositoryHref string, nameNormalized string, version string, pageOpts PageOptions)) *MockTangy_PythonBuildList_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 PageOptions
+		if args[4] != nil {
+			arg4 = args[4].(PageOptions)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTangy_PythonBuildList_Call) Return(pythonBuildListResponse PythonBuildListResponse, err error) *MockTangy_PythonBuildList_Call {
+	_c.Call.Return(pythonBuildListResponse, err)
+	return _c
+}
+
+func (_c *MockTangy_PythonBuildList_Call) RunAndReturn(run func(ctx context.Context, repositoryHref string, nameNormalized string, version string, pageOpts PageOptions) (PythonBuildListResponse, error)) *MockTangy_PythonBuildList_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PythonDistributionList provides a mock function for the type MockTangy
+func (_mock *MockTangy) PythonDistributionList(ctx context.Context, repositoryHref string, nameNormalized string, version string, pageOpts PageOptions) (PythonDistributionListResponse, error) {
+	ret := _mock.Called(ctx, repositoryHref, nameNormalized, version, pageOpts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PythonDistributionList")
+	}
+
+	var r0 PythonDistributionListResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, PageOptions) (PythonDistributionListResponse, error)); ok {
+		return returnFunc(ctx, repositoryHref, nameNormalized, version, pageOpts)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, PageOptions) PythonDistributionListResponse); ok {
+		r0 = returnFunc(ctx, repositoryHref, nameNormalized, version, pageOpts)
+	} else {
+		r0 = ret.Get(0).(PythonDistributionListResponse)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string, PageOptions) error); ok {
+		r1 = returnFunc(ctx, repositoryHref, nameNormalized, version, pageOpts)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTangy_PythonDistributionList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PythonDistributionList'
+type MockTangy_PythonDistributionList_Call struct {
+	*mock.Call
+}
+
+// PythonDistributionList is a helper method to define mock.On call
+//   - ctx context.Context
+//   - repositoryHref string
+//   - nameNormalized string
+//   - version string
+//   - pageOpts PageOptions
+func (_e *MockTangy_Expecter) PythonDistributionList(ctx any, repositoryHref any, nameNormalized any, version any, pageOpts any) *MockTangy_PythonDistributionList_Call {
+	return &MockTangy_PythonDistributionList_Call{Call: _e.mock.On("PythonDistributionList", ctx, repositoryHref, nameNormalized, version, pageOpts)}
+}
+
+func (_c *MockTangy_PythonDistributionList_Call) Run(run func(ctx context.Context, repositoryHref string, nameNormalized string, version string, pageOpts PageOptions)) *MockTangy_PythonDistributionList_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 PageOptions
+		if args[4] != nil {
+			arg4 = args[4].(PageOptions)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTangy_PythonDistributionList_Call) Return(pythonDistributionListResponse PythonDistributionListResponse, err error) *MockTangy_PythonDistributionList_Call {
+	_c.Call.Return(pythonDistributionListResponse, err)
+	return _c
+}
+
+func (_c *MockTangy_PythonDistributionList_Call) RunAndReturn(run func(ctx context.Context, repositoryHref string, nameNormalized string, version string, pageOpts PageOptions) (PythonDistributionListResponse, error)) *MockTangy_PythonDistributionList_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PythonPackageGet provides a mock function for the type MockTangy
+func (_mock *MockTangy) PythonPackageGet(ctx context.Context, repositoryHref string, nameNormalized string, version string) (PythonPackageDetail, error) {
+	ret := _mock.Called(ctx, repositoryHref, nameNormalized, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PythonPackageGet")
 	}
 
-	var r0 MavenVersionsResponse
+	var r0 PythonPackageDetail
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string, PageOptions) (MavenVersionsResponse, error)); ok {
-		return returnFunc(ctx, repositoryHref, groupID, artifactID, version, pageOpts)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) (PythonPackageDetail, error)); ok {
+		return returnFunc(ctx, repositoryHref, nameNormalized, version)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string, PageOptions) MavenVersionsResponse); ok {
-		r0 = returnFunc(ctx, repositoryHref, groupID, artifactID, version, pageOpts)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) PythonPackageDetail); ok {
+		r0 = returnFunc(ctx, repositoryHref, nameNormalized, version)
 	} else {
-		r0 = ret.Get(0).(MavenVersionsResponse)
+		r0 = ret.Get(0).(PythonPackageDetail)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string, string, PageOptions) error); ok {
-		r1 = returnFunc(ctx, repositoryHref, groupID, artifactID, version, pageOpts)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = returnFunc(ctx, repositoryHref, nameNormalized, version)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockTangy_MavenVersionsList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MavenVersionsList'
-type MockTangy_MavenVersionsList_Call struct {
+// MockTangy_PythonPackageGet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PythonPackageGet'
+type MockTangy_PythonPackageGet_Call struct {
 	*mock.Call
 }
 
-// MavenVersionsList is a helper method to define mock.On call
+// PythonPackageGet is a helper method to define mock.On call
 //   - ctx context.Context
 //   - repositoryHref string
-//   - groupID string
-//   - artifactID string
+//   - nameNormalized string
 //   - version string
-//   - pageOpts PageOptions
-func (_e *MockTangy_Expecter) MavenVersionsList(ctx any, repositoryHref any, groupID any, artifactID any, version any, pageOpts any) *MockTangy_MavenVersionsList_Call {
-	return &MockTangy_MavenVersionsList_Call{Call: _e.mock.On("MavenVersionsList", ctx, repositoryHref, groupID, artifactID, version, pageOpts)}
+func (_e *MockTangy_Expecter) PythonPackageGet(ctx any, repositoryHref any, nameNormalized any, version any) *MockTangy_PythonPackageGet_Call {
+	return &MockTangy_PythonPackageGet_Call{Call: _e.mock.On("PythonPackageGet", ctx, repositoryHref, nameNormalized, version)}
 }
 
-func (_c *MockTangy_MavenVersionsList_Call) Run(run func(ctx context.Context, repositoryHref string, groupID string, artifactID string, version string, pageOpts PageOptions)) *MockTangy_MavenVersionsList_Call {
+func (_c *MockTangy_PythonPackageGet_Call) Run(run func(ctx context.Context, repositoryHref string, nameNormalized string, version string)) *MockTangy_PythonPackageGet_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -130,55 +1586,45 @@ func (_c *MockTangy_MavenVersionsList_Call) Run(run func(ctx context.Context, re
 		if args[3] != nil {
 			arg3 = args[3].(string)
 		}
-		var arg4 string
-		if args[4] != nil {
-			arg4 = args[4].(string)
-		}
-		var arg5 PageOptions
-		if args[5] != nil {
-			arg5 = args[5].(PageOptions)
-		}
 		run(
 			arg0,
 			arg1,
 			arg2,
 			arg3,
-			arg4,
-			arg5,
 		)
 	})
 	return _c
 }
 
-func (_c *MockTangy_MavenVersionsList_Call) Return(mavenVersionsResponse MavenVersionsResponse, err error) *MockTangy_MavenVersionsList_Call {
-	_c.Call.Return(mavenVersionsResponse, err)
+func (_c *MockTangy_PythonPackageGet_Call) Return(pythonPackageDetail PythonPackageDetail, err error) *MockTangy_PythonPackageGet_Call {
+	_c.Call.Return(pythonPackageDetail, err)
 	return _c
 }
 
-func (_c *MockTangy_MavenVersionsList_Call) RunAndReturn(run func(ctx context.Context, repositoryHref string, groupID string, artifactID string, version string, pageOpts PageOptions) (MavenVersionsResponse, error)) *MockTangy_MavenVersionsList_Call {
+func (_c *MockTangy_PythonPackageGet_Call) RunAndReturn(run func(ctx context.Context, repositoryHref string, nameNormalized string, version string) (PythonPackageDetail, error)) *MockTangy_PythonPackageGet_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// MavenPackageList provides a mock function for the type MockTangy
-func (_mock *MockTangy) MavenPackageList(ctx context.Context, repositoryHref string, filterOpts MavenPackageListFilters, pageOpts PageOptions) (MavenPackageListResponse, error) {
+// PythonPackageList provides a mock function for the type MockTangy
+func (_mock *MockTangy) PythonPackageList(ctx context.Context, repositoryHref string, filterOpts PythonPackageListFilters, pageOpts PageOptions) (PythonPackageListResponse, error) {
 	ret := _mock.Called(ctx, repositoryHref, filterOpts, pageOpts)
 
 	if len(ret) == 0 {
-		panic("no return value specified for MavenPackageList")
+		panic("no return value specified for PythonPackageList")
 	}
 
-	var r0 MavenPackageListResponse
+	var r0 PythonPackageListResponse
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, MavenPackageListFilters, PageOptions) (MavenPackageListResponse, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, PythonPackageListFilters, PageOptions) (PythonPackageListResponse, error)); ok {
 		return returnFunc(ctx, repositoryHref, filterOpts, pageOpts)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, MavenPackageListFilters, PageOptions) MavenPackageListResponse); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, PythonPackageListFilters, PageOptions) PythonPackageListResponse); ok {
 		r0 = returnFunc(ctx, repositoryHref, filterOpts, pageOpts)
 	} else {
-		r0 = ret.Get(0).(MavenPackageListResponse)
+		r0 = ret.Get(0).(PythonPackageListResponse)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, MavenPackageListFilters, PageOptions) error); ok {
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, PythonPackageListFilters, PageOptions) error); ok {
 		r1 = returnFunc(ctx, repositoryHref, filterOpts, pageOpts)
 	} else {
 		r1 = ret.Error(1)
@@ -186,21 +1632,21 @@ func (_mock *MockTangy) MavenPackageList(ctx context.Context, repositoryHref str
 	return r0, r1
 }
 
-// MockTangy_MavenPackageList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MavenPackageList'
-type MockTangy_MavenPackageList_Call struct {
+// MockTangy_PythonPackageList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PythonPackageList'
+type MockTangy_PythonPackageList_Call struct {
 	*mock.Call
 }
 
-// MavenPackageList is a helper method to define mock.On call
+// PythonPackageList is a helper method to define mock.On call
 //   - ctx context.Context
 //   - repositoryHref string
-//   - filterOpts MavenPackageListFilters
+//   - filterOpts PythonPackageListFilters
 //   - pageOpts PageOptions
-func (_e *MockTangy_Expecter) MavenPackageList(ctx any, repositoryHref any, filterOpts any, pageOpts any) *MockTangy_MavenPackageList_Call {
-	return &MockTangy_MavenPackageList_Call{Call: _e.mock.On("MavenPackageList", ctx, repositoryHref, filterOpts, pageOpts)}
+func (_e *MockTangy_Expecter) PythonPackageList(ctx any, repositoryHref any, filterOpts any, pageOpts any) *MockTangy_PythonPackageList_Call {
+	return &MockTangy_PythonPackageList_Call{Call: _e.mock.On("PythonPackageList", ctx, repositoryHref, filterOpts, pageOpts)}
 }
 
-func (_c *MockTangy_MavenPackageList_Call) Run(run func(ctx context.Context, repositoryHref string, filterOpts MavenPackageListFilters, pageOpts PageOptions)) *MockTangy_MavenPackageList_Call {
+func (_c *MockTangy_PythonPackageList_Call) Run(run func(ctx context.Context, repositoryHref string, filterOpts PythonPackageListFilters, pageOpts PageOptions)) *MockTangy_PythonPackageList_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -210,9 +1656,9 @@ func (_c *MockTangy_MavenPackageList_Call) Run(run func(ctx context.Context, rep
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 MavenPackageListFilters
+		var arg2 PythonPackageListFilters
 		if args[2] != nil {
-			arg2 = args[2].(MavenPackageListFilters)
+			arg2 = args[2].(PythonPackageListFilters)
 		}
 		var arg3 PageOptions
 		if args[3] != nil {
@@ -228,33 +1674,107 @@ func (_c *MockTangy_MavenPackageList_Call) Run(run func(ctx context.Context, rep
 	return _c
 }
 
-func (_c *MockTangy_MavenPackageList_Call) Return(mavenPackageListResponse MavenPackageListResponse, err error) *MockTangy_MavenPackageList_Call {
-	_c.Call.Return(mavenPackageListResponse, err)
+func (_c *MockTangy_PythonPackageList_Call) Return(pythonPackageListResponse PythonPackageListResponse, err error) *MockTangy_PythonPackageList_Call {
+	_c.Call.Return(pythonPackageListResponse, err)
 	return _c
 }
 
-func (_c *MockTangy_MavenPackageList_Call) RunAndReturn(run func(ctx context.Context, repositoryHref string, filterOpts MavenPackageListFilters, pageOpts PageOptions) (MavenPackageListResponse, error)) *MockTangy_MavenPackageList_Call {
+func (_c *MockTangy_PythonPackageList_Call) RunAndReturn(run func(ctx context.Context, repositoryHref string, filterOpts PythonPackageListFilters, pageOpts PageOptions) (PythonPackageListResponse, error)) *MockTangy_PythonPackageList_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// MavenRepositoryMetrics provides a mock function for the type MockTangy
-func (_mock *MockTangy) MavenRepositoryMetrics(ctx context.Context, repositoryHref string) (MavenRepositoryMetrics, error) {
+// PythonPackageVersionsGet provides a mock function for the type MockTangy
+func (_mock *MockTangy) PythonPackageVersionsGet(ctx context.Context, repositoryHref string, nameNormalized string) ([]PythonPackageDetail, error) {
+	ret := _mock.Called(ctx, repositoryHref, nameNormalized)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PythonPackageVersionsGet")
+	}
+
+	var r0 []PythonPackageDetail
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) ([]PythonPackageDetail, error)); ok {
+		return returnFunc(ctx, repositoryHref, nameNormalized)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) []PythonPackageDetail); ok {
+		r0 = returnFunc(ctx, repositoryHref, nameNormalized)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]PythonPackageDetail)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, repositoryHref, nameNormalized)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockTangy_PythonPackageVersionsGet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PythonPackageVersionsGet'
+type MockTangy_PythonPackageVersionsGet_Call struct {
+	*mock.Call
+}
+
+// PythonPackageVersionsGet is a helper method to define mock.On call
+//   - ctx context.Context
+//   - repositoryHref string
+//   - nameNormalized string
+func (_e *MockTangy_Expecter) PythonPackageVersionsGet(ctx any, repositoryHref any, nameNormalized any) *MockTangy_PythonPackageVersionsGet_Call {
+	return &MockTangy_PythonPackageVersionsGet_Call{Call: _e.mock.On("PythonPackageVersionsGet", ctx, repositoryHref, nameNormalized)}
+}
+
+func (_c *MockTangy_PythonPackageVersionsGet_Call) Run(run func(ctx context.Context, repositoryHref string, nameNormalized string)) *MockTangy_PythonPackageVersionsGet_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTangy_PythonPackageVersionsGet_Call) Return(pythonPackageDetails []PythonPackageDetail, err error) *MockTangy_PythonPackageVersionsGet_Call {
+	_c.Call.Return(pythonPackageDetails, err)
+	return _c
+}
+
+func (_c *MockTangy_PythonPackageVersionsGet_Call) RunAndReturn(run func(ctx context.Context, repositoryHref string, nameNormalized string) ([]PythonPackageDetail, error)) *MockTangy_PythonPackageVersionsGet_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PythonRepositoryMetrics provides a mock function for the type MockTangy
+func (_mock *MockTangy) PythonRepositoryMetrics(ctx context.Context, repositoryHref string) (PythonRepositoryMetrics, error) {
 	ret := _mock.Called(ctx, repositoryHref)
 
 	if len(ret) == 0 {
-		panic("no return value specified for MavenRepositoryMetrics")
+		panic("no return value specified for PythonRepositoryMetrics")
 	}
 
-	var r0 MavenRepositoryMetrics
+	var r0 PythonRepositoryMetrics
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (MavenRepositoryMetrics, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (PythonRepositoryMetrics, error)); ok {
 		return returnFunc(ctx, repositoryHref)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) MavenRepositoryMetrics); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) PythonRepositoryMetrics); ok {
 		r0 = returnFunc(ctx, repositoryHref)
 	} else {
-		r0 = ret.Get(0).(MavenRepositoryMetrics)
+		r0 = ret.Get(0).(PythonRepositoryMetrics)
 	}
 	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
 		r1 = returnFunc(ctx, repositoryHref)
@@ -264,19 +1784,19 @@ func (_mock *MockTangy) MavenRepositoryMetrics(ctx context.Context, repositoryHr
 	return r0, r1
 }
 
-// MockTangy_MavenRepositoryMetrics_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MavenRepositoryMetrics'
-type MockTangy_MavenRepositoryMetrics_Call struct {
+// MockTangy_PythonRepositoryMetrics_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PythonRepositoryMetrics'
+type MockTangy_PythonRepositoryMetrics_Call struct {
 	*mock.Call
 }
 
-// MavenRepositoryMetrics is a helper method to define mock.On call
+// PythonRepositoryMetrics is a helper method to define mock.On call
 //   - ctx context.Context
 //   - repositoryHref string
-func (_e *MockTangy_Expecter) MavenRepositoryMetrics(ctx any, repositoryHref any) *MockTangy_MavenRepositoryMetrics_Call {
-	return &MockTangy_MavenRepositoryMetrics_Call{Call: _e.mock.On("MavenRepositoryMetrics", ctx, repositoryHref)}
+func (_e *MockTangy_Expecter) PythonRepositoryMetrics(ctx any, repositoryHref any) *MockTangy_PythonRepositoryMetrics_Call {
+	return &MockTangy_PythonRepositoryMetrics_Call{Call: _e.mock.On("PythonRepositoryMetrics", ctx, repositoryHref)}
 }
 
-func (_c *MockTangy_MavenRepositoryMetrics_Call) Run(run func(ctx context.Context, repositoryHref string)) *MockTangy_MavenRepositoryMetrics_Call {
+func (_c *MockTangy_PythonRepositoryMetrics_Call) Run(run func(ctx context.Context, repositoryHref string)) *MockTangy_PythonRepositoryMetrics_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -294,66 +1814,66 @@ func (_c *MockTangy_MavenRepositoryMetrics_Call) Run(run func(ctx context.Contex
 	return _c
 }
 
-func (_c *MockTangy_MavenRepositoryMetrics_Call) Return(mavenRepositoryMetrics MavenRepositoryMetrics, err error) *MockTangy_MavenRepositoryMetrics_Call {
-	_c.Call.Return(mavenRepositoryMetrics, err)
+func (_c *MockTangy_PythonRepositoryMetrics_Call) Return(pythonRepositoryMetrics PythonRepositoryMetrics, err error) *MockTangy_PythonRepositoryMetrics_Call {
+	_c.Call.Return(pythonRepositoryMetrics, err)
 	return _c
 }
 
-func (_c *MockTangy_MavenRepositoryMetrics_Call) RunAndReturn(run func(ctx context.Context, repositoryHref string) (MavenRepositoryMetrics, error)) *MockTangy_MavenRepositoryMetrics_Call {
+func (_c *MockTangy_PythonRepositoryMetrics_Call) RunAndReturn(run func(ctx context.Context, repositoryHref string) (PythonRepositoryMetrics, error)) *MockTangy_PythonRepositoryMetrics_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NpmBuildList provides a mock function for the type MockTangy
-func (_mock *MockTangy) NpmBuildList(ctx context.Context, repositoryHref string, name string, version string, pageOpts PageOptions) (NpmBuildListResponse, error) {
-	ret := _mock.Called(ctx, repositoryHref, name, version, pageOpts)
+// PythonRepositoryVersionBuildList provides a mock function for the type MockTangy
+func (_mock *MockTangy) PythonRepositoryVersionBuildList(ctx context.Context, hrefs []string, nameNormalized string, version string, pageOpts PageOptions) (PythonBuildListResponse, error) {
+	ret := _mock.Called(ctx, hrefs, nameNormalized, version, pageOpts)
 
 	if len(ret) == 0 {
-		panic("no return value specified for NpmBuildList")
+		panic("no return value specified for PythonRepositoryVersionBuildList")
 	}
 
-	var r0 NpmBuildListResponse
+	var r0 PythonBuildListResponse
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, PageOptions) (NpmBuildListResponse, error)); ok {
-		return returnFunc(ctx, repositoryHref, name, version, pageOpts)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string, string, string, PageOptions) (PythonBuildListResponse, error)); ok {
+		return returnFunc(ctx, hrefs, nameNormalized, version, pageOpts)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, PageOptions) NpmBuildListResponse); ok {
-		r0 = returnFunc(ctx, repositoryHref, name, version, pageOpts)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string, string, string, PageOptions) PythonBuildListResponse); ok {
+		r0 = returnFunc(ctx, hrefs, nameNormalized, version, pageOpts)
 	} else {
-		r0 = ret.Get(0).(NpmBuildListResponse)
+		r0 = ret.Get(0).(PythonBuildListResponse)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string, PageOptions) error); ok {
-		r1 = returnFunc(ctx, repositoryHref, name, version, pageOpts)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string, string, string, PageOptions) error); ok {
+		r1 = returnFunc(ctx, hrefs, nameNormalized, version, pageOpts)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockTangy_NpmBuildList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NpmBuildList'
-type MockTangy_NpmBuildList_Call struct {
+// MockTangy_PythonRepositoryVersionBuildList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PythonRepositoryVersionBuildList'
+type MockTangy_PythonRepositoryVersionBuildList_Call struct {
 	*mock.Call
 }
 
-// NpmBuildList is a helper method to define mock.On call
+// PythonRepositoryVersionBuildList is a helper method to define mock.On call
 //   - ctx context.Context
-//   - repositoryHref string
-//   - name string
+//   - hrefs []string
+//   - nameNormalized string
 //   - version string
 //   - pageOpts PageOptions
-func (_e *MockTangy_Expecter) NpmBuildList(ctx any, repositoryHref any, name any, version any, pageOpts any) *MockTangy_NpmBuildList_Call {
-	return &MockTangy_NpmBuildList_Call{Call: _e.mock.On("NpmBuildList", ctx, repositoryHref, name, version, pageOpts)}
+func (_e *MockTangy_Expecter) PythonRepositoryVersionBuildList(ctx any, hrefs any, nameNormalized any, version any, pageOpts any) *MockTangy_PythonRepositoryVersionBuildList_Call {
+	return &MockTangy_PythonRepositoryVersionBuildList_Call{Call: _e.mock.On("PythonRepositoryVersionBuildList", ctx, hrefs, nameNormalized, version, pageOpts)}
 }
 
-func (_c *MockTangy_NpmBuildList_Call) Run(run func(ctx context.Context, repositoryHref string, name string, version string, pageOpts PageOptions)) *MockTangy_NpmBuildList_Call {
+func (_c *MockTangy_PythonRepositoryVersionBuildList_Call) Run(run func(ctx context.Context, hrefs []string, nameNormalized string, version string, pageOpts PageOptions)) *MockTangy_PythonRepositoryVersionBuildList_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 []string
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].([]string)
 		}
 		var arg2 string
 		if args[2] != nil {
@@ -378,65 +1898,58 @@ func (_c *MockTangy_NpmBuildList_Call) Run(run func(ctx context.Context, reposit
 	return _c
 }
 
-func (_c *MockTangy_NpmBuildList_Call) Return(npmBuildListResponse NpmBuildListResponse, err error) *MockTangy_NpmBuildList_Call {
-	_c.Call.Return(npmBuildListResponse, err)
+func (_c *MockTangy_PythonRepositoryVersionBuildList_Call) Return(pythonBuildListResponse PythonBuildListResponse, err error) *MockTangy_PythonRepositoryVersionBuildList_Call {
+	_c.Call.Return(pythonBuildListResponse, err)
 	return _c
 }
 
-func (_c *MockTangy_NpmBuildList_Call) RunAndReturn(run func(ctx context.Context, repositoryHref string, name string, version string, pageOpts PageOptions) (NpmBuildListResponse, error)) *MockTangy_NpmBuildList_Call {
+func (_c *MockTangy_PythonRepositoryVersionBuildList_Call) RunAndReturn(run func(ctx context.Context, hrefs []string, nameNormalized string, version string, pageOpts PageOptions) (PythonBuildListResponse, error)) *MockTangy_PythonRepositoryVersionBuildList_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NpmPackageGet provides a mock function for the type MockTangy
-func (_mock *MockTangy) NpmPackageGet(ctx context.Context, repositoryHref string, name string, version string) (NpmPackageDetail, error) {
-	ret := _mock.Called(ctx, repositoryHref, name, version)
+// PythonRepositoryVersionDistributionAll provides a mock function for the type MockTangy
+func (_mock *MockTangy) PythonRepositoryVersionDistributionAll(ctx context.Context, hrefs []string, nameNormalized string, version string) iter.Seq2[PythonDistributionListItem, error] {
+	ret := _mock.Called(ctx, hrefs, nameNormalized, version)
 
 	if len(ret) == 0 {
-		panic("no return value specified for NpmPackageGet")
+		panic("no return value specified for PythonRepositoryVersionDistributionAll")
 	}
 
-	var r0 NpmPackageDetail
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) (NpmPackageDetail, error)); ok {
-		return returnFunc(ctx, repositoryHref, name, version)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) NpmPackageDetail); ok {
-		r0 = returnFunc(ctx, repositoryHref, name, version)
-	} else {
-		r0 = ret.Get(0).(NpmPackageDetail)
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
-		r1 = returnFunc(ctx, repositoryHref, name, version)
+	var r0 iter.Seq2[PythonDistributionListItem, error]
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string, string, string) iter.Seq2[PythonDistributionListItem, error]); ok {
+		r0 = returnFunc(ctx, hrefs, nameNormalized, version)
 	} else {
-		r1 = ret.Error(1)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(iter.Seq2[PythonDistributionListItem, error])
+		}
 	}
-	return r0, r1
+	return r0
 }
 
-// MockTangy_NpmPackageGet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NpmPackageGet'
-type MockTangy_NpmPackageGet_Call struct {
+// MockTangy_PythonRepositoryVersionDistributionAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PythonRepositoryVersionDistributionAll'
+type MockTangy_PythonRepositoryVersionDistributionAll_Call struct {
 	*mock.Call
 }
 
-// NpmPackageGet is a helper method to define mock.On call
+// PythonRepositoryVersionDistributionAll is a helper method to define mock.On call
 //   - ctx context.Context
-//   - repositoryHref string
-//   - name string
+//   - hrefs []string
+//   - nameNormalized string
 //   - version string
-func (_e *MockTangy_Expecter) NpmPackageGet(ctx any, repositoryHref any, name any, version any) *MockTangy_NpmPackageGet_Call {
-	return &MockTangy_NpmPackageGet_Call{Call: _e.mock.On("NpmPackageGet", ctx, repositoryHref, name, version)}
+func (_e *MockTangy_Expecter) PythonRepositoryVersionDistributionAll(ctx any, hrefs any, nameNormalized any, version any) *MockTangy_PythonRepositoryVersionDistributionAll_Call {
+	return &MockTangy_PythonRepositoryVersionDistributionAll_Call{Call: _e.mock.On("PythonRepositoryVersionDistributionAll", ctx, hrefs, nameNormalized, version)}
 }
 
-func (_c *MockTangy_NpmPackageGet_Call) Run(run func(ctx context.Context, repositoryHref string, name string, version string)) *MockTangy_NpmPackageGet_Call {
+func (_c *MockTangy_PythonRepositoryVersionDistributionAll_Call) Run(run func(ctx context.Context, hrefs []string, nameNormalized string, version string)) *MockTangy_PythonRepositoryVersionDistributionAll_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 []string
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].([]string)
 		}
 		var arg2 string
 		if args[2] != nil {
@@ -456,218 +1969,215 @@ func (_c *MockTangy_NpmPackageGet_Call) Run(run func(ctx context.Context, reposi
 	return _c
 }
 
-func (_c *MockTangy_NpmPackageGet_Call) Return(npmPackageDetail NpmPackageDetail, err error) *MockTangy_NpmPackageGet_Call {
-	_c.Call.Return(npmPackageDetail, err)
+func (_c *MockTangy_PythonRepositoryVersionDistributionAll_Call) Return(seq2 iter.Seq2[PythonDistributionListItem, error]) *MockTangy_PythonRepositoryVersionDistributionAll_Call {
+	_c.Call.Return(seq2)
 	return _c
 }
 
-func (_c *MockTangy_NpmPackageGet_Call) RunAndReturn(run func(ctx context.Context, repositoryHref string, name string, version string) (NpmPackageDetail, error)) *MockTangy_NpmPackageGet_Call {
+func (_c *MockTangy_PythonRepositoryVersionDistributionAll_Call) RunAndReturn(run func(ctx context.Context, hrefs []string, nameNormalized string, version string) iter.Seq2[PythonDistributionListItem, error]) *MockTangy_PythonRepositoryVersionDistributionAll_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NpmPackageList provides a mock function for the type MockTangy
-func (_mock *MockTangy) NpmPackageList(ctx context.Context, repositoryHref string, filterOpts NpmPackageListFilters, pageOpts PageOptions) (NpmPackageListResponse, error) {
-	ret := _mock.Called(ctx, repositoryHref, filterOpts, pageOpts)
+// PythonRepositoryVersionDistributionList provides a mock function for the type MockTangy
+func (_mock *MockTangy) PythonRepositoryVersionDistributionList(ctx context.Context, hrefs []string, nameNormalized string, version string, pageOpts PageOptions) (PythonDistributionListResponse, error) {
+	ret := _mock.Called(ctx, hrefs, nameNormalized, version, pageOpts)
 
 	if len(ret) == 0 {
-		panic("no return value specified for NpmPackageList")
+		panic("no return value specified for PythonRepositoryVersionDistributionList")
 	}
 
-	var r0 NpmPackageListResponse
+	var r0 PythonDistributionListResponse
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, NpmPackageListFilters, PageOptions) (NpmPackageListResponse, error)); ok {
-		return returnFunc(ctx, repositoryHref, filterOpts, pageOpts)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string, string, string, PageOptions) (PythonDistributionListResponse, error)); ok {
+		return returnFunc(ctx, hrefs, nameNormalized, version, pageOpts)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, NpmPackageListFilters, PageOptions) NpmPackageListResponse); ok {
-		r0 = returnFunc(ctx, repositoryHref, filterOpts, pageOpts)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string, string, string, PageOptions) PythonDistributionListResponse); ok {
+		r0 = returnFunc(ctx, hrefs, nameNormalized, version, pageOpts)
 	} else {
-		r0 = ret.Get(0).(NpmPackageListResponse)
+		r0 = ret.Get(0).(PythonDistributionListResponse)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, NpmPackageListFilters, PageOptions) error); ok {
-		r1 = returnFunc(ctx, repositoryHref, filterOpts, pageOpts)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string, string, string, PageOptions) error); ok {
+		r1 = returnFunc(ctx, hrefs, nameNormalized, version, pageOpts)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockTangy_NpmPackageList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NpmPackageList'
-type MockTangy_NpmPackageList_Call struct {
+// MockTangy_PythonRepositoryVersionDistributionList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PythonRepositoryVersionDistributionList'
+type MockTangy_PythonRepositoryVersionDistributionList_Call struct {
 	*mock.Call
 }
 
-// NpmPackageList is a helper method to define mock.On call
+// PythonRepositoryVersionDistributionList is a helper method to define mock.On call
 //   - ctx context.Context
-//   - repositoryHref string
-//   - filterOpts NpmPackageListFilters
+//   - hrefs []string
+//   - nameNormalized string
+//   - version string
 //   - pageOpts PageOptions
-func (_e *MockTangy_Expecter) NpmPackageList(ctx any, repositoryHref any, filterOpts any, pageOpts any) *MockTangy_NpmPackageList_Call {
-	return &MockTangy_NpmPackageList_Call{Call: _e.mock.On("NpmPackageList", ctx, repositoryHref, filterOpts, pageOpts)}
+func (_e *MockTangy_Expecter) PythonRepositoryVersionDistributionList(ctx any, hrefs any, nameNormalized any, version any, pageOpts any) *MockTangy_PythonRepositoryVersionDistributionList_Call {
+	return &MockTangy_PythonRepositoryVersionDistributionList_Call{Call: _e.mock.On("PythonRepositoryVersionDistributionList", ctx, hrefs, nameNormalized, version, pageOpts)}
 }
 
-func (_c *MockTangy_NpmPackageList_Call) Run(run func(ctx context.Context, repositoryHref string, filterOpts NpmPackageListFilters, pageOpts PageOptions)) *MockTangy_NpmPackageList_Call {
+func (_c *MockTangy_PythonRepositoryVersionDistributionList_Call) Run(run func(ctx context.Context, hrefs []string, nameNormalized string, version string, pageOpts PageOptions)) *MockTangy_PythonRepositoryVersionDistributionList_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 []string
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].([]string)
 		}
-		var arg2 NpmPackageListFilters
+		var arg2 string
 		if args[2] != nil {
-			arg2 = args[2].(NpmPackageListFilters)
+			arg2 = args[2].(string)
 		}
-		var arg3 PageOptions
+		var arg3 string
 		if args[3] != nil {
-			arg3 = args[3].(PageOptions)
+			arg3 = args[3].(string)
+		}
+		var arg4 PageOptions
+		if args[4] != nil {
+			arg4 = args[4].(PageOptions)
 		}
 		run(
 			arg0,
 			arg1,
 			arg2,
 			arg3,
+			arg4,
 		)
 	})
 	return _c
 }
 
-func (_c *MockTangy_NpmPackageList_Call) Return(npmPackageListResponse NpmPackageListResponse, err error) *MockTangy_NpmPackageList_Call {
-	_c.Call.Return(npmPackageListResponse, err)
+func (_c *MockTangy_PythonRepositoryVersionDistributionList_Call) Return(pythonDistributionListResponse PythonDistributionListResponse, err error) *MockTangy_PythonRepositoryVersionDistributionList_Call {
+	_c.Call.Return(pythonDistributionListResponse, err)
 	return _c
 }
 
-func (_c *MockTangy_NpmPackageList_Call) RunAndReturn(run func(ctx context.Context, repositoryHref string, filterOpts NpmPackageListFilters, pageOpts PageOptions) (NpmPackageListResponse, error)) *MockTangy_NpmPackageList_Call {
+func (_c *MockTangy_PythonRepositoryVersionDistributionList_Call) RunAndReturn(run func(ctx context.Context, hrefs []string, nameNormalized string, version string, pageOpts PageOptions) (PythonDistributionListResponse, error)) *MockTangy_PythonRepositoryVersionDistributionList_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NpmPackageVersionsGet provides a mock function for the type MockTangy
-func (_mock *MockTangy) NpmPackageVersionsGet(ctx context.Context, repositoryHref string, name string) ([]NpmPackageDetail, error) {
-	ret := _mock.Called(ctx, repositoryHref, name)
+// PythonRepositoryVersionMetrics provides a mock function for the type MockTangy
+func (_mock *MockTangy) PythonRepositoryVersionMetrics(ctx context.Context, hrefs []string) (PythonRepositoryMetrics, error) {
+	ret := _mock.Called(ctx, hrefs)
 
 	if len(ret) == 0 {
-		panic("no return value specified for NpmPackageVersionsGet")
+		panic("no return value specified for PythonRepositoryVersionMetrics")
 	}
 
-	var r0 []NpmPackageDetail
+	var r0 PythonRepositoryMetrics
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) ([]NpmPackageDetail, error)); ok {
-		return returnFunc(ctx, repositoryHref, name)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) (PythonRepositoryMetrics, error)); ok {
+		return returnFunc(ctx, hrefs)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) []NpmPackageDetail); ok {
-		r0 = returnFunc(ctx, repositoryHref, name)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) PythonRepositoryMetrics); ok {
+		r0 = returnFunc(ctx, hrefs)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]NpmPackageDetail)
-		}
+		r0 = ret.Get(0).(PythonRepositoryMetrics)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = returnFunc(ctx, repositoryHref, name)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = returnFunc(ctx, hrefs)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockTangy_NpmPackageVersionsGet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'NpmPackageVersionsGet'
-type MockTangy_NpmPackageVersionsGet_Call struct {
+// MockTangy_PythonRepositoryVersionMetrics_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PythonRepositoryVersionMetrics'
+type MockTangy_PythonRepositoryVersionMetrics_Call struct {
 	*mock.Call
 }
 
-// NpmPackageVersionsGet is a helper method to define mock.On call
+// PythonRepositoryVersionMetrics is a helper method to define mock.On call
 //   - ctx context.Context
-//   - repositoryHref string
-//   - name string
-func (_e *MockTangy_Expecter) NpmPackageVersionsGet(ctx any, repositoryHref any, name any) *MockTangy_NpmPackageVersionsGet_Call {
-	return &MockTangy_NpmPackageVersionsGet_Call{Call: _e.mock.On("NpmPackageVersionsGet", ctx, repositoryHref, name)}
+//   - hrefs []string
+func (_e *MockTangy_Expecter) PythonRepositoryVersionMetrics(ctx any, hrefs any) *MockTangy_PythonRepositoryVersionMetrics_Call {
+	return &MockTangy_PythonRepositoryVersionMetrics_Call{Call: _e.mock.On("PythonRepositoryVersionMetrics", ctx, hrefs)}
 }
 
-func (_c *MockTangy_NpmPackageVersionsGet_Call) Run(run func(ctx context.Context, repositoryHref string, name string)) *MockTangy_NpmPackageVersionsGet_Call {
+func (_c *MockTangy_PythonRepositoryVersionMetrics_Call) Run(run func(ctx context.Context, hrefs []string)) *MockTangy_PythonRepositoryVersionMetrics_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 []string
 		if args[1] != nil {
-			arg1 = args[1].(string)
-		}
-		var arg2 string
-		if args[2] != nil {
-			arg2 = args[2].(string)
+			arg1 = args[1].([]string)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockTangy_NpmPackageVersionsGet_Call) Return(npmPackageDetails []NpmPackageDetail, err error) *MockTangy_NpmPackageVersionsGet_Call {
-	_c.Call.Return(npmPackageDetails, err)
+func (_c *MockTangy_PythonRepositoryVersionMetrics_Call) Return(pythonRepositoryMetrics PythonRepositoryMetrics, err error) *MockTangy_PythonRepositoryVersionMetrics_Call {
+	_c.Call.Return(pythonRepositoryMetrics, err)
 	return _c
 }
 
-func (_c *MockTangy_NpmPackageVersionsGet_Call) RunAndReturn(run func(ctx context.Context, repositoryHref string, name string) ([]NpmPackageDetail, error)) *MockTangy_NpmPackageVersionsGet_Call {
+func (_c *MockTangy_PythonRepositoryVersionMetrics_Call) RunAndReturn(run func(ctx context.Context, hrefs []string) (PythonRepositoryMetrics, error)) *MockTangy_PythonRepositoryVersionMetrics_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// PythonBuildList provides a mock function for the type MockTangy
-func (_mock *MockTangy) PythonBuildList(ctx context.Context, repositoryHref string, nameNormalized string, version string, pageOpts PageOptions) (PythonBuildListResponse, error) {
-	ret := _mock.Called(ctx, repositoryHref, nameNormalized, version, pageOpts)
+// PythonRepositoryVersionPackageGet provides a mock function for the type MockTangy
+func (_mock *MockTangy) PythonRepositoryVersionPackageGet(ctx context.Context, hrefs []string, nameNormalized string, version string) (PythonPackageDetail, error) {
+	ret := _mock.Called(ctx, hrefs, nameNormalized, version)
 
 	if len(ret) == 0 {
-		panic("no return value specified for PythonBuildList")
+		panic("no return value specified for PythonRepositoryVersionPackageGet")
 	}
 
-	var r0 PythonBuildListResponse
+	var r0 PythonPackageDetail
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, PageOptions) (PythonBuildListResponse, error)); ok {
-		return returnFunc(ctx, repositoryHref, nameNormalized, version, pageOpts)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string, string, string) (PythonPackageDetail, error)); ok {
+		return returnFunc(ctx, hrefs, nameNormalized, version)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, PageOptions) PythonBuildListResponse); ok {
-		r0 = returnFunc(ctx, repositoryHref, nameNormalized, version, pageOpts)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string, string, string) PythonPackageDetail); ok {
+		r0 = returnFunc(ctx, hrefs, nameNormalized, version)
 	} else {
-		r0 = ret.Get(0).(PythonBuildListResponse)
+		r0 = ret.Get(0).(PythonPackageDetail)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string, PageOptions) error); ok {
-		r1 = returnFunc(ctx, repositoryHref, nameNormalized, version, pageOpts)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string, string, string) error); ok {
+		r1 = returnFunc(ctx, hrefs, nameNormalized, version)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockTangy_PythonBuildList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PythonBuildList'
-type MockTangy_PythonBuildList_Call struct {
+// MockTangy_PythonRepositoryVersionPackageGet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PythonRepositoryVersionPackageGet'
+type MockTangy_PythonRepositoryVersionPackageGet_Call struct {
 	*mock.Call
 }
 
-// PythonBuildList is a helper method to define mock.On call
+// PythonRepositoryVersionPackageGet is a helper method to define mock.On call
 //   - ctx context.Context
-//   - repositoryHref string
+//   - hrefs []string
 //   - nameNormalized string
 //   - version string
-//   - pageOpts PageOptions
-func (_e *MockTangy_Expecter) PythonBuildList(ctx any, repositoryHref any, nameNormalized any, version any, pageOpts any) *MockTangy_PythonBuildList_Call {
-	return &MockTangy_PythonBuildList_Call{Call: _e.mock.On("PythonBuildList", ctx, repositoryHref, nameNormalized, version, pageOpts)}
+func (_e *MockTangy_Expecter) PythonRepositoryVersionPackageGet(ctx any, hrefs any, nameNormalized any, version any) *MockTangy_PythonRepositoryVersionPackageGet_Call {
+	return &MockTangy_PythonRepositoryVersionPackageGet_Call{Call: _e.mock.On("PythonRepositoryVersionPackageGet", ctx, hrefs, nameNormalized, version)}
 }
 
-func (_c *MockTangy_PythonBuildList_Call) Run(run func(ctx context.Context, repositoryHref string, nameNormalized string, version string, pageOpts PageOptions)) *MockTangy_PythonBuildList_Call {
+func (_c *MockTangy_PythonRepositoryVersionPackageGet_Call) Run(run func(ctx context.Context, hrefs []string, nameNormalized string, version string)) *MockTangy_PythonRepositoryVersionPackageGet_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 []string
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].([]string)
 		}
 		var arg2 string
 		if args[2] != nil {
@@ -677,407 +2187,345 @@ func (_c *MockTangy_PythonBuildList_Call) Run(run func(ctx context.Context, repo
 		if args[3] != nil {
 			arg3 = args[3].(string)
 		}
-		var arg4 PageOptions
-		if args[4] != nil {
-			arg4 = args[4].(PageOptions)
-		}
 		run(
 			arg0,
 			arg1,
 			arg2,
 			arg3,
-			arg4,
 		)
 	})
 	return _c
 }
 
-func (_c *MockTangy_PythonBuildList_Call) Return(pythonBuildListResponse PythonBuildListResponse, err error) *MockTangy_PythonBuildList_Call {
-	_c.Call.Return(pythonBuildListResponse, err)
+func (_c *MockTangy_PythonRepositoryVersionPackageGet_Call) Return(pythonPackageDetail PythonPackageDetail, err error) *MockTangy_PythonRepositoryVersionPackageGet_Call {
+	_c.Call.Return(pythonPackageDetail, err)
 	return _c
 }
 
-func (_c *MockTangy_PythonBuildList_Call) RunAndReturn(run func(ctx context.Context, repositoryHref string, nameNormalized string, version string, pageOpts PageOptions) (PythonBuildListResponse, error)) *MockTangy_PythonBuildList_Call {
+func (_c *MockTangy_PythonRepositoryVersionPackageGet_Call) RunAndReturn(run func(ctx context.Context, hrefs []string, nameNormalized string, version string) (PythonPackageDetail, error)) *MockTangy_PythonRepositoryVersionPackageGet_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// PythonDistributionList provides a mock function for the type MockTangy
-func (_mock *MockTangy) PythonDistributionList(ctx context.Context, repositoryHref string, nameNormalized string, version string, pageOpts PageOptions) (PythonDistributionListResponse, error) {
-	ret := _mock.Called(ctx, repositoryHref, nameNormalized, version, pageOpts)
+// PythonRepositoryVersionPackageList provides a mock function for the type MockTangy
+func (_mock *MockTangy) PythonRepositoryVersionPackageList(ctx context.Context, hrefs []string, filterOpts PythonPackageListFilters, pageOpts PageOptions) (PythonPackageListResponse, error) {
+	ret := _mock.Called(ctx, hrefs, filterOpts, pageOpts)
 
 	if len(ret) == 0 {
-		panic("no return value specified for PythonDistributionList")
+		panic("no return value specified for PythonRepositoryVersionPackageList")
 	}
 
-	var r0 PythonDistributionListResponse
+	var r0 PythonPackageListResponse
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, PageOptions) (PythonDistributionListResponse, error)); ok {
-		return returnFunc(ctx, repositoryHref, nameNormalized, version, pageOpts)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string, PythonPackageListFilters, PageOptions) (PythonPackageListResponse, error)); ok {
+		return returnFunc(ctx, hrefs, filterOpts, pageOpts)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, PageOptions) PythonDistributionListResponse); ok {
-		r0 = returnFunc(ctx, repositoryHref, nameNormalized, version, pageOpts)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string, PythonPackageListFilters, PageOptions) PythonPackageListResponse); ok {
+		r0 = returnFunc(ctx, hrefs, filterOpts, pageOpts)
 	} else {
-		r0 = ret.Get(0).(PythonDistributionListResponse)
+		r0 = ret.Get(0).(PythonPackageListResponse)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string, PageOptions) error); ok {
-		r1 = returnFunc(ctx, repositoryHref, nameNormalized, version, pageOpts)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string, PythonPackageListFilters, PageOptions) error); ok {
+		r1 = returnFunc(ctx, hrefs, filterOpts, pageOpts)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockTangy_PythonDistributionList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PythonDistributionList'
-type MockTangy_PythonDistributionList_Call struct {
+// MockTangy_PythonRepositoryVersionPackageList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PythonRepositoryVersionPackageList'
+type MockTangy_PythonRepositoryVersionPackageList_Call struct {
 	*mock.Call
 }
 
-// PythonDistributionList is a helper method to define mock.On call
+// PythonRepositoryVersionPackageList is a helper method to define mock.On call
 //   - ctx context.Context
-//   - repositoryHref string
-//   - nameNormalized string
-//   - version string
+//   - hrefs []string
+//   - filterOpts PythonPackageListFilters
 //   - pageOpts PageOptions
-func (_e *MockTangy_Expecter) PythonDistributionList(ctx any, repositoryHref any, nameNormalized any, version any, pageOpts any) *MockTangy_PythonDistributionList_Call {
-	return &MockTangy_PythonDistributionList_Call{Call: _e.mock.On("PythonDistributionList", ctx, repositoryHref, nameNormalized, version, pageOpts)}
+func (_e *MockTangy_Expecter) PythonRepositoryVersionPackageList(ctx any, hrefs any, filterOpts any, pageOpts any) *MockTangy_PythonRepositoryVersionPackageList_Call {
+	return &MockTangy_PythonRepositoryVersionPackageList_Call{Call: _e.mock.On("PythonRepositoryVersionPackageList", ctx, hrefs, filterOpts, pageOpts)}
 }
 
-func (_c *MockTangy_PythonDistributionList_Call) Run(run func(ctx context.Context, repositoryHref string, nameNormalized string, version string, pageOpts PageOptions)) *MockTangy_PythonDistributionList_Call {
+func (_c *MockTangy_PythonRepositoryVersionPackageList_Call) Run(run func(ctx context.Context, hrefs []string, filterOpts PythonPackageListFilters, pageOpts PageOptions)) *MockTangy_PythonRepositoryVersionPackageList_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 []string
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].([]string)
 		}
-		var arg2 string
+		var arg2 PythonPackageListFilters
 		if args[2] != nil {
-			arg2 = args[2].(string)
+			arg2 = args[2].(PythonPackageListFilters)
 		}
-		var arg3 string
+		var arg3 PageOptions
 		if args[3] != nil {
-			arg3 = args[3].(string)
-		}
-		var arg4 PageOptions
-		if args[4] != nil {
-			arg4 = args[4].(PageOptions)
+			arg3 = args[3].(PageOptions)
 		}
 		run(
 			arg0,
 			arg1,
 			arg2,
 			arg3,
-			arg4,
 		)
 	})
 	return _c
 }
 
-func (_c *MockTangy_PythonDistributionList_Call) Return(pythonDistributionListResponse PythonDistributionListResponse, err error) *MockTangy_PythonDistributionList_Call {
-	_c.Call.Return(pythonDistributionListResponse, err)
+func (_c *MockTangy_PythonRepositoryVersionPackageList_Call) Return(pythonPackageListResponse PythonPackageListResponse, err error) *MockTangy_PythonRepositoryVersionPackageList_Call {
+	_c.Call.Return(pythonPackageListResponse, err)
 	return _c
 }
 
-func (_c *MockTangy_PythonDistributionList_Call) RunAndReturn(run func(ctx context.Context, repositoryHref string, nameNormalized string, version string, pageOpts PageOptions) (PythonDistributionListResponse, error)) *MockTangy_PythonDistributionList_Call {
+func (_c *MockTangy_PythonRepositoryVersionPackageList_Call) RunAndReturn(run func(ctx context.Context, hrefs []string, filterOpts PythonPackageListFilters, pageOpts PageOptions) (PythonPackageListResponse, error)) *MockTangy_PythonRepositoryVersionPackageList_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// PythonPackageGet provides a mock function for the type MockTangy
-func (_mock *MockTangy) PythonPackageGet(ctx context.Context, repositoryHref string, nameNormalized string, version string) (PythonPackageDetail, error) {
-	ret := _mock.Called(ctx, repositoryHref, nameNormalized, version)
+// PythonRepositoryVersionPackageVersionsGet provides a mock function for the type MockTangy
+func (_mock *MockTangy) PythonRepositoryVersionPackageVersionsGet(ctx context.Context, hrefs []string, nameNormalized string) ([]PythonPackageDetail, error) {
+	ret := _mock.Called(ctx, hrefs, nameNormalized)
 
 	if len(ret) == 0 {
-		panic("no return value specified for PythonPackageGet")
+		panic("no return value specified for PythonRepositoryVersionPackageVersionsGet")
 	}
 
-	var r0 PythonPackageDetail
+	var r0 []PythonPackageDetail
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) (PythonPackageDetail, error)); ok {
-		return returnFunc(ctx, repositoryHref, nameNormalized, version)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string, string) ([]PythonPackageDetail, error)); ok {
+		return returnFunc(ctx, hrefs, nameNormalized)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) PythonPackageDetail); ok {
-		r0 = returnFunc(ctx, repositoryHref, nameNormalized, version)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string, string) []PythonPackageDetail); ok {
+		r0 = returnFunc(ctx, hrefs, nameNormalized)
 	} else {
-		r0 = ret.Get(0).(PythonPackageDetail)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]PythonPackageDetail)
+		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
-		r1 = returnFunc(ctx, repositoryHref, nameNormalized, version)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string, string) error); ok {
+		r1 = returnFunc(ctx, hrefs, nameNormalized)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockTangy_PythonPackageGet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PythonPackageGet'
-type MockTangy_PythonPackageGet_Call struct {
+// MockTangy_PythonRepositoryVersionPackageVersionsGet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PythonRepositoryVersionPackageVersionsGet'
+type MockTangy_PythonRepositoryVersionPackageVersionsGet_Call struct {
 	*mock.Call
 }
 
-// PythonPackageGet is a helper method to define mock.On call
+// PythonRepositoryVersionPackageVersionsGet is a helper method to define mock.On call
 //   - ctx context.Context
-//   - repositoryHref string
+//   - hrefs []string
 //   - nameNormalized string
-//   - version string
-func (_e *MockTangy_Expecter) PythonPackageGet(ctx any, repositoryHref any, nameNormalized any, version any) *MockTangy_PythonPackageGet_Call {
-	return &MockTangy_PythonPackageGet_Call{Call: _e.mock.On("PythonPackageGet", ctx, repositoryHref, nameNormalized, version)}
+func (_e *MockTangy_Expecter) PythonRepositoryVersionPackageVersionsGet(ctx any, hrefs any, nameNormalized any) *MockTangy_PythonRepositoryVersionPackageVersionsGet_Call {
+	return &MockTangy_PythonRepositoryVersionPackageVersionsGet_Call{Call: _e.mock.On("PythonRepositoryVersionPackageVersionsGet", ctx, hrefs, nameNormalized)}
 }
 
-func (_c *MockTangy_PythonPackageGet_Call) Run(run func(ctx context.Context, repositoryHref string, nameNormalized string, version string)) *MockTangy_PythonPackageGet_Call {
+func (_c *MockTangy_PythonRepositoryVersionPackageVersionsGet_Call) Run(run func(ctx context.Context, hrefs []string, nameNormalized string)) *MockTangy_PythonRepositoryVersionPackageVersionsGet_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 []string
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].([]string)
 		}
 		var arg2 string
 		if args[2] != nil {
 			arg2 = args[2].(string)
 		}
-		var arg3 string
-		if args[3] != nil {
-			arg3 = args[3].(string)
-		}
 		run(
 			arg0,
 			arg1,
 			arg2,
-			arg3,
 		)
 	})
 	return _c
 }
-
-func (_c *MockTangy_PythonPackageGet_Call) Return(pythonPackageDetail PythonPackageDetail, err error) *MockTangy_PythonPackageGet_Call {
-	_c.Call.Return(pythonPackageDetail, err)
+
+func (_c *MockTangy_PythonRepositoryVersionPackageVersionsGet_Call) Return(pythonPackageDetails []PythonPackageDetail, err error) *MockTangy_PythonRepositoryVersionPackageVersionsGet_Call {
+	_c.Call.Return(pythonPackageDetails, err)
 	return _c
 }
 
-func (_c *MockTangy_PythonPackageGet_Call) RunAndReturn(run func(ctx context.Context, repositoryHref string, nameNormalized string, version string) (PythonPackageDetail, error)) *MockTangy_PythonPackageGet_Call {
+func (_c *MockTangy_PythonRepositoryVersionPackageVersionsGet_Call) RunAndReturn(run func(ctx context.Context, hrefs []string, nameNormalized string) ([]PythonPackageDetail, error)) *MockTangy_PythonRepositoryVersionPackageVersionsGet_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// PythonPackageList provides a mock function for the type MockTangy
-func (_mock *MockTangy) PythonPackageList(ctx context.Context, repositoryHref string, filterOpts PythonPackageListFilters, pageOpts PageOptions) (PythonPackageListResponse, error) {
-	ret := _mock.Called(ctx, repositoryHref, filterOpts, pageOpts)
+// ReadSnapshot provides a mock function for the type MockTangy
+func (_mock *MockTangy) ReadSnapshot(ctx context.Context, fn func(snapshot Tangy) error) error {
+	ret := _mock.Called(ctx, fn)
 
 	if len(ret) == 0 {
-		panic("no return value specified for PythonPackageList")
+		panic("no return value specified for ReadSnapshot")
 	}
 
-	var r0 PythonPackageListResponse
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, PythonPackageListFilters, PageOptions) (PythonPackageListResponse, error)); ok {
-		return returnFunc(ctx, repositoryHref, filterOpts, pageOpts)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, PythonPackageListFilters, PageOptions) PythonPackageListResponse); ok {
-		r0 = returnFunc(ctx, repositoryHref, filterOpts, pageOpts)
-	} else {
-		r0 = ret.Get(0).(PythonPackageListResponse)
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, PythonPackageListFilters, PageOptions) error); ok {
-		r1 = returnFunc(ctx, repositoryHref, filterOpts, pageOpts)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, func(snapshot Tangy) error) error); ok {
+		r0 = returnFunc(ctx, fn)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockTangy_PythonPackageList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PythonPackageList'
-type MockTangy_PythonPackageList_Call struct {
+// MockTangy_ReadSnapshot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReadSnapshot'
+type MockTangy_ReadSnapshot_Call struct {
 	*mock.Call
 }
 
-// PythonPackageList is a helper method to define mock.On call
+// ReadSnapshot is a helper method to define mock.On call
 //   - ctx context.Context
-//   - repositoryHref string
-//   - filterOpts PythonPackageListFilters
-//   - pageOpts PageOptions
-func (_e *MockTangy_Expecter) PythonPackageList(ctx any, repositoryHref any, filterOpts any, pageOpts any) *MockTangy_PythonPackageList_Call {
-	return &MockTangy_PythonPackageList_Call{Call: _e.mock.On("PythonPackageList", ctx, repositoryHref, filterOpts, pageOpts)}
+//   - fn func(snapshot Tangy) error
+func (_e *MockTangy_Expecter) ReadSnapshot(ctx any, fn any) *MockTangy_ReadSnapshot_Call {
+	return &MockTangy_ReadSnapshot_Call{Call: _e.mock.On("ReadSnapshot", ctx, fn)}
 }
 
-func (_c *MockTangy_PythonPackageList_Call) Run(run func(ctx context.Context, repositoryHref string, filterOpts PythonPackageListFilters, pageOpts PageOptions)) *MockTangy_PythonPackageList_Call {
+func (_c *MockTangy_ReadSnapshot_Call) Run(run func(ctx context.Context, fn func(snapshot Tangy) error)) *MockTangy_ReadSnapshot_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 func(snapshot Tangy) error
 		if args[1] != nil {
-			arg1 = args[1].(string)
-		}
-		var arg2 PythonPackageListFilters
-		if args[2] != nil {
-			arg2 = args[2].(PythonPackageListFilters)
-		}
-		var arg3 PageOptions
-		if args[3] != nil {
-			arg3 = args[3].(PageOptions)
+			arg1 = args[1].(func(snapshot Tangy) error)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
-			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockTangy_PythonPackageList_Call) Return(pythonPackageListResponse PythonPackageListResponse, err error) *MockTangy_PythonPackageList_Call {
-	_c.Call.Return(pythonPackageListResponse, err)
+func (_c *MockTangy_ReadSnapshot_Call) Return(err error) *MockTangy_ReadSnapshot_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockTangy_PythonPackageList_Call) RunAndReturn(run func(ctx context.Context, repositoryHref string, filterOpts PythonPackageListFilters, pageOpts PageOptions) (PythonPackageListResponse, error)) *MockTangy_PythonPackageList_Call {
+func (_c *MockTangy_ReadSnapshot_Call) RunAndReturn(run func(ctx context.Context, fn func(snapshot Tangy) error) error) *MockTangy_ReadSnapshot_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// PythonPackageVersionsGet provides a mock function for the type MockTangy
-func (_mock *MockTangy) PythonPackageVersionsGet(ctx context.Context, repositoryHref string, nameNormalized string) ([]PythonPackageDetail, error) {
-	ret := _mock.Called(ctx, repositoryHref, nameNormalized)
+// Ready provides a mock function for the type MockTangy
+func (_mock *MockTangy) Ready(ctx context.Context) error {
+	ret := _mock.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for PythonPackageVersionsGet")
+		panic("no return value specified for Ready")
 	}
 
-	var r0 []PythonPackageDetail
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) ([]PythonPackageDetail, error)); ok {
-		return returnFunc(ctx, repositoryHref, nameNormalized)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) []PythonPackageDetail); ok {
-		r0 = returnFunc(ctx, repositoryHref, nameNormalized)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]PythonPackageDetail)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = returnFunc(ctx, repositoryHref, nameNormalized)
-	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockTangy_PythonPackageVersionsGet_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PythonPackageVersionsGet'
-type MockTangy_PythonPackageVersionsGet_Call struct {
+// MockTangy_Ready_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Ready'
+type MockTangy_Ready_Call struct {
 	*mock.Call
 }
 
-// PythonPackageVersionsGet is a helper method to define mock.On call
+// Ready is a helper method to define mock.On call
 //   - ctx context.Context
-//   - repositoryHref string
-//   - nameNormalized string
-func (_e *MockTangy_Expecter) PythonPackageVersionsGet(ctx any, repositoryHref any, nameNormalized any) *MockTangy_PythonPackageVersionsGet_Call {
-	return &MockTangy_PythonPackageVersionsGet_Call{Call: _e.mock.On("PythonPackageVersionsGet", ctx, repositoryHref, nameNormalized)}
+func (_e *MockTangy_Expecter) Ready(ctx any) *MockTangy_Ready_Call {
+	return &MockTangy_Ready_Call{Call: _e.mock.On("Ready", ctx)}
 }
 
-func (_c *MockTangy_PythonPackageVersionsGet_Call) Run(run func(ctx context.Context, repositoryHref string, nameNormalized string)) *MockTangy_PythonPackageVersionsGet_Call {
+func (_c *MockTangy_Ready_Call) Run(run func(ctx context.Context)) *MockTangy_Ready_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
-		if args[1] != nil {
-			arg1 = args[1].(string)
-		}
-		var arg2 string
-		if args[2] != nil {
-			arg2 = args[2].(string)
-		}
 		run(
 			arg0,
-			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockTangy_PythonPackageVersionsGet_Call) Return(pythonPackageDetails []PythonPackageDetail, err error) *MockTangy_PythonPackageVersionsGet_Call {
-	_c.Call.Return(pythonPackageDetails, err)
+func (_c *MockTangy_Ready_Call) Return(err error) *MockTangy_Ready_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockTangy_PythonPackageVersionsGet_Call) RunAndReturn(run func(ctx context.Context, repositoryHref string, nameNormalized string) ([]PythonPackageDetail, error)) *MockTangy_PythonPackageVersionsGet_Call {
+func (_c *MockTangy_Ready_Call) RunAndReturn(run func(ctx context.Context) error) *MockTangy_Ready_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// PythonRepositoryMetrics provides a mock function for the type MockTangy
-func (_mock *MockTangy) PythonRepositoryMetrics(ctx context.Context, repositoryHref string) (PythonRepositoryMetrics, error) {
-	ret := _mock.Called(ctx, repositoryHref)
+// RpmRepositoryVersionBatch provides a mock function for the type MockTangy
+func (_mock *MockTangy) RpmRepositoryVersionBatch(ctx context.Context, hrefs []string, batch *Batch) error {
+	ret := _mock.Called(ctx, hrefs, batch)
 
 	if len(ret) == 0 {
-		panic("no return value specified for PythonRepositoryMetrics")
+		panic("no return value specified for RpmRepositoryVersionBatch")
 	}
 
-	var r0 PythonRepositoryMetrics
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (PythonRepositoryMetrics, error)); ok {
-		return returnFunc(ctx, repositoryHref)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) PythonRepositoryMetrics); ok {
-		r0 = returnFunc(ctx, repositoryHref)
-	} else {
-		r0 = ret.Get(0).(PythonRepositoryMetrics)
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, repositoryHref)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string, *Batch) error); ok {
+		r0 = returnFunc(ctx, hrefs, batch)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockTangy_PythonRepositoryMetrics_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PythonRepositoryMetrics'
-type MockTangy_PythonRepositoryMetrics_Call struct {
+// MockTangy_RpmRepositoryVersionBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RpmRepositoryVersionBatch'
+type MockTangy_RpmRepositoryVersionBatch_Call struct {
 	*mock.Call
 }
 
-// PythonRepositoryMetrics is a helper method to define mock.On call
+// RpmRepositoryVersionBatch is a helper method to define mock.On call
 //   - ctx context.Context
-//   - repositoryHref string
-func (_e *MockTangy_Expecter) PythonRepositoryMetrics(ctx any, repositoryHref any) *MockTangy_PythonRepositoryMetrics_Call {
-	return &MockTangy_PythonRepositoryMetrics_Call{Call: _e.mock.On("PythonRepositoryMetrics", ctx, repositoryHref)}
+//   - hrefs []string
+//   - batch *Batch
+func (_e *MockTangy_Expecter) RpmRepositoryVersionBatch(ctx any, hrefs any, batch any) *MockTangy_RpmRepositoryVersionBatch_Call {
+	return &MockTangy_RpmRepositoryVersionBatch_Call{Call: _e.mock.On("RpmRepositoryVersionBatch", ctx, hrefs, batch)}
 }
 
-func (_c *MockTangy_PythonRepositoryMetrics_Call) Run(run func(ctx context.Context, repositoryHref string)) *MockTangy_PythonRepositoryMetrics_Call {
+func (_c *MockTangy_RpmRepositoryVersionBatch_Call) Run(run func(ctx context.Context, hrefs []string, batch *Batch)) *MockTangy_RpmRepositoryVersionBatch_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 []string
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].([]string)
+		}
+		var arg2 *Batch
+		if args[2] != nil {
+			arg2 = args[2].(*Batch)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockTangy_PythonRepositoryMetrics_Call) Return(pythonRepositoryMetrics PythonRepositoryMetrics, err error) *MockTangy_PythonRepositoryMetrics_Call {
-	_c.Call.Return(pythonRepositoryMetrics, err)
+func (_c *MockTangy_RpmRepositoryVersionBatch_Call) Return(err error) *MockTangy_RpmRepositoryVersionBatch_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockTangy_PythonRepositoryMetrics_Call) RunAndReturn(run func(ctx context.Context, repositoryHref string) (PythonRepositoryMetrics, error)) *MockTangy_PythonRepositoryMetrics_Call {
+func (_c *MockTangy_RpmRepositoryVersionBatch_Call) RunAndReturn(run func(ctx context.Context, hrefs []string, batch *Batch) error) *MockTangy_RpmRepositoryVersionBatch_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -1162,38 +2610,95 @@ func (_c *MockTangy_RpmRepositoryVersionEnvironmentSearch_Call) RunAndReturn(run
 	return _c
 }
 
+// RpmRepositoryVersionErrataAll provides a mock function for the type MockTangy
+func (_mock *MockTangy) RpmRepositoryVersionErrataAll(ctx context.Context, hrefs []string, filterOpts ErrataListFilters) iter.Seq2[ErrataListItem, error] {
+	ret := _mock.Called(ctx, hrefs, filterOpts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RpmRepositoryVersionErrataAll")
+	}
+
+	var r0 iter.Seq2[ErrataListItem, error]
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string, ErrataListFilters) iter.Seq2[ErrataListItem, error]); ok {
+		r0 = returnFunc(ctx, hrefs, filterOpts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(iter.Seq2[ErrataListItem, error])
+		}
+	}
+	return r0
+}
+
+// MockTangy_RpmRepositoryVersionErrataAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RpmRepositoryVersionErrataAll'
+type MockTangy_RpmRepositoryVersionErrataAll_Call struct {
+	*mock.Call
+}
+
+// RpmRepositoryVersionErrataAll is a helper method to define mock.On call
+//   - ctx context.Context
+//   - hrefs []string
+//   - filterOpts ErrataListFilters
+func (_e *MockTangy_Expecter) RpmRepositoryVersionErrataAll(ctx any, hrefs any, filterOpts any) *MockTangy_RpmRepositoryVersionErrataAll_Call {
+	return &MockTangy_RpmRepositoryVersionErrataAll_Call{Call: _e.mock.On("RpmRepositoryVersionErrataAll", ctx, hrefs, filterOpts)}
+}
+
+func (_c *MockTangy_RpmRepositoryVersionErrataAll_Call) Run(run func(ctx context.Context, hrefs []string, filterOpts ErrataListFilters)) *MockTangy_RpmRepositoryVersionErrataAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
+		var arg2 ErrataListFilters
+		if args[2] != nil {
+			arg2 = args[2].(ErrataListFilters)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTangy_RpmRepositoryVersionErrataAll_Call) Return(seq2 iter.Seq2[ErrataListItem, error]) *MockTangy_RpmRepositoryVersionErrataAll_Call {
+	_c.Call.Return(seq2)
+	return _c
+}
+
+func (_c *MockTangy_RpmRepositoryVersionErrataAll_Call) RunAndReturn(run func(ctx context.Context, hrefs []string, filterOpts ErrataListFilters) iter.Seq2[ErrataListItem, error]) *MockTangy_RpmRepositoryVersionErrataAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RpmRepositoryVersionErrataList provides a mock function for the type MockTangy
-func (_mock *MockTangy) RpmRepositoryVersionErrataList(ctx context.Context, hrefs []string, filterOpts ErrataListFilters, pageOpts PageOptions) ([]ErrataListItem, int, error) {
+func (_mock *MockTangy) RpmRepositoryVersionErrataList(ctx context.Context, hrefs []string, filterOpts ErrataListFilters, pageOpts PageOptions) (ErrataListResponse, error) {
 	ret := _mock.Called(ctx, hrefs, filterOpts, pageOpts)
 
 	if len(ret) == 0 {
 		panic("no return value specified for RpmRepositoryVersionErrataList")
 	}
 
-	var r0 []ErrataListItem
-	var r1 int
-	var r2 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, []string, ErrataListFilters, PageOptions) ([]ErrataListItem, int, error)); ok {
+	var r0 ErrataListResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string, ErrataListFilters, PageOptions) (ErrataListResponse, error)); ok {
 		return returnFunc(ctx, hrefs, filterOpts, pageOpts)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, []string, ErrataListFilters, PageOptions) []ErrataListItem); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string, ErrataListFilters, PageOptions) ErrataListResponse); ok {
 		r0 = returnFunc(ctx, hrefs, filterOpts, pageOpts)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]ErrataListItem)
-		}
+		r0 = ret.Get(0).(ErrataListResponse)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, []string, ErrataListFilters, PageOptions) int); ok {
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string, ErrataListFilters, PageOptions) error); ok {
 		r1 = returnFunc(ctx, hrefs, filterOpts, pageOpts)
 	} else {
-		r1 = ret.Get(1).(int)
-	}
-	if returnFunc, ok := ret.Get(2).(func(context.Context, []string, ErrataListFilters, PageOptions) error); ok {
-		r2 = returnFunc(ctx, hrefs, filterOpts, pageOpts)
-	} else {
-		r2 = ret.Error(2)
+		r1 = ret.Error(1)
 	}
-	return r0, r1, r2
+	return r0, r1
 }
 
 // MockTangy_RpmRepositoryVersionErrataList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RpmRepositoryVersionErrataList'
@@ -1238,12 +2743,12 @@ func (_c *MockTangy_RpmRepositoryVersionErrataList_Call) Run(run func(ctx contex
 	return _c
 }
 
-func (_c *MockTangy_RpmRepositoryVersionErrataList_Call) Return(errataListItems []ErrataListItem, n int, err error) *MockTangy_RpmRepositoryVersionErrataList_Call {
-	_c.Call.Return(errataListItems, n, err)
+func (_c *MockTangy_RpmRepositoryVersionErrataList_Call) Return(errataListResponse ErrataListResponse, err error) *MockTangy_RpmRepositoryVersionErrataList_Call {
+	_c.Call.Return(errataListResponse, err)
 	return _c
 }
 
-func (_c *MockTangy_RpmRepositoryVersionErrataList_Call) RunAndReturn(run func(ctx context.Context, hrefs []string, filterOpts ErrataListFilters, pageOpts PageOptions) ([]ErrataListItem, int, error)) *MockTangy_RpmRepositoryVersionErrataList_Call {
+func (_c *MockTangy_RpmRepositoryVersionErrataList_Call) RunAndReturn(run func(ctx context.Context, hrefs []string, filterOpts ErrataListFilters, pageOpts PageOptions) (ErrataListResponse, error)) *MockTangy_RpmRepositoryVersionErrataList_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -1328,6 +2833,71 @@ func (_c *MockTangy_RpmRepositoryVersionModuleStreamsList_Call) RunAndReturn(run
 	return _c
 }
 
+// RpmRepositoryVersionPackageAll provides a mock function for the type MockTangy
+func (_mock *MockTangy) RpmRepositoryVersionPackageAll(ctx context.Context, hrefs []string, filterOpts RpmListFilters) iter.Seq2[RpmListItem, error] {
+	ret := _mock.Called(ctx, hrefs, filterOpts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RpmRepositoryVersionPackageAll")
+	}
+
+	var r0 iter.Seq2[RpmListItem, error]
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string, RpmListFilters) iter.Seq2[RpmListItem, error]); ok {
+		r0 = returnFunc(ctx, hrefs, filterOpts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(iter.Seq2[RpmListItem, error])
+		}
+	}
+	return r0
+}
+
+// MockTangy_RpmRepositoryVersionPackageAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RpmRepositoryVersionPackageAll'
+type MockTangy_RpmRepositoryVersionPackageAll_Call struct {
+	*mock.Call
+}
+
+// RpmRepositoryVersionPackageAll is a helper method to define mock.On call
+//   - ctx context.Context
+//   - hrefs []string
+//   - filterOpts RpmListFilters
+func (_e *MockTangy_Expecter) RpmRepositoryVersionPackageAll(ctx any, hrefs any, filterOpts any) *MockTangy_RpmRepositoryVersionPackageAll_Call {
+	return &MockTangy_RpmRepositoryVersionPackageAll_Call{Call: _e.mock.On("RpmRepositoryVersionPackageAll", ctx, hrefs, filterOpts)}
+}
+
+func (_c *MockTangy_RpmRepositoryVersionPackageAll_Call) Run(run func(ctx context.Context, hrefs []string, filterOpts RpmListFilters)) *MockTangy_RpmRepositoryVersionPackageAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
+		var arg2 RpmListFilters
+		if args[2] != nil {
+			arg2 = args[2].(RpmListFilters)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockTangy_RpmRepositoryVersionPackageAll_Call) Return(seq2 iter.Seq2[RpmListItem, error]) *MockTangy_RpmRepositoryVersionPackageAll_Call {
+	_c.Call.Return(seq2)
+	return _c
+}
+
+func (_c *MockTangy_RpmRepositoryVersionPackageAll_Call) RunAndReturn(run func(ctx context.Context, hrefs []string, filterOpts RpmListFilters) iter.Seq2[RpmListItem, error]) *MockTangy_RpmRepositoryVersionPackageAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RpmRepositoryVersionPackageGroupSearch provides a mock function for the type MockTangy
 func (_mock *MockTangy) RpmRepositoryVersionPackageGroupSearch(ctx context.Context, hrefs []string, search string, limit int) ([]RpmPackageGroupSearch, error) {
 	ret := _mock.Called(ctx, hrefs, search, limit)
@@ -1409,37 +2979,29 @@ func (_c *MockTangy_RpmRepositoryVersionPackageGroupSearch_Call) RunAndReturn(ru
 }
 
 // RpmRepositoryVersionPackageList provides a mock function for the type MockTangy
-func (_mock *MockTangy) RpmRepositoryVersionPackageList(ctx context.Context, hrefs []string, filterOpts RpmListFilters, pageOpts PageOptions) ([]RpmListItem, int, error) {
+func (_mock *MockTangy) RpmRepositoryVersionPackageList(ctx context.Context, hrefs []string, filterOpts RpmListFilters, pageOpts PageOptions) (RpmListResponse, error) {
 	ret := _mock.Called(ctx, hrefs, filterOpts, pageOpts)
 
 	if len(ret) == 0 {
 		panic("no return value specified for RpmRepositoryVersionPackageList")
 	}
 
-	var r0 []RpmListItem
-	var r1 int
-	var r2 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, []string, RpmListFilters, PageOptions) ([]RpmListItem, int, error)); ok {
+	var r0 RpmListResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string, RpmListFilters, PageOptions) (RpmListResponse, error)); ok {
 		return returnFunc(ctx, hrefs, filterOpts, pageOpts)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, []string, RpmListFilters, PageOptions) []RpmListItem); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string, RpmListFilters, PageOptions) RpmListResponse); ok {
 		r0 = returnFunc(ctx, hrefs, filterOpts, pageOpts)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]RpmListItem)
-		}
+		r0 = ret.Get(0).(RpmListResponse)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, []string, RpmListFilters, PageOptions) int); ok {
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string, RpmListFilters, PageOptions) error); ok {
 		r1 = returnFunc(ctx, hrefs, filterOpts, pageOpts)
 	} else {
-		r1 = ret.Get(1).(int)
-	}
-	if returnFunc, ok := ret.Get(2).(func(context.Context, []string, RpmListFilters, PageOptions) error); ok {
-		r2 = returnFunc(ctx, hrefs, filterOpts, pageOpts)
-	} else {
-		r2 = ret.Error(2)
+		r1 = ret.Error(1)
 	}
-	return r0, r1, r2
+	return r0, r1
 }
 
 // MockTangy_RpmRepositoryVersionPackageList_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RpmRepositoryVersionPackageList'
@@ -1484,12 +3046,12 @@ func (_c *MockTangy_RpmRepositoryVersionPackageList_Call) Run(run func(ctx conte
 	return _c
 }
 
-func (_c *MockTangy_RpmRepositoryVersionPackageList_Call) Return(rpmListItems []RpmListItem, n int, err error) *MockTangy_RpmRepositoryVersionPackageList_Call {
-	_c.Call.Return(rpmListItems, n, err)
+func (_c *MockTangy_RpmRepositoryVersionPackageList_Call) Return(rpmListResponse RpmListResponse, err error) *MockTangy_RpmRepositoryVersionPackageList_Call {
+	_c.Call.Return(rpmListResponse, err)
 	return _c
 }
 
-func (_c *MockTangy_RpmRepositoryVersionPackageList_Call) RunAndReturn(run func(ctx context.Context, hrefs []string, filterOpts RpmListFilters, pageOpts PageOptions) ([]RpmListItem, int, error)) *MockTangy_RpmRepositoryVersionPackageList_Call {
+func (_c *MockTangy_RpmRepositoryVersionPackageList_Call) RunAndReturn(run func(ctx context.Context, hrefs []string, filterOpts RpmListFilters, pageOpts PageOptions) (RpmListResponse, error)) *MockTangy_RpmRepositoryVersionPackageList_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -1573,3 +3135,47 @@ func (_c *MockTangy_RpmRepositoryVersionPackageSearch_Call) RunAndReturn(run fun
 	_c.Call.Return(run)
 	return _c
 }
+
+// Stats provides a mock function for the type MockTangy
+func (_mock *MockTangy) Stats() Stats {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Stats")
+	}
+
+	var r0 Stats
+	if returnFunc, ok := ret.Get(0).(func() Stats); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(Stats)
+	}
+	return r0
+}
+
+// MockTangy_Stats_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Stats'
+type MockTangy_Stats_Call struct {
+	*mock.Call
+}
+
+// Stats is a helper method to define mock.On call
+func (_e *MockTangy_Expecter) Stats() *MockTangy_Stats_Call {
+	return &MockTangy_Stats_Call{Call: _e.mock.On("Stats")}
+}
+
+func (_c *MockTangy_Stats_Call) Run(run func()) *MockTangy_Stats_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockTangy_Stats_Call) Return(stats Stats) *MockTangy_Stats_Call {
+	_c.Call.Return(stats)
+	return _c
+}
+
+func (_c *MockTangy_Stats_Call) RunAndReturn(run func() Stats) *MockTangy_Stats_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,147 @@
+// Package tangyprom exports the statistics of a Tangy as Prometheus metrics.
+// It is a separate package, so that the tangy package does not depend on the Prometheus client.
+package tangyprom
+
+import (
+	"strings"
+
+	"github.com/content-services/tang/pkg/tangy"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// PrimaryPool is the pool label of the metrics of the connection pool of the primary database.
+// The pools of replicas are labelled with the name of the replica, see tangy.ReplicaStats.
+const PrimaryPool = "primary"
+
+// Membership paths, labelling tangy_membership_calls_total
+const (
+	MembershipContentIds = "content_ids"
+	MembershipLegacy     = "legacy"
+)
+
+var (
+	methodLabels = []string{"method", "ecosystem"}
+
+	callsDesc = prometheus.NewDesc("tangy_calls_total",
+		"Calls of a Tangy method.", methodLabels, nil)
+	retriesDesc = prometheus.NewDesc("tangy_call_retries_total",
+		"Attempts of the calls of a Tangy method retried by the retry policy, after the first attempt.", methodLabels, nil)
+	errorsDesc = prometheus.NewDesc("tangy_call_errors_total",
+		"Calls of a Tangy method that failed, by class of error.", append(methodLabels, "class"), nil)
+	durationDesc = prometheus.NewDesc("tangy_call_duration_seconds",
+		"Duration of the calls of a Tangy method.", methodLabels, nil)
+	rowsDesc = prometheus.NewDesc("tangy_rows_returned_total",
+		"Result rows read by the queries of the calls of a Tangy method.", methodLabels, nil)
+	membershipDesc = prometheus.NewDesc("tangy_membership_calls_total",
+		"Calls of a Tangy method that read repository versions through content_ids, or through core_repositorycontent (legacy).",
+		append(methodLabels, "path"), nil)
+
+	poolConnectionsDesc = prometheus.NewDesc("tangy_pool_connections",
+		"Connections of a pool, by state.", []string{"pool", "state"}, nil)
+	poolMaxConnectionsDesc = prometheus.NewDesc("tangy_pool_max_connections",
+		"Maximum number of connections of a pool.", []string{"pool"}, nil)
+	poolAcquiresDesc = prometheus.NewDesc("tangy_pool_acquires_total",
+		"Connections acquired from a pool.", []string{"pool"}, nil)
+	poolAcquireSecondsDesc = prometheus.NewDesc("tangy_pool_acquire_seconds_total",
+		"Time spent acquiring connections from a pool.", []string{"pool"}, nil)
+	poolWaitsDesc = prometheus.NewDesc("tangy_pool_waits_total",
+		"Acquires that waited for a connection of a pool, as none was idle.", []string{"pool"}, nil)
+	poolWaitSecondsDesc = prometheus.NewDesc("tangy_pool_wait_seconds_total",
+		"Time spent waiting for a connection of a pool, as none was idle.", []string{"pool"}, nil)
+	poolCanceledAcquiresDesc = prometheus.NewDesc("tangy_pool_canceled_acquires_total",
+		"Acquires of a connection of a pool canceled by their context.", []string{"pool"}, nil)
+	poolNewConnectionsDesc = prometheus.NewDesc("tangy_pool_new_connections_total",
+		"Connections established by a pool.", []string{"pool"}, nil)
+	replicaHealthyDesc = prometheus.NewDesc("tangy_replica_healthy",
+		"Whether a replica is healthy, and is read from.", []string{"replica"}, nil)
+)
+
+// Collector collects the statistics returned by the Stats method of a Tangy
+type Collector struct {
+	tangy tangy.Tangy
+}
+
+// NewCollector returns a collector of the statistics of t, to be registered with a Prometheus registry
+func NewCollector(t tangy.Tangy) *Collector {
+	return &Collector{tangy: t}
+}
+
+// Describe implements prometheus.Collector
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range []*prometheus.Desc{
+		callsDesc, retriesDesc, errorsDesc, durationDesc, rowsDesc, membershipDesc,
+		poolConnectionsDesc, poolMaxConnectionsDesc, poolAcquiresDesc, poolAcquireSecondsDesc,
+		poolWaitsDesc, poolWaitSecondsDesc, poolCanceledAcquiresDesc, poolNewConnectionsDesc, replicaHealthyDesc,
+	} {
+		ch <- desc
+	}
+}
+
+// Collect implements prometheus.Collector
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	stats := c.tangy.Stats()
+
+	for method, methodStats := range stats.Methods {
+		labels := []string{method, Ecosystem(method)}
+		ch <- prometheus.MustNewConstMetric(callsDesc, prometheus.CounterValue, float64(methodStats.Calls), labels...)
+		ch <- prometheus.MustNewConstMetric(retriesDesc, prometheus.CounterValue, float64(methodStats.Retries), labels...)
+		for class, count := range methodStats.ErrorsByClass {
+			ch <- prometheus.MustNewConstMetric(errorsDesc, prometheus.CounterValue, float64(count), append(labels, string(class))...)
+		}
+		ch <- durationHistogram(methodStats.Latency, labels)
+		ch <- prometheus.MustNewConstMetric(rowsDesc, prometheus.CounterValue, float64(methodStats.Rows), labels...)
+		ch <- prometheus.MustNewConstMetric(membershipDesc, prometheus.CounterValue, float64(methodStats.ContentIdsCalls),
+			append(labels, MembershipContentIds)...)
+		ch <- prometheus.MustNewConstMetric(membershipDesc, prometheus.CounterValue, float64(methodStats.LegacyCalls),
+			append(labels, MembershipLegacy)...)
+	}
+
+	collectPool(ch, PrimaryPool, stats.Pool)
+	for _, replica := range stats.Replicas {
+		collectPool(ch, replica.Name, replica.Pool)
+		healthy := 0.0
+		if replica.Healthy {
+			healthy = 1
+		}
+		ch <- prometheus.MustNewConstMetric(replicaHealthyDesc, prometheus.GaugeValue, healthy, replica.Name)
+	}
+}
+
+// durationHistogram returns the latency histogram of a method as a Prometheus histogram, whose buckets are cumulative
+func durationHistogram(latency tangy.LatencyHistogram, labels []string) prometheus.Metric {
+	buckets := make(map[float64]uint64, len(latency.Buckets))
+	var count uint64
+	for _, bucket := range latency.Buckets {
+		count += bucket.Count
+		if bucket.UpperBound > 0 {
+			buckets[bucket.UpperBound.Seconds()] = count
+		}
+	}
+	return prometheus.MustNewConstHistogram(durationDesc, count, latency.Sum.Seconds(), buckets, labels...)
+}
+
+func collectPool(ch chan<- prometheus.Metric, pool string, stats tangy.PoolStats) {
+	ch <- prometheus.MustNewConstMetric(poolConnectionsDesc, prometheus.GaugeValue, float64(stats.AcquiredConns), pool, "acquired")
+	ch <- prometheus.MustNewConstMetric(poolConnectionsDesc, prometheus.GaugeValue, float64(stats.IdleConns), pool, "idle")
+	ch <- prometheus.MustNewConstMetric(poolConnectionsDesc, prometheus.GaugeValue, float64(stats.ConstructingConns), pool, "constructing")
+	ch <- prometheus.MustNewConstMetric(poolMaxConnectionsDesc, prometheus.GaugeValue, float64(stats.MaxConns), pool)
+	ch <- prometheus.MustNewConstMetric(poolAcquiresDesc, prometheus.CounterValue, float64(stats.AcquireCount), pool)
+	ch <- prometheus.MustNewConstMetric(poolAcquireSecondsDesc, prometheus.CounterValue, stats.AcquireDuration.Seconds(), pool)
+	ch <- prometheus.MustNewConstMetric(poolWaitsDesc, prometheus.CounterValue, float64(stats.WaitCount), pool)
+	ch <- prometheus.MustNewConstMetric(poolWaitSecondsDesc, prometheus.CounterValue, stats.WaitDuration.Seconds(), pool)
+	ch <- prometheus.MustNewConstMetric(poolCanceledAcquiresDesc, prometheus.CounterValue, float64(stats.CanceledAcquireCount), pool)
+	ch <- prometheus.MustNewConstMetric(poolNewConnectionsDesc, prometheus.CounterValue, float64(stats.NewConnsCount), pool)
+}
+
+// ecosystems are the prefixes of the names of the Tangy methods, by ecosystem label
+var ecosystems = []string{"Rpm", "Python", "Maven", "Npm"}
+
+// Ecosystem returns the ecosystem label of a Tangy method: rpm, python, maven or npm
+func Ecosystem(method string) string {
+	for _, ecosystem := range ecosystems {
+		if strings.HasPrefix(method, ecosystem) {
+			return strings.ToLower(ecosystem)
+		}
+	}
+	return "other"
+}
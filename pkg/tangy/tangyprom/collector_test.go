@@ -0,0 +1,117 @@
+package tangyprom
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/content-services/tang/pkg/tangy"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testStats() tangy.Stats {
+	return tangy.Stats{
+		Pool: tangy.PoolStats{MaxConns: 20, AcquiredConns: 3, IdleConns: 2, AcquireCount: 40, WaitCount: 5, WaitDuration: 1500 * time.Millisecond},
+		Replicas: []tangy.ReplicaStats{
+			{Name: "replica:5432", Healthy: true, Pool: tangy.PoolStats{MaxConns: 10}},
+		},
+		Methods: map[string]tangy.MethodStats{
+			"RpmRepositoryVersionErrataList": {
+				Calls:           3,
+				Errors:          1,
+				Retries:         1,
+				ErrorsByClass:   map[tangy.ErrorClass]uint64{tangy.ErrorClassTimeout: 1},
+				Rows:            42,
+				ContentIdsCalls: 2,
+				LegacyCalls:     1,
+				Latency: tangy.LatencyHistogram{
+					Buckets: []tangy.LatencyBucket{
+						{UpperBound: 10 * time.Millisecond, Count: 1},
+						{UpperBound: time.Second, Count: 1},
+						{Count: 1},
+					},
+					Sum: 30 * time.Second,
+				},
+			},
+		},
+	}
+}
+
+func TestCollector(t *testing.T) {
+	t.Parallel()
+
+	ta := tangy.NewMockTangy(t)
+	ta.On("Stats").Return(testStats())
+	collector := NewCollector(ta)
+
+	expected := `
+# HELP tangy_call_duration_seconds Duration of the calls of a Tangy method.
+# TYPE tangy_call_duration_seconds histogram
+tangy_call_duration_seconds_bucket{ecosystem="rpm",method="RpmRepositoryVersionErrataList",le="0.01"} 1
+tangy_call_duration_seconds_bucket{ecosystem="rpm",method="RpmRepositoryVersionErrataList",le="1"} 2
+tangy_call_duration_seconds_bucket{ecosystem="rpm",method="RpmRepositoryVersionErrataList",le="+Inf"} 3
+tangy_call_duration_seconds_sum{ecosystem="rpm",method="RpmRepositoryVersionErrataList"} 30
+tangy_call_duration_seconds_count{ecosystem="rpm",method="RpmRepositoryVersionErrataList"} 3
+# HELP tangy_call_errors_total Calls of a Tangy method that failed, by class of error.
+# TYPE tangy_call_errors_total counter
+tangy_call_errors_total{class="timeout",ecosystem="rpm",method="RpmRepositoryVersionErrataList"} 1
+# HELP tangy_call_retries_total Attempts of the calls of a Tangy method retried by the retry policy, after the first attempt.
+# TYPE tangy_call_retries_total counter
+tangy_call_retries_total{ecosystem="rpm",method="RpmRepositoryVersionErrataList"} 1
+# HELP tangy_membership_calls_total Calls of a Tangy method that read repository versions through content_ids, or through core_repositorycontent (legacy).
+# TYPE tangy_membership_calls_total counter
+tangy_membership_calls_total{ecosystem="rpm",method="RpmRepositoryVersionErrataList",path="content_ids"} 2
+tangy_membership_calls_total{ecosystem="rpm",method="RpmRepositoryVersionErrataList",path="legacy"} 1
+# HELP tangy_pool_wait_seconds_total Time spent waiting for a connection of a pool, as none was idle.
+# TYPE tangy_pool_wait_seconds_total counter
+tangy_pool_wait_seconds_total{pool="primary"} 1.5
+tangy_pool_wait_seconds_total{pool="replica:5432"} 0
+# HELP tangy_replica_healthy Whether a replica is healthy, and is read from.
+# TYPE tangy_replica_healthy gauge
+tangy_replica_healthy{replica="replica:5432"} 1
+# HELP tangy_rows_returned_total Result rows read by the queries of the calls of a Tangy method.
+# TYPE tangy_rows_returned_total counter
+tangy_rows_returned_total{ecosystem="rpm",method="RpmRepositoryVersionErrataList"} 42
+`
+	require.NoError(t, testutil.CollectAndCompare(collector, strings.NewReader(expected),
+		"tangy_call_duration_seconds", "tangy_call_errors_total", "tangy_call_retries_total", "tangy_membership_calls_total",
+		"tangy_pool_wait_seconds_total", "tangy_replica_healthy", "tangy_rows_returned_total"))
+
+	problems, err := testutil.CollectAndLint(collector)
+	require.NoError(t, err)
+	assert.Empty(t, problems)
+}
+
+func TestCollectorDSNReplicas(t *testing.T) {
+	t.Parallel()
+
+	ta, err := tangy.New(tangy.Database{DSN: "postgres://pulp@127.0.0.1:1/pulp", Replicas: []tangy.Database{
+		{DSN: "postgres://reader@127.0.0.1:2/pulp"},
+		{DSN: "postgres://reader@127.0.0.1:3/pulp"},
+	}}, tangy.Logger{})
+	require.NoError(t, err)
+	t.Cleanup(ta.Close)
+
+	// Every replica has a series of its own
+	replicas := ta.Stats().Replicas
+	require.Len(t, replicas, 2)
+	assert.NotEqual(t, replicas[0].Name, replicas[1].Name)
+
+	registry := prometheus.NewPedanticRegistry()
+	registry.MustRegister(NewCollector(ta))
+	_, err = registry.Gather()
+	assert.NoError(t, err)
+}
+
+func TestEcosystem(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "rpm", Ecosystem("RpmRepositoryVersionPackageSearch"))
+	assert.Equal(t, "python", Ecosystem("PythonPackageList"))
+	assert.Equal(t, "maven", Ecosystem("MavenRepositoryMetrics"))
+	assert.Equal(t, "npm", Ecosystem("NpmBuildList"))
+	assert.Equal(t, "other", Ecosystem("Stats"))
+}
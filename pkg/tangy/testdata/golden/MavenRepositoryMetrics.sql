@@ -0,0 +1,58 @@
+-- query 1
+SELECT
+			(SELECT COUNT(DISTINCT (rp.group_id, rp.artifact_id))
+			FROM maven_mavenartifact rp
+                INNER JOIN (
+                    SELECT DISTINCT membership.repository_id, membership.content_id
+                    FROM (
+                        SELECT crv.repository_id, UNNEST(crv.content_ids) AS content_id
+                        FROM UNNEST(@repoIds0::uuid[], @versionNums0::integer[]) AS requested(repository_id, number)
+                        INNER JOIN core_repositoryversion crv ON (crv.repository_id = requested.repository_id AND crv.number = requested.number)
+                        WHERE crv.content_ids IS NOT NULL
+	) membership
+                ) crv ON (rp.content_ptr_id = crv.content_id)
+                WHERE
+                    (TRUE)
+	
+		AND rp.filename LIKE '%.jar') AS package_count,
+			(SELECT COUNT(DISTINCT (rp.group_id, rp.artifact_id, rp.version))
+			FROM maven_mavenartifact rp
+                INNER JOIN (
+                    SELECT DISTINCT membership.repository_id, membership.content_id
+                    FROM (
+                        SELECT crv.repository_id, UNNEST(crv.content_ids) AS content_id
+                        FROM UNNEST(@repoIds1::uuid[], @versionNums1::integer[]) AS requested(repository_id, number)
+                        INNER JOIN core_repositoryversion crv ON (crv.repository_id = requested.repository_id AND crv.number = requested.number)
+                        WHERE crv.content_ids IS NOT NULL
+	) membership
+                ) crv ON (rp.content_ptr_id = crv.content_id)
+                WHERE
+                    (TRUE)
+	
+		AND rp.filename LIKE '%.jar') AS build_count,
+			(SELECT COUNT(DISTINCT (
+				rp.group_id,
+				rp.artifact_id,
+				regexp_replace(rp.version, '\.[a-zA-Z]+-\d+$', '')
+			))
+			FROM maven_mavenartifact rp
+                INNER JOIN (
+                    SELECT DISTINCT membership.repository_id, membership.content_id
+                    FROM (
+                        SELECT crv.repository_id, UNNEST(crv.content_ids) AS content_id
+                        FROM UNNEST(@repoIds2::uuid[], @versionNums2::integer[]) AS requested(repository_id, number)
+                        INNER JOIN core_repositoryversion crv ON (crv.repository_id = requested.repository_id AND crv.number = requested.number)
+                        WHERE crv.content_ids IS NOT NULL
+	) membership
+                ) crv ON (rp.content_ptr_id = crv.content_id)
+                WHERE
+                    (TRUE)
+	
+		AND rp.filename LIKE '%.jar') AS version_count
+-- @repoIds0 = ["018c1c95-4281-76eb-b277-842cbad524f4"]
+-- @repoIds1 = ["018c1c95-4281-76eb-b277-842cbad524f4"]
+-- @repoIds2 = ["018c1c95-4281-76eb-b277-842cbad524f4"]
+-- @versionNums0 = [3]
+-- @versionNums1 = [3]
+-- @versionNums2 = [3]
+
@@ -0,0 +1,117 @@
+-- query 1
+SELECT COUNT(*) AS total
+		FROM (
+		SELECT DISTINCT rp.group_id, rp.artifact_id
+		FROM maven_mavenartifact rp
+                INNER JOIN (
+                    SELECT DISTINCT membership.repository_id, membership.content_id
+                    FROM (
+                        SELECT crv.repository_id, UNNEST(crv.content_ids) AS content_id
+                        FROM UNNEST(@repoIds0::uuid[], @versionNums0::integer[]) AS requested(repository_id, number)
+                        INNER JOIN core_repositoryversion crv ON (crv.repository_id = requested.repository_id AND crv.number = requested.number)
+                        WHERE crv.content_ids IS NOT NULL
+	 UNION ALL 
+                        SELECT crv.repository_id, crc.content_id
+                        FROM UNNEST(@repoIds1::uuid[], @versionNums1::integer[]) AS requested(repository_id, number)
+                        INNER JOIN core_repositoryversion crv ON (crv.repository_id = requested.repository_id AND crv.number <= requested.number)
+                        INNER JOIN core_repositorycontent crc ON (crc.version_added_id = crv.pulp_id)
+                        LEFT OUTER JOIN core_repositoryversion crv2 ON (crc.version_removed_id = crv2.pulp_id)
+                        WHERE NOT (crv2.number <= requested.number AND crv2.number IS NOT NULL)
+	) membership
+                ) crv ON (rp.content_ptr_id = crv.content_id)
+                WHERE
+                    (TRUE)
+	
+		AND rp.filename LIKE '%.pom'
+		LIMIT @countLimit
+		) counted
+-- @countLimit = 10001
+-- @repoIds0 = ["018c1c95-4281-76eb-b277-842cbad524f4"]
+-- @repoIds1 = ["019f3808-fcc2-716e-a7d3-e5a7ef1522a0"]
+-- @versionNums0 = [3]
+-- @versionNums1 = [1]
+
+-- query 2
+WITH package_versions AS (
+			SELECT
+				rp.group_id,
+				rp.artifact_id,
+				regexp_replace(rp.version, '\.[a-zA-Z]+-\d+$', '') as base_version,
+				rp.filename,
+				cc.pulp_created,
+				crv.repository_id,
+				ROW_NUMBER() OVER (PARTITION BY rp.group_id, rp.artifact_id, regexp_replace(rp.version, '\.[a-zA-Z]+-\d+$', '') ORDER BY cc.pulp_created DESC) as rn
+			FROM maven_mavenartifact rp
+			INNER JOIN core_content cc ON rp.content_ptr_id = cc.pulp_id
+                INNER JOIN (
+                    SELECT DISTINCT membership.repository_id, membership.content_id
+                    FROM (
+                        SELECT crv.repository_id, UNNEST(crv.content_ids) AS content_id
+                        FROM UNNEST(@repoIds0::uuid[], @versionNums0::integer[]) AS requested(repository_id, number)
+                        INNER JOIN core_repositoryversion crv ON (crv.repository_id = requested.repository_id AND crv.number = requested.number)
+                        WHERE crv.content_ids IS NOT NULL
+	 UNION ALL 
+                        SELECT crv.repository_id, crc.content_id
+                        FROM UNNEST(@repoIds1::uuid[], @versionNums1::integer[]) AS requested(repository_id, number)
+                        INNER JOIN core_repositoryversion crv ON (crv.repository_id = requested.repository_id AND crv.number <= requested.number)
+                        INNER JOIN core_repositorycontent crc ON (crc.version_added_id = crv.pulp_id)
+                        LEFT OUTER JOIN core_repositoryversion crv2 ON (crc.version_removed_id = crv2.pulp_id)
+                        WHERE NOT (crv2.number <= requested.number AND crv2.number IS NOT NULL)
+	) membership
+                ) crv ON (rp.content_ptr_id = crv.content_id)
+                WHERE
+                    (TRUE)
+	
+		AND rp.filename LIKE '%.pom'
+		),
+		latest_per_version AS (
+			SELECT
+				group_id,
+				artifact_id,
+				base_version,
+				filename,
+				pulp_created
+			FROM package_versions
+			WHERE rn = 1
+		),
+		packages AS (
+			SELECT
+				group_id,
+				artifact_id,
+				ARRAY_AGG(DISTINCT base_version ORDER BY base_version) as versions,
+				ARRAY_AGG(DISTINCT repository_id) as repository_ids
+			FROM package_versions
+		WHERE (group_id, artifact_id) > (@cursorKey0::text, @cursorKey1::text)
+			GROUP BY group_id, artifact_id
+			ORDER BY group_id ASC, artifact_id ASC
+			LIMIT @limit OFFSET @offset
+		)
+		SELECT
+			p.group_id,
+			p.artifact_id,
+			p.versions,
+			p.repository_ids,
+			COALESCE(
+				JSON_AGG(
+					JSON_BUILD_OBJECT(
+						'version', lpv.base_version,
+						'release', '',
+						'filename', lpv.filename,
+						'created_at', lpv.pulp_created
+					) ORDER BY lpv.base_version
+				) FILTER (WHERE lpv.base_version IS NOT NULL),
+				'[]'::json
+			) as latest_releases_json
+		FROM packages p
+		LEFT JOIN latest_per_version lpv ON p.group_id = lpv.group_id AND p.artifact_id = lpv.artifact_id
+		GROUP BY p.group_id, p.artifact_id, p.versions, p.repository_ids
+		ORDER BY p.group_id, p.artifact_id
+-- @cursorKey0 = "io.vertx"
+-- @cursorKey1 = "vertx-core"
+-- @limit = 10
+-- @offset = 0
+-- @repoIds0 = ["018c1c95-4281-76eb-b277-842cbad524f4"]
+-- @repoIds1 = ["019f3808-fcc2-716e-a7d3-e5a7ef1522a0"]
+-- @versionNums0 = [3]
+-- @versionNums1 = [1]
+
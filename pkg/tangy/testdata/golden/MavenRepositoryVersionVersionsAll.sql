@@ -0,0 +1,77 @@
+-- query 1
+DECLARE tangy_iterator NO SCROLL CURSOR FOR 
+		WITH version_builds AS (
+			SELECT
+				rp.content_ptr_id,
+				crv.repository_id,
+				rp.group_id,
+				rp.artifact_id,
+				regexp_replace(rp.version, '\.[a-zA-Z]+-\d+$', '') as base_version,
+				rp.filename,
+				cc.pulp_created as created_at
+			FROM maven_mavenartifact rp
+			INNER JOIN core_content cc ON rp.content_ptr_id = cc.pulp_id
+                INNER JOIN (
+                    SELECT DISTINCT membership.repository_id, membership.content_id
+                    FROM (
+                        SELECT crv.repository_id, UNNEST(crv.content_ids) AS content_id
+                        FROM UNNEST(@repoIds0::uuid[], @versionNums0::integer[]) AS requested(repository_id, number)
+                        INNER JOIN core_repositoryversion crv ON (crv.repository_id = requested.repository_id AND crv.number = requested.number)
+                        WHERE crv.content_ids IS NOT NULL
+	 UNION ALL 
+                        SELECT crv.repository_id, crc.content_id
+                        FROM UNNEST(@repoIds1::uuid[], @versionNums1::integer[]) AS requested(repository_id, number)
+                        INNER JOIN core_repositoryversion crv ON (crv.repository_id = requested.repository_id AND crv.number <= requested.number)
+                        INNER JOIN core_repositorycontent crc ON (crc.version_added_id = crv.pulp_id)
+                        LEFT OUTER JOIN core_repositoryversion crv2 ON (crc.version_removed_id = crv2.pulp_id)
+                        WHERE NOT (crv2.number <= requested.number AND crv2.number IS NOT NULL)
+	) membership
+                ) crv ON (rp.content_ptr_id = crv.content_id)
+                WHERE
+                    (TRUE)
+	
+		AND rp.group_id = @group_id
+		AND rp.filename LIKE '%.pom'
+		),
+		distinct_versions AS (
+			SELECT
+				group_id,
+				artifact_id,
+				base_version,
+				MAX(created_at) as latest_created_at,
+				ARRAY_AGG(DISTINCT repository_id) as repository_ids
+			FROM version_builds
+			GROUP BY group_id, artifact_id, base_version
+			ORDER BY MAX(created_at) DESC, group_id DESC, artifact_id DESC, base_version DESC
+			
+		),
+		builds AS (
+			SELECT DISTINCT content_ptr_id, group_id, artifact_id, base_version, filename, created_at
+			FROM version_builds
+		)
+		SELECT
+			dv.group_id,
+			dv.artifact_id,
+			dv.base_version as version,
+			dv.latest_created_at,
+			dv.repository_ids,
+			COALESCE(
+				JSON_AGG(
+					JSON_BUILD_OBJECT(
+						'version', vb.base_version,
+						'filename', vb.filename,
+						'created_at', vb.created_at
+					) ORDER BY vb.created_at DESC
+				),
+				'[]'::json
+			) as builds_json
+		FROM distinct_versions dv
+		INNER JOIN builds vb ON dv.group_id = vb.group_id AND dv.artifact_id = vb.artifact_id AND dv.base_version = vb.base_version
+		GROUP BY dv.group_id, dv.artifact_id, dv.base_version, dv.latest_created_at, dv.repository_ids
+		ORDER BY dv.latest_created_at DESC, dv.group_id DESC, dv.artifact_id DESC, dv.base_version DESC
+-- @group_id = "io.vertx"
+-- @repoIds0 = ["018c1c95-4281-76eb-b277-842cbad524f4"]
+-- @repoIds1 = ["019f3808-fcc2-716e-a7d3-e5a7ef1522a0"]
+-- @versionNums0 = [3]
+-- @versionNums1 = [1]
+
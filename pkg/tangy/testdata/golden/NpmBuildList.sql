@@ -0,0 +1,55 @@
+-- query 1
+SELECT COUNT(*) AS total
+		FROM (
+		SELECT rp.name, rp.version
+		FROM npm_package rp
+                INNER JOIN (
+                    SELECT DISTINCT membership.repository_id, membership.content_id
+                    FROM (
+                        SELECT crv.repository_id, UNNEST(crv.content_ids) AS content_id
+                        FROM UNNEST(@repoIds0::uuid[], @versionNums0::integer[]) AS requested(repository_id, number)
+                        INNER JOIN core_repositoryversion crv ON (crv.repository_id = requested.repository_id AND crv.number = requested.number)
+                        WHERE crv.content_ids IS NOT NULL
+	) membership
+                ) crv ON (rp.content_ptr_id = crv.content_id)
+                WHERE
+                    (TRUE)
+	
+		AND rp.name = @name
+		AND rp.version = @version
+		GROUP BY rp.name, rp.version
+		) counted
+-- @name = "is-odd"
+-- @repoIds0 = ["018c1c95-4281-76eb-b277-842cbad524f4"]
+-- @version = "3.0.1"
+-- @versionNums0 = [3]
+
+-- query 2
+SELECT rp.name, rp.version, MAX(cc.pulp_created) AS created_at,
+		       ARRAY_AGG(DISTINCT crv.repository_id) AS repository_ids
+		FROM npm_package rp
+		INNER JOIN core_content cc ON rp.content_ptr_id = cc.pulp_id
+                INNER JOIN (
+                    SELECT DISTINCT membership.repository_id, membership.content_id
+                    FROM (
+                        SELECT crv.repository_id, UNNEST(crv.content_ids) AS content_id
+                        FROM UNNEST(@repoIds0::uuid[], @versionNums0::integer[]) AS requested(repository_id, number)
+                        INNER JOIN core_repositoryversion crv ON (crv.repository_id = requested.repository_id AND crv.number = requested.number)
+                        WHERE crv.content_ids IS NOT NULL
+	) membership
+                ) crv ON (rp.content_ptr_id = crv.content_id)
+                WHERE
+                    (TRUE)
+	
+		AND rp.name = @name
+		AND rp.version = @version
+		GROUP BY rp.name, rp.version
+		ORDER BY MAX(cc.pulp_created) DESC, rp.name DESC, rp.version DESC
+		LIMIT @limit OFFSET @offset
+-- @limit = 10
+-- @name = "is-odd"
+-- @offset = 20
+-- @repoIds0 = ["018c1c95-4281-76eb-b277-842cbad524f4"]
+-- @version = "3.0.1"
+-- @versionNums0 = [3]
+
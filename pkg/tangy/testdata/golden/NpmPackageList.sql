@@ -0,0 +1,77 @@
+-- query 1
+SELECT COUNT(*) AS total
+		FROM (
+		SELECT DISTINCT rp.name
+		FROM npm_package rp
+                INNER JOIN (
+                    SELECT DISTINCT membership.repository_id, membership.content_id
+                    FROM (
+                        SELECT crv.repository_id, UNNEST(crv.content_ids) AS content_id
+                        FROM UNNEST(@repoIds0::uuid[], @versionNums0::integer[]) AS requested(repository_id, number)
+                        INNER JOIN core_repositoryversion crv ON (crv.repository_id = requested.repository_id AND crv.number = requested.number)
+                        WHERE crv.content_ids IS NOT NULL
+	) membership
+                ) crv ON (rp.content_ptr_id = crv.content_id)
+                WHERE
+                    (TRUE)
+	
+		AND (
+			split_part(rp.name, '/', 1) ILIKE CONCAT(@searchFilter::text, '%')
+			OR (
+				POSITION('/' IN rp.name) > 0
+				AND split_part(rp.name, '/', 2) ILIKE CONCAT(@searchFilter::text, '%')
+			)
+		)
+		) counted
+-- @repoIds0 = ["018c1c95-4281-76eb-b277-842cbad524f4"]
+-- @searchFilter = "is-"
+-- @versionNums0 = [3]
+
+-- query 2
+WITH filtered AS (
+			SELECT rp.name, rp.version, cc.pulp_created, crv.repository_id
+			FROM npm_package rp
+			INNER JOIN core_content cc ON rp.content_ptr_id = cc.pulp_id
+                INNER JOIN (
+                    SELECT DISTINCT membership.repository_id, membership.content_id
+                    FROM (
+                        SELECT crv.repository_id, UNNEST(crv.content_ids) AS content_id
+                        FROM UNNEST(@repoIds0::uuid[], @versionNums0::integer[]) AS requested(repository_id, number)
+                        INNER JOIN core_repositoryversion crv ON (crv.repository_id = requested.repository_id AND crv.number = requested.number)
+                        WHERE crv.content_ids IS NOT NULL
+	) membership
+                ) crv ON (rp.content_ptr_id = crv.content_id)
+                WHERE
+                    (TRUE)
+	
+		AND (
+			split_part(rp.name, '/', 1) ILIKE CONCAT(@searchFilter::text, '%')
+			OR (
+				POSITION('/' IN rp.name) > 0
+				AND split_part(rp.name, '/', 2) ILIKE CONCAT(@searchFilter::text, '%')
+			)
+		)
+		),
+		package_versions AS (
+			SELECT name, version, MAX(pulp_created) AS created_at,
+			       ARRAY_AGG(DISTINCT repository_id) AS repository_ids
+			FROM filtered
+			GROUP BY name, version
+		),
+		paginated_packages AS (
+			SELECT name
+			FROM package_versions
+			GROUP BY name
+			ORDER BY name ASC
+			LIMIT @limit OFFSET @offset
+		)
+		SELECT pv.name, pv.version, pv.created_at, pv.repository_ids
+		FROM package_versions pv
+		INNER JOIN paginated_packages pp ON pv.name = pp.name
+		ORDER BY pv.name, pv.version
+-- @limit = 10
+-- @offset = 20
+-- @repoIds0 = ["018c1c95-4281-76eb-b277-842cbad524f4"]
+-- @searchFilter = "is-"
+-- @versionNums0 = [3]
+
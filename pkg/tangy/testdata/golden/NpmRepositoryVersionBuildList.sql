@@ -0,0 +1,71 @@
+-- query 1
+SELECT COUNT(*) AS total
+		FROM (
+		SELECT rp.name, rp.version
+		FROM npm_package rp
+                INNER JOIN (
+                    SELECT DISTINCT membership.repository_id, membership.content_id
+                    FROM (
+                        SELECT crv.repository_id, UNNEST(crv.content_ids) AS content_id
+                        FROM UNNEST(@repoIds0::uuid[], @versionNums0::integer[]) AS requested(repository_id, number)
+                        INNER JOIN core_repositoryversion crv ON (crv.repository_id = requested.repository_id AND crv.number = requested.number)
+                        WHERE crv.content_ids IS NOT NULL
+	 UNION ALL 
+                        SELECT crv.repository_id, crc.content_id
+                        FROM UNNEST(@repoIds1::uuid[], @versionNums1::integer[]) AS requested(repository_id, number)
+                        INNER JOIN core_repositoryversion crv ON (crv.repository_id = requested.repository_id AND crv.number <= requested.number)
+                        INNER JOIN core_repositorycontent crc ON (crc.version_added_id = crv.pulp_id)
+                        LEFT OUTER JOIN core_repositoryversion crv2 ON (crc.version_removed_id = crv2.pulp_id)
+                        WHERE NOT (crv2.number <= requested.number AND crv2.number IS NOT NULL)
+	) membership
+                ) crv ON (rp.content_ptr_id = crv.content_id)
+                WHERE
+                    (TRUE)
+	
+		GROUP BY rp.name, rp.version
+		LIMIT @countLimit
+		) counted
+-- @countLimit = 10001
+-- @repoIds0 = ["018c1c95-4281-76eb-b277-842cbad524f4"]
+-- @repoIds1 = ["019f3808-fcc2-716e-a7d3-e5a7ef1522a0"]
+-- @versionNums0 = [3]
+-- @versionNums1 = [1]
+
+-- query 2
+SELECT rp.name, rp.version, MAX(cc.pulp_created) AS created_at,
+		       ARRAY_AGG(DISTINCT crv.repository_id) AS repository_ids
+		FROM npm_package rp
+		INNER JOIN core_content cc ON rp.content_ptr_id = cc.pulp_id
+                INNER JOIN (
+                    SELECT DISTINCT membership.repository_id, membership.content_id
+                    FROM (
+                        SELECT crv.repository_id, UNNEST(crv.content_ids) AS content_id
+                        FROM UNNEST(@repoIds0::uuid[], @versionNums0::integer[]) AS requested(repository_id, number)
+                        INNER JOIN core_repositoryversion crv ON (crv.repository_id = requested.repository_id AND crv.number = requested.number)
+                        WHERE crv.content_ids IS NOT NULL
+	 UNION ALL 
+                        SELECT crv.repository_id, crc.content_id
+                        FROM UNNEST(@repoIds1::uuid[], @versionNums1::integer[]) AS requested(repository_id, number)
+                        INNER JOIN core_repositoryversion crv ON (crv.repository_id = requested.repository_id AND crv.number <= requested.number)
+                        INNER JOIN core_repositorycontent crc ON (crc.version_added_id = crv.pulp_id)
+                        LEFT OUTER JOIN core_repositoryversion crv2 ON (crc.version_removed_id = crv2.pulp_id)
+                        WHERE NOT (crv2.number <= requested.number AND crv2.number IS NOT NULL)
+	) membership
+                ) crv ON (rp.content_ptr_id = crv.content_id)
+                WHERE
+                    (TRUE)
+	
+		GROUP BY rp.name, rp.version
+		HAVING (MAX(cc.pulp_created), rp.name, rp.version) < (@cursorKey0::timestamptz, @cursorKey1::text, @cursorKey2::text)
+		ORDER BY MAX(cc.pulp_created) DESC, rp.name DESC, rp.version DESC
+		LIMIT @limit OFFSET @offset
+-- @cursorKey0 = "2024-01-01T00:00:00Z"
+-- @cursorKey1 = "is-odd"
+-- @cursorKey2 = "3.0.1"
+-- @limit = 10
+-- @offset = 0
+-- @repoIds0 = ["018c1c95-4281-76eb-b277-842cbad524f4"]
+-- @repoIds1 = ["019f3808-fcc2-716e-a7d3-e5a7ef1522a0"]
+-- @versionNums0 = [3]
+-- @versionNums1 = [1]
+
@@ -0,0 +1,48 @@
+-- query 1
+DECLARE tangy_iterator NO SCROLL CURSOR FOR 
+		WITH filtered AS (
+			SELECT rp.name, rp.version, cc.pulp_created, crv.repository_id
+			FROM npm_package rp
+			INNER JOIN core_content cc ON rp.content_ptr_id = cc.pulp_id
+                INNER JOIN (
+                    SELECT DISTINCT membership.repository_id, membership.content_id
+                    FROM (
+                        SELECT crv.repository_id, UNNEST(crv.content_ids) AS content_id
+                        FROM UNNEST(@repoIds0::uuid[], @versionNums0::integer[]) AS requested(repository_id, number)
+                        INNER JOIN core_repositoryversion crv ON (crv.repository_id = requested.repository_id AND crv.number = requested.number)
+                        WHERE crv.content_ids IS NOT NULL
+	 UNION ALL 
+                        SELECT crv.repository_id, crc.content_id
+                        FROM UNNEST(@repoIds1::uuid[], @versionNums1::integer[]) AS requested(repository_id, number)
+                        INNER JOIN core_repositoryversion crv ON (crv.repository_id = requested.repository_id AND crv.number <= requested.number)
+                        INNER JOIN core_repositorycontent crc ON (crc.version_added_id = crv.pulp_id)
+                        LEFT OUTER JOIN core_repositoryversion crv2 ON (crc.version_removed_id = crv2.pulp_id)
+                        WHERE NOT (crv2.number <= requested.number AND crv2.number IS NOT NULL)
+	) membership
+                ) crv ON (rp.content_ptr_id = crv.content_id)
+                WHERE
+                    (TRUE)
+	
+		),
+		package_versions AS (
+			SELECT name, version, MAX(pulp_created) AS created_at,
+			       ARRAY_AGG(DISTINCT repository_id) AS repository_ids
+			FROM filtered
+			GROUP BY name, version
+		),
+		paginated_packages AS (
+			SELECT name
+			FROM package_versions
+			GROUP BY name
+			ORDER BY name ASC
+			
+		)
+		SELECT pv.name, pv.version, pv.created_at, pv.repository_ids
+		FROM package_versions pv
+		INNER JOIN paginated_packages pp ON pv.name = pp.name
+		ORDER BY pv.name, pv.version
+-- @repoIds0 = ["018c1c95-4281-76eb-b277-842cbad524f4"]
+-- @repoIds1 = ["019f3808-fcc2-716e-a7d3-e5a7ef1522a0"]
+-- @versionNums0 = [3]
+-- @versionNums1 = [1]
+
@@ -0,0 +1,65 @@
+-- query 1
+WITH filtered AS (
+			SELECT rp.content_ptr_id, rp.name, rp.version, cc.pulp_created,
+			       cca.relative_path, ca.sha256, ca.size, crv.repository_id
+			FROM npm_package rp
+			INNER JOIN core_content cc ON rp.content_ptr_id = cc.pulp_id
+			LEFT JOIN core_contentartifact cca ON cca.content_id = rp.content_ptr_id
+			LEFT JOIN core_artifact ca ON ca.pulp_id = cca.artifact_id
+                INNER JOIN (
+                    SELECT DISTINCT membership.repository_id, membership.content_id
+                    FROM (
+                        SELECT crv.repository_id, UNNEST(crv.content_ids) AS content_id
+                        FROM UNNEST(@repoIds0::uuid[], @versionNums0::integer[]) AS requested(repository_id, number)
+                        INNER JOIN core_repositoryversion crv ON (crv.repository_id = requested.repository_id AND crv.number = requested.number)
+                        WHERE crv.content_ids IS NOT NULL
+	 UNION ALL 
+                        SELECT crv.repository_id, crc.content_id
+                        FROM UNNEST(@repoIds1::uuid[], @versionNums1::integer[]) AS requested(repository_id, number)
+                        INNER JOIN core_repositoryversion crv ON (crv.repository_id = requested.repository_id AND crv.number <= requested.number)
+                        INNER JOIN core_repositorycontent crc ON (crc.version_added_id = crv.pulp_id)
+                        LEFT OUTER JOIN core_repositoryversion crv2 ON (crc.version_removed_id = crv2.pulp_id)
+                        WHERE NOT (crv2.number <= requested.number AND crv2.number IS NOT NULL)
+	) membership
+                ) crv ON (rp.content_ptr_id = crv.content_id)
+                WHERE
+                    (TRUE)
+	
+		AND rp.name = @name
+		),
+		version_agg AS (
+			SELECT
+				ARRAY_AGG(version ORDER BY version) AS versions,
+				COALESCE(
+					JSON_AGG(
+						JSON_BUILD_OBJECT('version', version, 'created_at', created_at)
+						ORDER BY version
+					),
+					'[]'::json
+				) AS latest_versions_json
+			FROM (
+				SELECT version, MAX(pulp_created) AS created_at
+				FROM filtered
+				GROUP BY version
+			) v
+		),
+		detail AS (
+			SELECT f.content_ptr_id, f.name, f.version, f.pulp_created,
+			       f.relative_path, f.sha256, f.size,
+			       ARRAY_AGG(DISTINCT f.repository_id) AS repository_ids
+			FROM filtered f
+			GROUP BY f.content_ptr_id, f.name, f.version, f.pulp_created,
+			         f.relative_path, f.sha256, f.size
+		)
+		SELECT d.name, d.version, d.pulp_created AS created_at,
+		       d.relative_path, d.sha256, d.size,
+		       va.versions, va.latest_versions_json, d.repository_ids
+		FROM detail d
+		CROSS JOIN version_agg va
+		ORDER BY d.version
+-- @name = "is-odd"
+-- @repoIds0 = ["018c1c95-4281-76eb-b277-842cbad524f4"]
+-- @repoIds1 = ["019f3808-fcc2-716e-a7d3-e5a7ef1522a0"]
+-- @versionNums0 = [3]
+-- @versionNums1 = [1]
+
@@ -0,0 +1,55 @@
+-- query 1
+SELECT COUNT(*) AS total
+		FROM (
+		SELECT DISTINCT rp.content_ptr_id
+		FROM python_pythonpackagecontent rp
+                INNER JOIN (
+                    SELECT DISTINCT membership.repository_id, membership.content_id
+                    FROM (
+                        SELECT crv.repository_id, UNNEST(crv.content_ids) AS content_id
+                        FROM UNNEST(@repoIds0::uuid[], @versionNums0::integer[]) AS requested(repository_id, number)
+                        INNER JOIN core_repositoryversion crv ON (crv.repository_id = requested.repository_id AND crv.number = requested.number)
+                        WHERE crv.content_ids IS NOT NULL
+	) membership
+                ) crv ON (rp.content_ptr_id = crv.content_id)
+                WHERE
+                    (TRUE)
+	
+		AND rp.name_normalized = @name_normalized
+		AND rp.version = @version
+		) counted
+-- @name_normalized = "shelf-reader"
+-- @repoIds0 = ["018c1c95-4281-76eb-b277-842cbad524f4"]
+-- @version = "0.1"
+-- @versionNums0 = [3]
+
+-- query 2
+SELECT rp.content_ptr_id, rp.name, rp.name_normalized, rp.version, rp.filename, rp.packagetype,
+		       rp.python_version, rp.sha256, rp.size, cc.pulp_created AS created_at,
+		       ARRAY_AGG(DISTINCT crv.repository_id) AS repository_ids
+		FROM python_pythonpackagecontent rp
+		INNER JOIN core_content cc ON rp.content_ptr_id = cc.pulp_id
+                INNER JOIN (
+                    SELECT DISTINCT membership.repository_id, membership.content_id
+                    FROM (
+                        SELECT crv.repository_id, UNNEST(crv.content_ids) AS content_id
+                        FROM UNNEST(@repoIds0::uuid[], @versionNums0::integer[]) AS requested(repository_id, number)
+                        INNER JOIN core_repositoryversion crv ON (crv.repository_id = requested.repository_id AND crv.number = requested.number)
+                        WHERE crv.content_ids IS NOT NULL
+	) membership
+                ) crv ON (rp.content_ptr_id = crv.content_id)
+                WHERE
+                    (TRUE)
+	
+		AND rp.name_normalized = @name_normalized
+		AND rp.version = @version
+		GROUP BY rp.content_ptr_id, cc.pulp_created
+		ORDER BY cc.pulp_created DESC, rp.content_ptr_id DESC
+		LIMIT @limit OFFSET @offset
+-- @limit = 10
+-- @name_normalized = "shelf-reader"
+-- @offset = 20
+-- @repoIds0 = ["018c1c95-4281-76eb-b277-842cbad524f4"]
+-- @version = "0.1"
+-- @versionNums0 = [3]
+
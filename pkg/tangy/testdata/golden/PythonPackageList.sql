@@ -0,0 +1,65 @@
+-- query 1
+SELECT COUNT(*) AS total
+		FROM (
+		SELECT DISTINCT rp.name_normalized
+		FROM python_pythonpackagecontent rp
+                INNER JOIN (
+                    SELECT DISTINCT membership.repository_id, membership.content_id
+                    FROM (
+                        SELECT crv.repository_id, UNNEST(crv.content_ids) AS content_id
+                        FROM UNNEST(@repoIds0::uuid[], @versionNums0::integer[]) AS requested(repository_id, number)
+                        INNER JOIN core_repositoryversion crv ON (crv.repository_id = requested.repository_id AND crv.number = requested.number)
+                        WHERE crv.content_ids IS NOT NULL
+	) membership
+                ) crv ON (rp.content_ptr_id = crv.content_id)
+                WHERE
+                    (TRUE)
+	
+		AND (rp.name ILIKE CONCAT(@searchFilter::text, '%') OR rp.name_normalized ILIKE CONCAT(@searchFilter::text, '%'))
+		) counted
+-- @repoIds0 = ["018c1c95-4281-76eb-b277-842cbad524f4"]
+-- @searchFilter = "shelf"
+-- @versionNums0 = [3]
+
+-- query 2
+WITH filtered AS (
+			SELECT rp.name_normalized, rp.name, rp.version, cc.pulp_created, crv.repository_id
+			FROM python_pythonpackagecontent rp
+			INNER JOIN core_content cc ON rp.content_ptr_id = cc.pulp_id
+                INNER JOIN (
+                    SELECT DISTINCT membership.repository_id, membership.content_id
+                    FROM (
+                        SELECT crv.repository_id, UNNEST(crv.content_ids) AS content_id
+                        FROM UNNEST(@repoIds0::uuid[], @versionNums0::integer[]) AS requested(repository_id, number)
+                        INNER JOIN core_repositoryversion crv ON (crv.repository_id = requested.repository_id AND crv.number = requested.number)
+                        WHERE crv.content_ids IS NOT NULL
+	) membership
+                ) crv ON (rp.content_ptr_id = crv.content_id)
+                WHERE
+                    (TRUE)
+	
+		AND (rp.name ILIKE CONCAT(@searchFilter::text, '%') OR rp.name_normalized ILIKE CONCAT(@searchFilter::text, '%'))
+		),
+		package_versions AS (
+			SELECT name_normalized, MIN(name) AS name, version, MAX(pulp_created) AS created_at,
+			       ARRAY_AGG(DISTINCT repository_id) AS repository_ids
+			FROM filtered
+			GROUP BY name_normalized, version
+		),
+		paginated_packages AS (
+			SELECT name_normalized, MIN(name) AS name
+			FROM package_versions
+			GROUP BY name_normalized
+			ORDER BY name_normalized ASC
+			LIMIT @limit OFFSET @offset
+		)
+		SELECT pv.name_normalized, pv.name, pv.version, pv.created_at, pv.repository_ids
+		FROM package_versions pv
+		INNER JOIN paginated_packages pp ON pv.name_normalized = pp.name_normalized
+		ORDER BY pv.name_normalized, pv.version
+-- @limit = 10
+-- @offset = 20
+-- @repoIds0 = ["018c1c95-4281-76eb-b277-842cbad524f4"]
+-- @searchFilter = "shelf"
+-- @versionNums0 = [3]
+
@@ -0,0 +1,116 @@
+-- query 1
+WITH filtered AS (
+			SELECT rp.name, rp.name_normalized, rp.version, rp.summary, rp.description,
+			       rp.description_content_type, rp.author, rp.author_email,
+			       rp.maintainer, rp.maintainer_email, rp.license, rp.license_expression,
+			       rp.home_page, rp.project_url, rp.project_urls, rp.keywords,
+			       rp.requires_python, rp.classifiers, rp.requires_dist,
+			       rp.packagetype, cc.pulp_created, crv.repository_id
+			FROM python_pythonpackagecontent rp
+			INNER JOIN core_content cc ON rp.content_ptr_id = cc.pulp_id
+                INNER JOIN (
+                    SELECT DISTINCT membership.repository_id, membership.content_id
+                    FROM (
+                        SELECT crv.repository_id, UNNEST(crv.content_ids) AS content_id
+                        FROM UNNEST(@repoIds0::uuid[], @versionNums0::integer[]) AS requested(repository_id, number)
+                        INNER JOIN core_repositoryversion crv ON (crv.repository_id = requested.repository_id AND crv.number = requested.number)
+                        WHERE crv.content_ids IS NOT NULL
+	 UNION ALL 
+                        SELECT crv.repository_id, crc.content_id
+                        FROM UNNEST(@repoIds1::uuid[], @versionNums1::integer[]) AS requested(repository_id, number)
+                        INNER JOIN core_repositoryversion crv ON (crv.repository_id = requested.repository_id AND crv.number <= requested.number)
+                        INNER JOIN core_repositorycontent crc ON (crc.version_added_id = crv.pulp_id)
+                        LEFT OUTER JOIN core_repositoryversion crv2 ON (crc.version_removed_id = crv2.pulp_id)
+                        WHERE NOT (crv2.number <= requested.number AND crv2.number IS NOT NULL)
+	) membership
+                ) crv ON (rp.content_ptr_id = crv.content_id)
+                WHERE
+                    (TRUE)
+	
+		AND rp.name_normalized = @name_normalized
+		),
+		version_agg AS (
+			SELECT
+				ARRAY_AGG(version ORDER BY version) AS versions,
+				COALESCE(
+					JSON_AGG(
+						JSON_BUILD_OBJECT('version', version, 'created_at', last_updated)
+						ORDER BY version
+					),
+					'[]'::json
+				) AS latest_versions_json
+			FROM (
+				SELECT version, MAX(pulp_created) AS last_updated
+				FROM filtered
+				GROUP BY version
+			) v
+		),
+		version_repositories AS (
+			SELECT version, ARRAY_AGG(DISTINCT repository_id) AS repository_ids
+			FROM filtered
+			GROUP BY version
+		),
+		detail AS (
+			SELECT f.*,
+			       MAX(f.pulp_created) OVER (PARTITION BY f.version) AS last_updated,
+			       ROW_NUMBER() OVER (
+			           PARTITION BY f.version
+			           ORDER BY CASE WHEN f.packagetype = 'sdist' THEN 0 ELSE 1 END,
+			                    f.pulp_created DESC
+			       ) AS rn
+			FROM filtered f
+		WHERE f.version = @version
+		)
+		SELECT d.name, d.name_normalized, d.version, d.summary, d.description,
+		       d.description_content_type, d.author, d.author_email,
+		       d.maintainer, d.maintainer_email, d.license, d.license_expression,
+		       d.home_page, d.project_url, d.project_urls, d.keywords,
+		       d.requires_python, d.classifiers, d.requires_dist,
+		       d.last_updated, va.versions, va.latest_versions_json, vr.repository_ids
+		FROM detail d
+		CROSS JOIN version_agg va
+		INNER JOIN version_repositories vr ON vr.version = d.version
+		WHERE d.rn = 1
+-- @name_normalized = "shelf-reader"
+-- @repoIds0 = ["018c1c95-4281-76eb-b277-842cbad524f4"]
+-- @repoIds1 = ["019f3808-fcc2-716e-a7d3-e5a7ef1522a0"]
+-- @version = "0.1"
+-- @versionNums0 = [3]
+-- @versionNums1 = [1]
+
+-- query 2
+SELECT rp.content_ptr_id, rp.name, rp.name_normalized, rp.version, rp.filename, rp.packagetype,
+		       rp.python_version, rp.sha256, rp.size, cc.pulp_created AS created_at,
+		       ARRAY_AGG(DISTINCT crv.repository_id) AS repository_ids
+		FROM python_pythonpackagecontent rp
+		INNER JOIN core_content cc ON rp.content_ptr_id = cc.pulp_id
+                INNER JOIN (
+                    SELECT DISTINCT membership.repository_id, membership.content_id
+                    FROM (
+                        SELECT crv.repository_id, UNNEST(crv.content_ids) AS content_id
+                        FROM UNNEST(@repoIds0::uuid[], @versionNums0::integer[]) AS requested(repository_id, number)
+                        INNER JOIN core_repositoryversion crv ON (crv.repository_id = requested.repository_id AND crv.number = requested.number)
+                        WHERE crv.content_ids IS NOT NULL
+	 UNION ALL 
+                        SELECT crv.repository_id, crc.content_id
+                        FROM UNNEST(@repoIds1::uuid[], @versionNums1::integer[]) AS requested(repository_id, number)
+                        INNER JOIN core_repositoryversion crv ON (crv.repository_id = requested.repository_id AND crv.number <= requested.number)
+                        INNER JOIN core_repositorycontent crc ON (crc.version_added_id = crv.pulp_id)
+                        LEFT OUTER JOIN core_repositoryversion crv2 ON (crc.version_removed_id = crv2.pulp_id)
+                        WHERE NOT (crv2.number <= requested.number AND crv2.number IS NOT NULL)
+	) membership
+                ) crv ON (rp.content_ptr_id = crv.content_id)
+                WHERE
+                    (TRUE)
+	
+		AND rp.name_normalized = @name_normalized
+		AND rp.version = @version
+		GROUP BY rp.content_ptr_id, cc.pulp_created
+		ORDER BY cc.pulp_created DESC, rp.content_ptr_id DESC
+-- @name_normalized = "shelf-reader"
+-- @repoIds0 = ["018c1c95-4281-76eb-b277-842cbad524f4"]
+-- @repoIds1 = ["019f3808-fcc2-716e-a7d3-e5a7ef1522a0"]
+-- @version = "0.1"
+-- @versionNums0 = [3]
+-- @versionNums1 = [1]
+
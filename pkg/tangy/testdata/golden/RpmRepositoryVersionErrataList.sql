@@ -0,0 +1,89 @@
+-- query 1
+SELECT COUNT(*) AS total
+		FROM (
+		SELECT DISTINCT rp.content_ptr_id
+		FROM rpm_updaterecord rp
+                INNER JOIN (
+                    SELECT DISTINCT membership.repository_id, membership.content_id
+                    FROM (
+                        SELECT crv.repository_id, UNNEST(crv.content_ids) AS content_id
+                        FROM UNNEST(@repoIds0::uuid[], @versionNums0::integer[]) AS requested(repository_id, number)
+                        INNER JOIN core_repositoryversion crv ON (crv.repository_id = requested.repository_id AND crv.number = requested.number)
+                        WHERE crv.content_ids IS NOT NULL
+	 UNION ALL 
+                        SELECT crv.repository_id, crc.content_id
+                        FROM UNNEST(@repoIds1::uuid[], @versionNums1::integer[]) AS requested(repository_id, number)
+                        INNER JOIN core_repositoryversion crv ON (crv.repository_id = requested.repository_id AND crv.number <= requested.number)
+                        INNER JOIN core_repositorycontent crc ON (crc.version_added_id = crv.pulp_id)
+                        LEFT OUTER JOIN core_repositoryversion crv2 ON (crc.version_removed_id = crv2.pulp_id)
+                        WHERE NOT (crv2.number <= requested.number AND crv2.number IS NOT NULL)
+	) membership
+                ) crv ON (rp.content_ptr_id = crv.content_id)
+                WHERE
+                    (TRUE)
+	
+		AND (rp.id ILIKE CONCAT('%', @searchFilter::text, '%') OR rp.summary ILIKE CONCAT('%', @searchFilter::text, '%'))
+		AND (rp.type = ANY(@typeFilter) OR NOT (rp.type = ANY(@typeList)))
+		AND (rp.severity = ANY(@severityFilter) OR NOT (rp.severity = ANY(@severityList)))
+		LIMIT @countLimit
+		) counted
+-- @countLimit = 10001
+-- @repoIds0 = ["018c1c95-4281-76eb-b277-842cbad524f4"]
+-- @repoIds1 = ["019f3808-fcc2-716e-a7d3-e5a7ef1522a0"]
+-- @searchFilter = "RHSA"
+-- @severityFilter = ["Unknown"]
+-- @severityList = ["Important","Critical","Moderate","Low"]
+-- @typeFilter = ["security","other"]
+-- @typeList = ["security","bugfix","enhancement"]
+-- @versionNums0 = [3]
+-- @versionNums1 = [1]
+
+-- query 2
+SELECT DISTINCT rp.content_ptr_id AS id, rp.id AS ErrataId, rp.title, rp.summary, rp.description,
+		       rp.issued_date AS IssuedDate, rp.updated_date AS UpdatedDate, rp.type, rp.severity,
+		       rp.reboot_suggested AS RebootSuggested,
+		       (SELECT ARRAY_AGG(ru.ref_id)
+		        FROM rpm_updatereference ru
+		        WHERE ru.update_record_id = rp.content_ptr_id
+		        AND ru.ref_type = 'cve') AS CVEs
+		FROM rpm_updaterecord rp
+                INNER JOIN (
+                    SELECT DISTINCT membership.repository_id, membership.content_id
+                    FROM (
+                        SELECT crv.repository_id, UNNEST(crv.content_ids) AS content_id
+                        FROM UNNEST(@repoIds0::uuid[], @versionNums0::integer[]) AS requested(repository_id, number)
+                        INNER JOIN core_repositoryversion crv ON (crv.repository_id = requested.repository_id AND crv.number = requested.number)
+                        WHERE crv.content_ids IS NOT NULL
+	 UNION ALL 
+                        SELECT crv.repository_id, crc.content_id
+                        FROM UNNEST(@repoIds1::uuid[], @versionNums1::integer[]) AS requested(repository_id, number)
+                        INNER JOIN core_repositoryversion crv ON (crv.repository_id = requested.repository_id AND crv.number <= requested.number)
+                        INNER JOIN core_repositorycontent crc ON (crc.version_added_id = crv.pulp_id)
+                        LEFT OUTER JOIN core_repositoryversion crv2 ON (crc.version_removed_id = crv2.pulp_id)
+                        WHERE NOT (crv2.number <= requested.number AND crv2.number IS NOT NULL)
+	) membership
+                ) crv ON (rp.content_ptr_id = crv.content_id)
+                WHERE
+                    (TRUE)
+	
+		AND (rp.id ILIKE CONCAT('%', @searchFilter::text, '%') OR rp.summary ILIKE CONCAT('%', @searchFilter::text, '%'))
+		AND (rp.type = ANY(@typeFilter) OR NOT (rp.type = ANY(@typeList)))
+		AND (rp.severity = ANY(@severityFilter) OR NOT (rp.severity = ANY(@severityList)))
+		AND (rp.updated_date IS NULL, COALESCE(rp.updated_date, ''), rp.content_ptr_id) > (@cursorKey0::boolean, @cursorKey1::text, @cursorKey2::uuid)
+		ORDER BY rp.updated_date ASC NULLS LAST, rp.content_ptr_id ASC
+		LIMIT @limit OFFSET @offset
+-- @cursorKey0 = false
+-- @cursorKey1 = "2024-01-01"
+-- @cursorKey2 = "018c1c95-4281-76eb-b277-842cbad524f4"
+-- @limit = 10
+-- @offset = 0
+-- @repoIds0 = ["018c1c95-4281-76eb-b277-842cbad524f4"]
+-- @repoIds1 = ["019f3808-fcc2-716e-a7d3-e5a7ef1522a0"]
+-- @searchFilter = "RHSA"
+-- @severityFilter = ["Unknown"]
+-- @severityList = ["Important","Critical","Moderate","Low"]
+-- @typeFilter = ["security","other"]
+-- @typeList = ["security","bugfix","enhancement"]
+-- @versionNums0 = [3]
+-- @versionNums1 = [1]
+
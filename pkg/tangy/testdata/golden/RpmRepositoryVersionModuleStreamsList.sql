@@ -0,0 +1,35 @@
+-- query 1
+SELECT DISTINCT ON (rp.name, rp.stream) rp.name, rp.stream, rp.version, rp.profiles, rp.context, rp.arch, rp.description
+		FROM rpm_modulemd rp
+		INNER JOIN rpm_modulemd_packages rmp ON rmp.modulemd_id = rp.content_ptr_id
+		INNER JOIN rpm_package pack ON pack.content_ptr_id = rmp.package_id
+                INNER JOIN (
+                    SELECT DISTINCT membership.repository_id, membership.content_id
+                    FROM (
+                        SELECT crv.repository_id, UNNEST(crv.content_ids) AS content_id
+                        FROM UNNEST(@repoIds0::uuid[], @versionNums0::integer[]) AS requested(repository_id, number)
+                        INNER JOIN core_repositoryversion crv ON (crv.repository_id = requested.repository_id AND crv.number = requested.number)
+                        WHERE crv.content_ids IS NOT NULL
+	 UNION ALL 
+                        SELECT crv.repository_id, crc.content_id
+                        FROM UNNEST(@repoIds1::uuid[], @versionNums1::integer[]) AS requested(repository_id, number)
+                        INNER JOIN core_repositoryversion crv ON (crv.repository_id = requested.repository_id AND crv.number <= requested.number)
+                        INNER JOIN core_repositorycontent crc ON (crc.version_added_id = crv.pulp_id)
+                        LEFT OUTER JOIN core_repositoryversion crv2 ON (crc.version_removed_id = crv2.pulp_id)
+                        WHERE NOT (crv2.number <= requested.number AND crv2.number IS NOT NULL)
+	) membership
+                ) crv ON (rp.content_ptr_id = crv.content_id)
+                WHERE
+                    (TRUE)
+	
+		AND pack.name = ANY(@rpm_names)
+		AND rp.name ILIKE CONCAT('%', @nameFilter::text, '%')
+		ORDER BY rp.name DESC, rp.stream ASC, rp.version ASC
+		LIMIT 5000
+-- @nameFilter = "%node%"
+-- @repoIds0 = ["018c1c95-4281-76eb-b277-842cbad524f4"]
+-- @repoIds1 = ["019f3808-fcc2-716e-a7d3-e5a7ef1522a0"]
+-- @rpm_names = ["nodejs"]
+-- @versionNums0 = [3]
+-- @versionNums1 = [1]
+
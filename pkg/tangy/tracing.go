@@ -0,0 +1,250 @@
+package tangy
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/jackc/pgx/v5"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName is the name of the tracer of the spans of Tangy
+const instrumentationName = "github.com/content-services/tang/pkg/tangy"
+
+// Attributes of the spans of Tangy, named after the OpenTelemetry semantic conventions where they exist
+const (
+	attributeDBSystem           = attribute.Key("db.system.name")
+	attributeDBNamespace        = attribute.Key("db.namespace")
+	attributeDBQueryText        = attribute.Key("db.query.text")
+	attributeDBOperationName    = attribute.Key("db.operation.name")
+	attributeDBReturnedRows     = attribute.Key("db.response.returned_rows")
+	attributeDBBatchSize        = attribute.Key("db.operation.batch.size")
+	attributeMethod             = attribute.Key("tangy.method")
+	attributeErrorClass         = attribute.Key("tangy.error_class")
+	attributeHrefCount          = attribute.Key("tangy.href_count")
+	attributeRepositoryUUIDs    = attribute.Key("tangy.repository_uuids")
+	attributeRepositoryVersions = attribute.Key("tangy.repository_versions")
+	attributeRows               = attribute.Key("tangy.rows")
+	attributeContentIds         = attribute.Key("tangy.membership.content_ids")
+	attributeLegacy             = attribute.Key("tangy.membership.legacy")
+)
+
+// namedArg is an argument of a Tangy method, recorded on the span of its calls as tangy.<name>
+type namedArg struct {
+	name  string
+	value any
+}
+
+// arg returns an argument of a method, to be passed to call
+func arg(name string, value any) namedArg {
+	return namedArg{name: name, value: value}
+}
+
+// startCallSpan starts the span of a call of method, a child of the span of ctx. args are the arguments of the
+// method other than hrefs: named arguments, filters and page options. No span is started without tracer.
+func (t *tangyImpl) startCallSpan(ctx context.Context, method string, args []any) (context.Context, trace.Span) {
+	if t.tracer == nil {
+		return ctx, nil
+	}
+	ctx, span := t.tracer.Start(ctx, method, trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attributeDBSystem.String("postgresql"), attributeMethod.String(method)))
+	if span.IsRecording() {
+		span.SetAttributes(argAttributes(args)...)
+	}
+	return ctx, span
+}
+
+// endCallSpan ends the span of a call with what was recorded about the call, and its error
+func endCallSpan(span trace.Span, state *callState, err error) {
+	if span == nil {
+		return
+	}
+	span.SetAttributes(
+		attributeRows.Int64(int64(min(state.rows.Load(), uint64(1)<<62))),
+		attributeContentIds.Bool(state.contentIds.Load()),
+		attributeLegacy.Bool(state.legacy.Load()),
+	)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		span.SetAttributes(attributeErrorClass.String(string(ClassifyError(err))))
+	}
+	span.End()
+}
+
+// argAttributes returns the attributes of the arguments of a call. Filters are recorded field by field
+// as tangy.filter.<field>, page options as tangy.page.<field>, omitting the fields left empty.
+func argAttributes(args []any) []attribute.KeyValue {
+	var attributes []attribute.KeyValue
+	for _, a := range args {
+		switch a := a.(type) {
+		case namedArg:
+			if value, ok := attributeValue(reflect.ValueOf(a.value)); ok {
+				attributes = append(attributes, attribute.KeyValue{Key: attribute.Key("tangy." + a.name), Value: value})
+			}
+		case PageOptions:
+			attributes = append(attributes, structAttributes("tangy.page.", reflect.ValueOf(a))...)
+		default:
+			attributes = append(attributes, structAttributes("tangy.filter.", reflect.ValueOf(a))...)
+		}
+	}
+	return attributes
+}
+
+// structAttributes returns an attribute for each non-zero field of a struct, keyed by prefix and the field name
+func structAttributes(prefix string, v reflect.Value) []attribute.KeyValue {
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+	var attributes []attribute.KeyValue
+	for i := range v.NumField() {
+		field := v.Type().Field(i)
+		if !field.IsExported() || v.Field(i).IsZero() {
+			continue
+		}
+		if value, ok := attributeValue(v.Field(i)); ok {
+			attributes = append(attributes, attribute.KeyValue{Key: attribute.Key(prefix + snakeCase(field.Name)), Value: value})
+		}
+	}
+	return attributes
+}
+
+// attributeValue converts the strings, integers, booleans and string slices of arguments to attribute values
+func attributeValue(v reflect.Value) (attribute.Value, bool) {
+	switch v.Kind() {
+	case reflect.String:
+		return attribute.StringValue(v.String()), true
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		return attribute.Int64Value(v.Int()), true
+	case reflect.Bool:
+		return attribute.BoolValue(v.Bool()), true
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.String {
+			values := make([]string, v.Len())
+			for i := range values {
+				values[i] = v.Index(i).String()
+			}
+			return attribute.StringSliceValue(values), true
+		}
+	}
+	return attribute.Value{}, false
+}
+
+// snakeCase converts a Go field name such as RpmNames to snake case, rpm_names
+func snakeCase(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// recordRepositoryVersions records the repository versions a call reads on its span
+func recordRepositoryVersions(ctx context.Context, versions []ParsedRepoVersion) {
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return
+	}
+	uuids := make([]string, len(versions))
+	numbers := make([]int64, len(versions))
+	for i, version := range versions {
+		uuids[i] = version.RepositoryUUID
+		numbers[i] = int64(version.Version)
+	}
+	span.SetAttributes(attributeHrefCount.Int(len(versions)), attributeRepositoryUUIDs.StringSlice(uuids),
+		attributeRepositoryVersions.Int64Slice(numbers))
+}
+
+// queryTracer starts a span for each SQL statement run by a call, a child of the span of the call, and for each batch
+// of statements, with an event for each of its statements.
+// Statements run outside calls, such as the health checks of replicas, are not traced.
+type queryTracer struct {
+	tracer trace.Tracer
+}
+
+type querySpanContextKey struct{}
+
+func (q *queryTracer) TraceQueryStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	if callOf(ctx) == nil {
+		return ctx
+	}
+	operation := "QUERY"
+	if fields := strings.Fields(data.SQL); len(fields) > 0 {
+		operation = strings.ToUpper(fields[0])
+	}
+	ctx, span := q.tracer.Start(ctx, operation, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		attributeDBSystem.String("postgresql"),
+		attributeDBOperationName.String(operation),
+		attributeDBQueryText.String(data.SQL),
+	))
+	if conn != nil {
+		span.SetAttributes(attributeDBNamespace.String(conn.Config().Database))
+	}
+	return context.WithValue(ctx, querySpanContextKey{}, span)
+}
+
+func (q *queryTracer) TraceQueryEnd(ctx context.Context, conn *pgx.Conn, data pgx.TraceQueryEndData) {
+	span, ok := ctx.Value(querySpanContextKey{}).(trace.Span)
+	if !ok {
+		return
+	}
+	if data.Err != nil {
+		span.RecordError(data.Err)
+		span.SetStatus(codes.Error, data.Err.Error())
+	} else {
+		span.SetAttributes(attributeDBReturnedRows.Int64(data.CommandTag.RowsAffected()))
+	}
+	span.End()
+}
+
+func (q *queryTracer) TraceBatchStart(ctx context.Context, conn *pgx.Conn, data pgx.TraceBatchStartData) context.Context {
+	if callOf(ctx) == nil {
+		return ctx
+	}
+	ctx, span := q.tracer.Start(ctx, "BATCH", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		attributeDBSystem.String("postgresql"),
+		attributeDBOperationName.String("BATCH"),
+		attributeDBBatchSize.Int(data.Batch.Len()),
+	))
+	if conn != nil {
+		span.SetAttributes(attributeDBNamespace.String(conn.Config().Database))
+	}
+	return context.WithValue(ctx, querySpanContextKey{}, span)
+}
+
+func (q *queryTracer) TraceBatchQuery(ctx context.Context, _ *pgx.Conn, data pgx.TraceBatchQueryData) {
+	span, ok := ctx.Value(querySpanContextKey{}).(trace.Span)
+	if !ok {
+		return
+	}
+	if data.Err != nil {
+		span.RecordError(data.Err, trace.WithAttributes(attributeDBQueryText.String(data.SQL)))
+		return
+	}
+	span.AddEvent("query", trace.WithAttributes(
+		attributeDBQueryText.String(data.SQL),
+		attributeDBReturnedRows.Int64(data.CommandTag.RowsAffected()),
+	))
+}
+
+func (q *queryTracer) TraceBatchEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceBatchEndData) {
+	span, ok := ctx.Value(querySpanContextKey{}).(trace.Span)
+	if !ok {
+		return
+	}
+	// The error of a batch is the error of its failed statement, already recorded
+	if data.Err != nil {
+		span.SetStatus(codes.Error, data.Err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,156 @@
+package tangy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func testTracerProvider(t *testing.T) (*sdktrace.TracerProvider, *tracetest.SpanRecorder) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+	return provider, recorder
+}
+
+func TestArgAttributes(t *testing.T) {
+	t.Parallel()
+
+	attributes := argAttributes([]any{
+		arg("search", "kernel"),
+		arg("limit", 10),
+		ErrataListFilters{Type: []string{"security"}},
+		ModuleStreamListFilters{RpmNames: []string{"nodejs"}},
+		PageOptions{Limit: 5, Count: CountNone},
+	})
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.String("tangy.search", "kernel"),
+		attribute.Int("tangy.limit", 10),
+		attribute.StringSlice("tangy.filter.type", []string{"security"}),
+		attribute.StringSlice("tangy.filter.rpm_names", []string{"nodejs"}),
+		attribute.Int("tangy.page.limit", 5),
+		attribute.String("tangy.page.count", "none"),
+	}, attributes)
+}
+
+func TestCallSpan(t *testing.T) {
+	t.Parallel()
+
+	provider, recorder := testTracerProvider(t)
+	tangy := &tangyImpl{tracer: provider.Tracer(instrumentationName)}
+
+	// The span of the call is a child of the span of the context of the caller
+	ctx, parent := provider.Tracer("caller").Start(context.Background(), "request")
+	callCtx, done := tangy.call(ctx, "RpmRepositoryVersionErrataList", ErrataListFilters{Search: "CVE"}, PageOptions{Limit: 10})
+	innerCtx, innerDone := tangy.call(callCtx, "RpmRepositoryVersionPackageList", RpmListFilters{Name: "kernel"})
+	membership{Versions: []ParsedRepoVersion{{RepositoryUUID: "a1b2", Version: 3}}, OldVersions: []ParsedRepoVersion{{RepositoryUUID: "a1b2", Version: 3}}}.record(innerCtx)
+	callOf(innerCtx).rows.Add(7)
+	err := error(nil)
+	innerDone(&err)
+	err = ErrRepositoryVersionNotFound
+	done(&err)
+	parent.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	span := spans[0]
+	assert.Equal(t, "RpmRepositoryVersionErrataList", span.Name())
+	assert.Equal(t, parent.SpanContext().SpanID(), span.Parent().SpanID())
+	assert.Equal(t, codes.Error, span.Status().Code)
+	assert.Subset(t, span.Attributes(), []attribute.KeyValue{
+		attribute.String("tangy.method", "RpmRepositoryVersionErrataList"),
+		attribute.String("tangy.filter.search", "CVE"),
+		attribute.Int("tangy.page.limit", 10),
+		attribute.Int("tangy.href_count", 1),
+		attribute.StringSlice("tangy.repository_uuids", []string{"a1b2"}),
+		attribute.Int64Slice("tangy.repository_versions", []int64{3}),
+		attribute.Int("tangy.rows", 7),
+		attribute.Bool("tangy.membership.content_ids", false),
+		attribute.Bool("tangy.membership.legacy", true),
+		attribute.String("tangy.error_class", "not_found"),
+	})
+	// Arguments of nested calls are not recorded, as they are part of the outer call
+	assert.NotContains(t, span.Attributes(), attribute.String("tangy.filter.name", "kernel"))
+}
+
+func TestCallWithoutTracer(t *testing.T) {
+	t.Parallel()
+
+	ctx, done := (&tangyImpl{}).call(context.Background(), "NpmPackageList")
+	recordRepositoryVersions(ctx, []ParsedRepoVersion{{RepositoryUUID: "a1b2", Version: 3}})
+	err := error(nil)
+	done(&err)
+	assert.NoError(t, err)
+}
+
+func TestQueryTracer(t *testing.T) {
+	t.Parallel()
+
+	provider, recorder := testTracerProvider(t)
+	tangy := &tangyImpl{tracer: provider.Tracer(instrumentationName)}
+	tracer := &queryTracer{tracer: provider.Tracer(instrumentationName)}
+
+	// Statements run outside calls are not traced
+	ctx := tracer.TraceQueryStart(context.Background(), nil, pgx.TraceQueryStartData{SQL: "SELECT 1"})
+	tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{})
+	assert.Empty(t, recorder.Ended())
+
+	callCtx, done := tangy.call(context.Background(), "NpmPackageList")
+	ctx = tracer.TraceQueryStart(callCtx, nil, pgx.TraceQueryStartData{SQL: "\n\tselect name FROM npm_package"})
+	tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{CommandTag: pgconn.NewCommandTag("SELECT 4")})
+	ctx = tracer.TraceQueryStart(callCtx, nil, pgx.TraceQueryStartData{SQL: "SELECT missing"})
+	tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{Err: assert.AnError})
+	err := error(nil)
+	done(&err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 3)
+	call := spans[2]
+	for _, span := range spans[:2] {
+		assert.Equal(t, "SELECT", span.Name())
+		assert.Equal(t, call.SpanContext().SpanID(), span.Parent().SpanID())
+	}
+	assert.Contains(t, spans[0].Attributes(), attribute.Int("db.response.returned_rows", 4))
+	assert.Contains(t, spans[0].Attributes(), attribute.String("db.query.text", "\n\tselect name FROM npm_package"))
+	assert.Equal(t, codes.Error, spans[1].Status().Code)
+	assert.Equal(t, codes.Unset, call.Status().Code)
+}
+
+func TestQueryTracerBatch(t *testing.T) {
+	t.Parallel()
+
+	provider, recorder := testTracerProvider(t)
+	tangy := &tangyImpl{tracer: provider.Tracer(instrumentationName)}
+	tracer := &queryTracer{tracer: provider.Tracer(instrumentationName)}
+	batch := &pgx.Batch{}
+	batch.Queue("SELECT 1")
+	batch.Queue("SELECT missing")
+
+	callCtx, done := tangy.call(context.Background(), "RpmRepositoryVersionBatch")
+	ctx := tracer.TraceBatchStart(callCtx, nil, pgx.TraceBatchStartData{Batch: batch})
+	tracer.TraceBatchQuery(ctx, nil, pgx.TraceBatchQueryData{SQL: "SELECT 1", CommandTag: pgconn.NewCommandTag("SELECT 1")})
+	tracer.TraceBatchQuery(ctx, nil, pgx.TraceBatchQueryData{SQL: "SELECT missing", Err: assert.AnError})
+	tracer.TraceBatchEnd(ctx, nil, pgx.TraceBatchEndData{Err: assert.AnError})
+	err := error(nil)
+	done(&err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	span := spans[0]
+	assert.Equal(t, "BATCH", span.Name())
+	assert.Equal(t, spans[1].SpanContext().SpanID(), span.Parent().SpanID())
+	assert.Contains(t, span.Attributes(), attribute.Int("db.operation.batch.size", 2))
+	require.Len(t, span.Events(), 2)
+	assert.Equal(t, "query", span.Events()[0].Name)
+	assert.Contains(t, span.Events()[0].Attributes, attribute.String("db.query.text", "SELECT 1"))
+	assert.Equal(t, "exception", span.Events()[1].Name)
+	assert.Equal(t, codes.Error, span.Status().Code)
+}
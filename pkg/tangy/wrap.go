@@ -0,0 +1,381 @@
+package tangy
+
+import (
+	"context"
+	"iter"
+)
+
+// wrappedTangy runs every call of the methods of next through intercept. Iterators, ReadSnapshot, Ping, Ready, Stats
+// and Close are not intercepted, as iterators and ReadSnapshot run while the caller reads their results, Ping and Ready
+// report the state of the database as it is, and Stats and Close do not run queries.
+type wrappedTangy struct {
+	next      Tangy
+	intercept Interceptor
+}
+
+func (w *wrappedTangy) RpmRepositoryVersionPackageSearch(ctx context.Context, hrefs []string, search string, limit int) ([]RpmPackageSearch, error) {
+	call := &Call{Method: "RpmRepositoryVersionPackageSearch", Args: []any{hrefs, search, limit}}
+	err := w.intercept(ctx, call, func(ctx context.Context) (err error) {
+		call.Result, err = w.next.RpmRepositoryVersionPackageSearch(ctx, hrefs, search, limit)
+		return err
+	})
+	result, _ := call.Result.([]RpmPackageSearch)
+	return result, err
+}
+
+func (w *wrappedTangy) RpmRepositoryVersionPackageGroupSearch(ctx context.Context, hrefs []string, search string, limit int) ([]RpmPackageGroupSearch, error) {
+	call := &Call{Method: "RpmRepositoryVersionPackageGroupSearch", Args: []any{hrefs, search, limit}}
+	err := w.intercept(ctx, call, func(ctx context.Context) (err error) {
+		call.Result, err = w.next.RpmRepositoryVersionPackageGroupSearch(ctx, hrefs, search, limit)
+		return err
+	})
+	result, _ := call.Result.([]RpmPackageGroupSearch)
+	return result, err
+}
+
+func (w *wrappedTangy) RpmRepositoryVersionEnvironmentSearch(ctx context.Context, hrefs []string, search string, limit int) ([]RpmEnvironmentSearch, error) {
+	call := &Call{Method: "RpmRepositoryVersionEnvironmentSearch", Args: []any{hrefs, search, limit}}
+	err := w.intercept(ctx, call, func(ctx context.Context) (err error) {
+		call.Result, err = w.next.RpmRepositoryVersionEnvironmentSearch(ctx, hrefs, search, limit)
+		return err
+	})
+	result, _ := call.Result.([]RpmEnvironmentSearch)
+	return result, err
+}
+
+func (w *wrappedTangy) RpmRepositoryVersionPackageList(ctx context.Context, hrefs []string, filterOpts RpmListFilters, pageOpts PageOptions) (RpmListResponse, error) {
+	call := &Call{Method: "RpmRepositoryVersionPackageList", Args: []any{hrefs, filterOpts, pageOpts}}
+	err := w.intercept(ctx, call, func(ctx context.Context) (err error) {
+		call.Result, err = w.next.RpmRepositoryVersionPackageList(ctx, hrefs, filterOpts, pageOpts)
+		return err
+	})
+	result, _ := call.Result.(RpmListResponse)
+	return result, err
+}
+
+func (w *wrappedTangy) RpmRepositoryVersionModuleStreamsList(ctx context.Context, hrefs []string, filterOpts ModuleStreamListFilters, sortBy string) ([]ModuleStreams, error) {
+	call := &Call{Method: "RpmRepositoryVersionModuleStreamsList", Args: []any{hrefs, filterOpts, sortBy}}
+	err := w.intercept(ctx, call, func(ctx context.Context) (err error) {
+		call.Result, err = w.next.RpmRepositoryVersionModuleStreamsList(ctx, hrefs, filterOpts, sortBy)
+		return err
+	})
+	result, _ := call.Result.([]ModuleStreams)
+	return result, err
+}
+
+func (w *wrappedTangy) RpmRepositoryVersionErrataList(ctx context.Context, hrefs []string, filterOpts ErrataListFilters, pageOpts PageOptions) (ErrataListResponse, error) {
+	call := &Call{Method: "RpmRepositoryVersionErrataList", Args: []any{hrefs, filterOpts, pageOpts}}
+	err := w.intercept(ctx, call, func(ctx context.Context) (err error) {
+		call.Result, err = w.next.RpmRepositoryVersionErrataList(ctx, hrefs, filterOpts, pageOpts)
+		return err
+	})
+	result, _ := call.Result.(ErrataListResponse)
+	return result, err
+}
+
+func (w *wrappedTangy) PythonPackageList(ctx context.Context, repositoryHref string, filterOpts PythonPackageListFilters, pageOpts PageOptions) (PythonPackageListResponse, error) {
+	call := &Call{Method: "PythonPackageList", Args: []any{repositoryHref, filterOpts, pageOpts}}
+	err := w.intercept(ctx, call, func(ctx context.Context) (err error) {
+		call.Result, err = w.next.PythonPackageList(ctx, repositoryHref, filterOpts, pageOpts)
+		return err
+	})
+	result, _ := call.Result.(PythonPackageListResponse)
+	return result, err
+}
+
+func (w *wrappedTangy) PythonDistributionList(ctx context.Context, repositoryHref, nameNormalized, version string, pageOpts PageOptions) (PythonDistributionListResponse, error) {
+	call := &Call{Method: "PythonDistributionList", Args: []any{repositoryHref, nameNormalized, version, pageOpts}}
+	err := w.intercept(ctx, call, func(ctx context.Context) (err error) {
+		call.Result, err = w.next.PythonDistributionList(ctx, repositoryHref, nameNormalized, version, pageOpts)
+		return err
+	})
+	result, _ := call.Result.(PythonDistributionListResponse)
+	return result, err
+}
+
+func (w *wrappedTangy) PythonPackageGet(ctx context.Context, repositoryHref, nameNormalized, version string) (PythonPackageDetail, error) {
+	call := &Call{Method: "PythonPackageGet", Args: []any{repositoryHref, nameNormalized, version}}
+	err := w.intercept(ctx, call, func(ctx context.Context) (err error) {
+		call.Result, err = w.next.PythonPackageGet(ctx, repositoryHref, nameNormalized, version)
+		return err
+	})
+	result, _ := call.Result.(PythonPackageDetail)
+	return result, err
+}
+
+func (w *wrappedTangy) PythonPackageVersionsGet(ctx context.Context, repositoryHref, nameNormalized string) ([]PythonPackageDetail, error) {
+	call := &Call{Method: "PythonPackageVersionsGet", Args: []any{repositoryHref, nameNormalized}}
+	err := w.intercept(ctx, call, func(ctx context.Context) (err error) {
+		call.Result, err = w.next.PythonPackageVersionsGet(ctx, repositoryHref, nameNormalized)
+		return err
+	})
+	result, _ := call.Result.([]PythonPackageDetail)
+	return result, err
+}
+
+func (w *wrappedTangy) PythonBuildList(ctx context.Context, repositoryHref, nameNormalized, version string, pageOpts PageOptions) (PythonBuildListResponse, error) {
+	call := &Call{Method: "PythonBuildList", Args: []any{repositoryHref, nameNormalized, version, pageOpts}}
+	err := w.intercept(ctx, call, func(ctx context.Context) (err error) {
+		call.Result, err = w.next.PythonBuildList(ctx, repositoryHref, nameNormalized, version, pageOpts)
+		return err
+	})
+	result, _ := call.Result.(PythonBuildListResponse)
+	return result, err
+}
+
+func (w *wrappedTangy) PythonRepositoryMetrics(ctx context.Context, repositoryHref string) (PythonRepositoryMetrics, error) {
+	call := &Call{Method: "PythonRepositoryMetrics", Args: []any{repositoryHref}}
+	err := w.intercept(ctx, call, func(ctx context.Context) (err error) {
+		call.Result, err = w.next.PythonRepositoryMetrics(ctx, repositoryHref)
+		return err
+	})
+	result, _ := call.Result.(PythonRepositoryMetrics)
+	return result, err
+}
+
+func (w *wrappedTangy) PythonRepositoryVersionPackageList(ctx context.Context, hrefs []string, filterOpts PythonPackageListFilters, pageOpts PageOptions) (PythonPackageListResponse, error) {
+	call := &Call{Method: "PythonRepositoryVersionPackageList", Args: []any{hrefs, filterOpts, pageOpts}}
+	err := w.intercept(ctx, call, func(ctx context.Context) (err error) {
+		call.Result, err = w.next.PythonRepositoryVersionPackageList(ctx, hrefs, filterOpts, pageOpts)
+		return err
+	})
+	result, _ := call.Result.(PythonPackageListResponse)
+	return result, err
+}
+
+func (w *wrappedTangy) PythonRepositoryVersionDistributionList(ctx context.Context, hrefs []string, nameNormalized, version string, pageOpts PageOptions) (PythonDistributionListResponse, error) {
+	call := &Call{Method: "PythonRepositoryVersionDistributionList", Args: []any{hrefs, nameNormalized, version, pageOpts}}
+	err := w.intercept(ctx, call, func(ctx context.Context) (err error) {
+		call.Result, err = w.next.PythonRepositoryVersionDistributionList(ctx, hrefs, nameNormalized, version, pageOpts)
+		return err
+	})
+	result, _ := call.Result.(PythonDistributionListResponse)
+	return result, err
+}
+
+func (w *wrappedTangy) PythonRepositoryVersionPackageGet(ctx context.Context, hrefs []string, nameNormalized, version string) (PythonPackageDetail, error) {
+	call := &Call{Method: "PythonRepositoryVersionPackageGet", Args: []any{hrefs, nameNormalized, version}}
+	err := w.intercept(ctx, call, func(ctx context.Context) (err error) {
+		call.Result, err = w.next.PythonRepositoryVersionPackageGet(ctx, hrefs, nameNormalized, version)
+		return err
+	})
+	result, _ := call.Result.(PythonPackageDetail)
+	return result, err
+}
+
+func (w *wrappedTangy) PythonRepositoryVersionPackageVersionsGet(ctx context.Context, hrefs []string, nameNormalized string) ([]PythonPackageDetail, error) {
+	call := &Call{Method: "PythonRepositoryVersionPackageVersionsGet", Args: []any{hrefs, nameNormalized}}
+	err := w.intercept(ctx, call, func(ctx context.Context) (err error) {
+		call.Result, err = w.next.PythonRepositoryVersionPackageVersionsGet(ctx, hrefs, nameNormalized)
+		return err
+	})
+	result, _ := call.Result.([]PythonPackageDetail)
+	return result, err
+}
+
+func (w *wrappedTangy) PythonRepositoryVersionBuildList(ctx context.Context, hrefs []string, nameNormalized, version string, pageOpts PageOptions) (PythonBuildListResponse, error) {
+	call := &Call{Method: "PythonRepositoryVersionBuildList", Args: []any{hrefs, nameNormalized, version, pageOpts}}
+	err := w.intercept(ctx, call, func(ctx context.Context) (err error) {
+		call.Result, err = w.next.PythonRepositoryVersionBuildList(ctx, hrefs, nameNormalized, version, pageOpts)
+		return err
+	})
+	result, _ := call.Result.(PythonBuildListResponse)
+	return result, err
+}
+
+func (w *wrappedTangy) PythonRepositoryVersionMetrics(ctx context.Context, hrefs []string) (PythonRepositoryMetrics, error) {
+	call := &Call{Method: "PythonRepositoryVersionMetrics", Args: []any{hrefs}}
+	err := w.intercept(ctx, call, func(ctx context.Context) (err error) {
+		call.Result, err = w.next.PythonRepositoryVersionMetrics(ctx, hrefs)
+		return err
+	})
+	result, _ := call.Result.(PythonRepositoryMetrics)
+	return result, err
+}
+
+func (w *wrappedTangy) MavenPackageList(ctx context.Context, repositoryHref string, filterOpts MavenPackageListFilters, pageOpts PageOptions) (MavenPackageListResponse, error) {
+	call := &Call{Method: "MavenPackageList", Args: []any{repositoryHref, filterOpts, pageOpts}}
+	err := w.intercept(ctx, call, func(ctx context.Context) (err error) {
+		call.Result, err = w.next.MavenPackageList(ctx, repositoryHref, filterOpts, pageOpts)
+		return err
+	})
+	result, _ := call.Result.(MavenPackageListResponse)
+	return result, err
+}
+
+func (w *wrappedTangy) MavenVersionsList(ctx context.Context, repositoryHref, groupID, artifactID, version string, pageOpts PageOptions) (MavenVersionsResponse, error) {
+	call := &Call{Method: "MavenVersionsList", Args: []any{repositoryHref, groupID, artifactID, version, pageOpts}}
+	err := w.intercept(ctx, call, func(ctx context.Context) (err error) {
+		call.Result, err = w.next.MavenVersionsList(ctx, repositoryHref, groupID, artifactID, version, pageOpts)
+		return err
+	})
+	result, _ := call.Result.(MavenVersionsResponse)
+	return result, err
+}
+
+func (w *wrappedTangy) MavenRepositoryMetrics(ctx context.Context, repositoryHref string) (MavenRepositoryMetrics, error) {
+	call := &Call{Method: "MavenRepositoryMetrics", Args: []any{repositoryHref}}
+	err := w.intercept(ctx, call, func(ctx context.Context) (err error) {
+		call.Result, err = w.next.MavenRepositoryMetrics(ctx, repositoryHref)
+		return err
+	})
+	result, _ := call.Result.(MavenRepositoryMetrics)
+	return result, err
+}
+
+func (w *wrappedTangy) MavenRepositoryVersionPackageList(ctx context.Context, hrefs []string, filterOpts MavenPackageListFilters, pageOpts PageOptions) (MavenPackageListResponse, error) {
+	call := &Call{Method: "MavenRepositoryVersionPackageList", Args: []any{hrefs, filterOpts, pageOpts}}
+	err := w.intercept(ctx, call, func(ctx context.Context) (err error) {
+		call.Result, err = w.next.MavenRepositoryVersionPackageList(ctx, hrefs, filterOpts, pageOpts)
+		return err
+	})
+	result, _ := call.Result.(MavenPackageListResponse)
+	return result, err
+}
+
+func (w *wrappedTangy) MavenRepositoryVersionVersionsList(ctx context.Context, hrefs []string, groupID, artifactID, version string, pageOpts PageOptions) (MavenVersionsResponse, error) {
+	call := &Call{Method: "MavenRepositoryVersionVersionsList", Args: []any{hrefs, groupID, artifactID, version, pageOpts}}
+	err := w.intercept(ctx, call, func(ctx context.Context) (err error) {
+		call.Result, err = w.next.MavenRepositoryVersionVersionsList(ctx, hrefs, groupID, artifactID, version, pageOpts)
+		return err
+	})
+	result, _ := call.Result.(MavenVersionsResponse)
+	return result, err
+}
+
+func (w *wrappedTangy) MavenRepositoryVersionMetrics(ctx context.Context, hrefs []string) (MavenRepositoryMetrics, error) {
+	call := &Call{Method: "MavenRepositoryVersionMetrics", Args: []any{hrefs}}
+	err := w.intercept(ctx, call, func(ctx context.Context) (err error) {
+		call.Result, err = w.next.MavenRepositoryVersionMetrics(ctx, hrefs)
+		return err
+	})
+	result, _ := call.Result.(MavenRepositoryMetrics)
+	return result, err
+}
+
+func (w *wrappedTangy) NpmPackageList(ctx context.Context, repositoryHref string, filterOpts NpmPackageListFilters, pageOpts PageOptions) (NpmPackageListResponse, error) {
+	call := &Call{Method: "NpmPackageList", Args: []any{repositoryHref, filterOpts, pageOpts}}
+	err := w.intercept(ctx, call, func(ctx context.Context) (err error) {
+		call.Result, err = w.next.NpmPackageList(ctx, repositoryHref, filterOpts, pageOpts)
+		return err
+	})
+	result, _ := call.Result.(NpmPackageListResponse)
+	return result, err
+}
+
+func (w *wrappedTangy) NpmPackageGet(ctx context.Context, repositoryHref, name, version string) (NpmPackageDetail, error) {
+	call := &Call{Method: "NpmPackageGet", Args: []any{repositoryHref, name, version}}
+	err := w.intercept(ctx, call, func(ctx context.Context) (err error) {
+		call.Result, err = w.next.NpmPackageGet(ctx, repositoryHref, name, version)
+		return err
+	})
+	result, _ := call.Result.(NpmPackageDetail)
+	return result, err
+}
+
+func (w *wrappedTangy) NpmPackageVersionsGet(ctx context.Context, repositoryHref, name string) ([]NpmPackageDetail, error) {
+	call := &Call{Method: "NpmPackageVersionsGet", Args: []any{repositoryHref, name}}
+	err := w.intercept(ctx, call, func(ctx context.Context) (err error) {
+		call.Result, err = w.next.NpmPackageVersionsGet(ctx, repositoryHref, name)
+		return err
+	})
+	result, _ := call.Result.([]NpmPackageDetail)
+	return result, err
+}
+
+func (w *wrappedTangy) NpmBuildList(ctx context.Context, repositoryHref, name, version string, pageOpts PageOptions) (NpmBuildListResponse, error) {
+	call := &Call{Method: "NpmBuildList", Args: []any{repositoryHref, name, version, pageOpts}}
+	err := w.intercept(ctx, call, func(ctx context.Context) (err error) {
+		call.Result, err = w.next.NpmBuildList(ctx, repositoryHref, name, version, pageOpts)
+		return err
+	})
+	result, _ := call.Result.(NpmBuildListResponse)
+	return result, err
+}
+
+func (w *wrappedTangy) NpmRepositoryVersionPackageList(ctx context.Context, hrefs []string, filterOpts NpmPackageListFilters, pageOpts PageOptions) (NpmPackageListResponse, error) {
+	call := &Call{Method: "NpmRepositoryVersionPackageList", Args: []any{hrefs, filterOpts, pageOpts}}
+	err := w.intercept(ctx, call, func(ctx context.Context) (err error) {
+		call.Result, err = w.next.NpmRepositoryVersionPackageList(ctx, hrefs, filterOpts, pageOpts)
+		return err
+	})
+	result, _ := call.Result.(NpmPackageListResponse)
+	return result, err
+}
+
+func (w *wrappedTangy) NpmRepositoryVersionPackageGet(ctx context.Context, hrefs []string, name, version string) (NpmPackageDetail, error) {
+	call := &Call{Method: "NpmRepositoryVersionPackageGet", Args: []any{hrefs, name, version}}
+	err := w.intercept(ctx, call, func(ctx context.Context) (err error) {
+		call.Result, err = w.next.NpmRepositoryVersionPackageGet(ctx, hrefs, name, version)
+		return err
+	})
+	result, _ := call.Result.(NpmPackageDetail)
+	return result, err
+}
+
+func (w *wrappedTangy) NpmRepositoryVersionPackageVersionsGet(ctx context.Context, hrefs []string, name string) ([]NpmPackageDetail, error) {
+	call := &Call{Method: "NpmRepositoryVersionPackageVersionsGet", Args: []any{hrefs, name}}
+	err := w.intercept(ctx, call, func(ctx context.Context) (err error) {
+		call.Result, err = w.next.NpmRepositoryVersionPackageVersionsGet(ctx, hrefs, name)
+		return err
+	})
+	result, _ := call.Result.([]NpmPackageDetail)
+	return result, err
+}
+
+func (w *wrappedTangy) NpmRepositoryVersionBuildList(ctx context.Context, hrefs []string, name, version string, pageOpts PageOptions) (NpmBuildListResponse, error) {
+	call := &Call{Method: "NpmRepositoryVersionBuildList", Args: []any{hrefs, name, version, pageOpts}}
+	err := w.intercept(ctx, call, func(ctx context.Context) (err error) {
+		call.Result, err = w.next.NpmRepositoryVersionBuildList(ctx, hrefs, name, version, pageOpts)
+		return err
+	})
+	result, _ := call.Result.(NpmBuildListResponse)
+	return result, err
+}
+
+func (w *wrappedTangy) RpmRepositoryVersionBatch(ctx context.Context, hrefs []string, batch *Batch) error {
+	call := &Call{Method: "RpmRepositoryVersionBatch", Args: []any{hrefs, batch}}
+	return w.intercept(ctx, call, func(ctx context.Context) error {
+		return w.next.RpmRepositoryVersionBatch(ctx, hrefs, batch)
+	})
+}
+
+func (w *wrappedTangy) RpmRepositoryVersionPackageAll(ctx context.Context, hrefs []string, filterOpts RpmListFilters) iter.Seq2[RpmListItem, error] {
+	return w.next.RpmRepositoryVersionPackageAll(ctx, hrefs, filterOpts)
+}
+
+func (w *wrappedTangy) RpmRepositoryVersionErrataAll(ctx context.Context, hrefs []string, filterOpts ErrataListFilters) iter.Seq2[ErrataListItem, error] {
+	return w.next.RpmRepositoryVersionErrataAll(ctx, hrefs, filterOpts)
+}
+
+func (w *wrappedTangy) PythonRepositoryVersionDistributionAll(ctx context.Context, hrefs []string, nameNormalized, version string) iter.Seq2[PythonDistributionListItem, error] {
+	return w.next.PythonRepositoryVersionDistributionAll(ctx, hrefs, nameNormalized, version)
+}
+
+func (w *wrappedTangy) MavenRepositoryVersionVersionsAll(ctx context.Context, hrefs []string, groupID, artifactID, version string) iter.Seq2[MavenVersionsItem, error] {
+	return w.next.MavenRepositoryVersionVersionsAll(ctx, hrefs, groupID, artifactID, version)
+}
+
+func (w *wrappedTangy) NpmRepositoryVersionPackageAll(ctx context.Context, hrefs []string, filterOpts NpmPackageListFilters) iter.Seq2[NpmPackageListItem, error] {
+	return w.next.NpmRepositoryVersionPackageAll(ctx, hrefs, filterOpts)
+}
+
+func (w *wrappedTangy) ReadSnapshot(ctx context.Context, fn func(snapshot Tangy) error) error {
+	return w.next.ReadSnapshot(ctx, fn)
+}
+
+func (w *wrappedTangy) Ping(ctx context.Context) error {
+	return w.next.Ping(ctx)
+}
+
+func (w *wrappedTangy) Ready(ctx context.Context) error {
+	return w.next.Ready(ctx)
+}
+
+func (w *wrappedTangy) Stats() Stats {
+	return w.next.Stats()
+}
+
+func (w *wrappedTangy) Close() {
+	w.next.Close()
+}
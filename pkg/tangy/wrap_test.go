@@ -0,0 +1,89 @@
+package tangy
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestWrappedTangyForwards checks that every query method of the wrapped Tangy is called through intercept,
+// with its arguments, and returns the result and error of the wrapped method. Iterators are returned as is.
+func TestWrappedTangyForwards(t *testing.T) {
+	t.Parallel()
+
+	methodErr := errors.New("method error")
+	for _, method := range queryMethods() {
+		t.Run(method, func(t *testing.T) {
+			t.Parallel()
+
+			next := NewMockTangy(t)
+			var calls []*Call
+			wrapped := reflect.ValueOf(&wrappedTangy{next: next, intercept: func(ctx context.Context, call *Call, invoke func(ctx context.Context) error) error {
+				calls = append(calls, call)
+				return invoke(ctx)
+			}}).MethodByName(method)
+
+			methodType := wrapped.Type()
+			args := []reflect.Value{reflect.ValueOf(context.Background())}
+			mockArgs := []any{context.Background()}
+			for i := 1; i < methodType.NumIn(); i++ {
+				args = append(args, reflect.Zero(methodType.In(i)))
+				mockArgs = append(mockArgs, mock.Anything)
+			}
+			result := reflect.Zero(methodType.Out(0)).Interface()
+			if methodType.Out(0).Kind() == reflect.Func {
+				next.On(method, mockArgs...).Return(result).Once()
+				out := wrapped.Call(args)
+				assert.Empty(t, calls)
+				assert.Nil(t, out[0].Interface())
+				return
+			}
+			if methodType.NumOut() == 1 {
+				// Methods returning only an error, such as RpmRepositoryVersionBatch, set their results on an argument
+				next.On(method, mockArgs...).Return(methodErr).Once()
+				out := wrapped.Call(args)
+				require.Len(t, calls, 1)
+				assert.Nil(t, calls[0].Result)
+				err, _ := out[0].Interface().(error)
+				require.ErrorIs(t, err, methodErr)
+				return
+			}
+			next.On(method, mockArgs...).Return(result, methodErr).Once()
+
+			out := wrapped.Call(args)
+			require.Len(t, calls, 1)
+			assert.Equal(t, method, calls[0].Method)
+			assert.Len(t, calls[0].Args, methodType.NumIn()-1)
+			assert.Equal(t, result, out[0].Interface())
+			err, _ := out[1].Interface().(error)
+			require.ErrorIs(t, err, methodErr)
+		})
+	}
+}
+
+// TestWrappedTangyResult checks that an interceptor may return a result without calling the wrapped Tangy
+func TestWrappedTangyResult(t *testing.T) {
+	t.Parallel()
+
+	cached := []RpmPackageSearch{{Name: "kernel"}}
+	wrapped := &wrappedTangy{next: NewMockTangy(t), intercept: func(ctx context.Context, call *Call, invoke func(ctx context.Context) error) error {
+		call.Result = cached
+		return nil
+	}}
+	result, err := wrapped.RpmRepositoryVersionPackageSearch(context.Background(), []string{"/href"}, "kern", 10)
+	require.NoError(t, err)
+	assert.Equal(t, cached, result)
+}
+
+func TestWrappedTangyClose(t *testing.T) {
+	t.Parallel()
+
+	next := NewMockTangy(t)
+	next.On("Close").Return().Once()
+	(&wrappedTangy{next: next}).Close()
+}